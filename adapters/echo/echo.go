@@ -0,0 +1,34 @@
+// Package echo adapts valex to Echo's echo.Validator:
+//
+//	import valexecho "github.com/tedla-brandsema/valex/adapters/echo"
+//
+//	e := echo.New()
+//	e.Validator = &valexecho.Validator{}
+//
+// Errors are returned as is; use valex.HTTPStatus or an HTTP error handler
+// to turn them into responses. The package does not import Echo.
+package echo
+
+import "github.com/tedla-brandsema/valex"
+
+// Validator implements Echo's echo.Validator.
+type Validator struct {
+	engine *valex.Engine
+	opts   []valex.Option
+}
+
+// New returns a Validator that validates with e, or the default engine if e
+// is nil, passing opts to every validation. The zero Validator uses the
+// default engine without options.
+func New(e *valex.Engine, opts ...valex.Option) *Validator {
+	return &Validator{engine: e, opts: opts}
+}
+
+func (v *Validator) Validate(i any) error {
+	e := v.engine
+	if e == nil {
+		e = valex.Default()
+	}
+	_, err := e.ValidateStruct(i, v.opts...)
+	return err
+}
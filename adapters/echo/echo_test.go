@@ -0,0 +1,36 @@
+package echo
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/tedla-brandsema/valex"
+)
+
+// validator mirrors echo.Validator.
+type validator interface {
+	Validate(i any) error
+}
+
+var _ validator = (*Validator)(nil)
+
+type login struct {
+	User string `val:"min,size=3"`
+}
+
+func TestValidator(t *testing.T) {
+	v := &Validator{}
+	if err := v.Validate(&login{User: "john"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	var fe *valex.FieldError
+	if err := v.Validate(&login{User: "x"}); !errors.As(err, &fe) {
+		t.Errorf("expected a *valex.FieldError, got %v", err)
+	}
+
+	v = New(nil, valex.WithCollectAll())
+	var errs valex.ValidationErrors
+	if err := v.Validate(&login{User: "x"}); !errors.As(err, &errs) {
+		t.Errorf("expected options to be applied, got %T", err)
+	}
+}
@@ -0,0 +1,32 @@
+// Package fiber adapts valex to Fiber's StructValidator (Fiber v3):
+//
+//	import valexfiber "github.com/tedla-brandsema/valex/adapters/fiber"
+//
+//	app := fiber.New(fiber.Config{StructValidator: &valexfiber.Validator{}})
+//
+// The package does not import Fiber.
+package fiber
+
+import "github.com/tedla-brandsema/valex"
+
+// Validator implements Fiber's StructValidator.
+type Validator struct {
+	engine *valex.Engine
+	opts   []valex.Option
+}
+
+// New returns a Validator that validates with e, or the default engine if e
+// is nil, passing opts to every validation. The zero Validator uses the
+// default engine without options.
+func New(e *valex.Engine, opts ...valex.Option) *Validator {
+	return &Validator{engine: e, opts: opts}
+}
+
+func (v *Validator) Validate(out any) error {
+	e := v.engine
+	if e == nil {
+		e = valex.Default()
+	}
+	_, err := e.ValidateStruct(out, v.opts...)
+	return err
+}
@@ -0,0 +1,31 @@
+package fiber
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/tedla-brandsema/valex"
+)
+
+// structValidator mirrors Fiber's StructValidator.
+type structValidator interface {
+	Validate(out any) error
+}
+
+var _ structValidator = (*Validator)(nil)
+
+type login struct {
+	User string `val:"min,size=3"`
+}
+
+func TestValidator(t *testing.T) {
+	e := valex.NewEngine()
+	v := New(e)
+	if err := v.Validate(&login{User: "john"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	var fe *valex.FieldError
+	if err := v.Validate(&login{User: "x"}); !errors.As(err, &fe) {
+		t.Errorf("expected a *valex.FieldError, got %v", err)
+	}
+}
@@ -0,0 +1,64 @@
+// Package gin adapts valex to Gin's binding.StructValidator, so it can
+// replace Gin's default validator:
+//
+//	import valexgin "github.com/tedla-brandsema/valex/adapters/gin"
+//
+//	binding.Validator = &valexgin.Validator{}
+//
+// The package does not import Gin; Validator satisfies the interface
+// structurally.
+package gin
+
+import (
+	"reflect"
+
+	"github.com/tedla-brandsema/valex"
+)
+
+// Validator implements Gin's binding.StructValidator.
+type Validator struct {
+	engine *valex.Engine
+	opts   []valex.Option
+}
+
+// New returns a Validator that validates with e, or the default engine if e
+// is nil, passing opts to every validation. The zero Validator uses the
+// default engine without options.
+func New(e *valex.Engine, opts ...valex.Option) *Validator {
+	return &Validator{engine: e, opts: opts}
+}
+
+func (v *Validator) valex() *valex.Engine {
+	if v.engine == nil {
+		return valex.Default()
+	}
+	return v.engine
+}
+
+// ValidateStruct validates obj if it is a struct or a pointer to one, and
+// each element if it is a slice or array, like Gin's default validator.
+// Other values are not validated.
+func (v *Validator) ValidateStruct(obj any) error {
+	val := reflect.ValueOf(obj)
+	for val.Kind() == reflect.Ptr && !val.IsNil() {
+		val = val.Elem()
+	}
+	switch val.Kind() {
+	case reflect.Struct:
+		if _, err := v.valex().ValidateStruct(obj, v.opts...); err != nil {
+			return err
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < val.Len(); i++ {
+			if err := v.ValidateStruct(val.Index(i).Interface()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Engine returns the underlying *valex.Engine.
+func (v *Validator) Engine() any {
+	return v.valex()
+}
@@ -0,0 +1,59 @@
+package gin
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/tedla-brandsema/valex"
+)
+
+// structValidator mirrors Gin's binding.StructValidator.
+type structValidator interface {
+	ValidateStruct(any) error
+	Engine() any
+}
+
+var _ structValidator = (*Validator)(nil)
+
+type login struct {
+	User string `val:"min,size=3"`
+}
+
+func TestValidator(t *testing.T) {
+	v := &Validator{}
+	tests := []struct {
+		name    string
+		obj     any
+		wantErr bool
+	}{
+		{name: "valid", obj: &login{User: "john"}},
+		{name: "invalid", obj: &login{User: "x"}, wantErr: true},
+		{name: "struct value", obj: login{User: "x"}, wantErr: true},
+		{name: "slice", obj: []login{{User: "john"}, {User: "x"}}, wantErr: true},
+		{name: "slice of pointers", obj: &[]*login{{User: "john"}}},
+		{name: "map", obj: map[string]string{"a": "b"}},
+		{name: "nil", obj: nil},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := v.ValidateStruct(tc.obj)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("expected error=%v, got %v", tc.wantErr, err)
+			}
+			var fe *valex.FieldError
+			if tc.wantErr && !errors.As(err, &fe) {
+				t.Errorf("expected a *valex.FieldError, got %T", err)
+			}
+		})
+	}
+}
+
+func TestValidator_Engine(t *testing.T) {
+	if (&Validator{}).Engine() != valex.Default() {
+		t.Error("expected the default engine")
+	}
+	e := valex.NewEngine()
+	if New(e).Engine() != e {
+		t.Error("expected the configured engine")
+	}
+}
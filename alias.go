@@ -0,0 +1,43 @@
+package valex
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Alias makes name usable in tags as a shorthand for tagValue, e.g.
+// Alias("username", "alphanum,len,min=3,max=20"). Aliases cannot refer to
+// other aliases.
+func (e *Engine) Alias(name, tagValue string) error {
+	if _, err := parseTagValue(tagValue, e.get); err != nil {
+		err = suggest(err, func() []string { return e.knownNames(nil, false) })
+		return fmt.Errorf("invalid alias %q: %w", name, err)
+	}
+
+	e.mut.Lock()
+	if _, ok := e.registry[name]; ok {
+		e.mut.Unlock()
+		return fmt.Errorf("invalid alias %q: a directive with that name exists", name)
+	}
+	e.aliases[name] = tagValue
+	e.mut.Unlock()
+
+	e.resetPlans()
+	return nil
+}
+
+func (e *Engine) expandAliases(tagValue string) string {
+	e.mut.RLock()
+	defer e.mut.RUnlock()
+
+	if len(e.aliases) == 0 {
+		return tagValue
+	}
+	parts := splitTag(tagValue)
+	for n, part := range parts {
+		if alias, ok := e.aliases[strings.TrimSpace(part)]; ok {
+			parts[n] = alias
+		}
+	}
+	return strings.Join(parts, ",")
+}
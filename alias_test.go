@@ -0,0 +1,49 @@
+package valex
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEngine_Alias(t *testing.T) {
+	e := NewEngine()
+	if err := e.Alias("username", "alphanum,len,min=3,max=8"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		input string
+		ok    bool
+	}{
+		{"john", true},
+		{"jo", false},
+		{"john-doe", false},
+	}
+	for _, tc := range tests {
+		data := struct {
+			User string `val:"username"`
+		}{User: tc.input}
+		ok, err := e.ValidateStruct(data)
+		if ok != tc.ok {
+			t.Errorf("ValidateStruct(%q): expected ok=%v, got ok=%v (err: %v)", tc.input, tc.ok, ok, err)
+		}
+	}
+}
+
+func TestEngine_AliasInvalid(t *testing.T) {
+	e := NewEngine()
+	tests := []struct {
+		name      string
+		tagValue  string
+		errSubstr string
+	}{
+		{"username", "alphanum,foobar", `unknown directive "foobar"`},
+		{"email", "alphanum", "a directive with that name exists"},
+	}
+	for _, tc := range tests {
+		err := e.Alias(tc.name, tc.tagValue)
+		if err == nil || !strings.Contains(err.Error(), tc.errSubstr) {
+			t.Errorf("Alias(%q, %q): expected error containing %q, got %v", tc.name, tc.tagValue, tc.errSubstr, err)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package valex
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync/atomic"
+)
+
+// Assertions check the contracts of code rather than its input: Require
+// for preconditions, Ensure for postconditions and AssertValid for values
+// that must already be valid. A failed assertion is a bug, so it panics by
+// default. Production builds can return the error instead, with the
+// valex_noassertpanic build tag or by setting VALEX_ASSERT=error in the
+// environment; VALEX_ASSERT=panic overrides the build tag.
+//
+//	func Withdraw(acct *Account, amount int) error {
+//		if err := valex.Require(amount > 0, "amount %d is not positive", amount); err != nil {
+//			return err
+//		}
+//		...
+//	}
+
+var (
+	ErrAssertion     = errors.New("assertion failed")
+	ErrPrecondition  = errors.New("precondition failed")
+	ErrPostcondition = errors.New("postcondition failed")
+)
+
+const assertEnv = "VALEX_ASSERT"
+
+var assertPanics atomic.Bool
+
+func init() {
+	assertPanics.Store(assertMode(os.Getenv(assertEnv), assertPanicDefault))
+}
+
+// assertMode returns whether assertions panic for the value of the
+// environment variable, or def when it is not set or not understood.
+func assertMode(env string, def bool) bool {
+	switch env {
+	case "panic":
+		return true
+	case "error":
+		return false
+	}
+	return def
+}
+
+// AssertValid fails when val does not pass v, with an error wrapping both
+// ErrAssertion and the error of v.
+func AssertValid[T any](val T, v Validator[T]) error {
+	if ok, err := v.Validate(val); !ok {
+		return assertionFailed(fmt.Errorf("%w: %w", ErrAssertion, err))
+	}
+	return nil
+}
+
+// Require fails with ErrPrecondition and the formatted message when cond
+// is false.
+func Require(cond bool, format string, args ...any) error {
+	if !cond {
+		return assertionFailed(fmt.Errorf("%w: %s", ErrPrecondition, fmt.Sprintf(format, args...)))
+	}
+	return nil
+}
+
+// Ensure fails with ErrPostcondition and the formatted message when cond is
+// false.
+func Ensure(cond bool, format string, args ...any) error {
+	if !cond {
+		return assertionFailed(fmt.Errorf("%w: %s", ErrPostcondition, fmt.Sprintf(format, args...)))
+	}
+	return nil
+}
+
+func assertionFailed(err error) error {
+	if assertPanics.Load() {
+		panic(err)
+	}
+	return err
+}
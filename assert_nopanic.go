@@ -0,0 +1,5 @@
+//go:build valex_noassertpanic
+
+package valex
+
+const assertPanicDefault = false
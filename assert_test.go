@@ -0,0 +1,87 @@
+package valex
+
+import (
+	"errors"
+	"testing"
+)
+
+// setAssertPanics switches the assertion mode for the duration of a test.
+func setAssertPanics(t *testing.T, panics bool) {
+	t.Helper()
+	prev := assertPanics.Swap(panics)
+	t.Cleanup(func() { assertPanics.Store(prev) })
+}
+
+func TestAssertMode(t *testing.T) {
+	tests := []struct {
+		env  string
+		def  bool
+		want bool
+	}{
+		{"", true, true},
+		{"", false, false},
+		{"panic", false, true},
+		{"error", true, false},
+		{"nonsense", true, true},
+	}
+	for _, tc := range tests {
+		if got := assertMode(tc.env, tc.def); got != tc.want {
+			t.Errorf("assertMode(%q, %v) = %v, expected %v", tc.env, tc.def, got, tc.want)
+		}
+	}
+}
+
+func TestAssertions_Errors(t *testing.T) {
+	setAssertPanics(t, false)
+
+	if err := AssertValid(5, &IntRangeValidator{Min: 0, Max: 10}); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+	if err := Require(true, "unused"); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+	if err := Ensure(true, "unused"); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+
+	v := &IntRangeValidator{Min: 0, Max: 10}
+	_, rangeErr := v.Validate(11)
+	err := AssertValid(11, v)
+	if !errors.Is(err, ErrAssertion) || err.Error() != "assertion failed: "+rangeErr.Error() {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	err = Require(false, "amount %d is not positive", -3)
+	if !errors.Is(err, ErrPrecondition) || err.Error() != "precondition failed: amount -3 is not positive" {
+		t.Errorf("unexpected error: %v", err)
+	}
+	err = Ensure(false, "balance %d is negative", -1)
+	if !errors.Is(err, ErrPostcondition) || err.Error() != "postcondition failed: balance -1 is negative" {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestAssertions_Panic(t *testing.T) {
+	setAssertPanics(t, true)
+
+	tests := []struct {
+		name   string
+		assert func() error
+		want   error
+	}{
+		{"AssertValid", func() error { return AssertValid("", &NonEmptyStringValidator{}) }, ErrAssertion},
+		{"Require", func() error { return Require(false, "no") }, ErrPrecondition},
+		{"Ensure", func() error { return Ensure(false, "no") }, ErrPostcondition},
+	}
+	for _, tc := range tests {
+		func() {
+			defer func() {
+				err, _ := recover().(error)
+				if !errors.Is(err, tc.want) {
+					t.Errorf("%s: expected a panic with %v, got %v", tc.name, tc.want, err)
+				}
+			}()
+			tc.assert()
+		}()
+	}
+}
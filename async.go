@@ -0,0 +1,81 @@
+package valex
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+)
+
+// Deferrer is implemented by directives that are too slow to run while a
+// request is being handled, e.g. because they call an external system.
+// ValidateAsync runs them after the immediate pass; ValidateStruct still runs
+// them inline.
+type Deferrer interface {
+	Deferred() bool
+}
+
+type phase int
+
+const (
+	phaseAll phase = iota
+	phaseImmediate
+	phaseDeferred
+)
+
+func (ph phase) runs(s step) bool {
+	switch ph {
+	case phaseImmediate:
+		return !s.deferred
+	case phaseDeferred:
+		return s.deferred
+	default:
+		return true
+	}
+}
+
+// Verdict is the outcome of the deferred pass of ValidateAsync.
+type Verdict struct {
+	ID  string
+	OK  bool
+	Err error
+}
+
+// ValidateAsync validates data in two phases. The immediate pass runs every
+// directive except deferred ones and its outcome is returned directly,
+// together with a correlation ID. If it passes, the deferred directives run
+// in the background and callback receives their verdict under the same ID.
+// callback is not called when the immediate pass fails.
+//
+// data is read by the deferred pass after ValidateAsync returns and must not
+// be modified until callback has been called. Cancelling ctx ends the
+// deferred pass with ctx's error as the verdict.
+func (e *Engine) ValidateAsync(ctx context.Context, data any, callback func(Verdict), opts ...Option) (id string, ok bool, err error) {
+	o := newOptions(opts)
+	id = o.correlationID
+	if id == "" {
+		id = newCorrelationID()
+	}
+
+	if ok, err := e.validate(ctx, data, o, phaseImmediate); !ok {
+		return id, false, err
+	}
+
+	go func() {
+		ok, err := e.validate(ctx, data, o, phaseDeferred)
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			ok, err = false, ctxErr
+		}
+		callback(Verdict{ID: id, OK: ok, Err: err})
+	}()
+	return id, true, nil
+}
+
+func ValidateAsync(ctx context.Context, data any, callback func(Verdict), opts ...Option) (id string, ok bool, err error) {
+	return std.ValidateAsync(ctx, data, callback, opts...)
+}
+
+func newCorrelationID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
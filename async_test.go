@@ -0,0 +1,154 @@
+package valex
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// mxValidator stands in for a directive that checks a domain's mail servers.
+type mxValidator struct {
+	calls atomic.Int32
+	block chan struct{}
+}
+
+func (v *mxValidator) Name() string {
+	return "mx"
+}
+
+func (v *mxValidator) Deferred() bool {
+	return true
+}
+
+func (v *mxValidator) Handle(val string) error {
+	return v.HandleContext(context.Background(), val)
+}
+
+func (v *mxValidator) HandleContext(ctx context.Context, val string) error {
+	v.calls.Add(1)
+	if v.block != nil {
+		select {
+		case <-v.block:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	if strings.HasSuffix(val, ".invalid") {
+		return fmt.Errorf("no mail servers for %q", val)
+	}
+	return nil
+}
+
+type asyncDummy struct {
+	Name   string `val:"min,size=3"`
+	Domain string `val:"mx"`
+}
+
+func newAsyncEngine(mx *mxValidator) *Engine {
+	e := NewEngine()
+	// cloning copies the counter, so share it through a pointer field
+	RegisterDirective(e, &mxDirective{mx})
+	return e
+}
+
+type mxDirective struct {
+	*mxValidator
+}
+
+func waitVerdict(t *testing.T, ch <-chan Verdict) Verdict {
+	t.Helper()
+	select {
+	case v := <-ch:
+		return v
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for verdict")
+		return Verdict{}
+	}
+}
+
+func TestValidateAsync(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   asyncDummy
+		wantOK bool
+	}{
+		{name: "valid", data: asyncDummy{Name: "john", Domain: "example.com"}, wantOK: true},
+		{name: "deferred failure", data: asyncDummy{Name: "john", Domain: "example.invalid"}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mx := &mxValidator{}
+			e := newAsyncEngine(mx)
+			verdicts := make(chan Verdict, 1)
+
+			id, ok, err := e.ValidateAsync(context.Background(), &tc.data, func(v Verdict) { verdicts <- v }, WithCorrelationID("req-1"))
+			if !ok || err != nil {
+				t.Fatalf("expected the immediate pass to succeed, got %v", err)
+			}
+			if id != "req-1" {
+				t.Errorf("expected correlation ID req-1, got %q", id)
+			}
+			v := waitVerdict(t, verdicts)
+			if v.ID != id || v.OK != tc.wantOK {
+				t.Errorf("expected verdict {%s %v}, got %+v", id, tc.wantOK, v)
+			}
+			var fe *FieldError
+			if !tc.wantOK && (!errors.As(v.Err, &fe) || fe.Field != "Domain") {
+				t.Errorf("expected a Domain error, got %v", v.Err)
+			}
+			if n := mx.calls.Load(); n != 1 {
+				t.Errorf("expected the deferred directive to run once, ran %d times", n)
+			}
+		})
+	}
+}
+
+func TestValidateAsync_ImmediateFailure(t *testing.T) {
+	mx := &mxValidator{}
+	e := newAsyncEngine(mx)
+	called := make(chan Verdict, 1)
+
+	id, ok, err := e.ValidateAsync(context.Background(), &asyncDummy{Name: "x"}, func(v Verdict) { called <- v })
+	if ok || err == nil {
+		t.Fatal("expected the immediate pass to fail")
+	}
+	if len(id) != 32 {
+		t.Errorf("expected a generated correlation ID, got %q", id)
+	}
+	select {
+	case v := <-called:
+		t.Errorf("expected no callback, got %+v", v)
+	case <-time.After(50 * time.Millisecond):
+	}
+	if n := mx.calls.Load(); n != 0 {
+		t.Errorf("expected the deferred directive not to run, ran %d times", n)
+	}
+}
+
+func TestValidateAsync_Cancel(t *testing.T) {
+	mx := &mxValidator{block: make(chan struct{})}
+	e := newAsyncEngine(mx)
+	verdicts := make(chan Verdict, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	_, ok, _ := e.ValidateAsync(ctx, &asyncDummy{Name: "john"}, func(v Verdict) { verdicts <- v })
+	if !ok {
+		t.Fatal("expected the immediate pass to succeed")
+	}
+	cancel()
+	if v := waitVerdict(t, verdicts); v.OK || !errors.Is(v.Err, context.Canceled) {
+		t.Errorf("expected a cancelled verdict, got %+v", v)
+	}
+}
+
+func TestValidateStruct_RunsDeferredInline(t *testing.T) {
+	mx := &mxValidator{}
+	e := newAsyncEngine(mx)
+	if ok, _ := e.ValidateStruct(&asyncDummy{Name: "john", Domain: "example.invalid"}); ok {
+		t.Error("expected ValidateStruct to run deferred directives")
+	}
+}
@@ -0,0 +1,40 @@
+package valex
+
+// batch collects registrations to apply them to an engine at once.
+type batch struct {
+	directives map[string]anyDirective
+	sanitizers map[string]anyDirective
+}
+
+func (b *batch) setDirective(name string, d anyDirective) {
+	b.directives[name] = d
+}
+
+func (b *batch) setSanitizer(name string, s anyDirective) {
+	b.sanitizers[name] = s
+}
+
+// Batch applies the registrations fn makes on r in one step, e.g. to add a
+// set of related directives at runtime. They become visible together and
+// cached plans are rebuilt once instead of after every registration.
+func (e *Engine) Batch(fn func(r Registrar)) {
+	b := &batch{
+		directives: make(map[string]anyDirective),
+		sanitizers: make(map[string]anyDirective),
+	}
+	fn(b)
+	if len(b.directives) == 0 && len(b.sanitizers) == 0 {
+		return
+	}
+
+	e.mut.Lock()
+	for name, d := range b.directives {
+		e.registry[name] = d
+	}
+	for name, s := range b.sanitizers {
+		e.sanitizers[name] = s
+	}
+	e.mut.Unlock()
+
+	e.resetPlans()
+}
@@ -0,0 +1,30 @@
+package valex
+
+import "testing"
+
+func TestEngine_Batch(t *testing.T) {
+	e := NewEngine()
+	type dummy struct {
+		Name string `val:"allcaps" sane:"shout"`
+	}
+	if ok, _ := e.ValidateStruct(&dummy{Name: "abc"}); ok {
+		t.Fatal("expected unknown directives to fail")
+	}
+
+	e.Batch(func(r Registrar) {
+		RegisterDirective(r, &allCapsValidator{})
+		RegisterSanitizer(r, &shoutSanitizer{})
+	})
+	d := &dummy{Name: "ABC"}
+	if ok, err := e.ValidateStruct(d); !ok {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if d.Name != "ABC!" {
+		t.Errorf("expected sanitized value %q, got %q", "ABC!", d.Name)
+	}
+
+	e.Batch(func(r Registrar) {}) // no-op
+	if ok, err := e.ValidateStruct(&dummy{Name: "ABC"}); !ok {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
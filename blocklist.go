@@ -0,0 +1,101 @@
+package valex
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+)
+
+const blocklistTable = "blocklist"
+
+func init() {
+	declareTable(blocklistTable)
+}
+
+func defaultBlocklist() ([]string, error) {
+	return tableData(blocklistTable, func(lines []string) []string {
+		words := make([]string, len(lines))
+		for n, line := range lines {
+			words[n] = foldWord(line)
+		}
+		return words
+	})
+}
+
+var folder = cases.Fold()
+
+// foldWord normalizes s so that compatibility variants (e.g. full-width
+// letters) and case variants of a word compare equal.
+func foldWord(s string) string {
+	return folder.String(norm.NFKC.String(s))
+}
+
+// BlocklistValidator rejects strings containing blocked words. Matching is
+// done on whole words, or anywhere in the string when Substring is set, after
+// NFKC normalization and case folding of both the value and the words.
+// UseDefault adds the embedded default word list.
+type BlocklistValidator struct {
+	Words      []string `param:"words,optional"`
+	UseDefault bool     `param:"default,optional"`
+	Substring  bool     `param:"substr,optional"`
+}
+
+func (v *BlocklistValidator) Validate(val string) (ok bool, err error) {
+	folded := foldWord(val)
+
+	var tokens []string
+	if !v.Substring {
+		tokens = strings.FieldsFunc(folded, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+		})
+	}
+
+	check := func(word string) error {
+		if word == "" {
+			return nil
+		}
+		if v.Substring {
+			if strings.Contains(folded, word) {
+				return failf(ErrNotAllowed, "value %q contains a blocked word", val)
+			}
+			return nil
+		}
+		for _, token := range tokens {
+			if token == word {
+				return failf(ErrNotAllowed, "value %q contains a blocked word", val)
+			}
+		}
+		return nil
+	}
+
+	for _, word := range v.Words {
+		if err := check(foldWord(word)); err != nil {
+			return false, err
+		}
+	}
+	if v.UseDefault {
+		words, err := defaultBlocklist()
+		if err != nil {
+			return false, err
+		}
+		for _, word := range words {
+			if err := check(word); err != nil {
+				return false, err
+			}
+		}
+	}
+	return true, nil
+}
+
+func (v *BlocklistValidator) Name() string {
+	return "blocklist"
+}
+
+func (v *BlocklistValidator) Handle(val string) error {
+	if ok, err := v.Validate(val); !ok {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,12 @@
+//go:build !valex_notables && !valex_noblocklist
+
+package valex
+
+import _ "embed"
+
+//go:embed blocklist.txt
+var blocklistData string
+
+func init() {
+	embedTable(blocklistTable, blocklistData)
+}
@@ -0,0 +1,60 @@
+package valex
+
+import (
+	"os"
+	"testing"
+)
+
+// provideTable sets a table from its source file when it was excluded by a
+// build tag.
+func provideTable(t *testing.T, name, file string) {
+	t.Helper()
+	for _, info := range Tables() {
+		if info.Name == name && !info.Embedded && info.Bytes == 0 {
+			data, err := os.ReadFile(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := SetTable(name, data); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+}
+
+func TestBlocklistValidator(t *testing.T) {
+	provideTable(t, blocklistTable, "blocklist.txt")
+	tests := []struct {
+		v     *BlocklistValidator
+		input string
+		ok    bool
+	}{
+		{&BlocklistValidator{Words: []string{"admin", "root"}}, "jane_doe", true},
+		{&BlocklistValidator{Words: []string{"admin", "root"}}, "the admin", false},
+		{&BlocklistValidator{Words: []string{"admin", "root"}}, "THE ADMIN", false},
+		{&BlocklistValidator{Words: []string{"admin", "root"}}, "ｒｏｏｔ", false}, // full-width letters
+		{&BlocklistValidator{Words: []string{"admin", "root"}}, "Straße", true},
+		{&BlocklistValidator{Words: []string{"strasse"}}, "STRASSE-Straße", false},
+		{&BlocklistValidator{Words: []string{"ass"}}, "classic", true},
+		{&BlocklistValidator{Words: []string{"ass"}, Substring: true}, "classic", false},
+		{&BlocklistValidator{UseDefault: true}, "friendly name", true},
+		{&BlocklistValidator{UseDefault: true}, "Total Bullshit", false},
+		{&BlocklistValidator{}, "anything goes", true},
+	}
+	for _, tc := range tests {
+		ok, err := tc.v.Validate(tc.input)
+		if ok != tc.ok {
+			t.Errorf("%T(%q): expected ok=%v, got ok=%v, err=%v", *tc.v, tc.input, tc.ok, ok, err)
+		}
+	}
+}
+
+func TestBlocklistValidator_Tag(t *testing.T) {
+	data := struct {
+		DisplayName string `val:"blocklist,default,words=admin moderator"`
+	}{DisplayName: "Moderator Bob"}
+
+	if ok, err := ValidateStruct(data); ok {
+		t.Errorf("expected blocked word to fail validation, got ok=%v err=%v", ok, err)
+	}
+}
@@ -0,0 +1,150 @@
+package valex
+
+import (
+	"regexp"
+	"slices"
+)
+
+// The builders assemble validators step by step, as a discoverable
+// alternative to composing struct literals:
+//
+//	username := valex.String().NonEmpty().MinLen(3).Matches(re).Build()
+//	percent := valex.Int().Min(0).Max(100).Build()
+//
+// Checks run in the order they were added and stop at the first failure.
+// Builder methods modify and return their receiver; Build takes a snapshot,
+// so a builder can be extended after building.
+
+// StringBuilder builds a Validator[string].
+type StringBuilder struct {
+	vs []Validator[string]
+}
+
+func String() *StringBuilder {
+	return &StringBuilder{}
+}
+
+// With adds any validator, e.g. one of the directives.
+func (b *StringBuilder) With(v Validator[string]) *StringBuilder {
+	b.vs = append(b.vs, v)
+	return b
+}
+
+func (b *StringBuilder) NonEmpty() *StringBuilder {
+	return b.With(&NonEmptyStringValidator{})
+}
+
+// MinLen requires at least n bytes; MinLen(0) accepts every value.
+func (b *StringBuilder) MinLen(n int) *StringBuilder {
+	if n <= 0 {
+		return b
+	}
+	return b.With(&MinLengthValidator{Size: n})
+}
+
+// MaxLen allows at most n bytes; MaxLen(0) only accepts the empty string.
+func (b *StringBuilder) MaxLen(n int) *StringBuilder {
+	if n <= 0 {
+		return b.With(ValidatorFunc[string](func(val string) (bool, error) {
+			if val != "" {
+				return false, rangeFailf(ErrTooLong, len(val), nil, 0, "value %s exeeds maximum length 0", val)
+			}
+			return true, nil
+		}))
+	}
+	return b.With(&MaxLengthValidator{Size: n})
+}
+
+// Len requires between min and max bytes; a min of 0 only limits the
+// length to max.
+func (b *StringBuilder) Len(min, max int) *StringBuilder {
+	if min <= 0 || max <= 0 {
+		return b.MinLen(min).MaxLen(max)
+	}
+	return b.With(&LengthRangeValidator{Min: min, Max: max})
+}
+
+func (b *StringBuilder) Matches(re *regexp.Regexp) *StringBuilder {
+	return b.With(&RegexValidator{Pattern: re})
+}
+
+func (b *StringBuilder) AlphaNumeric() *StringBuilder {
+	return b.With(&AlphaNumericValidator{})
+}
+
+func (b *StringBuilder) Email() *StringBuilder {
+	return b.With(&EmailValidator{})
+}
+
+func (b *StringBuilder) URL() *StringBuilder {
+	return b.With(&UrlValidator{})
+}
+
+func (b *StringBuilder) Contains(substr string) *StringBuilder {
+	return b.With(&ContainsValidator{Substr: substr})
+}
+
+func (b *StringBuilder) Prefix(prefix string) *StringBuilder {
+	return b.With(&PrefixValidator{Prefix: prefix})
+}
+
+func (b *StringBuilder) Suffix(suffix string) *StringBuilder {
+	return b.With(&SuffixValidator{Suffix: suffix})
+}
+
+func (b *StringBuilder) Build() Validator[string] {
+	return &CompositeValidator[string]{Validators: slices.Clone(b.vs)}
+}
+
+// IntBuilder builds a Validator[int].
+type IntBuilder struct {
+	vs []Validator[int]
+}
+
+func Int() *IntBuilder {
+	return &IntBuilder{}
+}
+
+// With adds any validator, e.g. one of the directives.
+func (b *IntBuilder) With(v Validator[int]) *IntBuilder {
+	b.vs = append(b.vs, v)
+	return b
+}
+
+func (b *IntBuilder) Min(n int) *IntBuilder {
+	return b.With(ValidatorFunc[int](func(val int) (bool, error) {
+		if val < n {
+			return false, rangeFailf(ErrOutOfRange, val, n, nil, "value %d is less than the minimum of %d", val, n)
+		}
+		return true, nil
+	}))
+}
+
+func (b *IntBuilder) Max(n int) *IntBuilder {
+	return b.With(ValidatorFunc[int](func(val int) (bool, error) {
+		if val > n {
+			return false, rangeFailf(ErrOutOfRange, val, nil, n, "value %d exceeds the maximum of %d", val, n)
+		}
+		return true, nil
+	}))
+}
+
+func (b *IntBuilder) Range(min, max int) *IntBuilder {
+	return b.With(&IntRangeValidator{Min: min, Max: max})
+}
+
+func (b *IntBuilder) NonNegative() *IntBuilder {
+	return b.With(&NonNegativeIntValidator{})
+}
+
+func (b *IntBuilder) NonPositive() *IntBuilder {
+	return b.With(&NonPositiveIntValidator{})
+}
+
+func (b *IntBuilder) MultipleOf(n int) *IntBuilder {
+	return b.With(&MultipleOfValidator{Factor: n})
+}
+
+func (b *IntBuilder) Build() Validator[int] {
+	return &CompositeValidator[int]{Validators: slices.Clone(b.vs)}
+}
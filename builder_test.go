@@ -0,0 +1,109 @@
+package valex
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestStringBuilder(t *testing.T) {
+	username := String().NonEmpty().MinLen(3).MaxLen(12).Matches(regexp.MustCompile(`^[a-z0-9_]+$`)).Build()
+	tests := []struct {
+		input     string
+		errSubstr string
+	}{
+		{"jane_doe", ""},
+		{"", "string is empty"},
+		{"ab", "exeeds minimum length 3"},
+		{"a_very_long_name", "exeeds maximum length 12"},
+		{"Jane", "does not match pattern"},
+	}
+	for _, tc := range tests {
+		ok, err := username.Validate(tc.input)
+		if tc.errSubstr == "" {
+			if !ok {
+				t.Errorf("%q: unexpected error: %v", tc.input, err)
+			}
+			continue
+		}
+		if ok || !strings.Contains(err.Error(), tc.errSubstr) {
+			t.Errorf("%q: expected error containing %q, got %v", tc.input, tc.errSubstr, err)
+		}
+	}
+
+	email := String().Email().Suffix("@example.com").With(&DisposableEmailValidator{}).Build()
+	if ok, err := email.Validate("jane@example.org"); ok || !strings.Contains(err.Error(), "does not end with") {
+		t.Errorf("expected a suffix error, got %v", err)
+	}
+}
+
+func TestStringBuilder_ZeroLengths(t *testing.T) {
+	tests := []struct {
+		name  string
+		v     Validator[string]
+		input string
+		ok    bool
+	}{
+		{"min 0 empty", String().MinLen(0).Build(), "", true},
+		{"min 0", String().MinLen(0).Build(), "abc", true},
+		{"max 0 empty", String().MaxLen(0).Build(), "", true},
+		{"max 0", String().MaxLen(0).Build(), "a", false},
+		{"len 0 to 2", String().Len(0, 2).Build(), "", true},
+		{"len 0 to 2 long", String().Len(0, 2).Build(), "abc", false},
+	}
+	for _, tc := range tests {
+		ok, err := tc.v.Validate(tc.input)
+		if ok != tc.ok {
+			t.Errorf("%s: %q: expected ok=%v, got ok=%v (err: %v)", tc.name, tc.input, tc.ok, ok, err)
+		}
+		if !ok && !errors.Is(err, ErrTooLong) {
+			t.Errorf("%s: expected ErrTooLong, got %v", tc.name, err)
+		}
+	}
+}
+
+func TestIntBuilder(t *testing.T) {
+	percent := Int().Min(0).Max(100).MultipleOf(5).Build()
+	tests := []struct {
+		input     int
+		errSubstr string
+	}{
+		{0, ""},
+		{100, ""},
+		{-5, "value -5 is less than the minimum of 0"},
+		{105, "value 105 exceeds the maximum of 100"},
+		{42, "multiple of 5"},
+	}
+	for _, tc := range tests {
+		ok, err := percent.Validate(tc.input)
+		if tc.errSubstr == "" {
+			if !ok {
+				t.Errorf("%d: unexpected error: %v", tc.input, err)
+			}
+			continue
+		}
+		if ok || !strings.Contains(err.Error(), tc.errSubstr) {
+			t.Errorf("%d: expected error containing %q, got %v", tc.input, tc.errSubstr, err)
+		}
+	}
+
+	_, err := percent.Validate(101)
+	var re *RangeError
+	if !errors.As(err, &re) || re.Max != 100 || re.Min != nil {
+		t.Errorf("expected a RangeError with a maximum of 100, got %v", err)
+	}
+}
+
+func TestBuilder_Snapshot(t *testing.T) {
+	b := Int().NonNegative()
+	base := b.Build()
+	strict := b.Max(10).Build()
+
+	if ok, _ := base.Validate(11); !ok {
+		t.Error("expected a built validator not to change when its builder is extended")
+	}
+	if ok, _ := strict.Validate(11); ok {
+		t.Error("expected the extended validator to apply the maximum")
+	}
+}
@@ -0,0 +1,133 @@
+package valex
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+)
+
+// The validators in this file check []byte fields, e.g. uploaded files,
+// without converting them to strings. Their errors never include the bytes.
+
+type MinBytesValidator struct {
+	Size ByteSize `param:"size"`
+}
+
+func (v *MinBytesValidator) Validate(val []byte) (ok bool, err error) {
+	if v.Size == 0 {
+		return false, errors.New(`value of parameter "size" cannot be 0`)
+	}
+	if ByteSize(len(val)) < v.Size {
+		return false, rangeFailf(ErrTooShort, len(val), v.Size, nil, "%d bytes is less than the minimum of %d", len(val), v.Size)
+	}
+	return true, nil
+}
+
+func (v *MinBytesValidator) Name() string {
+	return "minbytes"
+}
+
+func (v *MinBytesValidator) Handle(val []byte) error {
+	if ok, err := v.Validate(val); !ok {
+		return err
+	}
+	return nil
+}
+
+type MaxBytesValidator struct {
+	Size ByteSize `param:"size"`
+}
+
+func (v *MaxBytesValidator) Validate(val []byte) (ok bool, err error) {
+	if v.Size == 0 {
+		return false, errors.New(`value of parameter "size" cannot be 0`)
+	}
+	if ByteSize(len(val)) > v.Size {
+		return false, rangeFailf(ErrTooLong, len(val), nil, v.Size, "%d bytes exceeds the maximum of %d", len(val), v.Size)
+	}
+	return true, nil
+}
+
+func (v *MaxBytesValidator) Name() string {
+	return "maxbytes"
+}
+
+func (v *MaxBytesValidator) Handle(val []byte) error {
+	if ok, err := v.Validate(val); !ok {
+		return err
+	}
+	return nil
+}
+
+type NonEmptyBytesValidator struct{}
+
+func (v *NonEmptyBytesValidator) Validate(val []byte) (ok bool, err error) {
+	if len(val) == 0 {
+		return false, failf(ErrEmpty, "bytes are empty")
+	}
+	return true, nil
+}
+
+func (v *NonEmptyBytesValidator) Name() string {
+	return "!emptybytes"
+}
+
+func (v *NonEmptyBytesValidator) Handle(val []byte) error {
+	if ok, err := v.Validate(val); !ok {
+		return err
+	}
+	return nil
+}
+
+type UTF8Validator struct{}
+
+func (v *UTF8Validator) Validate(val []byte) (ok bool, err error) {
+	if !utf8.Valid(val) {
+		return false, failf(ErrInvalidFormat, "bytes are not valid UTF-8")
+	}
+	return true, nil
+}
+
+func (v *UTF8Validator) Name() string {
+	return "utf8"
+}
+
+func (v *UTF8Validator) Handle(val []byte) error {
+	if ok, err := v.Validate(val); !ok {
+		return err
+	}
+	return nil
+}
+
+// FileTypeValidator detects the media type of the bytes from their first
+// 512 bytes, as http.DetectContentType does, and checks it is one of Types,
+// e.g. `val:"filetype,types=image/png image/jpeg application/pdf"`. A type
+// ending in /* matches any subtype.
+type FileTypeValidator struct {
+	Types []string `param:"types"`
+}
+
+func (v *FileTypeValidator) Validate(val []byte) (ok bool, err error) {
+	if len(v.Types) == 0 {
+		return false, errors.New(`parameter "types" cannot be empty`)
+	}
+	detected, _, _ := strings.Cut(http.DetectContentType(val), ";")
+	for _, t := range v.Types {
+		if prefix, ok := strings.CutSuffix(t, "*"); ok && strings.HasPrefix(detected, prefix) || t == detected {
+			return true, nil
+		}
+	}
+	return false, failf(ErrNotAllowed, "file type %s is not one of %s", detected, strings.Join(v.Types, ", "))
+}
+
+func (v *FileTypeValidator) Name() string {
+	return "filetype"
+}
+
+func (v *FileTypeValidator) Handle(val []byte) error {
+	if ok, err := v.Validate(val); !ok {
+		return err
+	}
+	return nil
+}
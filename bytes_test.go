@@ -0,0 +1,58 @@
+package valex
+
+import (
+	"strings"
+	"testing"
+)
+
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+func TestByteValidators(t *testing.T) {
+	tests := []struct {
+		name  string
+		v     Validator[[]byte]
+		input []byte
+		ok    bool
+	}{
+		{"min", &MinBytesValidator{Size: 3}, []byte("abc"), true},
+		{"min short", &MinBytesValidator{Size: 3}, []byte("ab"), false},
+		{"min no size", &MinBytesValidator{}, []byte("ab"), false},
+		{"max", &MaxBytesValidator{Size: 3}, []byte("abc"), true},
+		{"max long", &MaxBytesValidator{Size: 3}, []byte("abcd"), false},
+		{"non-empty", &NonEmptyBytesValidator{}, []byte{0}, true},
+		{"empty", &NonEmptyBytesValidator{}, nil, false},
+		{"utf8", &UTF8Validator{}, []byte("héllo"), true},
+		{"not utf8", &UTF8Validator{}, []byte{0xff, 0xfe}, false},
+		{"png", &FileTypeValidator{Types: []string{"image/png"}}, pngHeader, true},
+		{"wildcard", &FileTypeValidator{Types: []string{"application/pdf", "image/*"}}, pngHeader, true},
+		{"wrong type", &FileTypeValidator{Types: []string{"application/pdf"}}, pngHeader, false},
+		{"text", &FileTypeValidator{Types: []string{"text/plain"}}, []byte("hello"), true},
+		{"no types", &FileTypeValidator{}, pngHeader, false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, err := tc.v.Validate(tc.input)
+			if ok != tc.ok {
+				t.Errorf("expected ok=%v, got ok=%v (err: %v)", tc.ok, ok, err)
+			}
+		})
+	}
+}
+
+func TestValidateStruct_Bytes(t *testing.T) {
+	type upload struct {
+		Avatar []byte `val:"!emptybytes,maxbytes,size=64,filetype,types=image/png image/gif"`
+		Notes  []byte `val:"utf8"`
+	}
+	if ok, err := ValidateStruct(upload{Avatar: pngHeader, Notes: []byte("ok")}); !ok {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, err := ValidateStruct(upload{Avatar: []byte("GIF89a" + strings.Repeat("x", 64))})
+	if fe, ok := err.(*FieldError); !ok || fe.Directive != "maxbytes" {
+		t.Errorf("expected maxbytes to fail, got %v", err)
+	}
+	_, err = ValidateStruct(upload{Avatar: []byte("%PDF-1.7")})
+	if fe, ok := err.(*FieldError); !ok || fe.Directive != "filetype" || strings.Contains(fe.Error(), "PDF-1.7") {
+		t.Errorf("expected filetype to fail without echoing the bytes, got %v", err)
+	}
+}
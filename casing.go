@@ -0,0 +1,128 @@
+package valex
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// The validators in this file enforce naming conventions, e.g. for config
+// keys and generated identifiers. lowercase and uppercase accept any text
+// without letters of the other case; the others accept ASCII identifiers
+// only, with digits allowed after the first letter.
+
+var (
+	camelCasePattern  = regexp.MustCompile(`^[a-z][a-zA-Z0-9]*$`)
+	pascalCasePattern = regexp.MustCompile(`^[A-Z][a-zA-Z0-9]*$`)
+	snakeCasePattern  = regexp.MustCompile(`^[a-z][a-z0-9]*(_[a-z0-9]+)*$`)
+	kebabCasePattern  = regexp.MustCompile(`^[a-z][a-z0-9]*(-[a-z0-9]+)*$`)
+)
+
+func checkCase(val, style string, ok bool) (bool, error) {
+	if !ok {
+		return false, failf(ErrInvalidFormat, "value %q is not %s", val, style)
+	}
+	return true, nil
+}
+
+type LowerCaseValidator struct{}
+
+func (v *LowerCaseValidator) Validate(val string) (ok bool, err error) {
+	return checkCase(val, "lowercase", val != "" && strings.IndexFunc(val, unicode.IsUpper) < 0)
+}
+
+func (v *LowerCaseValidator) Name() string {
+	return "lowercase"
+}
+
+func (v *LowerCaseValidator) Handle(val string) error {
+	if ok, err := v.Validate(val); !ok {
+		return err
+	}
+	return nil
+}
+
+type UpperCaseValidator struct{}
+
+func (v *UpperCaseValidator) Validate(val string) (ok bool, err error) {
+	return checkCase(val, "uppercase", val != "" && strings.IndexFunc(val, unicode.IsLower) < 0)
+}
+
+func (v *UpperCaseValidator) Name() string {
+	return "uppercase"
+}
+
+func (v *UpperCaseValidator) Handle(val string) error {
+	if ok, err := v.Validate(val); !ok {
+		return err
+	}
+	return nil
+}
+
+type CamelCaseValidator struct{}
+
+func (v *CamelCaseValidator) Validate(val string) (ok bool, err error) {
+	return checkCase(val, "camelCase", camelCasePattern.MatchString(val))
+}
+
+func (v *CamelCaseValidator) Name() string {
+	return "camelcase"
+}
+
+func (v *CamelCaseValidator) Handle(val string) error {
+	if ok, err := v.Validate(val); !ok {
+		return err
+	}
+	return nil
+}
+
+type PascalCaseValidator struct{}
+
+func (v *PascalCaseValidator) Validate(val string) (ok bool, err error) {
+	return checkCase(val, "PascalCase", pascalCasePattern.MatchString(val))
+}
+
+func (v *PascalCaseValidator) Name() string {
+	return "pascalcase"
+}
+
+func (v *PascalCaseValidator) Handle(val string) error {
+	if ok, err := v.Validate(val); !ok {
+		return err
+	}
+	return nil
+}
+
+type SnakeCaseValidator struct{}
+
+func (v *SnakeCaseValidator) Validate(val string) (ok bool, err error) {
+	return checkCase(val, "snake_case", snakeCasePattern.MatchString(val))
+}
+
+func (v *SnakeCaseValidator) Name() string {
+	return "snakecase"
+}
+
+func (v *SnakeCaseValidator) Handle(val string) error {
+	if ok, err := v.Validate(val); !ok {
+		return err
+	}
+	return nil
+}
+
+type KebabCaseValidator struct{}
+
+func (v *KebabCaseValidator) Validate(val string) (ok bool, err error) {
+	return checkCase(val, "kebab-case", kebabCasePattern.MatchString(val))
+}
+
+func (v *KebabCaseValidator) Name() string {
+	return "kebabcase"
+}
+
+func (v *KebabCaseValidator) Handle(val string) error {
+	if ok, err := v.Validate(val); !ok {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package valex
+
+import (
+	"testing"
+)
+
+func TestCaseValidators(t *testing.T) {
+	tests := []struct {
+		v     interface{ Validate(string) (bool, error) }
+		input string
+		ok    bool
+	}{
+		{&LowerCaseValidator{}, "hello world 42", true},
+		{&LowerCaseValidator{}, "straße", true},
+		{&LowerCaseValidator{}, "Hello", false},
+		{&LowerCaseValidator{}, "", false},
+		{&UpperCaseValidator{}, "HELLO_WORLD", true},
+		{&UpperCaseValidator{}, "HELLo", false},
+		{&CamelCaseValidator{}, "userId2", true},
+		{&CamelCaseValidator{}, "UserId", false},
+		{&CamelCaseValidator{}, "user_id", false},
+		{&PascalCaseValidator{}, "UserID", true},
+		{&PascalCaseValidator{}, "userID", false},
+		{&SnakeCaseValidator{}, "max_conn_2", true},
+		{&SnakeCaseValidator{}, "max__conn", false},
+		{&SnakeCaseValidator{}, "_max", false},
+		{&SnakeCaseValidator{}, "Max_conn", false},
+		{&KebabCaseValidator{}, "my-app-v2", true},
+		{&KebabCaseValidator{}, "my-app-", false},
+		{&KebabCaseValidator{}, "my_app", false},
+	}
+	for _, tc := range tests {
+		ok, err := tc.v.Validate(tc.input)
+		if ok != tc.ok {
+			t.Errorf("%T(%q): expected ok=%v, got ok=%v, error: %v", tc.v, tc.input, tc.ok, ok, err)
+		}
+	}
+}
+
+func TestCaseValidators_Tags(t *testing.T) {
+	type setting struct {
+		Key  string `val:"snakecase"`
+		Name string `val:"kebabcase"`
+	}
+	if _, err := ValidateStruct(&setting{Key: "max_conns", Name: "web-api"}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, err := ValidateStruct(&setting{Key: "maxConns", Name: "web-api"}); err == nil {
+		t.Error("expected a camelCase key to fail")
+	}
+}
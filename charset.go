@@ -0,0 +1,187 @@
+package valex
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// The validators in this file restrict the characters of display names and
+// other free text.
+
+// emojiTable approximates the Extended_Pictographic property, which the
+// unicode package lacks: the pictograph and dingbat blocks, including
+// regional indicators and skin tone modifiers.
+var emojiTable = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{Lo: 0x203c, Hi: 0x203c, Stride: 1},
+		{Lo: 0x2049, Hi: 0x2049, Stride: 1},
+		{Lo: 0x231a, Hi: 0x231b, Stride: 1},
+		{Lo: 0x23e9, Hi: 0x23fa, Stride: 1},
+		{Lo: 0x24c2, Hi: 0x24c2, Stride: 1},
+		{Lo: 0x25aa, Hi: 0x25fe, Stride: 1},
+		{Lo: 0x2600, Hi: 0x27bf, Stride: 1},
+		{Lo: 0x2934, Hi: 0x2935, Stride: 1},
+		{Lo: 0x2b05, Hi: 0x2b55, Stride: 1},
+		{Lo: 0x3030, Hi: 0x3030, Stride: 1},
+		{Lo: 0x303d, Hi: 0x303d, Stride: 1},
+		{Lo: 0x3297, Hi: 0x3299, Stride: 1},
+	},
+	R32: []unicode.Range32{
+		{Lo: 0x1f000, Hi: 0x1faff, Stride: 1},
+	},
+}
+
+const (
+	zeroWidthJoiner   = '‍'
+	emojiPresentation = '️'
+)
+
+func isRegionalIndicator(r rune) bool {
+	return r >= 0x1f1e6 && r <= 0x1f1ff
+}
+
+func isSkinTone(r rune) bool {
+	return r >= 0x1f3fb && r <= 0x1f3ff
+}
+
+// countEmoji counts the emoji in s, counting sequences such as flags,
+// skin tones and ZWJ families as one, and text symbols followed by the
+// emoji presentation selector as emoji.
+func countEmoji(s string) int {
+	n := 0
+	var prev rune
+	inEmoji, pairing := false, false
+	for _, r := range s {
+		switch {
+		case r == emojiPresentation:
+			if !inEmoji && prev != 0 && !unicode.IsSpace(prev) {
+				n++
+				inEmoji = true
+			}
+		case r == zeroWidthJoiner:
+		case isRegionalIndicator(r):
+			if !pairing {
+				n++
+			}
+			pairing, inEmoji = !pairing, true
+		case unicode.Is(emojiTable, r):
+			if !(inEmoji && (prev == zeroWidthJoiner || isSkinTone(r))) {
+				n++
+			}
+			inEmoji, pairing = true, false
+		default:
+			inEmoji, pairing = false, false
+		}
+		prev = r
+	}
+	return n
+}
+
+type NoEmojiValidator struct{}
+
+func (v *NoEmojiValidator) Validate(val string) (ok bool, err error) {
+	if countEmoji(val) > 0 {
+		return false, failf(ErrNotAllowed, "value %q contains emoji", val)
+	}
+	return true, nil
+}
+
+func (v *NoEmojiValidator) Name() string {
+	return "!emoji"
+}
+
+func (v *NoEmojiValidator) Handle(val string) error {
+	if ok, err := v.Validate(val); !ok {
+		return err
+	}
+	return nil
+}
+
+// MaxEmojiValidator accepts at most Count emoji, e.g. `val:"maxemoji=3"`.
+type MaxEmojiValidator struct {
+	Count int `param:"maxemoji"`
+}
+
+func (v *MaxEmojiValidator) Validate(val string) (ok bool, err error) {
+	if n := countEmoji(val); n > v.Count {
+		return false, failf(ErrNotAllowed, "value %q contains %d emoji, more than the maximum of %d", val, n, v.Count)
+	}
+	return true, nil
+}
+
+func (v *MaxEmojiValidator) Name() string {
+	return "maxemoji"
+}
+
+func (v *MaxEmojiValidator) Handle(val string) error {
+	if ok, err := v.Validate(val); !ok {
+		return err
+	}
+	return nil
+}
+
+// UnicodeValidator restricts values to the Unicode categories or scripts
+// in Allow, when set, and rejects those in Deny, e.g.
+// `val:"unicode,allow=L N Zs,deny=C"` for letters, digits and spaces
+// without control or format characters. Names are those of the unicode
+// package: categories such as L, Lu, N or Zs and scripts such as Latin.
+type UnicodeValidator struct {
+	Allow []string `param:"allow,optional"`
+	Deny  []string `param:"deny,optional"`
+}
+
+func (v *UnicodeValidator) Validate(val string) (ok bool, err error) {
+	allow, err := unicodeTables(v.Allow)
+	if err != nil {
+		return false, err
+	}
+	deny, err := unicodeTables(v.Deny)
+	if err != nil {
+		return false, err
+	}
+	for _, r := range val {
+		if len(allow) > 0 && !unicode.In(r, allow...) {
+			return false, failf(ErrNotAllowed, "value %q contains %q, which is not in %s", val, r, strings.Join(v.Allow, " "))
+		}
+		if unicode.In(r, deny...) {
+			return false, failf(ErrNotAllowed, "value %q contains %q, which is in %s", val, r, strings.Join(v.Deny, " "))
+		}
+	}
+	return true, nil
+}
+
+// configure rejects unknown category and script names when the directive
+// is set up.
+func (v *UnicodeValidator) configure() error {
+	if _, err := unicodeTables(v.Allow); err != nil {
+		return err
+	}
+	_, err := unicodeTables(v.Deny)
+	return err
+}
+
+func unicodeTables(names []string) ([]*unicode.RangeTable, error) {
+	tables := make([]*unicode.RangeTable, 0, len(names))
+	for _, name := range names {
+		t, ok := unicode.Categories[name]
+		if !ok {
+			if t, ok = unicode.Scripts[name]; !ok {
+				return nil, fmt.Errorf("unknown Unicode category or script %q", name)
+			}
+		}
+		tables = append(tables, t)
+	}
+	return tables, nil
+}
+
+func (v *UnicodeValidator) Name() string {
+	return "unicode"
+}
+
+func (v *UnicodeValidator) Handle(val string) error {
+	if ok, err := v.Validate(val); !ok {
+		return err
+	}
+	return nil
+}
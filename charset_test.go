@@ -0,0 +1,76 @@
+package valex
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCountEmoji(t *testing.T) {
+	tests := []struct {
+		input string
+		want  int
+	}{
+		{"plain text", 0},
+		{"Zoë 123 – ok", 0},
+		{"hi 😀", 1},
+		{"😀😀😀", 3},
+		{"👍🏽", 1},       // skin tone
+		{"👨‍👩‍👧", 1},    // ZWJ family
+		{"🇳🇱🇧🇪", 2},     // flags
+		{"❤️ and ❤", 2}, // with and without presentation selector
+		{"©️", 1},
+		{"©", 0},
+	}
+	for _, tc := range tests {
+		if got := countEmoji(tc.input); got != tc.want {
+			t.Errorf("countEmoji(%q) = %d, expected %d", tc.input, got, tc.want)
+		}
+	}
+}
+
+func TestCharsetValidators(t *testing.T) {
+	tests := []struct {
+		name      string
+		v         interface{ Validate(string) (bool, error) }
+		input     string
+		errSubstr string
+	}{
+		{"no emoji", &NoEmojiValidator{}, "Jane Doe", ""},
+		{"emoji", &NoEmojiValidator{}, "Jane 🚀", "contains emoji"},
+		{"max emoji", &MaxEmojiValidator{Count: 2}, "🎉 Jane 🎉", ""},
+		{"too many emoji", &MaxEmojiValidator{Count: 2}, "🎉🎉🎉", "contains 3 emoji, more than the maximum of 2"},
+		{"allow", &UnicodeValidator{Allow: []string{"L", "N", "Zs"}}, "Zoë 42", ""},
+		{"not allowed", &UnicodeValidator{Allow: []string{"L", "N", "Zs"}}, "Zoë!", `contains '!', which is not in L N Zs`},
+		{"deny", &UnicodeValidator{Deny: []string{"C"}}, "a​b", "which is in C"},
+		{"script", &UnicodeValidator{Allow: []string{"Latin", "Zs"}}, "Jane Doe", ""},
+		{"other script", &UnicodeValidator{Allow: []string{"Latin"}}, "Jаne", "not in Latin"}, // Cyrillic а
+		{"unknown", &UnicodeValidator{Allow: []string{"Klingon"}}, "a", "unknown Unicode category or script"},
+	}
+	for _, tc := range tests {
+		ok, err := tc.v.Validate(tc.input)
+		if tc.errSubstr == "" {
+			if !ok {
+				t.Errorf("%s(%q): unexpected error: %v", tc.name, tc.input, err)
+			}
+			continue
+		}
+		if ok || !strings.Contains(err.Error(), tc.errSubstr) {
+			t.Errorf("%s(%q): expected error containing %q, got %v", tc.name, tc.input, tc.errSubstr, err)
+		}
+	}
+}
+
+func TestCharsetValidators_Tags(t *testing.T) {
+	type profile struct {
+		DisplayName string `val:"maxemoji=1,unicode,allow=L N Zs So,deny=C"`
+	}
+	if _, err := ValidateStruct(&profile{DisplayName: "Jane 🚀"}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, err := ValidateStruct(&profile{DisplayName: "Jane‮"}); err == nil {
+		t.Error("expected a format character to fail")
+	}
+	if _, err := ParseTag("unicode,allow=Letters"); err == nil {
+		t.Error("expected an unknown category to fail")
+	}
+}
@@ -0,0 +1,148 @@
+package valex
+
+// The validators in this file check the check digit of IDs, for custom ID
+// schemes built on the standard algorithms. They accept the digits only,
+// without spaces or separators.
+
+// LuhnValidator checks the Luhn (mod 10) check digit used by payment card
+// numbers, IMEIs and many national IDs.
+type LuhnValidator struct{}
+
+func (v *LuhnValidator) Validate(val string) (ok bool, err error) {
+	if err := checkDigits(val); err != nil {
+		return false, err
+	}
+	sum := 0
+	for n := 0; n < len(val); n++ {
+		d := int(val[len(val)-1-n] - '0')
+		if n%2 == 1 {
+			if d *= 2; d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+	}
+	if sum%10 != 0 {
+		return false, failf(ErrInvalidFormat, "value %q fails the Luhn check", val)
+	}
+	return true, nil
+}
+
+func (v *LuhnValidator) Name() string {
+	return "luhn"
+}
+
+func (v *LuhnValidator) Handle(val string) error {
+	if ok, err := v.Validate(val); !ok {
+		return err
+	}
+	return nil
+}
+
+// Mod97Validator checks ISO 7064 MOD 97-10 check digits: read as a number,
+// with letters counting as 10 (A) to 35 (Z), the value leaves a remainder of
+// 1 when divided by 97. An IBAN passes once its first four characters are
+// moved to the end.
+type Mod97Validator struct{}
+
+func (v *Mod97Validator) Validate(val string) (ok bool, err error) {
+	if len(val) < 3 {
+		return false, failf(ErrTooShort, "value %q is too short for a check", val)
+	}
+	rem := 0
+	for _, c := range []byte(val) {
+		switch {
+		case c >= '0' && c <= '9':
+			rem = (rem*10 + int(c-'0')) % 97
+		case c >= 'A' && c <= 'Z':
+			rem = (rem*100 + int(c-'A') + 10) % 97
+		case c >= 'a' && c <= 'z':
+			rem = (rem*100 + int(c-'a') + 10) % 97
+		default:
+			return false, failf(ErrInvalidFormat, "value %q contains %q, expected only digits and letters", val, c)
+		}
+	}
+	if rem != 1 {
+		return false, failf(ErrInvalidFormat, "value %q fails the mod 97 check", val)
+	}
+	return true, nil
+}
+
+func (v *Mod97Validator) Name() string {
+	return "mod97"
+}
+
+func (v *Mod97Validator) Handle(val string) error {
+	if ok, err := v.Validate(val); !ok {
+		return err
+	}
+	return nil
+}
+
+// VerhoeffValidator checks the Verhoeff check digit, which catches every
+// single digit error and every swap of adjacent digits.
+type VerhoeffValidator struct{}
+
+var (
+	verhoeffMul = [10][10]byte{
+		{0, 1, 2, 3, 4, 5, 6, 7, 8, 9},
+		{1, 2, 3, 4, 0, 6, 7, 8, 9, 5},
+		{2, 3, 4, 0, 1, 7, 8, 9, 5, 6},
+		{3, 4, 0, 1, 2, 8, 9, 5, 6, 7},
+		{4, 0, 1, 2, 3, 9, 5, 6, 7, 8},
+		{5, 9, 8, 7, 6, 0, 4, 3, 2, 1},
+		{6, 5, 9, 8, 7, 1, 0, 4, 3, 2},
+		{7, 6, 5, 9, 8, 2, 1, 0, 4, 3},
+		{8, 7, 6, 5, 9, 3, 2, 1, 0, 4},
+		{9, 8, 7, 6, 5, 4, 3, 2, 1, 0},
+	}
+	verhoeffPerm = [8][10]byte{
+		{0, 1, 2, 3, 4, 5, 6, 7, 8, 9},
+		{1, 5, 7, 6, 2, 8, 3, 0, 9, 4},
+		{5, 8, 0, 3, 7, 9, 6, 1, 4, 2},
+		{8, 9, 1, 6, 0, 4, 3, 5, 2, 7},
+		{9, 4, 5, 3, 1, 2, 6, 8, 7, 0},
+		{4, 2, 8, 6, 5, 7, 3, 9, 0, 1},
+		{2, 7, 9, 3, 8, 0, 6, 4, 1, 5},
+		{7, 0, 4, 6, 9, 1, 3, 2, 5, 8},
+	}
+)
+
+func (v *VerhoeffValidator) Validate(val string) (ok bool, err error) {
+	if err := checkDigits(val); err != nil {
+		return false, err
+	}
+	var c byte
+	for n := 0; n < len(val); n++ {
+		c = verhoeffMul[c][verhoeffPerm[n%8][val[len(val)-1-n]-'0']]
+	}
+	if c != 0 {
+		return false, failf(ErrInvalidFormat, "value %q fails the Verhoeff check", val)
+	}
+	return true, nil
+}
+
+func (v *VerhoeffValidator) Name() string {
+	return "verhoeff"
+}
+
+func (v *VerhoeffValidator) Handle(val string) error {
+	if ok, err := v.Validate(val); !ok {
+		return err
+	}
+	return nil
+}
+
+// checkDigits reports val if it is not a number of at least two digits: a
+// payload and a check digit.
+func checkDigits(val string) error {
+	if len(val) < 2 {
+		return failf(ErrTooShort, "value %q is too short for a check digit", val)
+	}
+	for _, c := range []byte(val) {
+		if c < '0' || c > '9' {
+			return failf(ErrInvalidFormat, "value %q contains %q, expected only digits", val, c)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package valex
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestChecksumValidators(t *testing.T) {
+	tests := []struct {
+		name      string
+		v         interface{ Validate(string) (bool, error) }
+		input     string
+		errSubstr string
+	}{
+		{"luhn", &LuhnValidator{}, "79927398713", ""},
+		{"luhn card", &LuhnValidator{}, "4111111111111111", ""},
+		{"luhn wrong digit", &LuhnValidator{}, "79927398710", "fails the Luhn check"},
+		{"luhn letters", &LuhnValidator{}, "7992739871X", "expected only digits"},
+		{"luhn short", &LuhnValidator{}, "0", "too short"},
+		{"mod97 iban", &Mod97Validator{}, "096123456769BE71", ""},                // BE71 0961 2345 6769, rearranged
+		{"mod97 numeric", &Mod97Validator{}, "3214282912345698765432161182", ""}, // GB82 WEST ..., letters as numbers
+		{"mod97 letters", &Mod97Validator{}, "WEST12345698765432GB82", ""},       // GB82 WEST 1234 5698 7654 32, rearranged
+		{"mod97 wrong", &Mod97Validator{}, "WEST12345698765432GB83", "fails the mod 97 check"},
+		{"mod97 symbol", &Mod97Validator{}, "GB82-WEST", "expected only digits and letters"},
+		{"verhoeff", &VerhoeffValidator{}, "2363", ""},
+		{"verhoeff long", &VerhoeffValidator{}, "123451", ""},
+		{"verhoeff wrong", &VerhoeffValidator{}, "2364", "fails the Verhoeff check"},
+		{"verhoeff swapped", &VerhoeffValidator{}, "3263", "fails the Verhoeff check"},
+	}
+	for _, tc := range tests {
+		ok, err := tc.v.Validate(tc.input)
+		if tc.errSubstr == "" {
+			if !ok {
+				t.Errorf("%s(%q): unexpected error: %v", tc.name, tc.input, err)
+			}
+			continue
+		}
+		if ok || !strings.Contains(err.Error(), tc.errSubstr) {
+			t.Errorf("%s(%q): expected error containing %q, got %v", tc.name, tc.input, tc.errSubstr, err)
+		}
+	}
+}
+
+func TestChecksumValidators_Tag(t *testing.T) {
+	type member struct {
+		Number string `val:"luhn"`
+	}
+	if _, err := ValidateStruct(&member{Number: "79927398713"}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, err := ValidateStruct(&member{Number: "79927398714"}); err == nil {
+		t.Error("expected a wrong check digit to fail")
+	}
+}
@@ -0,0 +1,118 @@
+// Package cliflags provides flag values that validate their argument with a
+// valex.Validator. They implement pflag.Value, and so work with cobra, as
+// well as flag.Value:
+//
+//	var port int = 8080
+//	cmd.Flags().Var(cliflags.NewInt(&port, &valex.IntRangeValidator{Min: 1, Max: 65535}), "port", "port to listen on")
+package cliflags
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/tedla-brandsema/valex"
+)
+
+type value[T any] struct {
+	p         *T
+	validator valex.Validator[T]
+	parse     func(s string) (T, error)
+	format    func(v T) string
+	typ       string
+}
+
+func (v *value[T]) String() string {
+	if v.p == nil { // the zero value flag.PrintDefaults creates
+		var zero T
+		return v.formatValue(zero)
+	}
+	return v.formatValue(*v.p)
+}
+
+func (v *value[T]) formatValue(val T) string {
+	if v.format == nil {
+		return fmt.Sprint(val)
+	}
+	return v.format(val)
+}
+
+// Set parses s and stores it when it passes validation.
+func (v *value[T]) Set(s string) error {
+	val, err := v.parse(s)
+	if err != nil {
+		return err
+	}
+	if v.validator == nil {
+		return errors.New("no validator set")
+	}
+	if ok, err := v.validator.Validate(val); !ok {
+		return err
+	}
+	*v.p = val
+	return nil
+}
+
+// Type names the value type in help output, as pflag does.
+func (v *value[T]) Type() string {
+	return v.typ
+}
+
+func (v *value[T]) Get() any {
+	return *v.p
+}
+
+type ValidatedString struct {
+	value[string]
+}
+
+// NewString returns a flag value that sets *p. The current value of *p is
+// the default.
+func NewString(p *string, v valex.Validator[string]) *ValidatedString {
+	return &ValidatedString{value[string]{
+		p:         p,
+		validator: v,
+		parse:     func(s string) (string, error) { return s, nil },
+		typ:       "string",
+	}}
+}
+
+type ValidatedInt struct {
+	value[int]
+}
+
+func NewInt(p *int, v valex.Validator[int]) *ValidatedInt {
+	return &ValidatedInt{value[int]{
+		p:         p,
+		validator: v,
+		parse: func(s string) (int, error) {
+			i, err := strconv.ParseInt(s, 0, strconv.IntSize)
+			if err != nil {
+				return 0, fmt.Errorf("invalid int %q", s)
+			}
+			return int(i), nil
+		},
+		typ: "int",
+	}}
+}
+
+type ValidatedDuration struct {
+	value[time.Duration]
+}
+
+func NewDuration(p *time.Duration, v valex.Validator[time.Duration]) *ValidatedDuration {
+	return &ValidatedDuration{value[time.Duration]{
+		p:         p,
+		validator: v,
+		parse: func(s string) (time.Duration, error) {
+			d, err := time.ParseDuration(s)
+			if err != nil {
+				return 0, fmt.Errorf("invalid duration %q", s)
+			}
+			return d, nil
+		},
+		format: time.Duration.String,
+		typ:    "duration",
+	}}
+}
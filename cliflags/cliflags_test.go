@@ -0,0 +1,84 @@
+package cliflags
+
+import (
+	"errors"
+	"flag"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/tedla-brandsema/valex"
+)
+
+// pflagValue mirrors pflag.Value.
+type pflagValue interface {
+	String() string
+	Set(string) error
+	Type() string
+}
+
+var (
+	_ pflagValue  = (*ValidatedString)(nil)
+	_ pflagValue  = (*ValidatedInt)(nil)
+	_ pflagValue  = (*ValidatedDuration)(nil)
+	_ flag.Getter = (*ValidatedDuration)(nil)
+)
+
+func TestValues(t *testing.T) {
+	name := "default"
+	port := 8080
+	timeout := time.Second
+	positive := valex.ValidatorFunc[time.Duration](func(d time.Duration) (bool, error) {
+		if d <= 0 {
+			return false, errors.New("must be positive")
+		}
+		return true, nil
+	})
+
+	tests := []struct {
+		name    string
+		v       pflagValue
+		arg     string
+		want    string
+		typ     string
+		wantErr bool
+	}{
+		{name: "string", v: NewString(&name, &valex.MinLengthValidator{Size: 3}), arg: "john", want: "john", typ: "string"},
+		{name: "invalid string", v: NewString(&name, &valex.MinLengthValidator{Size: 3}), arg: "jo", want: "john", typ: "string", wantErr: true},
+		{name: "int", v: NewInt(&port, &valex.IntRangeValidator{Min: 1, Max: 65535}), arg: "9000", want: "9000", typ: "int"},
+		{name: "hex int", v: NewInt(&port, &valex.IntRangeValidator{Min: 1, Max: 65535}), arg: "0x10", want: "16", typ: "int"},
+		{name: "out of range", v: NewInt(&port, &valex.IntRangeValidator{Min: 1, Max: 65535}), arg: "70000", want: "16", typ: "int", wantErr: true},
+		{name: "not an int", v: NewInt(&port, &valex.IntRangeValidator{Min: 1, Max: 65535}), arg: "high", want: "16", typ: "int", wantErr: true},
+		{name: "duration", v: NewDuration(&timeout, positive), arg: "1m30s", want: "1m30s", typ: "duration"},
+		{name: "invalid duration", v: NewDuration(&timeout, positive), arg: "-1s", want: "1m30s", typ: "duration", wantErr: true},
+		{name: "no validator", v: NewString(&name, nil), arg: "jane", want: "john", typ: "string", wantErr: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.v.Set(tc.arg)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("Set(%q) = %v, wantErr %v", tc.arg, err, tc.wantErr)
+			}
+			if got := tc.v.String(); got != tc.want {
+				t.Errorf("String() = %q, want %q", got, tc.want)
+			}
+			if got := tc.v.Type(); got != tc.typ {
+				t.Errorf("Type() = %q, want %q", got, tc.typ)
+			}
+		})
+	}
+}
+
+func TestFlagSet(t *testing.T) {
+	port := 8080
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.Var(NewInt(&port, &valex.IntRangeValidator{Min: 1, Max: 65535}), "port", "")
+
+	if err := fs.Parse([]string{"-port", "0"}); err == nil {
+		t.Error("expected an error")
+	}
+	if err := fs.Parse([]string{"-port", "443"}); err != nil || port != 443 {
+		t.Errorf("got port %d, err %v", port, err)
+	}
+}
@@ -0,0 +1,60 @@
+package valex
+
+import (
+	"context"
+	"time"
+)
+
+// Clock tells time-based validators what time it is. Engines use the system
+// clock unless another one is set with SetClock, e.g. a frozen one in tests.
+type Clock interface {
+	Now() time.Time
+}
+
+type ClockFunc func() time.Time
+
+func (f ClockFunc) Now() time.Time {
+	return f()
+}
+
+// FixedClock returns a clock that is frozen at t.
+func FixedClock(t time.Time) Clock {
+	return ClockFunc(func() time.Time { return t })
+}
+
+var SystemClock Clock = ClockFunc(time.Now)
+
+type clockBox struct {
+	Clock
+}
+
+// SetClock sets the clock handed to time-based directives. A nil clock
+// restores the system clock.
+func (e *Engine) SetClock(c Clock) {
+	if c == nil {
+		c = SystemClock
+	}
+	e.clock.Store(clockBox{c})
+}
+
+func (e *Engine) Clock() Clock {
+	if b, ok := e.clock.Load().(clockBox); ok {
+		return b.Clock
+	}
+	return SystemClock
+}
+
+type clockKey struct{}
+
+func withClock(ctx context.Context, c Clock) context.Context {
+	return context.WithValue(ctx, clockKey{}, c)
+}
+
+// ClockFromContext returns the clock of the engine running the validation,
+// for directives implementing ContextHandler, or the system clock.
+func ClockFromContext(ctx context.Context) Clock {
+	if c, ok := ctx.Value(clockKey{}).(Clock); ok {
+		return c
+	}
+	return SystemClock
+}
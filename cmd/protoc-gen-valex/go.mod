@@ -0,0 +1,5 @@
+module github.com/tedla-brandsema/valex/cmd/protoc-gen-valex
+
+go 1.23.2
+
+require google.golang.org/protobuf v1.34.2
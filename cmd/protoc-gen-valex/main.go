@@ -0,0 +1,142 @@
+// Command protoc-gen-valex is a protoc plugin that derives valex rules from
+// the field constraints of protovalidate (buf.validate.field) or
+// protoc-gen-validate (validate.rules), so messages generated by
+// protoc-gen-go validate with valex, e.g. through grpcvalex:
+//
+//	protoc --go_out=. --valex_out=. user.proto
+//
+// For each file with constraints it writes <file>_valex.pb.go, holding the
+// rules of its messages, keyed like valex.Rules:
+//
+//	valex.Default().SetRules(userpb.ValexRules_user_proto)
+//
+// Constraints without a valex equivalent, such as CEL expressions, are
+// listed in a comment of the generated file, as are those of oneof fields and
+// optional scalars, which are not plain struct fields in Go.
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+const valexPackage = protogen.GoImportPath("github.com/tedla-brandsema/valex")
+
+func main() {
+	protogen.Options{}.Run(generate)
+}
+
+func generate(gen *protogen.Plugin) error {
+	gen.SupportedFeatures = uint64(pluginpb.CodeGeneratorResponse_FEATURE_PROTO3_OPTIONAL)
+	for _, f := range gen.Files {
+		if !f.Generate {
+			continue
+		}
+		if err := generateFile(gen, f); err != nil {
+			return fmt.Errorf("%s: %w", f.Desc.Path(), err)
+		}
+	}
+	return nil
+}
+
+type messageRules struct {
+	typeName string
+	fields   [][2]string // Go field name, tag value
+}
+
+func generateFile(gen *protogen.Plugin, f *protogen.File) error {
+	var rules []messageRules
+	var unsupported []string
+	var walk func(msgs []*protogen.Message) error
+	walk = func(msgs []*protogen.Message) error {
+		for _, m := range msgs {
+			if m.Desc.IsMapEntry() {
+				continue
+			}
+			mr := messageRules{typeName: string(f.GoPackageName) + "." + m.GoIdent.GoName}
+			for _, field := range m.Fields {
+				c, required, err := constraints(field.Desc)
+				if err != nil {
+					return fmt.Errorf("field %s: invalid constraints: %w", field.Desc.FullName(), err)
+				}
+				if c == nil && !required {
+					continue
+				}
+				if o := field.Desc.ContainingOneof(); o != nil && !o.IsSynthetic() {
+					unsupported = append(unsupported, fmt.Sprintf("%s: fields of oneofs", field.Desc.FullName()))
+					continue
+				}
+				if isPointerScalar(field) && len(c.unknown(protovalidateRequired, rulesMessage)) > 0 {
+					// only required applies to the pointer itself
+					unsupported = append(unsupported, fmt.Sprintf("%s: constraints of optional scalars", field.Desc.FullName()))
+					c = nil
+				}
+				tag, skipped, err := fieldTag(field.Desc, c, required)
+				if err != nil {
+					return fmt.Errorf("field %s: invalid constraints: %w", field.Desc.FullName(), err)
+				}
+				for _, s := range skipped {
+					unsupported = append(unsupported, fmt.Sprintf("%s: %s", field.Desc.FullName(), s))
+				}
+				if tag != "" {
+					mr.fields = append(mr.fields, [2]string{field.GoName, tag})
+				}
+			}
+			if len(mr.fields) > 0 {
+				rules = append(rules, mr)
+			}
+			if err := walk(m.Messages); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := walk(f.Messages); err != nil {
+		return err
+	}
+	if len(rules) == 0 && len(unsupported) == 0 {
+		return nil
+	}
+
+	g := gen.NewGeneratedFile(f.GeneratedFilenamePrefix+"_valex.pb.go", f.GoImportPath)
+	g.P("// Code generated by protoc-gen-valex. DO NOT EDIT.")
+	g.P("// source: ", f.Desc.Path())
+	g.P()
+	g.P("package ", f.GoPackageName)
+	g.P()
+	for _, u := range unsupported {
+		g.P("// not supported by valex: ", u)
+	}
+	if len(unsupported) > 0 {
+		g.P()
+	}
+	name := rulesVarName(f)
+	g.P("// ", name, " holds the valex rules of the messages in ", f.Desc.Path(), ".")
+	g.P("var ", name, " = ", g.QualifiedGoIdent(valexPackage.Ident("Rules")), "{")
+	for _, mr := range rules {
+		g.P(strconv.Quote(mr.typeName), ": {")
+		for _, fr := range mr.fields {
+			g.P(strconv.Quote(fr[0]), ": ", strconv.Quote(fr[1]), ",")
+		}
+		g.P("},")
+	}
+	g.P("}")
+	return nil
+}
+
+// isPointerScalar reports whether field is a scalar that protoc-gen-go
+// generates as a pointer, to track its presence.
+func isPointerScalar(field *protogen.Field) bool {
+	d := field.Desc
+	return d.HasPresence() && d.Message() == nil && (d.ContainingOneof() == nil || d.ContainingOneof().IsSynthetic())
+}
+
+// rulesVarName names the rules of f after the descriptor variable of
+// protoc-gen-go, e.g. ValexRules_user_proto for File_user_proto.
+func rulesVarName(f *protogen.File) string {
+	return "ValexRules_" + strings.TrimPrefix(f.GoDescriptorIdent.GoName, "File_")
+}
@@ -0,0 +1,186 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+// msg encodes a message from fields encoded by the helpers below.
+func msg(fields ...[]byte) []byte {
+	var b []byte
+	for _, f := range fields {
+		b = append(b, f...)
+	}
+	return b
+}
+
+func sub(num protowire.Number, fields ...[]byte) []byte {
+	b := protowire.AppendTag(nil, num, protowire.BytesType)
+	return protowire.AppendBytes(b, msg(fields...))
+}
+
+func str(num protowire.Number, s string) []byte {
+	b := protowire.AppendTag(nil, num, protowire.BytesType)
+	return protowire.AppendString(b, s)
+}
+
+func varint(num protowire.Number, v uint64) []byte {
+	b := protowire.AppendTag(nil, num, protowire.VarintType)
+	return protowire.AppendVarint(b, v)
+}
+
+func field(name string, num int32, typ descriptorpb.FieldDescriptorProto_Type, options []byte) *descriptorpb.FieldDescriptorProto {
+	f := &descriptorpb.FieldDescriptorProto{
+		Name:     proto.String(name),
+		JsonName: proto.String(name),
+		Number:   proto.Int32(num),
+		Type:     typ.Enum(),
+		Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+	}
+	if options != nil {
+		f.Options = &descriptorpb.FieldOptions{}
+		f.Options.ProtoReflect().SetUnknown(options)
+	}
+	return f
+}
+
+func testRequest() *pluginpb.CodeGeneratorRequest {
+	const (
+		tString = descriptorpb.FieldDescriptorProto_TYPE_STRING
+		tInt32  = descriptorpb.FieldDescriptorProto_TYPE_INT32
+		tSint64 = descriptorpb.FieldDescriptorProto_TYPE_SINT64
+		tMsg    = descriptorpb.FieldDescriptorProto_TYPE_MESSAGE
+	)
+	repeated := descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+
+	name := field("name", 1, tString, sub(protovalidateField, varint(protovalidateRequired, 1), sub(rulesString, varint(2, 2), varint(3, 20))))
+	age := field("age", 2, tInt32, sub(protovalidateField, sub(rulesInt32, varint(3, 130), varint(5, 0))))
+	tags := field("tags", 3, tString, sub(protovalidateField, sub(rulesRepeated, varint(2, 3), sub(4, sub(rulesString, varint(2, 2))))))
+	tags.Label = repeated
+	address := field("address", 4, tMsg, sub(protovalidateField, varint(protovalidateRequired, 1)))
+	address.TypeName = proto.String(".user.Address")
+	email := field("email", 5, tString, sub(pgvRules, sub(rulesString, varint(12, 1))))
+	role := field("role", 6, tString, sub(protovalidateField, sub(rulesString, str(10, "admin"), str(10, "user"))))
+	host := field("host", 7, tString, sub(protovalidateField, sub(rulesString, varint(13, 1)), sub(23, str(1, "cel"))))
+	scores := field("scores", 8, tMsg, sub(protovalidateField, sub(rulesMap, sub(5, sub(rulesSint64, varint(4, protowire.EncodeZigZag(-1)))))))
+	scores.TypeName = proto.String(".user.User.ScoresEntry")
+	scores.Label = repeated
+	nick := field("nick", 9, tString, sub(protovalidateField, sub(rulesString, varint(2, 1))))
+	nick.Proto3Optional = proto.Bool(true)
+	nick.OneofIndex = proto.Int32(0)
+	plain := field("plain", 10, tString, nil)
+
+	entry := &descriptorpb.DescriptorProto{
+		Name: proto.String("ScoresEntry"),
+		Field: []*descriptorpb.FieldDescriptorProto{
+			field("key", 1, tString, nil),
+			field("value", 2, tSint64, nil),
+		},
+		Options: &descriptorpb.MessageOptions{MapEntry: proto.Bool(true)},
+	}
+	user := &descriptorpb.DescriptorProto{
+		Name:       proto.String("User"),
+		Field:      []*descriptorpb.FieldDescriptorProto{name, age, tags, address, email, role, host, scores, nick, plain},
+		NestedType: []*descriptorpb.DescriptorProto{entry},
+		OneofDecl:  []*descriptorpb.OneofDescriptorProto{{Name: proto.String("_nick")}},
+	}
+	addr := &descriptorpb.DescriptorProto{
+		Name:  proto.String("Address"),
+		Field: []*descriptorpb.FieldDescriptorProto{field("zip", 1, tString, sub(protovalidateField, sub(rulesString, varint(19, 4))))},
+	}
+	return &pluginpb.CodeGeneratorRequest{
+		FileToGenerate: []string{"user.proto"},
+		ProtoFile: []*descriptorpb.FileDescriptorProto{{
+			Name:        proto.String("user.proto"),
+			Package:     proto.String("user"),
+			Syntax:      proto.String("proto3"),
+			MessageType: []*descriptorpb.DescriptorProto{user, addr},
+			Options:     &descriptorpb.FileOptions{GoPackage: proto.String("example.com/userpb;userpb")},
+		}},
+	}
+}
+
+const wantUserValex = `// Code generated by protoc-gen-valex. DO NOT EDIT.
+// source: user.proto
+
+package userpb
+
+import (
+	valex "github.com/tedla-brandsema/valex"
+)
+
+// not supported by valex: user.User.host: string rule 13
+// not supported by valex: user.User.host: field rule 23
+// not supported by valex: user.User.nick: constraints of optional scalars
+
+// ValexRules_user_proto holds the valex rules of the messages in user.proto.
+var ValexRules_user_proto = valex.Rules{
+	"userpb.User": {
+		"Name":    "required,min,size=2,max,size=20",
+		"Age":     "expr=value <= 130,expr=value >= 0",
+		"Tags":    "maxitems=3,dive,min,size=2",
+		"Address": "required,dive",
+		"Email":   "email",
+		"Role":    "expr=value == 'admin' || value == 'user'",
+		"Scores":  "dive,expr=value > -1",
+	},
+	"userpb.Address": {
+		"Zip": "len,min=4,max=4",
+	},
+}
+`
+
+func TestGenerate(t *testing.T) {
+	gen, err := protogen.Options{}.New(testRequest())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := generate(gen); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp := gen.Response()
+	if resp.Error != nil {
+		t.Fatalf("unexpected error: %s", resp.GetError())
+	}
+	if len(resp.File) != 1 || resp.File[0].GetName() != "example.com/userpb/user_valex.pb.go" {
+		t.Fatalf("expected a single file, got %v", resp.File)
+	}
+	if got := resp.File[0].GetContent(); got != wantUserValex {
+		t.Errorf("unexpected output:\n%s", got)
+	}
+}
+
+func TestGenerate_NoConstraints(t *testing.T) {
+	req := testRequest()
+	req.ProtoFile[0].MessageType = req.ProtoFile[0].MessageType[1:]
+	req.ProtoFile[0].MessageType[0].Field[0].Options = nil
+	gen, err := protogen.Options{}.New(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := generate(gen); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if files := gen.Response().File; len(files) != 0 {
+		t.Errorf("expected no files, got %v", files)
+	}
+}
+
+func TestGenerate_InvalidConstraints(t *testing.T) {
+	req := testRequest()
+	zip := req.ProtoFile[0].MessageType[1].Field[0]
+	zip.Options.ProtoReflect().SetUnknown(protowire.AppendTag(nil, protovalidateField, protowire.BytesType))
+	gen, err := protogen.Options{}.New(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := generate(gen); err == nil || !strings.Contains(err.Error(), "user.Address.zip: invalid constraints") {
+		t.Errorf("expected an error for user.Address.zip, got %v", err)
+	}
+}
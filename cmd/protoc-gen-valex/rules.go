@@ -0,0 +1,454 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Extension numbers of the field options of protovalidate
+// (buf.validate.field) and protoc-gen-validate (validate.rules).
+const (
+	protovalidateField protowire.Number = 1159
+	pgvRules           protowire.Number = 1071
+)
+
+// Field numbers shared by buf.validate.FieldConstraints and
+// validate.FieldRules, except for required.
+const (
+	rulesFloat    protowire.Number = 1
+	rulesDouble   protowire.Number = 2
+	rulesInt32    protowire.Number = 3
+	rulesInt64    protowire.Number = 4
+	rulesUint32   protowire.Number = 5
+	rulesUint64   protowire.Number = 6
+	rulesSint32   protowire.Number = 7
+	rulesSint64   protowire.Number = 8
+	rulesFixed32  protowire.Number = 9
+	rulesFixed64  protowire.Number = 10
+	rulesSfixed32 protowire.Number = 11
+	rulesSfixed64 protowire.Number = 12
+	rulesBool     protowire.Number = 13
+	rulesString   protowire.Number = 14
+	rulesBytes    protowire.Number = 15
+	rulesEnum     protowire.Number = 16
+	rulesMessage  protowire.Number = 17 // validate.FieldRules only
+	rulesRepeated protowire.Number = 18
+	rulesMap      protowire.Number = 19
+
+	protovalidateRequired protowire.Number = 25
+	pgvMessageRequired    protowire.Number = 2
+)
+
+// wireValue is a field of an encoded message: a varint or fixed value in num,
+// or a length-delimited one in bytes.
+type wireValue struct {
+	typ   protowire.Type
+	num   uint64
+	bytes []byte
+}
+
+type wireMessage map[protowire.Number][]wireValue
+
+func decodeMessage(b []byte) (wireMessage, error) {
+	m := make(wireMessage)
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		b = b[n:]
+		v := wireValue{typ: typ}
+		switch typ {
+		case protowire.VarintType:
+			v.num, n = protowire.ConsumeVarint(b)
+		case protowire.Fixed32Type:
+			var u uint32
+			u, n = protowire.ConsumeFixed32(b)
+			v.num = uint64(u)
+		case protowire.Fixed64Type:
+			v.num, n = protowire.ConsumeFixed64(b)
+		case protowire.BytesType:
+			v.bytes, n = protowire.ConsumeBytes(b)
+		default:
+			n = protowire.ConsumeFieldValue(num, typ, b)
+		}
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		b = b[n:]
+		m[num] = append(m[num], v)
+	}
+	return m, nil
+}
+
+func (m wireMessage) has(num protowire.Number) bool {
+	return len(m[num]) > 0
+}
+
+// last returns the value of a singular field, which is the last one encoded.
+func (m wireMessage) last(num protowire.Number) (wireValue, bool) {
+	vs := m[num]
+	if len(vs) == 0 {
+		return wireValue{}, false
+	}
+	return vs[len(vs)-1], true
+}
+
+func (m wireMessage) message(num protowire.Number) (wireMessage, error) {
+	v, ok := m.last(num)
+	if !ok {
+		return nil, nil
+	}
+	return decodeMessage(v.bytes)
+}
+
+func (m wireMessage) bool(num protowire.Number) bool {
+	v, ok := m.last(num)
+	return ok && v.num != 0
+}
+
+func (m wireMessage) uint(num protowire.Number) (uint64, bool) {
+	v, ok := m.last(num)
+	return v.num, ok
+}
+
+func (m wireMessage) string(num protowire.Number) (string, bool) {
+	v, ok := m.last(num)
+	return string(v.bytes), ok
+}
+
+func (m wireMessage) strings(num protowire.Number) []string {
+	var ss []string
+	for _, v := range m[num] {
+		ss = append(ss, string(v.bytes))
+	}
+	return ss
+}
+
+// numbers returns the values of a repeated scalar field, packed or not,
+// whose elements have wire type typ.
+func (m wireMessage) numbers(num protowire.Number, typ protowire.Type) []uint64 {
+	var ns []uint64
+	for _, v := range m[num] {
+		if v.typ != protowire.BytesType {
+			ns = append(ns, v.num)
+			continue
+		}
+		for b := v.bytes; len(b) > 0; {
+			var u uint64
+			var n int
+			switch typ {
+			case protowire.Fixed32Type:
+				var u32 uint32
+				u32, n = protowire.ConsumeFixed32(b)
+				u = uint64(u32)
+			case protowire.Fixed64Type:
+				u, n = protowire.ConsumeFixed64(b)
+			default:
+				u, n = protowire.ConsumeVarint(b)
+			}
+			if n < 0 {
+				break
+			}
+			ns, b = append(ns, u), b[n:]
+		}
+	}
+	return ns
+}
+
+// unknown returns the fields of m not in known, in order.
+func (m wireMessage) unknown(known ...protowire.Number) []protowire.Number {
+	var nums []protowire.Number
+	for num := range m {
+		if !slices.Contains(known, num) {
+			nums = append(nums, num)
+		}
+	}
+	slices.Sort(nums)
+	return nums
+}
+
+// constraints returns the constraints set on field with protovalidate or
+// protoc-gen-validate options, or nil.
+func constraints(field protoreflect.FieldDescriptor) (rules wireMessage, required bool, err error) {
+	opts, err := decodeMessage(field.Options().ProtoReflect().GetUnknown())
+	if err != nil {
+		return nil, false, err
+	}
+	if rules, err = opts.message(protovalidateField); err != nil || rules != nil {
+		return rules, rules.bool(protovalidateRequired), err
+	}
+	if rules, err = opts.message(pgvRules); err != nil || rules == nil {
+		return nil, false, err
+	}
+	msg, err := rules.message(rulesMessage)
+	return rules, msg.bool(pgvMessageRequired), err
+}
+
+// tagger builds the `val` tag of a field, collecting the constraints it
+// cannot express.
+type tagger struct {
+	parts       []string
+	unsupported []string
+}
+
+func (t *tagger) add(format string, args ...any) {
+	t.parts = append(t.parts, fmt.Sprintf(format, args...))
+}
+
+func (t *tagger) skip(format string, args ...any) {
+	t.unsupported = append(t.unsupported, fmt.Sprintf(format, args...))
+}
+
+// fieldTag returns the `val` tag expressing rules, the constraints of
+// field, and the constraints it cannot express.
+func fieldTag(field protoreflect.FieldDescriptor, rules wireMessage, required bool) (string, []string, error) {
+	t := &tagger{}
+	if required {
+		t.add("required")
+	}
+	var err error
+	switch {
+	case field.IsList():
+		err = t.collection(rules, rulesRepeated, "repeated", 4)
+	case field.IsMap():
+		err = t.collection(rules, rulesMap, "map", 5)
+	default:
+		err = t.value(rules)
+	}
+	nested := field.Message() != nil && (!field.IsMap() || field.MapValue().Message() != nil)
+	if nested && !slices.Contains(t.parts, "dive") {
+		t.add("dive") // nested messages are validated, as with protovalidate
+	}
+	return strings.Join(t.parts, ","), t.unsupported, err
+}
+
+// collection adds repeated and map rules, which share the numbers of their
+// size limits, 1 and 2. The rules of the elements, or map values, are at
+// elems.
+func (t *tagger) collection(rules wireMessage, num protowire.Number, name string, elems protowire.Number) error {
+	r, err := rules.message(num)
+	if err != nil || r == nil {
+		return err
+	}
+	if n, ok := r.uint(1); ok {
+		t.add("minitems=%d", n)
+	}
+	if n, ok := r.uint(2); ok {
+		t.add("maxitems=%d", n)
+	}
+	for _, n := range r.unknown(1, 2, elems) {
+		t.skip("%s rule %d", name, n)
+	}
+	items, err := r.message(elems)
+	if err != nil || items == nil {
+		return err
+	}
+	t.add("dive")
+	return t.value(items)
+}
+
+// value adds the rules of a single value.
+func (t *tagger) value(rules wireMessage) error {
+	for _, num := range rules.unknown(rulesMessage, rulesRepeated, rulesMap, protovalidateRequired) {
+		r, err := rules.message(num)
+		if err != nil {
+			return err
+		}
+		switch num {
+		case rulesString:
+			t.stringRules(r)
+		case rulesBytes:
+			t.bytesRules(r)
+		case rulesBool:
+			if v, ok := r.uint(1); ok {
+				t.add("expr=value == %t", v != 0)
+			}
+		case rulesEnum:
+			t.enumRules(r)
+		case rulesFloat, rulesDouble, rulesInt32, rulesInt64, rulesUint32, rulesUint64,
+			rulesSint32, rulesSint64, rulesFixed32, rulesFixed64, rulesSfixed32, rulesSfixed64:
+			t.numberRules(num, r)
+		default:
+			t.skip("field rule %d", num)
+		}
+	}
+	return nil
+}
+
+// stringFormats maps the well-known formats of string rules to directives.
+var stringFormats = []struct {
+	num       protowire.Number
+	directive string
+}{
+	{12, "email"},
+	{14, "ip"},
+	{15, "ipv4"},
+	{16, "ipv6"},
+	{17, "url"},
+	{22, "regex=" + strconv.Quote("^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$")},
+}
+
+func (t *tagger) stringRules(r wireMessage) {
+	known := []protowire.Number{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 19, 20, 23}
+	if s, ok := r.string(1); ok {
+		t.oneOf("string.const", []string{s}, nil, exprString)
+	}
+	// valex counts bytes, so character and byte lengths translate alike
+	t.lengths(r, 19, 2, 3, "len,min=%d,max=%d", "min,size=%d", "max,size=%d")
+	t.lengths(r, 20, 4, 5, "len,min=%d,max=%d", "min,size=%d", "max,size=%d")
+	for _, p := range []struct {
+		num       protowire.Number
+		directive string
+	}{{6, "regex"}, {7, "prefix"}, {8, "suffix"}, {9, "contains"}, {23, "!contains"}} {
+		if s, ok := r.string(p.num); ok {
+			t.add("%s=%s", p.directive, strconv.Quote(s))
+		}
+	}
+	t.oneOf("string.in", r.strings(10), r.strings(11), exprString)
+	for _, f := range stringFormats {
+		known = append(known, f.num)
+		if r.bool(f.num) {
+			t.add("%s", f.directive)
+		}
+	}
+	for _, n := range r.unknown(known...) {
+		t.skip("string rule %d", n)
+	}
+}
+
+func (t *tagger) bytesRules(r wireMessage) {
+	t.lengths(r, 13, 2, 3, "minbytes,size=%d,maxbytes,size=%d", "minbytes,size=%d", "maxbytes,size=%d")
+	for _, n := range r.unknown(2, 3, 13) {
+		t.skip("bytes rule %d", n)
+	}
+}
+
+// lengths adds an exact length at exact, or the bounds at min and max, with
+// the given formats.
+func (t *tagger) lengths(r wireMessage, exact, min, max protowire.Number, exactFormat, minFormat, maxFormat string) {
+	if n, ok := r.uint(exact); ok {
+		t.add(exactFormat, n, n)
+		return
+	}
+	lo, hasMin := r.uint(min)
+	hi, hasMax := r.uint(max)
+	if hasMin {
+		t.add(minFormat, lo)
+	}
+	if hasMax {
+		t.add(maxFormat, hi)
+	}
+}
+
+// numberRules adds the rules of numeric kind num: const 1, lt 2, lte 3,
+// gt 4, gte 5, in 6 and not_in 7.
+func (t *tagger) numberRules(num protowire.Number, r wireMessage) {
+	typ := numberWireType(num)
+	format := func(ns []uint64) []string {
+		var ss []string
+		for _, n := range ns {
+			ss = append(ss, formatNumber(num, n))
+		}
+		return ss
+	}
+	if v, ok := r.last(1); ok {
+		t.oneOf("const", format([]uint64{v.num}), nil, nil)
+	}
+	for _, c := range []struct {
+		num protowire.Number
+		op  string
+	}{{2, "<"}, {3, "<="}, {4, ">"}, {5, ">="}} {
+		if v, ok := r.last(c.num); ok {
+			t.add("expr=value %s %s", c.op, formatNumber(num, v.num))
+		}
+	}
+	t.oneOf("in", format(r.numbers(6, typ)), format(r.numbers(7, typ)), nil)
+	for _, n := range r.unknown(1, 2, 3, 4, 5, 6, 7) {
+		t.skip("numeric rule %d", n)
+	}
+}
+
+// enumRules adds enum rules: const 1, in 3 and not_in 4.
+func (t *tagger) enumRules(r wireMessage) {
+	format := func(ns []uint64) []string {
+		var ss []string
+		for _, n := range ns {
+			ss = append(ss, formatNumber(rulesEnum, n))
+		}
+		return ss
+	}
+	if v, ok := r.last(1); ok {
+		t.oneOf("enum.const", format([]uint64{v.num}), nil, nil)
+	}
+	t.oneOf("enum.in", format(r.numbers(3, protowire.VarintType)), format(r.numbers(4, protowire.VarintType)), nil)
+	for _, n := range r.unknown(1, 3, 4) {
+		t.skip("enum rule %d", n)
+	}
+}
+
+// oneOf adds the expression accepting the values of in and rejecting those
+// of notIn, written as literals with lit when set.
+func (t *tagger) oneOf(rule string, in, notIn []string, lit func(string) (string, bool)) {
+	terms := func(vs []string, op, join string) string {
+		var ts []string
+		for _, v := range vs {
+			if lit != nil {
+				var ok bool
+				if v, ok = lit(v); !ok {
+					t.skip("%s value %q", rule, v)
+					return ""
+				}
+			}
+			ts = append(ts, "value "+op+" "+v)
+		}
+		return strings.Join(ts, join)
+	}
+	if e := terms(in, "==", " || "); e != "" {
+		t.add("expr=%s", e)
+	}
+	if e := terms(notIn, "!=", " && "); e != "" {
+		t.add("expr=%s", e)
+	}
+}
+
+func numberWireType(num protowire.Number) protowire.Type {
+	switch num {
+	case rulesFloat, rulesFixed32, rulesSfixed32:
+		return protowire.Fixed32Type
+	case rulesDouble, rulesFixed64, rulesSfixed64:
+		return protowire.Fixed64Type
+	}
+	return protowire.VarintType
+}
+
+func formatNumber(num protowire.Number, u uint64) string {
+	switch num {
+	case rulesFloat:
+		return strconv.FormatFloat(float64(math.Float32frombits(uint32(u))), 'g', -1, 32)
+	case rulesDouble:
+		return strconv.FormatFloat(math.Float64frombits(u), 'g', -1, 64)
+	case rulesSint32, rulesSint64:
+		return strconv.FormatInt(protowire.DecodeZigZag(u), 10)
+	case rulesSfixed32, rulesInt32, rulesEnum:
+		return strconv.FormatInt(int64(int32(u)), 10)
+	case rulesUint32, rulesUint64, rulesFixed32, rulesFixed64:
+		return strconv.FormatUint(u, 10)
+	}
+	return strconv.FormatInt(int64(u), 10)
+}
+
+// exprString returns s as a string literal of a valex expression, which
+// cannot hold single quotes.
+func exprString(s string) (string, bool) {
+	if strings.Contains(s, "'") {
+		return s, false
+	}
+	return "'" + s + "'", true
+}
@@ -0,0 +1,390 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/tedla-brandsema/valex"
+)
+
+const valexPath = "github.com/tedla-brandsema/valex"
+
+type generator struct {
+	fset    *token.FileSet
+	structs map[string]*ast.StructType
+	gen     map[string]bool // types that get a Validate method
+	imports map[string]string
+	buf     bytes.Buffer
+}
+
+// generate returns the source of the Validate methods of the named struct
+// types in the Go file src, or of all its structs with `val` tags when
+// names is empty.
+func generate(filename string, src []byte, names []string) ([]byte, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filename, src, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, err
+	}
+	g := &generator{
+		fset:    fset,
+		structs: make(map[string]*ast.StructType),
+		gen:     make(map[string]bool),
+		imports: map[string]string{valexPath: "valex"},
+	}
+
+	var order []string
+	ast.Inspect(file, func(n ast.Node) bool {
+		ts, ok := n.(*ast.TypeSpec)
+		if !ok || ts.TypeParams != nil {
+			return true
+		}
+		if st, ok := ts.Type.(*ast.StructType); ok {
+			g.structs[ts.Name.Name] = st
+			order = append(order, ts.Name.Name)
+		}
+		return true
+	})
+
+	if len(names) == 0 {
+		for _, name := range order {
+			if hasTags(g.structs[name]) {
+				names = append(names, name)
+			}
+		}
+	}
+	for _, name := range names {
+		if _, ok := g.structs[name]; !ok {
+			return nil, fmt.Errorf("no struct type %s in %s", name, filename)
+		}
+		g.gen[name] = true
+	}
+
+	var body bytes.Buffer
+	for _, name := range names {
+		g.buf.Reset()
+		if err := g.validateFunc(name, g.structs[name]); err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		body.Write(g.buf.Bytes())
+	}
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "// Code generated by valexgen. DO NOT EDIT.\n\npackage %s\n\nimport (\n", file.Name.Name)
+	paths := make([]string, 0, len(g.imports))
+	for p := range g.imports {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	for _, p := range paths {
+		if g.imports[p] == path.Base(p) {
+			fmt.Fprintf(&out, "\t%q\n", p)
+		} else {
+			fmt.Fprintf(&out, "\t%s %q\n", g.imports[p], p)
+		}
+	}
+	out.WriteString(")\n")
+	out.Write(body.Bytes())
+	return format.Source(out.Bytes())
+}
+
+func hasTags(st *ast.StructType) bool {
+	for _, f := range st.Fields.List {
+		if _, ok := fieldTag(f, "val"); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func fieldTag(f *ast.Field, key string) (string, bool) {
+	if f.Tag == nil {
+		return "", false
+	}
+	tag, err := strconv.Unquote(f.Tag.Value)
+	if err != nil {
+		return "", false
+	}
+	return reflect.StructTag(tag).Lookup(key)
+}
+
+func (g *generator) printf(format string, args ...any) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+func (g *generator) validateFunc(name string, st *ast.StructType) error {
+	g.printf("\n// Validate checks the `val` tags of %s without reflection and returns the\n// first failure as a *valex.FieldError.\n", name)
+	g.printf("func (s *%s) Validate() error {\n", name)
+	for _, f := range st.Fields.List {
+		tagValue, ok := fieldTag(f, "val")
+		if !ok {
+			continue
+		}
+		if _, ok := fieldTag(f, "sane"); ok {
+			return fmt.Errorf("%s: sanitizers are not supported", g.fset.Position(f.Pos()))
+		}
+		if hasGroups(tagValue) {
+			return fmt.Errorf("%s: groups are not supported", g.fset.Position(f.Pos()))
+		}
+		names := make([]string, 0, len(f.Names))
+		for _, n := range f.Names {
+			names = append(names, n.Name)
+		}
+		if len(names) == 0 { // embedded
+			names = append(names, embeddedName(f.Type))
+		}
+		for _, fieldName := range names {
+			if !ast.IsExported(fieldName) {
+				return fmt.Errorf("%s: field %s is unexported", g.fset.Position(f.Pos()), fieldName)
+			}
+			if err := g.field(fieldName, f.Type, tagValue); err != nil {
+				return fmt.Errorf("%s: field %s: %w", g.fset.Position(f.Pos()), fieldName, err)
+			}
+		}
+	}
+	g.printf("\treturn nil\n}\n")
+	return nil
+}
+
+func hasGroups(tagValue string) bool {
+	for _, part := range strings.Split(tagValue, ",") {
+		if k, _, ok := strings.Cut(part, "="); ok && strings.TrimSpace(k) == "groups" {
+			return true
+		}
+	}
+	return false
+}
+
+func embeddedName(t ast.Expr) string {
+	switch t := t.(type) {
+	case *ast.StarExpr:
+		return embeddedName(t.X)
+	case *ast.SelectorExpr:
+		return t.Sel.Name
+	case *ast.Ident:
+		return t.Name
+	}
+	return ""
+}
+
+// target is a value the generated code checks: the Go expression reading
+// it, and expressions for its field path and for its name in errors.
+type target struct {
+	expr  string
+	path  string
+	field string
+}
+
+func (g *generator) field(name string, typ ast.Expr, tagValue string) error {
+	tds, err := valex.ParseTag(tagValue)
+	if err != nil {
+		return err
+	}
+	var fieldTDs, elemTDs []valex.TagDirective
+	dive := false
+	for _, td := range tds {
+		switch {
+		case td.Name == "dive":
+			dive = true
+		case td.Directive == nil:
+			return fmt.Errorf("%s is not supported", td.Name)
+		case dive:
+			elemTDs = append(elemTDs, td)
+		default:
+			fieldTDs = append(fieldTDs, td)
+		}
+	}
+
+	t := target{
+		expr:  "s." + name,
+		path:  fmt.Sprintf("valex.FieldPath{}.Field(%q)", name),
+		field: strconv.Quote(name),
+	}
+	for _, td := range fieldTDs {
+		if err := g.check(td, typ, t); err != nil {
+			return err
+		}
+	}
+	if !dive {
+		return nil
+	}
+
+	switch tt := typ.(type) {
+	case *ast.ArrayType:
+		if _, ok := tt.Elt.(*ast.ArrayType); ok {
+			return fmt.Errorf("cannot dive into nested collections")
+		}
+		g.printf("\tfor i := range %s {\n", t.expr)
+		elemPath := t.path + ".Index(i)" // only built on failure
+		elem := target{expr: t.expr + "[i]", path: elemPath, field: elemPath + ".String()"}
+		for _, td := range elemTDs {
+			if err := g.check(td, tt.Elt, elem); err != nil {
+				return err
+			}
+		}
+		if err := g.nested(tt.Elt, elem, true); err != nil {
+			return err
+		}
+		g.printf("\t}\n")
+		return nil
+	case *ast.MapType:
+		return fmt.Errorf("cannot dive into maps")
+	}
+	if len(elemTDs) > 0 {
+		return fmt.Errorf("directives after dive need a slice or array")
+	}
+	return g.nested(typ, t, false)
+}
+
+// nested calls the generated Validate method of a struct, or a pointer to
+// one. In collections, elements of other types are left alone.
+func (g *generator) nested(typ ast.Expr, t target, inCollection bool) error {
+	ptr := false
+	if star, ok := typ.(*ast.StarExpr); ok {
+		typ, ptr = star.X, true
+	}
+	ident, ok := typ.(*ast.Ident)
+	if !ok || !g.gen[ident.Name] {
+		if inCollection && (!ok || g.structs[ident.Name] == nil) {
+			return nil
+		}
+		return fmt.Errorf("cannot dive into %s: it gets no generated Validate method", types.ExprString(typ))
+	}
+	if ptr {
+		g.printf("\tif %s != nil {\n", t.expr)
+	}
+	g.printf("\tif err := %s.Validate(); err != nil {\n\t\treturn valex.NestError(%s, err)\n\t}\n", t.expr, t.path)
+	if ptr {
+		g.printf("\t}\n")
+	}
+	return nil
+}
+
+func (g *generator) fail(t target, directive, errExpr string) {
+	g.printf("\t\treturn &valex.FieldError{Field: %s, Path: %s, Directive: %q, Err: %s}\n\t}\n", t.field, t.path, directive, errExpr)
+}
+
+// check emits the code of a single directive. The built-in checks on ints and
+// string lengths are inlined, calling their validator only to build the
+// error, so that it is the one ValidateStruct returns; other directives are
+// called through their Handle method.
+func (g *generator) check(td valex.TagDirective, typ ast.Expr, t target) error {
+	if want := td.ValueType.String(); types.ExprString(typ) != want {
+		return fmt.Errorf("directive %q needs a %s, not a %s", td.Name, want, types.ExprString(typ))
+	}
+	e := t.expr
+	lit, err := g.literal(td.Directive)
+	if err != nil {
+		return fmt.Errorf("directive %q: %w", td.Name, err)
+	}
+	handle := fmt.Sprintf("(%s).Handle(%s)", lit, e)
+
+	switch d := td.Directive.(type) {
+	case *valex.IntRangeValidator:
+		g.printf("\tif %s < %d || %s > %d {\n", e, d.Min, e, d.Max)
+	case *valex.NonNegativeIntValidator:
+		g.printf("\tif %s < 0 {\n", e)
+	case *valex.NonPositiveIntValidator:
+		g.printf("\tif %s > 0 {\n", e)
+	case *valex.NonEmptyStringValidator:
+		g.printf("\tif %s == \"\" {\n", e)
+	case *valex.MinLengthValidator:
+		if d.Size == 0 {
+			return fmt.Errorf(`directive %q: value of parameter "size" cannot be 0`, td.Name)
+		}
+		g.printf("\tif %s < %d {\n", g.length(e, d.Runes), d.Size)
+	case *valex.MaxLengthValidator:
+		if d.Size == 0 {
+			return fmt.Errorf(`directive %q: value of parameter "size" cannot be 0`, td.Name)
+		}
+		g.printf("\tif %s > %d {\n", g.length(e, d.Runes), d.Size)
+	case *valex.LengthRangeValidator:
+		if d.Min == 0 || d.Max == 0 {
+			return fmt.Errorf(`directive %q: "min" and "max" cannot be 0`, td.Name)
+		}
+		l := g.length(e, d.Runes)
+		g.printf("\tif %s < %d || %s > %d {\n", l, d.Min, l, d.Max)
+	default:
+		g.printf("\tif err := %s; err != nil {\n", handle)
+		g.fail(t, td.Name, "err")
+		return nil
+	}
+	g.fail(t, td.Name, handle)
+	return nil
+}
+
+// length returns the expression for the length of the string e, in bytes or
+// in runes.
+func (g *generator) length(e string, runes bool) string {
+	if runes {
+		return fmt.Sprintf("%s.RuneCountInString(%s)", g.importName("unicode/utf8"), e)
+	}
+	return fmt.Sprintf("len(%s)", e)
+}
+
+// literal returns a composite literal creating d, a pointer to a struct
+// whose configuration is held in exported fields of basic types.
+func (g *generator) literal(d any) (string, error) {
+	v := reflect.ValueOf(d)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return "", fmt.Errorf("%T cannot be generated", d)
+	}
+	v = v.Elem()
+	t := v.Type()
+	if t.PkgPath() == "" || !ast.IsExported(t.Name()) {
+		return "", fmt.Errorf("%T cannot be generated", d)
+	}
+
+	var fields []string
+	for n := 0; n < t.NumField(); n++ {
+		fv := v.Field(n)
+		if fv.IsZero() {
+			continue
+		}
+		if !t.Field(n).IsExported() {
+			return "", fmt.Errorf("%T has unexported configuration", d)
+		}
+		if !basic(fv.Type()) {
+			return "", fmt.Errorf("field %s of %T cannot be generated", t.Field(n).Name, d)
+		}
+		fields = append(fields, fmt.Sprintf("%s: %#v", t.Field(n).Name, fv.Interface()))
+	}
+	return fmt.Sprintf("&%s.%s{%s}", g.importName(t.PkgPath()), t.Name(), strings.Join(fields, ", ")), nil
+}
+
+func basic(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return t.PkgPath() == "" || t.String() == "time.Duration" || t.String() == "valex.ByteSize"
+	case reflect.Slice:
+		return t.PkgPath() == "" && basic(t.Elem())
+	}
+	return false
+}
+
+func (g *generator) importName(pkgPath string) string {
+	if name, ok := g.imports[pkgPath]; ok {
+		return name
+	}
+	name := strings.Map(func(r rune) rune {
+		if r == '-' || r == '.' {
+			return '_'
+		}
+		return r
+	}, path.Base(pkgPath))
+	g.imports[pkgPath] = name
+	return name
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestGenerate_Golden(t *testing.T) {
+	src, err := os.ReadFile("internal/example/user.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := generate("user.go", src, []string{"User", "Address"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want, err := os.ReadFile("internal/example/user_valex.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("generated code differs from internal/example/user_valex.go, run go generate:\n%s", got)
+	}
+}
+
+func TestGenerate_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     string
+		types   []string
+		wantErr string
+	}{
+		{name: "unknown directive", src: "type T struct { A string `val:\"nope\"` }", wantErr: `unknown directive "nope"`},
+		{name: "type mismatch", src: "type T struct { A int `val:\"email\"` }", wantErr: `directive "email" needs a string, not a int`},
+		{name: "sanitizer", src: "type T struct { A string `val:\"email\" sane:\"trim\"` }", wantErr: "sanitizers are not supported"},
+		{name: "groups", src: "type T struct { A string `val:\"email,groups=create\"` }", wantErr: "groups are not supported"},
+		{name: "omitempty", src: "type T struct { A string `val:\"omitempty,email\"` }", wantErr: "omitempty is not supported"},
+		{name: "keepgoing", src: "type T struct { A string `val:\"keepgoing,email\"` }", wantErr: "keepgoing is not supported"},
+		{name: "map", src: "type T struct { A map[string]string `val:\"dive,email\"` }", wantErr: "cannot dive into maps"},
+		{name: "not generated", src: "type U struct{}\ntype T struct { A U `val:\"dive\"` }", wantErr: "cannot dive into U"},
+		{name: "unexported", src: "type T struct { a string `val:\"email\"` }", wantErr: "field a is unexported"},
+		{name: "unknown type", src: "type T struct{}", types: []string{"V"}, wantErr: "no struct type V"},
+		{name: "zero size", src: "type T struct { A string `val:\"min,size=0\"` }", wantErr: `"size" cannot be 0`},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := generate("t.go", []byte("package p\n"+tc.src), tc.types)
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("got %v, want an error containing %q", err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+// Package example holds types to test the code valexgen generates against
+// the reflection based validation.
+package example
+
+//go:generate go run github.com/tedla-brandsema/valex/cmd/valexgen -type User,Address
+
+type Address struct {
+	Zip     string `val:"len,min=4,max=6"`
+	Country string `val:"!empty"`
+}
+
+type User struct {
+	Name      string     `val:"min,size=3,runes"`
+	Nick      string     `val:"max,size=8,alphanum"`
+	Email     string     `val:"email"`
+	Homepage  string     `val:"url"`
+	Age       int        `val:"range,min=18,max=130"`
+	Balance   int        `val:"pos"`
+	Debt      int        `val:"neg"`
+	Emails    []string   `val:"dive,email"`
+	Address   Address    `val:"dive"`
+	Billing   *Address   `val:"dive"`
+	Previous  []*Address `val:"dive"`
+	Note      string
+	Signature string `val:"csv,cols=2"`
+}
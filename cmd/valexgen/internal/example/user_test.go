@@ -0,0 +1,100 @@
+package example
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/tedla-brandsema/valex"
+)
+
+func validUser() User {
+	return User{
+		Name:      "john",
+		Nick:      "jd",
+		Email:     "john@example.com",
+		Homepage:  "https://example.com",
+		Age:       30,
+		Balance:   10,
+		Debt:      -5,
+		Emails:    []string{"a@b.co"},
+		Address:   Address{Zip: "1234", Country: "NL"},
+		Previous:  []*Address{nil, {Zip: "123456", Country: "BE"}},
+		Signature: "a,b",
+	}
+}
+
+// TestGenerated checks that the generated Validate methods agree with the
+// reflection based validation.
+func TestGenerated(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(u *User)
+	}{
+		{name: "valid", modify: func(u *User) {}},
+		{name: "short name", modify: func(u *User) { u.Name = "jo" }},
+		{name: "short name in runes", modify: func(u *User) { u.Name = "日本" }},
+		{name: "name in runes", modify: func(u *User) { u.Name = "日本語" }},
+		{name: "long nick", modify: func(u *User) { u.Nick = "johndoe123" }},
+		{name: "nick not alphanumeric", modify: func(u *User) { u.Nick = "j.d" }},
+		{name: "email", modify: func(u *User) { u.Email = "nope" }},
+		{name: "url", modify: func(u *User) { u.Homepage = "nope" }},
+		{name: "age", modify: func(u *User) { u.Age = 12 }},
+		{name: "balance", modify: func(u *User) { u.Balance = -1 }},
+		{name: "debt", modify: func(u *User) { u.Debt = 1 }},
+		{name: "element", modify: func(u *User) { u.Emails = append(u.Emails, "nope") }},
+		{name: "nested", modify: func(u *User) { u.Address.Zip = "1" }},
+		{name: "nested empty", modify: func(u *User) { u.Address.Country = "" }},
+		{name: "pointer", modify: func(u *User) { u.Billing = &Address{Zip: "1234"} }},
+		{name: "pointer element", modify: func(u *User) { u.Previous[1].Zip = "1234567" }},
+		{name: "csv", modify: func(u *User) { u.Signature = "a,b,c" }},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			u := validUser()
+			tc.modify(&u)
+
+			got := u.Validate()
+			_, want := valex.ValidateStruct(&u)
+			if fmt.Sprint(got) != fmt.Sprint(want) {
+				t.Errorf("generated: %v\nreflection: %v", got, want)
+			}
+			for _, sentinel := range []error{valex.ErrOutOfRange, valex.ErrTooShort, valex.ErrTooLong, valex.ErrEmpty, valex.ErrInvalidFormat, valex.ErrNotAllowed} {
+				if errors.Is(got, sentinel) != errors.Is(want, sentinel) {
+					t.Errorf("errors.Is(%v): generated %v, reflection %v", sentinel, errors.Is(got, sentinel), errors.Is(want, sentinel))
+				}
+			}
+			var gre, wre *valex.RangeError
+			if errors.As(got, &gre) != errors.As(want, &wre) {
+				t.Errorf("errors.As(*RangeError): generated %v, reflection %v", gre, wre)
+			} else if gre != nil && (gre.Value != wre.Value || gre.Min != wre.Min || gre.Max != wre.Max) {
+				t.Errorf("got range %v [%v, %v], want %v [%v, %v]", gre.Value, gre.Min, gre.Max, wre.Value, wre.Min, wre.Max)
+			}
+			if fe, ok := got.(*valex.FieldError); ok {
+				if wfe := want.(*valex.FieldError); !fe.Path.Equal(wfe.Path) || fe.Directive != wfe.Directive {
+					t.Errorf("got path %s and directive %q, want %s and %q", fe.Path, fe.Directive, wfe.Path, wfe.Directive)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkGenerated(b *testing.B) {
+	u := validUser()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if err := u.Validate(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkReflection(b *testing.B) {
+	u := validUser()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if ok, err := valex.ValidateStruct(&u); !ok {
+			b.Fatal(err)
+		}
+	}
+}
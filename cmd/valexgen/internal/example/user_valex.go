@@ -0,0 +1,73 @@
+// Code generated by valexgen. DO NOT EDIT.
+
+package example
+
+import (
+	"github.com/tedla-brandsema/valex"
+	"unicode/utf8"
+)
+
+// Validate checks the `val` tags of User without reflection and returns the
+// first failure as a *valex.FieldError.
+func (s *User) Validate() error {
+	if utf8.RuneCountInString(s.Name) < 3 {
+		return &valex.FieldError{Field: "Name", Path: valex.FieldPath{}.Field("Name"), Directive: "min", Err: (&valex.MinLengthValidator{Size: 3, Runes: true}).Handle(s.Name)}
+	}
+	if len(s.Nick) > 8 {
+		return &valex.FieldError{Field: "Nick", Path: valex.FieldPath{}.Field("Nick"), Directive: "max", Err: (&valex.MaxLengthValidator{Size: 8}).Handle(s.Nick)}
+	}
+	if err := (&valex.AlphaNumericValidator{}).Handle(s.Nick); err != nil {
+		return &valex.FieldError{Field: "Nick", Path: valex.FieldPath{}.Field("Nick"), Directive: "alphanum", Err: err}
+	}
+	if err := (&valex.EmailValidator{}).Handle(s.Email); err != nil {
+		return &valex.FieldError{Field: "Email", Path: valex.FieldPath{}.Field("Email"), Directive: "email", Err: err}
+	}
+	if err := (&valex.UrlValidator{}).Handle(s.Homepage); err != nil {
+		return &valex.FieldError{Field: "Homepage", Path: valex.FieldPath{}.Field("Homepage"), Directive: "url", Err: err}
+	}
+	if s.Age < 18 || s.Age > 130 {
+		return &valex.FieldError{Field: "Age", Path: valex.FieldPath{}.Field("Age"), Directive: "range", Err: (&valex.IntRangeValidator{Min: 18, Max: 130}).Handle(s.Age)}
+	}
+	if s.Balance < 0 {
+		return &valex.FieldError{Field: "Balance", Path: valex.FieldPath{}.Field("Balance"), Directive: "pos", Err: (&valex.NonNegativeIntValidator{}).Handle(s.Balance)}
+	}
+	if s.Debt > 0 {
+		return &valex.FieldError{Field: "Debt", Path: valex.FieldPath{}.Field("Debt"), Directive: "neg", Err: (&valex.NonPositiveIntValidator{}).Handle(s.Debt)}
+	}
+	for i := range s.Emails {
+		if err := (&valex.EmailValidator{}).Handle(s.Emails[i]); err != nil {
+			return &valex.FieldError{Field: valex.FieldPath{}.Field("Emails").Index(i).String(), Path: valex.FieldPath{}.Field("Emails").Index(i), Directive: "email", Err: err}
+		}
+	}
+	if err := s.Address.Validate(); err != nil {
+		return valex.NestError(valex.FieldPath{}.Field("Address"), err)
+	}
+	if s.Billing != nil {
+		if err := s.Billing.Validate(); err != nil {
+			return valex.NestError(valex.FieldPath{}.Field("Billing"), err)
+		}
+	}
+	for i := range s.Previous {
+		if s.Previous[i] != nil {
+			if err := s.Previous[i].Validate(); err != nil {
+				return valex.NestError(valex.FieldPath{}.Field("Previous").Index(i), err)
+			}
+		}
+	}
+	if err := (&valex.CSVValidator{Cols: 2}).Handle(s.Signature); err != nil {
+		return &valex.FieldError{Field: "Signature", Path: valex.FieldPath{}.Field("Signature"), Directive: "csv", Err: err}
+	}
+	return nil
+}
+
+// Validate checks the `val` tags of Address without reflection and returns the
+// first failure as a *valex.FieldError.
+func (s *Address) Validate() error {
+	if len(s.Zip) < 4 || len(s.Zip) > 6 {
+		return &valex.FieldError{Field: "Zip", Path: valex.FieldPath{}.Field("Zip"), Directive: "len", Err: (&valex.LengthRangeValidator{Min: 4, Max: 6}).Handle(s.Zip)}
+	}
+	if s.Country == "" {
+		return &valex.FieldError{Field: "Country", Path: valex.FieldPath{}.Field("Country"), Directive: "!empty", Err: (&valex.NonEmptyStringValidator{}).Handle(s.Country)}
+	}
+	return nil
+}
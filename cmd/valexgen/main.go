@@ -0,0 +1,54 @@
+// Command valexgen generates Validate methods for struct types from their
+// `val` tags. The generated methods check the tags without reflection and
+// return the first failure as a *valex.FieldError, like ValidateStruct:
+//
+//	//go:generate valexgen -type User,Order
+//
+// Most built-in directives are supported. Sanitizers, dives into maps and
+// directives registered at runtime are not; rules set with SetRules do not
+// apply to generated code.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+func main() {
+	typeNames := flag.String("type", "", "comma-separated struct `names`, defaults to all structs with val tags")
+	out := flag.String("o", "", "output `file`, defaults to <file>_valex.go")
+	flag.Parse()
+
+	file := flag.Arg(0)
+	if file == "" {
+		file = os.Getenv("GOFILE")
+	}
+	if err := run(file, *typeNames, *out); err != nil {
+		fmt.Fprintln(os.Stderr, "valexgen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(file, typeNames, out string) error {
+	if file == "" {
+		return fmt.Errorf("no input file, pass one or run from go:generate")
+	}
+	src, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	var names []string
+	if typeNames != "" {
+		names = strings.Split(typeNames, ",")
+	}
+	gen, err := generate(file, src, names)
+	if err != nil {
+		return err
+	}
+	if out == "" {
+		out = strings.TrimSuffix(file, ".go") + "_valex.go"
+	}
+	return os.WriteFile(out, gen, 0o644)
+}
@@ -0,0 +1,67 @@
+// Command valexstruct generates Go structs with `val` tags from a JSON Schema
+// or a JSON rules file, for contracts that are designed schema first:
+//
+//	//go:generate valexstruct -schema user.schema.json -pkg api -o user_gen.go
+//
+// A rules file holds valex.Rules, i.e. an object mapping type names to field
+// names to tag values.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/tedla-brandsema/valex"
+)
+
+func main() {
+	schemaFile := flag.String("schema", "", "JSON Schema `file` to generate from")
+	rulesFile := flag.String("rules", "", "JSON rules `file` to generate from")
+	pkg := flag.String("pkg", os.Getenv("GOPACKAGE"), "package `name` of the generated file")
+	typeName := flag.String("type", "", "`name` of the root type when the schema has no title")
+	out := flag.String("o", "", "output `file`, defaults to stdout")
+	flag.Parse()
+
+	if err := run(*schemaFile, *rulesFile, *pkg, *typeName, *out); err != nil {
+		fmt.Fprintln(os.Stderr, "valexstruct:", err)
+		os.Exit(1)
+	}
+}
+
+func run(schemaFile, rulesFile, pkg, typeName, out string) error {
+	if (schemaFile == "") == (rulesFile == "") {
+		return fmt.Errorf("set exactly one of -schema and -rules")
+	}
+	cfg := valex.StructGenConfig{Package: pkg, TypeName: typeName, Generator: "valexstruct"}
+
+	var src []byte
+	if schemaFile != "" {
+		schema, err := os.ReadFile(schemaFile)
+		if err != nil {
+			return err
+		}
+		if src, err = valex.StructsFromJSONSchema(schema, cfg); err != nil {
+			return fmt.Errorf("%s: %w", schemaFile, err)
+		}
+	} else {
+		data, err := os.ReadFile(rulesFile)
+		if err != nil {
+			return err
+		}
+		var rules valex.Rules
+		if err := json.Unmarshal(data, &rules); err != nil {
+			return fmt.Errorf("%s: %w", rulesFile, err)
+		}
+		if src, err = valex.StructsFromRules(rules, cfg); err != nil {
+			return fmt.Errorf("%s: %w", rulesFile, err)
+		}
+	}
+
+	if out == "" {
+		_, err := os.Stdout.Write(src)
+		return err
+	}
+	return os.WriteFile(out, src, 0o644)
+}
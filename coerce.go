@@ -0,0 +1,139 @@
+package valex
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+const defaultKeyTag = "form"
+
+// CoercionErrors maps source keys to the error found converting or
+// validating their value.
+type CoercionErrors map[string]error
+
+func (ce CoercionErrors) keys() []string {
+	keys := make([]string, 0, len(ce))
+	for k := range ce {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (ce CoercionErrors) Error() string {
+	keys := ce.keys()
+	msgs := make([]string, len(keys))
+	for n, k := range keys {
+		msgs[n] = fmt.Sprintf("%s: %v", k, ce[k])
+	}
+	return strings.Join(msgs, "; ")
+}
+
+func (ce CoercionErrors) Unwrap() []error {
+	keys := ce.keys()
+	errs := make([]error, len(keys))
+	for n, k := range keys {
+		errs[n] = ce[k]
+	}
+	return errs
+}
+
+type coercedField struct {
+	key  string
+	path FieldPath
+}
+
+// Coerce populates the struct dst points to from string values such as form
+// values or environment variables, converting each value to its field's
+// type, and then validates the struct. Keys are taken from the `form` struct
+// tag, or the tag set with WithKeyTag, and default to the field name.
+// Embedded structs are flattened. Conversion and validation failures are
+// reported per key as CoercionErrors.
+func (e *Engine) Coerce(dst any, src map[string]string, opts ...Option) error {
+	val := reflect.ValueOf(dst)
+	if val.Kind() != reflect.Ptr || val.IsNil() || val.Elem().Kind() != reflect.Struct {
+		return WithCategory(fmt.Errorf("expected a pointer to a struct but got %T", dst), CategoryConfig)
+	}
+	o := newOptions(opts)
+	keyTag := o.keyTag
+	if keyTag == "" {
+		keyTag = defaultKeyTag
+	}
+
+	if o.keyFold {
+		folded := make(map[string]string, len(src))
+		for k, v := range src {
+			folded[strings.ToLower(k)] = v
+		}
+		src = folded
+	}
+
+	var fields []coercedField
+	errs := make(CoercionErrors)
+	coerceStruct(val.Elem(), nil, keyTag, o.keyFold, src, &fields, errs)
+
+	_, err := e.ValidateStruct(dst, append(opts, WithCollectAll())...)
+	var verrs ValidationErrors
+	if err != nil && !errors.As(err, &verrs) {
+		return err
+	}
+	for _, fe := range verrs {
+		key := fe.Field
+		for _, f := range fields {
+			if fe.Path.HasPrefix(f.path) {
+				key = f.key
+				break
+			}
+		}
+		if _, ok := errs[key]; !ok {
+			errs[key] = fe
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func coerceStruct(val reflect.Value, path FieldPath, keyTag string, fold bool, src map[string]string, fields *[]coercedField, errs CoercionErrors) {
+	t := val.Type()
+	for n := 0; n < t.NumField(); n++ {
+		field := t.Field(n)
+		key, opts, _ := strings.Cut(field.Tag.Get(keyTag), ",")
+		if key == "-" && opts == "" {
+			continue
+		}
+		fieldVal := val.Field(n)
+		if field.Anonymous && key == "" && fieldVal.Kind() == reflect.Struct {
+			coerceStruct(fieldVal, path.Field(field.Name), keyTag, fold, src, fields, errs)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if key == "" {
+			key = field.Name
+		}
+		fieldPath := path.Field(field.Name)
+		*fields = append(*fields, coercedField{key: key, path: fieldPath})
+
+		lookup := key
+		if fold {
+			lookup = strings.ToLower(key)
+		}
+		raw, ok := src[lookup]
+		if !ok {
+			continue
+		}
+		if err := setFromString(fieldVal, raw); err != nil {
+			errs[key] = &FieldError{Field: fieldPath.String(), Path: fieldPath, Err: WithCategory(err, CategoryValidation)}
+		}
+	}
+}
+
+func Coerce(dst any, src map[string]string, opts ...Option) error {
+	return std.Coerce(dst, src, opts...)
+}
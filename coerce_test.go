@@ -0,0 +1,113 @@
+package valex
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type coerceBase struct {
+	Region string `form:"region" env:"REGION" val:"min,size=2"`
+}
+
+type coerceDummy struct {
+	coerceBase `val:"dive"`
+	Port       int           `form:"port" env:"PORT" val:"range,min=1,max=65535"`
+	Debug      bool          `form:"debug" env:"DEBUG"`
+	Timeout    time.Duration `form:"timeout" env:"TIMEOUT"`
+	Ratio      *float64      `form:"ratio"`
+	Hosts      []string      `form:"hosts" env:"HOSTS"`
+	Name       string        `val:"default=svc"`
+	Skipped    string        `form:"-" env:"-"`
+}
+
+func TestCoerce(t *testing.T) {
+	var got coerceDummy
+	err := Coerce(&got, map[string]string{
+		"region":  "eu",
+		"port":    "8080",
+		"debug":   "true",
+		"timeout": "1m30s",
+		"ratio":   "0.25",
+		"hosts":   "a b",
+		"Skipped": "x",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ratio := 0.25
+	want := coerceDummy{
+		coerceBase: coerceBase{Region: "eu"},
+		Port:       8080,
+		Debug:      true,
+		Timeout:    90 * time.Second,
+		Ratio:      &ratio,
+		Hosts:      []string{"a", "b"},
+		Name:       "svc",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %+v, got %+v", want, got)
+	}
+}
+
+func TestCoerce_KeyTag(t *testing.T) {
+	var got coerceDummy
+	err := Coerce(&got, map[string]string{"REGION": "us", "PORT": "443"}, WithKeyTag("env"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Region != "us" || got.Port != 443 {
+		t.Errorf("expected values read by env keys, got %+v", got)
+	}
+}
+
+func TestCoerce_Errors(t *testing.T) {
+	var got coerceDummy
+	err := Coerce(&got, map[string]string{
+		"region": "e",
+		"port":   "eighty",
+		"debug":  "maybe",
+	})
+	var errs CoercionErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("expected CoercionErrors, got %v", err)
+	}
+	// port only reports the conversion, not the range check on its zero value
+	wantKeys := []string{"debug", "port", "region"}
+	if got := errs.keys(); !reflect.DeepEqual(got, wantKeys) {
+		t.Fatalf("expected errors for %v, got %v", wantKeys, errs)
+	}
+	if !strings.Contains(errs["port"].Error(), `invalid value "eighty" for int`) {
+		t.Errorf("expected a conversion error for port, got %v", errs["port"])
+	}
+	var fe *FieldError
+	if !errors.As(errs["region"], &fe) || fe.Directive != "min" {
+		t.Errorf("expected a min failure for region, got %v", errs["region"])
+	}
+	if c := Categorize(err); c != CategoryValidation {
+		t.Errorf("expected a validation error, got %v", c)
+	}
+	body := ErrorBody(err, nil, NestedErrors)
+	if _, ok := body["port"]; !ok {
+		t.Errorf("expected error body keyed by source key, got %v", body)
+	}
+}
+
+func TestCoerce_NotAPointer(t *testing.T) {
+	err := Coerce(coerceDummy{}, nil)
+	if err == nil || Categorize(err) != CategoryConfig {
+		t.Errorf("expected a config error, got %v", err)
+	}
+}
+
+func TestCoerce_KeyFold(t *testing.T) {
+	var got coerceDummy
+	if err := Coerce(&got, map[string]string{"Port": "80", "REGION": "eu"}, WithKeyFold()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Port != 80 || got.Region != "eu" {
+		t.Errorf("expected keys to match regardless of case, got %+v", got)
+	}
+}
@@ -0,0 +1,12 @@
+//go:build !valex_notables && !valex_noconfusables
+
+package valex
+
+import _ "embed"
+
+//go:embed confusables.txt
+var confusablesData string
+
+func init() {
+	embedTable(confusablesTable, confusablesData)
+}
@@ -0,0 +1,83 @@
+package valex
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Contrib is a set of optional directives maintained outside the core
+// package, e.g. phone number or geo validators. Contrib packages register
+// themselves from an init func, so importing one for its side effects is
+// enough to make its directives available to `val` tags:
+//
+//	import _ "github.com/tedla-brandsema/valex/contrib/phone"
+type Contrib struct {
+	Name        string
+	Description string
+	// Register adds the directives and sanitizers of the contrib.
+	Register func(r Registrar)
+}
+
+var contribs = struct {
+	mut sync.RWMutex
+	m   map[string]Contrib
+}{m: make(map[string]Contrib)}
+
+// RegisterContrib makes c available to every engine: it is applied to the
+// default engine right away and to engines created later by NewEngine.
+// Engines that already exist can adopt it with UseContrib. RegisterContrib
+// panics when a contrib with the same name is already registered.
+func RegisterContrib(c Contrib) {
+	if c.Name == "" || c.Register == nil {
+		panic("valex: contrib needs a name and a Register func")
+	}
+
+	contribs.mut.Lock()
+	if _, ok := contribs.m[c.Name]; ok {
+		contribs.mut.Unlock()
+		panic(fmt.Sprintf("valex: contrib %q registered twice", c.Name))
+	}
+	contribs.m[c.Name] = c
+	contribs.mut.Unlock()
+
+	c.Register(std)
+}
+
+// Contribs lists the registered contribs sorted by name.
+func Contribs() []Contrib {
+	contribs.mut.RLock()
+	defer contribs.mut.RUnlock()
+
+	cs := make([]Contrib, 0, len(contribs.m))
+	for _, c := range contribs.m {
+		cs = append(cs, c)
+	}
+	sort.Slice(cs, func(i, j int) bool {
+		return cs[i].Name < cs[j].Name
+	})
+	return cs
+}
+
+// UseContrib applies the named contribs to r, e.g. a Tenant or an engine
+// created before the contribs were registered.
+func UseContrib(r Registrar, names ...string) error {
+	contribs.mut.RLock()
+	defer contribs.mut.RUnlock()
+
+	for _, name := range names {
+		if _, ok := contribs.m[name]; !ok {
+			return fmt.Errorf("unknown contrib %q, is its package imported?", name)
+		}
+	}
+	for _, name := range names {
+		contribs.m[name].Register(r)
+	}
+	return nil
+}
+
+func registerContribs(e *Engine) {
+	for _, c := range Contribs() {
+		c.Register(e)
+	}
+}
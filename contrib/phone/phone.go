@@ -0,0 +1,59 @@
+// Package phone is a valex contrib providing phone number directives.
+// Import it for its side effects to use them in `val` tags:
+//
+//	import _ "github.com/tedla-brandsema/valex/contrib/phone"
+//
+//	type Contact struct {
+//		Mobile string `val:"e164"`
+//	}
+package phone
+
+import (
+	"fmt"
+
+	"github.com/tedla-brandsema/valex"
+)
+
+func init() {
+	valex.RegisterContrib(valex.Contrib{
+		Name:        "phone",
+		Description: "phone number directives (e164)",
+		Register: func(r valex.Registrar) {
+			valex.RegisterDirective(r, &E164Validator{})
+		},
+	})
+}
+
+// E164Validator accepts phone numbers in E.164 format: a "+", a country code
+// not starting with 0 and at most 15 digits in total.
+type E164Validator struct{}
+
+func (v *E164Validator) Validate(val string) (ok bool, err error) {
+	if len(val) < 3 || val[0] != '+' {
+		return false, fmt.Errorf("invalid phone number %q: expected \"+\" followed by the country code", val)
+	}
+	digits := val[1:]
+	if len(digits) > 15 {
+		return false, fmt.Errorf("invalid phone number %q: more than 15 digits", val)
+	}
+	if digits[0] == '0' {
+		return false, fmt.Errorf("invalid phone number %q: country code cannot start with 0", val)
+	}
+	for _, r := range digits {
+		if r < '0' || r > '9' {
+			return false, fmt.Errorf("invalid phone number %q: unexpected %q", val, r)
+		}
+	}
+	return true, nil
+}
+
+func (v *E164Validator) Name() string {
+	return "e164"
+}
+
+func (v *E164Validator) Handle(val string) error {
+	if ok, err := v.Validate(val); !ok {
+		return err
+	}
+	return nil
+}
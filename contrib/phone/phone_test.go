@@ -0,0 +1,45 @@
+package phone
+
+import (
+	"testing"
+
+	"github.com/tedla-brandsema/valex"
+)
+
+func TestE164Validator(t *testing.T) {
+	v := &E164Validator{}
+	tests := []struct {
+		input string
+		ok    bool
+	}{
+		{"+31612345678", true},
+		{"+14155552671", true},
+		{"+123456789012345", true},
+		{"+1234567890123456", false},
+		{"31612345678", false},
+		{"+0612345678", false},
+		{"+31 6 1234", false},
+		{"+", false},
+	}
+	for _, tc := range tests {
+		ok, err := v.Validate(tc.input)
+		if ok != tc.ok {
+			t.Errorf("%T(%q): expected ok=%v, got ok=%v (err: %v)", *v, tc.input, tc.ok, ok, err)
+		}
+	}
+}
+
+type contact struct {
+	Mobile string `val:"e164"`
+}
+
+func TestContrib(t *testing.T) {
+	for _, e := range []*valex.Engine{valex.Default(), valex.NewEngine()} {
+		if ok, err := e.ValidateStruct(contact{Mobile: "+31612345678"}); !ok {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if ok, _ := e.ValidateStruct(contact{Mobile: "0612345678"}); ok {
+			t.Error("expected an invalid number to fail")
+		}
+	}
+}
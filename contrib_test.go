@@ -0,0 +1,67 @@
+package valex
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+type contribDummy struct {
+	Code string `val:"allcaps"`
+}
+
+func TestRegisterContrib(t *testing.T) {
+	before := NewEngine()
+	RegisterContrib(Contrib{
+		Name: "test",
+		Register: func(r Registrar) {
+			RegisterDirective(r, &allCapsValidator{})
+		},
+	})
+
+	for _, e := range []*Engine{std, NewEngine()} {
+		if ok, err := e.ValidateStruct(contribDummy{Code: "ABC"}); !ok {
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+	if _, err := before.ValidateStruct(contribDummy{Code: "ABC"}); err == nil {
+		t.Error("expected an unknown directive error on an engine created before the contrib")
+	}
+	if err := UseContrib(before, "test"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ok, err := before.ValidateStruct(contribDummy{Code: "abc"}); ok {
+		t.Errorf("expected an error, got %v", err)
+	}
+	if err := UseContrib(before, "nope"); err == nil {
+		t.Error("expected an error for an unknown contrib")
+	}
+
+	var found bool
+	for _, c := range Contribs() {
+		found = found || c.Name == "test"
+	}
+	if !found {
+		t.Error("contrib not listed")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic for a duplicate contrib")
+		}
+	}()
+	RegisterContrib(Contrib{Name: "test", Register: func(Registrar) {}})
+}
+
+type allCapsValidator struct{}
+
+func (v *allCapsValidator) Name() string {
+	return "allcaps"
+}
+
+func (v *allCapsValidator) Handle(val string) error {
+	if strings.ToUpper(val) != val {
+		return fmt.Errorf("%q is not in capitals", val)
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package valex
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// RuleRef identifies one directive in the tag of a struct field.
+type RuleRef struct {
+	Type      string `json:"type"`
+	Field     string `json:"field"`
+	Directive string `json:"directive"`
+}
+
+func (r RuleRef) String() string {
+	return fmt.Sprintf("%s.%s:%s", r.Type, r.Field, r.Directive)
+}
+
+// RuleCoverage counts how often a rule passed and failed.
+type RuleCoverage struct {
+	RuleRef
+	Passed int `json:"passed"`
+	Failed int `json:"failed"`
+}
+
+// Coverage records which rules were exercised while it was active, much like
+// code coverage but for `val` tags. It knows every rule of the struct types
+// the engine compiled while tracking and of the types passed to Require.
+type Coverage struct {
+	e     *Engine
+	mut   sync.Mutex
+	rules map[RuleRef]*RuleCoverage
+}
+
+// StartCoverage starts recording rule coverage, replacing any coverage being
+// recorded. Typically called from TestMain.
+func (e *Engine) StartCoverage() *Coverage {
+	c := &Coverage{e: e, rules: make(map[RuleRef]*RuleCoverage)}
+	e.coverage.Store(c)
+	e.resetPlans() // register the rules of types compiled before
+	return c
+}
+
+// StopCoverage stops recording. The Coverage keeps what it recorded so far.
+func (e *Engine) StopCoverage() {
+	e.coverage.Store(nil)
+}
+
+func (c *Coverage) register(p *structPlan) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	for _, f := range p.fields {
+		for _, steps := range [][]step{f.sanitizers, f.steps, f.elemSanitizers, f.elemSteps} {
+			for _, s := range steps {
+				if s.rule.Directive != "" && c.rules[s.rule] == nil {
+					c.rules[s.rule] = &RuleCoverage{RuleRef: s.rule}
+				}
+			}
+		}
+	}
+}
+
+func (c *Coverage) record(r RuleRef, passed bool) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	rc := c.rules[r]
+	if rc == nil {
+		rc = &RuleCoverage{RuleRef: r}
+		c.rules[r] = rc
+	}
+	if passed {
+		rc.Passed++
+	} else {
+		rc.Failed++
+	}
+}
+
+// Require adds the rules of the struct types of vs, and of the types they
+// dive into, so they are reported even if no test ever validates them.
+func (c *Coverage) Require(vs ...any) error {
+	for _, v := range vs {
+		t := reflect.TypeOf(v)
+		for t != nil && t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		if t == nil || t.Kind() != reflect.Struct {
+			return fmt.Errorf("expected a struct but got %T", v)
+		}
+		if err := c.e.walkPlans(t, options{}, func(_ reflect.Type, p *structPlan) { c.register(p) }); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Report returns the coverage of every known rule, sorted by type, field and
+// directive.
+func (c *Coverage) Report() []RuleCoverage {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	report := make([]RuleCoverage, 0, len(c.rules))
+	for _, rc := range c.rules {
+		report = append(report, *rc)
+	}
+	sort.Slice(report, func(i, j int) bool {
+		return report[i].String() < report[j].String()
+	})
+	return report
+}
+
+// Uncovered lists the known rules that never ran.
+func (c *Coverage) Uncovered() []RuleRef {
+	var refs []RuleRef
+	for _, rc := range c.Report() {
+		if rc.Passed+rc.Failed == 0 {
+			refs = append(refs, rc.RuleRef)
+		}
+	}
+	return refs
+}
+
+// Ratio returns the fraction of known rules that ran at least once.
+func (c *Coverage) Ratio() float64 {
+	report := c.Report()
+	if len(report) == 0 {
+		return 1
+	}
+	return float64(len(report)-len(c.Uncovered())) / float64(len(report))
+}
+
+// Verify returns an error listing every uncovered rule, for failing a test
+// run in CI.
+func (c *Coverage) Verify() error {
+	uncovered := c.Uncovered()
+	if len(uncovered) == 0 {
+		return nil
+	}
+	lines := make([]string, len(uncovered))
+	for n, r := range uncovered {
+		lines[n] = "\t" + r.String()
+	}
+	return fmt.Errorf("%d validation rules were never exercised:\n%s", len(uncovered), strings.Join(lines, "\n"))
+}
@@ -0,0 +1,68 @@
+package valex
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type coverageInner struct {
+	Code string `val:"alphanum"`
+}
+
+type coverageDummy struct {
+	Name  string          `val:"min,size=3"`
+	Email string          `val:"email"`
+	Inner []coverageInner `val:"dive"`
+}
+
+func TestEngine_Coverage(t *testing.T) {
+	e := NewEngine()
+	// compiled before tracking started, must still be known
+	e.ValidateStruct(coverageDummy{Name: "john", Email: "a@b.co"})
+
+	c := e.StartCoverage()
+	defer e.StopCoverage()
+	if err := c.Require(coverageDummy{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	e.ValidateStruct(coverageDummy{Name: "jo", Email: "a@b.co"})
+	e.ValidateStruct(coverageDummy{Name: "john", Email: "a@b.co"})
+
+	want := []RuleRef{{Type: "valex.coverageInner", Field: "Code", Directive: "alphanum"}}
+	if got := c.Uncovered(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Uncovered() = %v, want %v", got, want)
+	}
+	err := c.Verify()
+	if err == nil || !strings.Contains(err.Error(), "valex.coverageInner.Code:alphanum") {
+		t.Errorf("Verify() = %v, want the uncovered rule listed", err)
+	}
+
+	for _, rc := range c.Report() {
+		if rc.Field == "Name" && (rc.Passed != 1 || rc.Failed != 1) {
+			t.Errorf("Name coverage = %+v, want one pass and one failure", rc)
+		}
+	}
+
+	e.ValidateStruct(coverageDummy{Name: "john", Email: "a@b.co", Inner: []coverageInner{{Code: "a1"}}})
+	if err := c.Verify(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if c.Ratio() != 1 {
+		t.Errorf("Ratio() = %v, want 1", c.Ratio())
+	}
+}
+
+func TestCoverage_Require(t *testing.T) {
+	c := NewEngine().StartCoverage()
+	if err := c.Require("nope"); err == nil {
+		t.Error("expected an error for a non-struct")
+	}
+	if err := c.Require(&coverageDummy{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := len(c.Uncovered()); got != 3 {
+		t.Errorf("len(Uncovered()) = %d, want 3", got)
+	}
+}
@@ -0,0 +1,12 @@
+//go:build !valex_notables && !valex_nocurrencies
+
+package valex
+
+import _ "embed"
+
+//go:embed currencies.txt
+var currencyData string
+
+func init() {
+	embedTable(currencyTable, currencyData)
+}
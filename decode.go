@@ -0,0 +1,105 @@
+package valex
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+const defaultMaxDecodeBytes = 1 << 20
+
+// DecodeAndValidate decodes a single JSON value from r into v, a pointer to
+// a struct, and validates it with WithCollectAll. Unknown fields are
+// rejected unless WithUnknownFields is given, and reading stops with a *http.MaxBytesError once the input
+// exceeds 1 MiB, or the limit set with WithMaxBytes. A value of the wrong
+// type does not stop decoding: it is reported as a FieldError together with
+// the validation failures of the other fields.
+func (e *Engine) DecodeAndValidate(r io.Reader, v any, opts ...Option) error {
+	val := reflect.ValueOf(v)
+	if val.Kind() != reflect.Ptr || val.IsNil() || val.Elem().Kind() != reflect.Struct {
+		return WithCategory(fmt.Errorf("expected a pointer to a struct but got %T", v), CategoryConfig)
+	}
+	o := newOptions(opts)
+	limit := o.maxBytes
+	if limit == 0 {
+		limit = defaultMaxDecodeBytes
+	}
+	if limit > 0 {
+		r = http.MaxBytesReader(nil, io.NopCloser(r), limit)
+	}
+
+	dec := json.NewDecoder(r)
+	if !o.unknownFields {
+		dec.DisallowUnknownFields()
+	}
+	var typeErr *json.UnmarshalTypeError
+	var decodeErr *FieldError
+	switch err := dec.Decode(v); {
+	case errors.As(err, &typeErr) && typeErr.Field != "":
+		decodeErr = e.typeFieldError(val.Elem().Type(), typeErr)
+	case errors.Is(err, io.EOF):
+		return WithCategory(errors.New("input is empty"), CategoryDecode)
+	case err != nil:
+		return decodeError(err)
+	}
+	if err := dec.Decode(&struct{}{}); !errors.Is(err, io.EOF) {
+		if err == nil {
+			err = errors.New("input must contain a single JSON value")
+		}
+		return decodeError(err)
+	}
+
+	_, err := e.ValidateStruct(v, append(opts, WithCollectAll())...)
+	if decodeErr == nil {
+		return err
+	}
+	var verrs ValidationErrors
+	if err != nil && !errors.As(err, &verrs) {
+		return err
+	}
+	errs := ValidationErrors{decodeErr}
+	for _, fe := range verrs {
+		if !fe.Path.HasPrefix(decodeErr.Path) { // the field kept its zero value
+			errs = append(errs, fe)
+		}
+	}
+	return errs
+}
+
+func DecodeAndValidate(r io.Reader, v any, opts ...Option) error {
+	return std.DecodeAndValidate(r, v, opts...)
+}
+
+func decodeError(err error) error {
+	var maxBytes *http.MaxBytesError
+	if errors.As(err, &maxBytes) {
+		return fmt.Errorf("input too large: %w", err)
+	}
+	return WithCategory(err, CategoryDecode)
+}
+
+// typeFieldError reports a json.UnmarshalTypeError on the Go path of the
+// field, which encoding/json gives as dotted json names.
+func (e *Engine) typeFieldError(t reflect.Type, typeErr *json.UnmarshalTypeError) *FieldError {
+	err := WithCategory(fmt.Errorf("expected %s but got %s", typeErr.Type, typeErr.Value), CategoryDecode)
+
+	var path FieldPath
+	ft := t
+	for _, key := range strings.Split(typeErr.Field, ".") {
+		for ft.Kind() == reflect.Ptr || ft.Kind() == reflect.Slice || ft.Kind() == reflect.Array || ft.Kind() == reflect.Map {
+			ft = ft.Elem()
+		}
+		if ft.Kind() != reflect.Struct {
+			return &FieldError{Field: typeErr.Field, Err: err}
+		}
+		var ok bool
+		if path, ft, ok = jsonField(ft, key, path); !ok {
+			return &FieldError{Field: typeErr.Field, Err: err}
+		}
+	}
+	return &FieldError{Field: e.fieldName(t, path), Path: path, Err: err}
+}
@@ -0,0 +1,81 @@
+package valex
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+type decodeAddress struct {
+	Zip string `json:"zip" val:"len,min=4,max=6"`
+}
+
+type decodeUser struct {
+	Name    string        `json:"name" val:"min,size=3"`
+	Age     int           `json:"age" val:"range,min=18,max=130"`
+	Address decodeAddress `json:"address" val:"dive"`
+}
+
+func TestDecodeAndValidate(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        string
+		opts         []Option
+		wantCategory ErrorCategory
+		wantFields   []string
+	}{
+		{name: "valid", input: `{"name":"john","age":30,"address":{"zip":"1234"}}`},
+		{name: "invalid", input: `{"name":"jo","age":30,"address":{"zip":"1"}}`, wantCategory: CategoryValidation, wantFields: []string{"Name", "Address.Zip"}},
+		{name: "wrong type", input: `{"name":"jo","age":"old","address":{"zip":"1234"}}`, wantCategory: CategoryDecode, wantFields: []string{"Age", "Name"}},
+		{name: "nested wrong type", input: `{"name":"john","age":30,"address":{"zip":1234}}`, wantCategory: CategoryDecode, wantFields: []string{"Address.Zip"}},
+		{name: "unknown field", input: `{"name":"john","age":30,"admin":true}`, wantCategory: CategoryDecode},
+		{name: "malformed", input: `{"name":`, wantCategory: CategoryDecode},
+		{name: "empty", input: ``, wantCategory: CategoryDecode},
+		{name: "trailing data", input: `{"name":"john","age":30,"address":{"zip":"1234"}} {}`, wantCategory: CategoryDecode},
+		{name: "not an object", input: `[1]`, wantCategory: CategoryDecode},
+		{name: "too large", input: `{"name":"` + strings.Repeat("x", 100) + `"}`, opts: []Option{WithMaxBytes(64)}, wantCategory: CategorySize},
+		{name: "no limit", input: `{"name":"` + strings.Repeat("x", 2<<20) + `","age":30,"address":{"zip":"1234"}}`, opts: []Option{WithMaxBytes(-1)}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var u decodeUser
+			err := DecodeAndValidate(strings.NewReader(tc.input), &u, tc.opts...)
+			if tc.wantCategory == CategoryUnknown {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if got := Categorize(err); got != tc.wantCategory {
+				t.Errorf("Categorize(%v) = %v, want %v", err, got, tc.wantCategory)
+			}
+			if tc.wantFields == nil {
+				return
+			}
+			var errs ValidationErrors
+			if !errors.As(err, &errs) {
+				t.Fatalf("expected ValidationErrors, got %v", err)
+			}
+			var fields []string
+			for _, fe := range errs {
+				fields = append(fields, fe.Field)
+			}
+			if strings.Join(fields, ",") != strings.Join(tc.wantFields, ",") {
+				t.Errorf("got fields %v, want %v", fields, tc.wantFields)
+			}
+		})
+	}
+}
+
+func TestDecodeAndValidate_TooLarge(t *testing.T) {
+	var u decodeUser
+	err := DecodeAndValidate(strings.NewReader(`{"name":"`+strings.Repeat("x", 2<<20)+`"}`), &u)
+	var maxBytes *http.MaxBytesError
+	if !errors.As(err, &maxBytes) || maxBytes.Limit != defaultMaxDecodeBytes {
+		t.Errorf("expected a MaxBytesError at the default limit, got %v", err)
+	}
+	if err := DecodeAndValidate(strings.NewReader(`{}`), u); Categorize(err) != CategoryConfig {
+		t.Errorf("expected a config error for a non-pointer, got %v", err)
+	}
+}
@@ -0,0 +1,155 @@
+package valex
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+const defaultDirectiveName = "default"
+
+// defaultDirective sets zero-valued fields to a default, e.g.
+// `val:"default=8080,range,min=1,max=65535"`. Unlike other directives it
+// works on fields of any basic type, and it runs after the sanitizers but
+// before any validation, so it needs a pointer to the struct.
+type defaultDirective struct{}
+
+type defaultValue struct {
+	Value string `param:"default"`
+}
+
+var defaultParams = paramsOf(&defaultValue{})
+
+func (defaultDirective) valueType() reflect.Type {
+	return reflect.TypeFor[any]()
+}
+
+func (defaultDirective) params() params {
+	return defaultParams
+}
+
+func (defaultDirective) instance(args map[string]string) (any, error) {
+	d := &defaultValue{}
+	if err := processParams(d, defaultParams, args); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+func (defaultDirective) handleAny(_ context.Context, d any, val reflect.Value) error {
+	if !val.IsZero() {
+		return nil
+	}
+	if !val.CanSet() {
+		return WithCategory(fmt.Errorf("cannot set default value, pass a pointer to the struct"), CategoryConfig)
+	}
+	if err := setFromString(val, d.(*defaultValue).Value); err != nil {
+		return WithCategory(fmt.Errorf("invalid default: %w", err), CategoryConfig)
+	}
+	return nil
+}
+
+// mutator marks directives that modify the field, which the plan runs right
+// after the field's sanitizers.
+func (defaultDirective) mutator() {}
+
+type mutator interface {
+	mutator()
+}
+
+// splitMutators separates the directives that modify a field from those that
+// only validate it, keeping their relative order.
+func splitMutators(steps []step) (mutators, rest []step) {
+	for _, s := range steps {
+		if _, ok := s.d.(mutator); ok {
+			mutators = append(mutators, s)
+		} else {
+			rest = append(rest, s)
+		}
+	}
+	return mutators, rest
+}
+
+var durationType = reflect.TypeFor[time.Duration]()
+
+// setFromString converts raw to val's type and stores it in val. Lists are
+// space separated.
+func setFromString(val reflect.Value, raw string) error {
+	convErr := func() error { // only allocated on failure
+		return fmt.Errorf("invalid value %q for %s", raw, val.Type())
+	}
+
+	switch val.Kind() {
+	case reflect.Ptr:
+		elem := reflect.New(val.Type().Elem())
+		if err := setFromString(elem.Elem(), raw); err != nil {
+			return err
+		}
+		val.Set(elem)
+	case reflect.String:
+		val.SetString(raw)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return convErr()
+		}
+		val.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if val.Type() == durationType {
+			d, err := time.ParseDuration(raw)
+			if err != nil {
+				return convErr()
+			}
+			val.SetInt(int64(d))
+			return nil
+		}
+		i, err := strconv.ParseInt(raw, 10, val.Type().Bits())
+		if err != nil {
+			return convErr()
+		}
+		val.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(raw, 10, val.Type().Bits())
+		if err != nil {
+			return convErr()
+		}
+		val.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(raw, val.Type().Bits())
+		if err != nil {
+			return convErr()
+		}
+		val.SetFloat(f)
+	case reflect.Slice:
+		if err := setSlice(val, raw, val.Type().String()); err != nil {
+			return convErr()
+		}
+	default:
+		return fmt.Errorf("conversion to %s is unsupported", val.Type())
+	}
+	return nil
+}
+
+func (d *defaultValue) describeSchema(f *schemaField) {
+	var v any = d.Value
+	switch f.schema["type"] {
+	case "integer":
+		if i, err := strconv.ParseInt(d.Value, 10, 64); err == nil {
+			v = i
+		}
+	case "number":
+		if n, err := strconv.ParseFloat(d.Value, 64); err == nil {
+			v = n
+		}
+	case "boolean":
+		if b, err := strconv.ParseBool(d.Value); err == nil {
+			v = b
+		}
+	case "string":
+	default:
+		return
+	}
+	f.schema["default"] = v
+}
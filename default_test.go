@@ -0,0 +1,115 @@
+package valex
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type defaultConfig struct {
+	Port    int           `val:"default=8080,range,min=1,max=65535"`
+	Host    string        `val:"default=localhost"`
+	Timeout time.Duration `val:"default=30s"`
+	Ratio   float32       `val:"default=0.5"`
+	Verbose *bool         `val:"default=true"`
+	Retries uint8         `val:"default=3"`
+	Tags    []string      `val:"default=a b"`
+	Name    string        `sane:"trim" val:"default=anon,min,size=3"`
+}
+
+func TestValidateStruct_Default(t *testing.T) {
+	cfg := &defaultConfig{Name: "   "}
+	if ok, err := ValidateStruct(cfg); !ok {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	verbose := true
+	want := &defaultConfig{
+		Port:    8080,
+		Host:    "localhost",
+		Timeout: 30 * time.Second,
+		Ratio:   0.5,
+		Verbose: &verbose,
+		Retries: 3,
+		Tags:    []string{"a", "b"},
+		Name:    "anon",
+	}
+	if !reflect.DeepEqual(cfg, want) {
+		t.Errorf("expected %+v, got %+v", want, cfg)
+	}
+}
+
+func TestValidateStruct_DefaultKeepsValues(t *testing.T) {
+	cfg := &defaultConfig{Port: 70000, Host: "example.com"}
+	_, err := ValidateStruct(cfg)
+	var fe *FieldError
+	if !errors.As(err, &fe) || fe.Field != "Port" {
+		t.Fatalf("expected the explicit port to be validated, got %v", err)
+	}
+	if cfg.Host != "example.com" {
+		t.Errorf("expected non-zero field to be kept, got %q", cfg.Host)
+	}
+}
+
+func TestValidateStruct_DefaultErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		data      any
+		errSubstr string
+	}{
+		{
+			name: "not a pointer",
+			data: struct {
+				Port int `val:"default=80"`
+			}{},
+			errSubstr: "pass a pointer",
+		},
+		{
+			name: "invalid value",
+			data: &struct {
+				Port int `val:"default=eighty"`
+			}{},
+			errSubstr: `invalid default: invalid value "eighty" for int`,
+		},
+		{
+			name: "overflow",
+			data: &struct {
+				Port int8 `val:"default=300"`
+			}{},
+			errSubstr: "invalid default",
+		},
+		{
+			name: "unsupported type",
+			data: &struct {
+				Ch chan int `val:"default=1"`
+			}{},
+			errSubstr: "unsupported",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ValidateStruct(tc.data)
+			if err == nil || !strings.Contains(err.Error(), tc.errSubstr) {
+				t.Fatalf("expected error containing %q, got %v", tc.errSubstr, err)
+			}
+			if c := Categorize(err); c != CategoryConfig {
+				t.Errorf("expected a config error, got %v", c)
+			}
+		})
+	}
+}
+
+func TestGenerateJSONSchema_Default(t *testing.T) {
+	s, err := GenerateJSONSchema(&defaultConfig{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	props := s["properties"].(map[string]any)
+	if got := props["Port"].(map[string]any)["default"]; got != int64(8080) {
+		t.Errorf("expected integer default 8080, got %#v", got)
+	}
+	if got := props["Host"].(map[string]any)["default"]; got != "localhost" {
+		t.Errorf("expected string default, got %#v", got)
+	}
+}
@@ -0,0 +1,115 @@
+package valex
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// Deprecation marks a directive as deprecated, optionally naming the
+// directive that replaces it.
+type Deprecation struct {
+	Directive   string `json:"directive"`
+	Replacement string `json:"replacement,omitempty"`
+	Message     string `json:"message,omitempty"`
+}
+
+func (d Deprecation) String() string {
+	s := fmt.Sprintf("directive %q is deprecated", d.Directive)
+	if d.Replacement != "" {
+		s += fmt.Sprintf(", use %q instead", d.Replacement)
+	}
+	if d.Message != "" {
+		s += ": " + d.Message
+	}
+	return s
+}
+
+// DeprecationUse is a struct field whose tag uses a deprecated directive.
+type DeprecationUse struct {
+	Deprecation
+	Type  string `json:"type"`
+	Field string `json:"field"`
+}
+
+func (u DeprecationUse) String() string {
+	return fmt.Sprintf("%s.%s: %s", u.Type, u.Field, u.Deprecation)
+}
+
+// Deprecate marks directive as deprecated. Directives keep working; every
+// struct field using one is reported to the deprecation handler and by
+// DeprecatedUses.
+func (e *Engine) Deprecate(directive, replacement, message string) error {
+	if _, ok := e.get(directive); !ok {
+		return fmt.Errorf("unknown directive %q", directive)
+	}
+	if replacement != "" {
+		if _, ok := e.get(replacement); !ok {
+			return fmt.Errorf("unknown replacement directive %q", replacement)
+		}
+	}
+	e.mut.Lock()
+	e.deprecations[directive] = Deprecation{Directive: directive, Replacement: replacement, Message: message}
+	e.mut.Unlock()
+
+	e.resetPlans() // let the handler see uses in already compiled types
+	return nil
+}
+
+func (e *Engine) deprecation(directive string) (Deprecation, bool) {
+	e.mut.RLock()
+	defer e.mut.RUnlock()
+
+	d, ok := e.deprecations[directive]
+	return d, ok
+}
+
+// SetDeprecationHandler sets a function that is called once for every
+// deprecated directive use the engine compiles, e.g. to log it.
+func (e *Engine) SetDeprecationHandler(h func(DeprecationUse)) {
+	e.mut.Lock()
+	e.onDeprecated = h
+	e.mut.Unlock()
+}
+
+func (e *Engine) reportDeprecations(p *structPlan) {
+	if len(p.deprecated) == 0 {
+		return
+	}
+	e.mut.RLock()
+	h := e.onDeprecated
+	e.mut.RUnlock()
+
+	if h != nil {
+		for _, u := range p.deprecated {
+			h(u)
+		}
+	}
+}
+
+// DeprecatedUses lists the deprecated directives used by the struct type of
+// v and the struct types it dives into, sorted by type and field.
+func (e *Engine) DeprecatedUses(v any, opts ...Option) ([]DeprecationUse, error) {
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("expected a struct but got %T", v)
+	}
+
+	var uses []DeprecationUse
+	err := e.walkPlans(t, newOptions(opts), func(_ reflect.Type, p *structPlan) {
+		uses = append(uses, p.deprecated...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(uses, func(i, j int) bool {
+		if uses[i].Type != uses[j].Type {
+			return uses[i].Type < uses[j].Type
+		}
+		return uses[i].Field < uses[j].Field
+	})
+	return uses, nil
+}
@@ -0,0 +1,112 @@
+package valex
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+type deprecatedInner struct {
+	Code string `val:"alphanum"`
+}
+
+type deprecatedDummy struct {
+	Name  string            `val:"min,size=3"`
+	Alias string            `val:"alphanum"`
+	Inner []deprecatedInner `val:"dive"`
+	Other string            `val:"email"`
+}
+
+func TestEngine_Deprecate(t *testing.T) {
+	e := NewEngine()
+	if err := e.Deprecate("nope", "", ""); err == nil {
+		t.Error("expected an error for an unknown directive")
+	}
+	if err := e.Deprecate("alphanum", "nope", ""); err == nil {
+		t.Error("expected an error for an unknown replacement")
+	}
+
+	var mut sync.Mutex
+	var reported []string
+	e.SetDeprecationHandler(func(u DeprecationUse) {
+		mut.Lock()
+		reported = append(reported, u.String())
+		mut.Unlock()
+	})
+
+	// compiled before the deprecation, reported once it is recompiled
+	if ok, err := e.ValidateStruct(deprecatedDummy{Name: "john", Alias: "a1", Other: "a@b.co"}); !ok {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := e.Deprecate("alphanum", "len", "alphanum is ambiguous about unicode"); err != nil {
+		t.Fatal(err)
+	}
+	for n := 0; n < 3; n++ {
+		if ok, err := e.ValidateStruct(deprecatedDummy{Name: "john", Alias: "a1", Inner: []deprecatedInner{{Code: "x"}}, Other: "a@b.co"}); !ok {
+			t.Fatalf("expected deprecated directives to keep working, got %v", err)
+		}
+	}
+
+	want := []string{
+		`valex.deprecatedDummy.Alias: directive "alphanum" is deprecated, use "len" instead: alphanum is ambiguous about unicode`,
+		`valex.deprecatedInner.Code: directive "alphanum" is deprecated, use "len" instead: alphanum is ambiguous about unicode`,
+	}
+	if !reflect.DeepEqual(reported, want) {
+		t.Errorf("expected each use to be reported once:\n%v\ngot:\n%v", want, reported)
+	}
+}
+
+func TestEngine_DeprecatedUses(t *testing.T) {
+	e := NewEngine()
+	if err := e.Deprecate("alphanum", "", ""); err != nil {
+		t.Fatal(err)
+	}
+	uses, err := e.DeprecatedUses(&deprecatedDummy{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var got []string
+	for _, u := range uses {
+		got = append(got, u.Type+"."+u.Field)
+	}
+	want := []string{"valex.deprecatedDummy.Alias", "valex.deprecatedInner.Code"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	if _, err := e.DeprecatedUses(42); err == nil {
+		t.Error("expected an error for a non-struct")
+	}
+}
+
+func TestEngine_DirectivesDeprecated(t *testing.T) {
+	e := NewEngine()
+	if err := e.Deprecate("alphanum", "len", ""); err != nil {
+		t.Fatal(err)
+	}
+	for _, d := range e.Directives() {
+		if (d.Deprecated != nil) != (d.Name == "alphanum") {
+			t.Errorf("unexpected deprecation for %q: %v", d.Name, d.Deprecated)
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	e.IntrospectionHandler().ServeHTTP(rec, httptest.NewRequest("GET", "/", nil))
+	var body struct {
+		Directives []DirectiveInfo `json:"directives"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	found := false
+	for _, d := range body.Directives {
+		if d.Name == "alphanum" {
+			found = d.Deprecated != nil && d.Deprecated.Replacement == "len"
+		}
+	}
+	if !found {
+		t.Error("expected the introspection endpoint to include the deprecation")
+	}
+}
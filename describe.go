@@ -0,0 +1,125 @@
+package valex
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// StructRules describes the rules of a struct type, see DescribeStruct.
+type StructRules struct {
+	Type   string       `json:"type"`
+	Fields []FieldRules `json:"fields"`
+}
+
+// FieldRules holds the directives of a field in the order they run.
+// Directives after dive are in Elem and apply to each element.
+type FieldRules struct {
+	Field      string          `json:"field"`
+	Tag        string          `json:"tag"`
+	Groups     []string        `json:"groups,omitempty"`
+	Directives []DirectiveRule `json:"directives"`
+	Dive       bool            `json:"dive,omitempty"`
+	Elem       []DirectiveRule `json:"elem,omitempty"`
+}
+
+// DirectiveRule is a directive with its parameters as configured. Markers
+// such as omitempty and warn have no parameters.
+type DirectiveRule struct {
+	Name       string         `json:"name"`
+	Params     map[string]any `json:"params,omitempty"`
+	Deprecated *Deprecation   `json:"deprecated,omitempty"`
+}
+
+// DescribeStruct returns the rules of the struct type of v, and of the
+// struct types it dives into, without validating anything, so tools can
+// document the constraints of a type or render them in a UI. It honours
+// rules set on the engine and the tenant and profile in opts. A tag that
+// does not parse is reported as an error.
+func (e *Engine) DescribeStruct(v any, opts ...Option) ([]StructRules, error) {
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, WithCategory(fmt.Errorf("expected a struct but got %T", v), CategoryConfig)
+	}
+	o := newOptions(opts)
+	pe, err := e.forProfile(o)
+	if err != nil {
+		return nil, WithCategory(err, CategoryConfig)
+	}
+	var tenant *Tenant
+	if o.tenant != "" {
+		var ok bool
+		if tenant, ok = pe.lookupTenant(o.tenant); !ok {
+			return nil, WithCategory(fmt.Errorf("unknown tenant %q", o.tenant), CategoryConfig)
+		}
+	}
+
+	var (
+		rules  []StructRules
+		tagErr error
+	)
+	err = pe.walkPlans(t, o, func(st reflect.Type, p *structPlan) {
+		sr := StructRules{Type: st.String(), Fields: []FieldRules{}}
+		for _, f := range p.fields {
+			if f.err != nil {
+				if tagErr == nil {
+					tagErr = &FieldError{Field: st.String() + "." + f.name, Path: f.path, Err: f.err}
+				}
+				continue
+			}
+			if f.tag == "" {
+				continue // only sanitized
+			}
+			steps, groups, err := pe.compileGroupedTag(f.tag, tenant)
+			if err != nil { // cannot happen, the plan compiled
+				tagErr = err
+				continue
+			}
+			fr := FieldRules{Field: f.name, Tag: f.tag, Groups: groups}
+			fieldSteps, elemSteps, dive := splitDive(steps)
+			fr.Directives, fr.Dive, fr.Elem = pe.describeSteps(fieldSteps), dive, pe.describeSteps(elemSteps)
+			sr.Fields = append(sr.Fields, fr)
+		}
+		rules = append(rules, sr)
+	})
+	if err == nil {
+		err = tagErr
+	}
+	if err != nil {
+		return nil, WithCategory(err, CategoryConfig)
+	}
+	return rules, nil
+}
+
+func DescribeStruct(v any, opts ...Option) ([]StructRules, error) {
+	return std.DescribeStruct(v, opts...)
+}
+
+func (e *Engine) describeSteps(steps []step) []DirectiveRule {
+	if len(steps) == 0 {
+		return nil
+	}
+	drs := make([]DirectiveRule, len(steps))
+	for n, s := range steps {
+		drs[n] = DirectiveRule{Name: s.name}
+		if d, ok := e.deprecation(s.name); ok {
+			drs[n].Deprecated = &d
+		}
+		if s.d == nil {
+			continue
+		}
+		val := reflect.Indirect(reflect.ValueOf(s.inst))
+		if val.Kind() != reflect.Struct {
+			continue
+		}
+		for _, p := range s.d.params() {
+			if drs[n].Params == nil {
+				drs[n].Params = make(map[string]any)
+			}
+			drs[n].Params[p.key] = val.Field(p.field).Interface()
+		}
+	}
+	return drs
+}
@@ -0,0 +1,81 @@
+package valex
+
+import (
+	"reflect"
+	"testing"
+)
+
+type describeAddress struct {
+	City string `val:"min,size=2"`
+}
+
+type describeDummy struct {
+	Name      string            `val:"omitempty,min,size=3,max,size=10,groups=create"`
+	Addresses []describeAddress `val:"max,size=2,dive"`
+	Nick      string            `sane:"trim"`
+	Untagged  int
+}
+
+func TestEngine_DescribeStruct(t *testing.T) {
+	e := NewEngine()
+	if err := e.Deprecate("max", "", "use range"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got, err := e.DescribeStruct(&describeDummy{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	dep, _ := e.deprecation("max")
+	want := []StructRules{
+		{
+			Type: "valex.describeDummy",
+			Fields: []FieldRules{
+				{
+					Field:  "Name",
+					Tag:    "omitempty,min,size=3,max,size=10,groups=create",
+					Groups: []string{"create"},
+					Directives: []DirectiveRule{
+						{Name: "omitempty"},
+						{Name: "min", Params: map[string]any{"size": 3, "runes": false}},
+						{Name: "max", Params: map[string]any{"size": 10, "runes": false}, Deprecated: &dep},
+					},
+				},
+				{
+					Field:      "Addresses",
+					Tag:        "max,size=2,dive",
+					Directives: []DirectiveRule{{Name: "max", Params: map[string]any{"size": 2, "runes": false}, Deprecated: &dep}},
+					Dive:       true,
+				},
+			},
+		},
+		{
+			Type:   "valex.describeAddress",
+			Fields: []FieldRules{{Field: "City", Tag: "min,size=2", Directives: []DirectiveRule{{Name: "min", Params: map[string]any{"size": 2, "runes": false}}}}},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %+v, got %+v", want, got)
+	}
+}
+
+func TestEngine_DescribeStruct_Errors(t *testing.T) {
+	type broken struct {
+		Name string `val:"nosuchdirective"`
+	}
+	tests := []struct {
+		name string
+		v    any
+		opts []Option
+	}{
+		{"not a struct", 42, nil},
+		{"broken tag", broken{}, nil},
+		{"unknown tenant", describeDummy{}, []Option{WithTenant("nope")}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := NewEngine().DescribeStruct(tc.v, tc.opts...); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,221 @@
+package valex
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+type DirectiveHandler[T any] interface {
+	Handle(val T) error
+}
+
+type Directive[T any] interface {
+	Name() string
+	DirectiveHandler[T]
+}
+
+// ContextHandler is implemented by directives that need a context, e.g. to
+// bound calls to external systems. HandleContext is used instead of Handle
+// when present.
+type ContextHandler[T any] interface {
+	HandleContext(ctx context.Context, val T) error
+}
+
+type anyDirective interface {
+	valueType() reflect.Type
+	params() params
+	instance(args map[string]string) (any, error)
+	handleAny(ctx context.Context, d any, val reflect.Value) error
+}
+
+type directiveWrapper[T any] struct {
+	proto Directive[T]
+	ps    params
+}
+
+func wrapDirective[T any](d Directive[T]) *directiveWrapper[T] {
+	return &directiveWrapper[T]{proto: d, ps: paramsOf(d)}
+}
+
+func (dw *directiveWrapper[T]) valueType() reflect.Type {
+	return reflect.TypeFor[T]()
+}
+
+func (dw *directiveWrapper[T]) params() params {
+	return dw.ps
+}
+
+// instance returns a copy of the registered directive configured with args,
+// leaving the registered prototype untouched.
+func (dw *directiveWrapper[T]) instance(args map[string]string) (any, error) {
+	d := cloneDirective(dw.proto)
+	if err := processParams(d, dw.ps, args); err != nil {
+		return nil, err
+	}
+	if c, ok := any(d).(configurer); ok {
+		if err := c.configure(); err != nil {
+			return nil, err
+		}
+	}
+	return d, nil
+}
+
+// configurer is implemented by directives that derive state from their
+// parameters, e.g. a compiled pattern.
+type configurer interface {
+	configure() error
+}
+
+func (dw *directiveWrapper[T]) handleAny(ctx context.Context, d any, val reflect.Value) error {
+	val, err := indirect[T](val)
+	if err != nil {
+		return err
+	}
+	v, err := valParse[T](val)
+	if err != nil {
+		return err
+	}
+	if ch, ok := d.(ContextHandler[T]); ok {
+		return ch.HandleContext(ctx, v)
+	}
+	return d.(Directive[T]).Handle(v)
+}
+
+func cloneDirective[D any](d D) D {
+	v := reflect.ValueOf(d)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return d
+	}
+	c := reflect.New(v.Elem().Type())
+	c.Elem().Set(v.Elem())
+	return c.Interface().(D)
+}
+
+// indirect follows pointers in val to the value a directive on T validates,
+// so that e.g. a *string field takes string directives. Nil pointers are
+// left to omitempty and required.
+func indirect[T any](val reflect.Value) (reflect.Value, error) {
+	for val.Kind() == reflect.Ptr && !val.Type().AssignableTo(reflect.TypeFor[T]()) {
+		if val.IsNil() {
+			return val, fmt.Errorf("value is nil")
+		}
+		val = val.Elem()
+	}
+	return val, nil
+}
+
+func valParse[T any](val reflect.Value) (T, error) {
+	var zero T
+	if !val.CanInterface() {
+		return zero, fmt.Errorf("cannot access field value")
+	}
+
+	if val.CanAddr() && val.Type() == reflect.TypeFor[T]() {
+		return *(*T)(val.Addr().UnsafePointer()), nil // saves boxing the value
+	}
+
+	if !val.Type().AssignableTo(reflect.TypeFor[T]()) { // type assertion
+		return zero, fmt.Errorf("type mismatch: expected %v, got %v", reflect.TypeFor[T](), val.Type())
+	}
+
+	typedVal, ok := val.Interface().(T) // convert val to T
+	if !ok {
+		return zero, fmt.Errorf("type assertion failed")
+	}
+	return typedVal, nil
+}
+
+// diveDirective makes validation descend into a nested struct, or into the
+// elements of a slice, array or map. Directives following it in the tag apply
+// to each element.
+const diveDirective = "dive"
+
+// omitEmptyDirective skips the directives following it, and diving, when the
+// value is the zero value of its type, e.g. `val:"omitempty,email"` for an
+// optional email address.
+const omitEmptyDirective = "omitempty"
+
+// keepGoingDirective makes the directives following it run even after one of
+// them failed, reporting every failure of the field, e.g.
+// `val:"keepgoing,min,size=8,alphanum"`.
+const keepGoingDirective = "keepgoing"
+
+// warnDirective turns failures of the directives following it into
+// warnings, which do not fail validation, e.g. `val:"email,warn,max,size=64"`.
+// ValidateStructResult reports them.
+const warnDirective = "warn"
+
+// marker reports whether name is a directive that steers validation rather
+// than checking the value.
+func marker(name string) bool {
+	switch name {
+	case diveDirective, omitEmptyDirective, keepGoingDirective, warnDirective, secretDirective:
+		return true
+	}
+	return false
+}
+
+type directiveCall struct {
+	name string
+	d    anyDirective
+	args map[string]string
+}
+
+// parseTagValue splits a tag value into directive calls. A bare element or a
+// "key=value" pair is a parameter when the preceding directive declares it,
+// and otherwise starts the next directive. A directive can take a value
+// directly ("name=value") by declaring a parameter with its own name.
+func parseTagValue(tagVal string, lookup func(name string) (anyDirective, bool)) ([]directiveCall, error) {
+	parts := splitTag(tagVal)
+	if len(parts) == 0 || strings.TrimSpace(parts[0]) == "" {
+		return nil, errors.New("no directive set")
+	}
+
+	var calls []directiveCall
+	for _, part := range parts {
+		k, v, hasValue := strings.Cut(part, "=")
+		k = strings.TrimSpace(k)
+		if t := strings.TrimSpace(v); t != "" {
+			v = t
+		} // else keep a value of only spaces, e.g. `val:"!contains= "`
+		if k == "" || (hasValue && v == "") {
+			return nil, fmt.Errorf("malformed key value pair %q, expected format is \"key=value\"", strings.TrimSpace(part))
+		}
+
+		if n := len(calls); n > 0 && calls[n-1].d != nil {
+			cur := &calls[n-1]
+			if p, ok := cur.d.params().lookup(k); ok && (hasValue || p.kind == reflect.Bool) {
+				if !hasValue {
+					v = "true"
+				}
+				cur.args[k] = v
+				continue
+			}
+		}
+
+		if marker(k) && !hasValue {
+			calls = append(calls, directiveCall{name: k, args: make(map[string]string)})
+			continue
+		}
+
+		d, ok := lookup(k)
+		if !ok {
+			if n := len(calls); n > 0 && hasValue && calls[n-1].d != nil {
+				return nil, fmt.Errorf("unknown parameter %q for directive %q", k, calls[n-1].name)
+			}
+			return nil, &UnknownDirectiveError{Directive: k}
+		}
+		call := directiveCall{name: k, d: d, args: make(map[string]string)}
+		if hasValue {
+			if _, ok := d.params().lookup(k); !ok {
+				return nil, fmt.Errorf("directive %q does not take a value", k)
+			}
+			call.args[k] = v
+		}
+		calls = append(calls, call)
+	}
+	return calls, nil
+}
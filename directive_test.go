@@ -0,0 +1,100 @@
+package valex
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseTagValue(t *testing.T) {
+	e := NewEngine()
+	tests := []struct {
+		tag       string
+		wantNames []string
+		wantArgs  []map[string]string
+		errSubstr string
+	}{
+		{
+			tag:       "range,min=0,max=120",
+			wantNames: []string{"range"},
+			wantArgs:  []map[string]string{{"min": "0", "max": "120"}},
+		},
+		{
+			tag:       "min, size=3, alphanum",
+			wantNames: []string{"min", "alphanum"},
+			wantArgs:  []map[string]string{{"size": "3"}, {}},
+		},
+		{
+			tag:       "csv,header=id name",
+			wantNames: []string{"csv"},
+			wantArgs:  []map[string]string{{"header": "id name"}},
+		},
+		{
+			tag:       `regex='^[a-z]{2,4}$',min,size=2`,
+			wantNames: []string{"regex", "min"},
+			wantArgs:  []map[string]string{{"regex": `'^[a-z]{2,4}$'`}, {"size": "2"}},
+		},
+		{
+			tag:       `csv,header="last, first" id`,
+			wantNames: []string{"csv"},
+			wantArgs:  []map[string]string{{"header": `"last, first" id`}},
+		},
+		{tag: "", errSubstr: "no directive set"},
+		{tag: "min,", errSubstr: "malformed key value pair"},
+		{tag: "min,size=", errSubstr: "malformed key value pair"},
+		{tag: "foobar", errSubstr: "unknown directive \"foobar\""},
+		{tag: "min,foo=bar", errSubstr: "unknown parameter \"foo\" for directive \"min\""},
+		{tag: "email=foo", errSubstr: "directive \"email\" does not take a value"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.tag, func(t *testing.T) {
+			calls, err := parseTagValue(tc.tag, e.get)
+			if tc.errSubstr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.errSubstr) {
+					t.Fatalf("expected error containing %q, got %v", tc.errSubstr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var names []string
+			var args []map[string]string
+			for _, c := range calls {
+				names = append(names, c.name)
+				args = append(args, c.args)
+			}
+			if !reflect.DeepEqual(names, tc.wantNames) {
+				t.Errorf("expected directives %v, got %v", tc.wantNames, names)
+			}
+			if !reflect.DeepEqual(args, tc.wantArgs) {
+				t.Errorf("expected args %v, got %v", tc.wantArgs, args)
+			}
+		})
+	}
+}
+
+func TestDirectiveWrapper_InstanceIsolation(t *testing.T) {
+	proto := &IntRangeValidator{}
+	dw := wrapDirective[int](proto)
+
+	inst, err := dw.instance(map[string]string{"min": "1", "max": "5"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := inst.(*IntRangeValidator); got.Min != 1 || got.Max != 5 {
+		t.Errorf("expected instance configured with [1, 5], got [%d, %d]", got.Min, got.Max)
+	}
+	if proto.Min != 0 || proto.Max != 0 {
+		t.Errorf("expected prototype to stay unconfigured, got [%d, %d]", proto.Min, proto.Max)
+	}
+
+	if err := dw.handleAny(context.Background(), inst, reflect.ValueOf(3)); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+	if err := dw.handleAny(context.Background(), inst, reflect.ValueOf("3")); err == nil || !strings.Contains(err.Error(), "type mismatch") {
+		t.Errorf("expected type mismatch error, got %v", err)
+	}
+}
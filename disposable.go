@@ -0,0 +1,63 @@
+package valex
+
+import (
+	"net/mail"
+	"strings"
+)
+
+const disposableTable = "disposable"
+
+func init() {
+	declareTable(disposableTable)
+}
+
+func disposableDomains() (map[string]bool, error) {
+	return tableData(disposableTable, domainSet)
+}
+
+func domainSet(lines []string) map[string]bool {
+	set := make(map[string]bool, len(lines))
+	for _, line := range lines {
+		set[strings.ToLower(strings.TrimSuffix(line, "."))] = true
+	}
+	return set
+}
+
+// DisposableEmailValidator rejects email addresses at throwaway domains, or
+// at their subdomains. The embedded "disposable" table lists the domains;
+// Domains adds more, e.g. `val:"email,!disposable,domains=spam.example"`.
+// Keep the table current with SetTable or RefreshTable.
+type DisposableEmailValidator struct {
+	Domains []string `param:"domains,optional"`
+}
+
+func (v *DisposableEmailValidator) Validate(val string) (ok bool, err error) {
+	addr, err := mail.ParseAddress(val)
+	if err != nil {
+		return false, err
+	}
+	domain := strings.ToLower(addr.Address[strings.LastIndexByte(addr.Address, '@')+1:])
+
+	set, err := disposableDomains()
+	if err != nil {
+		return false, err
+	}
+	for d := domain; d != ""; {
+		if set[d] || containsFold(v.Domains, d) {
+			return false, failf(ErrNotAllowed, "email domain %q is disposable", domain)
+		}
+		_, d, _ = strings.Cut(d, ".")
+	}
+	return true, nil
+}
+
+func (v *DisposableEmailValidator) Name() string {
+	return "!disposable"
+}
+
+func (v *DisposableEmailValidator) Handle(val string) error {
+	if ok, err := v.Validate(val); !ok {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,12 @@
+//go:build !valex_notables && !valex_nodisposable
+
+package valex
+
+import _ "embed"
+
+//go:embed disposable.txt
+var disposableData string
+
+func init() {
+	embedTable(disposableTable, disposableData)
+}
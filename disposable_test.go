@@ -0,0 +1,53 @@
+package valex
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDisposableEmailValidator(t *testing.T) {
+	provideTable(t, disposableTable, "disposable.txt")
+
+	tests := []struct {
+		v         DisposableEmailValidator
+		input     string
+		errSubstr string
+	}{
+		{DisposableEmailValidator{}, "jane@example.com", ""},
+		{DisposableEmailValidator{}, "jane@mailinator.com", `email domain "mailinator.com" is disposable`},
+		{DisposableEmailValidator{}, "Jane <jane@YOPMAIL.com>", `"yopmail.com" is disposable`},
+		{DisposableEmailValidator{}, "jane@inbox.guerrillamail.com", "is disposable"},
+		{DisposableEmailValidator{}, "jane@notmailinator.com", ""},
+		{DisposableEmailValidator{Domains: []string{"spam.example"}}, "jane@spam.example", "is disposable"},
+		{DisposableEmailValidator{Domains: []string{"spam.example"}}, "jane@example.com", ""},
+		{DisposableEmailValidator{}, "jane", "missing '@'"},
+	}
+	for _, tc := range tests {
+		ok, err := tc.v.Validate(tc.input)
+		if tc.errSubstr == "" {
+			if !ok {
+				t.Errorf("%q: unexpected error: %v", tc.input, err)
+			}
+			continue
+		}
+		if ok || !strings.Contains(err.Error(), tc.errSubstr) {
+			t.Errorf("%q: expected error containing %q, got %v", tc.input, tc.errSubstr, err)
+		}
+	}
+}
+
+func TestDisposableEmailValidator_Tag(t *testing.T) {
+	provideTable(t, disposableTable, "disposable.txt")
+
+	type signup struct {
+		Email string `val:"email,!disposable,domains=spam.example other.example"`
+	}
+	if _, err := ValidateStruct(&signup{Email: "jane@example.com"}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	for _, email := range []string{"jane@trashmail.com", "jane@other.example"} {
+		if _, err := ValidateStruct(&signup{Email: email}); err == nil {
+			t.Errorf("expected %q to fail", email)
+		}
+	}
+}
@@ -0,0 +1,180 @@
+// Package envval loads environment variables into a struct and validates
+// them with valex, so a service can check all of its configuration at
+// startup:
+//
+//	type Config struct {
+//		Port  int    `env:"PORT" val:"range,min=1,max=65535"`
+//		DBURL string `env:"DB_URL,required" val:"url"`
+//	}
+//
+// Every invalid or missing variable is reported in a single Error.
+package envval
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/tedla-brandsema/valex"
+)
+
+const keyTag = "env"
+
+// Error lists every variable that is required but not set, and every
+// variable whose value could not be converted or failed validation.
+type Error struct {
+	Missing []string
+	Invalid valex.CoercionErrors
+}
+
+func (e *Error) Error() string {
+	lines := make(map[string]string, len(e.Missing)+len(e.Invalid))
+	for _, key := range e.Missing {
+		lines[key] = "required but not set"
+	}
+	for key, err := range e.Invalid {
+		lines[key] = err.Error()
+	}
+	keys := make([]string, 0, len(lines))
+	for key := range lines {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	b.WriteString("invalid environment:")
+	for _, key := range keys {
+		fmt.Fprintf(&b, "\n\t%s: %s", key, lines[key])
+	}
+	return b.String()
+}
+
+func (e *Error) Unwrap() error {
+	if len(e.Invalid) == 0 {
+		return nil
+	}
+	return e.Invalid
+}
+
+// Loader configures how variables are read. The zero value uses the default
+// engine and the process environment.
+type Loader struct {
+	Engine *valex.Engine
+	// Prefix is prepended to every key, e.g. "APP_" reads `env:"PORT"` from
+	// APP_PORT.
+	Prefix string
+	// Lookup replaces os.LookupEnv, e.g. in tests.
+	Lookup func(key string) (string, bool)
+}
+
+func (l *Loader) engine() *valex.Engine {
+	if l.Engine == nil {
+		return valex.Default()
+	}
+	return l.Engine
+}
+
+func (l *Loader) lookup(key string) (string, bool) {
+	if l.Lookup == nil {
+		return os.LookupEnv(l.Prefix + key)
+	}
+	return l.Lookup(l.Prefix + key)
+}
+
+// Load reads the variables named by the `env` tags of the struct dst points
+// to, converts them to the field types and validates the struct. Fields
+// without an `env` tag are read from the variable named after the field,
+// fields tagged `env:"-"` are skipped and embedded structs are flattened.
+// Variables tagged `env:"NAME,required"` must be set; unset optional
+// variables leave the field as is, so defaults can be assigned beforehand.
+func (l *Loader) Load(dst any, opts ...valex.Option) error {
+	val := reflect.ValueOf(dst)
+	if val.Kind() != reflect.Ptr || val.IsNil() || val.Elem().Kind() != reflect.Struct {
+		return valex.WithCategory(fmt.Errorf("expected a pointer to a struct but got %T", dst), valex.CategoryConfig)
+	}
+
+	src := make(map[string]string)
+	var missing []string
+	for _, v := range vars(val.Elem().Type()) {
+		raw, ok := l.lookup(v.key)
+		switch {
+		case ok:
+			src[v.key] = raw
+		case v.required:
+			missing = append(missing, l.Prefix+v.key)
+		}
+	}
+
+	err := l.engine().Coerce(dst, src, append(opts, valex.WithKeyTag(keyTag))...)
+	var cerrs valex.CoercionErrors
+	if err != nil && !errors.As(err, &cerrs) {
+		return err
+	}
+	invalid := make(valex.CoercionErrors, len(cerrs))
+	for key, err := range cerrs {
+		invalid[l.Prefix+key] = err
+	}
+	for _, key := range missing {
+		delete(invalid, key) // the zero value failing validation adds nothing
+	}
+	if len(missing) > 0 || len(invalid) > 0 {
+		sort.Strings(missing)
+		return &Error{Missing: missing, Invalid: invalid}
+	}
+	return nil
+}
+
+// MustLoad is like Load but panics on error, for use in main.
+func (l *Loader) MustLoad(dst any, opts ...valex.Option) {
+	if err := l.Load(dst, opts...); err != nil {
+		panic(err)
+	}
+}
+
+type envVar struct {
+	key      string
+	required bool
+}
+
+// vars lists the variables of t the way Coerce looks them up.
+func vars(t reflect.Type) []envVar {
+	var vs []envVar
+	for n := 0; n < t.NumField(); n++ {
+		field := t.Field(n)
+		key, opts, _ := strings.Cut(field.Tag.Get(keyTag), ",")
+		if key == "-" && opts == "" {
+			continue
+		}
+		if field.Anonymous && key == "" && field.Type.Kind() == reflect.Struct {
+			vs = append(vs, vars(field.Type)...)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if key == "" {
+			key = field.Name
+		}
+		v := envVar{key: key}
+		for _, opt := range strings.Split(opts, ",") {
+			if strings.TrimSpace(opt) == "required" {
+				v.required = true
+			}
+		}
+		vs = append(vs, v)
+	}
+	return vs
+}
+
+var std = &Loader{}
+
+func Load(dst any, opts ...valex.Option) error {
+	return std.Load(dst, opts...)
+}
+
+func MustLoad(dst any, opts ...valex.Option) {
+	std.MustLoad(dst, opts...)
+}
@@ -0,0 +1,120 @@
+package envval
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tedla-brandsema/valex"
+)
+
+type dbConfig struct {
+	DBURL string `env:"DB_URL,required" val:"url"`
+}
+
+type config struct {
+	dbConfig
+	Port    int           `env:"PORT" val:"range,min=1,max=65535"`
+	Timeout time.Duration `env:"TIMEOUT"`
+	Debug   bool          `env:"DEBUG"`
+	Ignored string        `env:"-"`
+}
+
+func lookupIn(env map[string]string) func(string) (string, bool) {
+	return func(key string) (string, bool) {
+		v, ok := env[key]
+		return v, ok
+	}
+}
+
+func TestLoader_Load(t *testing.T) {
+	tests := []struct {
+		name        string
+		prefix      string
+		env         map[string]string
+		want        config
+		wantMissing []string
+		wantInvalid []string
+	}{
+		{
+			name: "valid",
+			env:  map[string]string{"DB_URL": "postgres://db/app", "PORT": "8080", "TIMEOUT": "5s", "DEBUG": "true", "Ignored": "x"},
+			want: config{dbConfig: dbConfig{DBURL: "postgres://db/app"}, Port: 8080, Timeout: 5 * time.Second, Debug: true},
+		},
+		{
+			name:   "prefix",
+			prefix: "APP_",
+			env:    map[string]string{"APP_DB_URL": "postgres://db/app", "APP_PORT": "8080", "PORT": "1"},
+			want:   config{dbConfig: dbConfig{DBURL: "postgres://db/app"}, Port: 8080, Timeout: time.Second},
+		},
+		{
+			name:        "missing and invalid",
+			env:         map[string]string{"PORT": "70000", "TIMEOUT": "soon"},
+			wantMissing: []string{"DB_URL"},
+			wantInvalid: []string{"PORT", "TIMEOUT"},
+		},
+		{
+			name:        "prefixed keys in error",
+			prefix:      "APP_",
+			env:         map[string]string{"APP_PORT": "x"},
+			wantMissing: []string{"APP_DB_URL"},
+			wantInvalid: []string{"APP_PORT"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			l := &Loader{Prefix: tc.prefix, Lookup: lookupIn(tc.env)}
+			cfg := config{Port: 1, Timeout: time.Second}
+			err := l.Load(&cfg)
+
+			if tc.wantMissing == nil && tc.wantInvalid == nil {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if !reflect.DeepEqual(cfg, tc.want) {
+					t.Errorf("got %+v, want %+v", cfg, tc.want)
+				}
+				return
+			}
+
+			var envErr *Error
+			if !errors.As(err, &envErr) {
+				t.Fatalf("expected an *Error, got %v", err)
+			}
+			if !reflect.DeepEqual(envErr.Missing, tc.wantMissing) {
+				t.Errorf("Missing = %v, want %v", envErr.Missing, tc.wantMissing)
+			}
+			var invalid []string
+			for key := range envErr.Invalid {
+				invalid = append(invalid, key)
+			}
+			if len(invalid) != len(tc.wantInvalid) {
+				t.Errorf("Invalid = %v, want keys %v", envErr.Invalid, tc.wantInvalid)
+			}
+			for _, key := range append(tc.wantMissing, tc.wantInvalid...) {
+				if !strings.Contains(err.Error(), "\n\t"+key+": ") {
+					t.Errorf("error %q does not list %s", err, key)
+				}
+			}
+		})
+	}
+}
+
+func TestLoad_NotAPointer(t *testing.T) {
+	err := Load(config{})
+	if valex.Categorize(err) != valex.CategoryConfig {
+		t.Errorf("expected a config error, got %v", err)
+	}
+}
+
+func TestMustLoad(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic")
+		}
+	}()
+	l := &Loader{Lookup: lookupIn(nil)}
+	l.MustLoad(&config{})
+}
@@ -0,0 +1,134 @@
+package valex
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+)
+
+// ErrorShape selects how ErrorBody lays out field errors.
+type ErrorShape int
+
+const (
+	// FlatErrors maps full paths to messages: {"address.zip": ["..."]}.
+	FlatErrors ErrorShape = iota
+	// NestedErrors mirrors the payload: {"address": {"zip": ["..."]}}.
+	// Slice elements become arrays, with null for elements without errors.
+	NestedErrors
+)
+
+// ErrorBody converts err into a JSON-encodable map of error messages. v is
+// the validated value; when given, paths use its json field names instead of
+// Go field names. CoercionErrors keep their source keys. Errors not tied to a
+// field are listed under the empty key.
+func ErrorBody(err error, v any, shape ErrorShape) map[string]any {
+	body := make(map[string]any)
+	if err == nil {
+		return body
+	}
+
+	var fieldErrs []*FieldError
+	var cerrs CoercionErrors
+	var errs ValidationErrors
+	var fe *FieldError
+	switch {
+	case errors.As(err, &cerrs):
+		// keyed by source key, which is flat by nature
+		for key, err := range cerrs {
+			msg := err.Error()
+			if errors.As(err, &fe) {
+				msg = fe.Err.Error()
+			}
+			body[key] = []string{msg}
+		}
+		return body
+	case errors.As(err, &errs):
+		fieldErrs = errs
+	case errors.As(err, &fe):
+		fieldErrs = []*FieldError{fe}
+	default:
+		body[""] = []string{err.Error()}
+		return body
+	}
+
+	t := reflect.TypeOf(v)
+	for _, fe := range fieldErrs {
+		path := fe.Path
+		if path == nil && fe.Field != "" {
+			path = FieldPath{}.Field(fe.Field)
+		}
+		if t != nil {
+			path = jsonPath(t, path)
+		}
+		msg := fe.Err.Error()
+		if shape == NestedErrors {
+			body = nestError(body, path, msg).(map[string]any)
+		} else {
+			key := path.String()
+			msgs, _ := body[key].([]string)
+			body[key] = append(msgs, msg)
+		}
+	}
+	return body
+}
+
+// nestError adds msg to node at path, creating objects for fields and map
+// keys and arrays for indexes on the way, and returns the updated node.
+func nestError(node any, path FieldPath, msg string) any {
+	if len(path) == 0 {
+		switch n := node.(type) {
+		case []string:
+			return append(n, msg)
+		case map[string]any:
+			// the value has errors of its own as well as nested ones
+			msgs, _ := n[""].([]string)
+			n[""] = append(msgs, msg)
+			return n
+		default:
+			return []string{msg}
+		}
+	}
+
+	pe := path[0]
+	if pe.IsIndex() {
+		arr, _ := node.([]any)
+		for len(arr) <= pe.Index {
+			arr = append(arr, nil)
+		}
+		arr[pe.Index] = nestError(arr[pe.Index], path[1:], msg)
+		return arr
+	}
+
+	obj, ok := node.(map[string]any)
+	if !ok {
+		obj = make(map[string]any)
+		if msgs, ok := node.([]string); ok {
+			obj[""] = msgs
+		}
+	}
+	key := pe.Field
+	if pe.IsKey() {
+		key = pe.Key
+	}
+	obj[key] = nestError(obj[key], path[1:], msg)
+	return obj
+}
+
+// jsonPath renames the fields in p to the names encoding/json uses for t,
+// dropping embedded structs whose fields json promotes.
+func jsonPath(t reflect.Type, p FieldPath) FieldPath {
+	return renamePath(t, p, JSONFieldName)
+}
+
+// WriteErrors writes err as JSON in the given shape, with the status code
+// the engine's StatusMapper assigns to it.
+func (e *Engine) WriteErrors(w http.ResponseWriter, err error, v any, shape ErrorShape) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(e.HTTPStatus(err))
+	_ = json.NewEncoder(w).Encode(ErrorBody(err, v, shape))
+}
+
+func WriteErrors(w http.ResponseWriter, err error, v any, shape ErrorShape) {
+	std.WriteErrors(w, err, v, shape)
+}
@@ -0,0 +1,141 @@
+package valex
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+type errAddress struct {
+	Zip string `json:"zip" val:"len,min=6,max=6"`
+}
+
+type errBase struct {
+	ID int `json:"id" val:"pos"`
+}
+
+type errItem struct {
+	SKU string `json:"sku" val:"alphanum"`
+}
+
+type errForm struct {
+	errBase `val:"dive"`
+	Name    string     `json:"name" val:"min,size=3"`
+	Address errAddress `json:"address" val:"dive"`
+	Items   []errItem  `json:"items" val:"dive"`
+	Plain   string     `val:"max,size=2"`
+}
+
+func invalidErrForm(t *testing.T) (*errForm, error) {
+	t.Helper()
+	form := &errForm{
+		errBase: errBase{ID: -1},
+		Name:    "x",
+		Address: errAddress{Zip: "1"},
+		Items:   []errItem{{SKU: "ok"}, {SKU: "n o"}},
+		Plain:   "abc",
+	}
+	ok, err := ValidateStruct(form, WithCollectAll())
+	if ok {
+		t.Fatal("expected validation to fail")
+	}
+	return form, err
+}
+
+// roundTrip normalizes a body to what a client would decode.
+func roundTrip(t *testing.T, v any) any {
+	t.Helper()
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out any
+	if err := json.Unmarshal(b, &out); err != nil {
+		t.Fatal(err)
+	}
+	return out
+}
+
+func TestErrorBody_Flat(t *testing.T) {
+	form, err := invalidErrForm(t)
+	got := roundTrip(t, ErrorBody(err, form, FlatErrors)).(map[string]any)
+
+	want := []string{"id", "name", "address.zip", "items[1].sku", "Plain"}
+	if len(got) != len(want) {
+		t.Fatalf("expected keys %v, got %v", want, got)
+	}
+	for _, key := range want {
+		if msgs, ok := got[key].([]any); !ok || len(msgs) != 1 {
+			t.Errorf("expected one message for %q, got %v", key, got[key])
+		}
+	}
+}
+
+func TestErrorBody_Nested(t *testing.T) {
+	form, err := invalidErrForm(t)
+	got := roundTrip(t, ErrorBody(err, form, NestedErrors)).(map[string]any)
+
+	if _, ok := got["address"].(map[string]any)["zip"].([]any); !ok {
+		t.Errorf("expected address.zip to be nested, got %v", got["address"])
+	}
+	items, ok := got["items"].([]any)
+	if !ok || len(items) != 2 || items[0] != nil {
+		t.Fatalf("expected an items array with a null first element, got %v", got["items"])
+	}
+	if _, ok := items[1].(map[string]any)["sku"]; !ok {
+		t.Errorf("expected items[1].sku, got %v", items[1])
+	}
+	for _, key := range []string{"id", "name", "Plain"} {
+		if _, ok := got[key].([]any); !ok {
+			t.Errorf("expected messages for %q, got %v", key, got[key])
+		}
+	}
+}
+
+func TestErrorBody_GoNamesAndOtherErrors(t *testing.T) {
+	_, err := invalidErrForm(t)
+	got := ErrorBody(err, nil, FlatErrors)
+	if _, ok := got["Address.Zip"]; !ok {
+		t.Errorf("expected Go field names without a value, got %v", got)
+	}
+
+	got = ErrorBody(errors.New("boom"), nil, NestedErrors)
+	if !reflect.DeepEqual(got, map[string]any{"": []string{"boom"}}) {
+		t.Errorf("expected the error under the empty key, got %v", got)
+	}
+	if got := ErrorBody(nil, nil, FlatErrors); len(got) != 0 {
+		t.Errorf("expected an empty body for nil, got %v", got)
+	}
+}
+
+func TestNestError_OwnAndNestedErrors(t *testing.T) {
+	body := nestError(nil, MustParseFieldPath("A.B"), "inner")
+	body = nestError(body, MustParseFieldPath("A"), "outer")
+	want := map[string]any{"A": map[string]any{"B": []string{"inner"}, "": []string{"outer"}}}
+	if !reflect.DeepEqual(body, want) {
+		t.Errorf("expected %v, got %v", want, body)
+	}
+}
+
+func TestWriteErrors(t *testing.T) {
+	form, err := invalidErrForm(t)
+	rec := httptest.NewRecorder()
+	WriteErrors(rec, err, form, NestedErrors)
+
+	if rec.Code != http.StatusUnprocessableEntity {
+		t.Errorf("expected status 422, got %d", rec.Code)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("expected JSON content type, got %q", ct)
+	}
+	var body map[string]any
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatalf("invalid JSON body: %v", err)
+	}
+	if _, ok := body["address"].(map[string]any); !ok {
+		t.Errorf("expected nested body, got %v", body)
+	}
+}
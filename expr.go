@@ -0,0 +1,531 @@
+package valex
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// exprDirective checks a field against a boolean expression, for one-off
+// rules that don't deserve a validator of their own:
+//
+//	Port  int       `val:"expr=value > 1024 && value % 2 == 0"`
+//	End   time.Time `val:"expr=.Start < value"`
+//
+// value is the field, or the element when diving, and .Name a field of the
+// struct that holds it. Numbers are float64, and strings, bools and
+// time.Time values compare as expected. len(x) gives the length of a
+// string, slice or map and nil matches nil pointers, slices and maps. The
+// operators are those of Go: || && ! == != < <= > >= + - * / %. Tags are
+// only split on commas outside quotes, so string literals may hold them,
+// e.g. `val:"expr=value != 'a,b'"`.
+type exprDirective struct{}
+
+const exprDirectiveName = "expr"
+
+type exprRule struct {
+	Expr string `param:"expr"`
+	root exprNode
+}
+
+var exprParams = paramsOf(&exprRule{})
+
+func (exprDirective) valueType() reflect.Type {
+	return reflect.TypeFor[any]()
+}
+
+func (exprDirective) params() params {
+	return exprParams
+}
+
+func (exprDirective) instance(args map[string]string) (any, error) {
+	r := &exprRule{}
+	if err := processParams(r, exprParams, args); err != nil {
+		return nil, err
+	}
+	root, err := parseExpr(r.Expr)
+	if err != nil {
+		return nil, err
+	}
+	r.root = root
+	return r, nil
+}
+
+func (d exprDirective) handleAny(ctx context.Context, r any, val reflect.Value) error {
+	return d.handleField(ctx, r, val, reflect.Value{})
+}
+
+// handleField implements structAware.
+func (exprDirective) handleField(_ context.Context, r any, val, parent reflect.Value) error {
+	rule := r.(*exprRule)
+	res, err := rule.root.eval(exprEnv{value: val, parent: parent})
+	if err != nil {
+		return WithCategory(fmt.Errorf("expression %q: %w", rule.Expr, err), CategoryConfig)
+	}
+	ok, isBool := res.(bool)
+	if !isBool {
+		return WithCategory(fmt.Errorf("expression %q does not yield a bool", rule.Expr), CategoryConfig)
+	}
+	if !ok {
+		return fmt.Errorf("value does not satisfy %s", rule.Expr)
+	}
+	return nil
+}
+
+// structAware is implemented by directives that also look at the struct
+// holding the field, which is invalid outside of struct validation.
+type structAware interface {
+	handleField(ctx context.Context, d any, val, parent reflect.Value) error
+}
+
+type exprEnv struct {
+	value, parent reflect.Value
+}
+
+type exprNode interface {
+	eval(env exprEnv) (any, error)
+}
+
+type (
+	exprLit   struct{ v any }
+	exprValue struct{}
+	exprField struct{ names []string }
+	exprLen   struct{ x exprNode }
+	exprUnary struct {
+		op string
+		x  exprNode
+	}
+	exprBinary struct {
+		op   string
+		x, y exprNode
+	}
+)
+
+func (n exprLit) eval(exprEnv) (any, error) { return n.v, nil }
+
+func (exprValue) eval(env exprEnv) (any, error) { return exprOperand(env.value), nil }
+
+func (n exprField) eval(env exprEnv) (any, error) {
+	v := env.parent
+	for _, name := range n.names {
+		for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+			if v.IsNil() {
+				return nil, fmt.Errorf(".%s: nil", strings.Join(n.names, "."))
+			}
+			v = v.Elem()
+		}
+		if v.Kind() != reflect.Struct {
+			return nil, fmt.Errorf(".%s: not in a struct", strings.Join(n.names, "."))
+		}
+		if v = v.FieldByName(name); !v.IsValid() {
+			return nil, fmt.Errorf("unknown field .%s", strings.Join(n.names, "."))
+		}
+	}
+	return exprOperand(v), nil
+}
+
+func (n exprLen) eval(env exprEnv) (any, error) {
+	x, err := n.x.eval(env)
+	if err != nil {
+		return nil, err
+	}
+	switch x := x.(type) {
+	case string:
+		return float64(len(x)), nil
+	case reflect.Value:
+		switch x.Kind() {
+		case reflect.Slice, reflect.Array, reflect.Map, reflect.Chan:
+			return float64(x.Len()), nil
+		}
+	}
+	return nil, fmt.Errorf("invalid argument for len: %s", exprTypeName(x))
+}
+
+func (n exprUnary) eval(env exprEnv) (any, error) {
+	x, err := n.x.eval(env)
+	if err != nil {
+		return nil, err
+	}
+	switch x := x.(type) {
+	case bool:
+		if n.op == "!" {
+			return !x, nil
+		}
+	case float64:
+		if n.op == "-" {
+			return -x, nil
+		}
+	}
+	return nil, fmt.Errorf("invalid operation %s%s", n.op, exprTypeName(x))
+}
+
+func (n exprBinary) eval(env exprEnv) (any, error) {
+	x, err := n.x.eval(env)
+	if err != nil {
+		return nil, err
+	}
+	if n.op == "&&" || n.op == "||" {
+		xb, ok := x.(bool)
+		if !ok {
+			return nil, fmt.Errorf("invalid operation %s on %s", n.op, exprTypeName(x))
+		}
+		if xb == (n.op == "||") {
+			return xb, nil // short circuit
+		}
+		y, err := n.y.eval(env)
+		if err != nil {
+			return nil, err
+		}
+		if yb, ok := y.(bool); ok {
+			return yb, nil
+		}
+		return nil, fmt.Errorf("invalid operation %s on %s", n.op, exprTypeName(y))
+	}
+	y, err := n.y.eval(env)
+	if err != nil {
+		return nil, err
+	}
+	if n.op == "==" || n.op == "!=" {
+		eq, err := exprEqual(x, y)
+		if err != nil {
+			return nil, err
+		}
+		return eq == (n.op == "=="), nil
+	}
+
+	switch x := x.(type) {
+	case float64:
+		if y, ok := y.(float64); ok {
+			return exprArith(n.op, x, y)
+		}
+	case string:
+		if y, ok := y.(string); ok {
+			if n.op == "+" {
+				return x + y, nil
+			}
+			if c, ok := exprCompare(n.op, strings.Compare(x, y)); ok {
+				return c, nil
+			}
+		}
+	case time.Time:
+		if y, ok := y.(time.Time); ok {
+			if c, ok := exprCompare(n.op, x.Compare(y)); ok {
+				return c, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("invalid operation %s %s %s", exprTypeName(x), n.op, exprTypeName(y))
+}
+
+func exprArith(op string, x, y float64) (any, error) {
+	switch op {
+	case "+":
+		return x + y, nil
+	case "-":
+		return x - y, nil
+	case "*":
+		return x * y, nil
+	case "/":
+		if y == 0 {
+			return nil, errors.New("division by zero")
+		}
+		return x / y, nil
+	case "%":
+		if x != math.Trunc(x) || y != math.Trunc(y) {
+			return nil, errors.New("% needs whole numbers")
+		}
+		if y == 0 {
+			return nil, errors.New("division by zero")
+		}
+		return math.Mod(x, y), nil
+	}
+	c, _ := exprCompare(op, cmpFloat(x, y))
+	return c, nil
+}
+
+func cmpFloat(x, y float64) int {
+	switch {
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	}
+	return 0
+}
+
+func exprCompare(op string, c int) (bool, bool) {
+	switch op {
+	case "<":
+		return c < 0, true
+	case "<=":
+		return c <= 0, true
+	case ">":
+		return c > 0, true
+	case ">=":
+		return c >= 0, true
+	}
+	return false, false
+}
+
+func exprEqual(x, y any) (bool, error) {
+	if x == nil || y == nil {
+		return exprIsNil(x) && exprIsNil(y), nil
+	}
+	switch x := x.(type) {
+	case time.Time:
+		if y, ok := y.(time.Time); ok {
+			return x.Equal(y), nil
+		}
+	case float64, string, bool:
+		if reflect.TypeOf(x) == reflect.TypeOf(y) {
+			return x == y, nil
+		}
+	}
+	return false, fmt.Errorf("cannot compare %s and %s", exprTypeName(x), exprTypeName(y))
+}
+
+func exprIsNil(x any) bool {
+	if v, ok := x.(reflect.Value); ok {
+		switch v.Kind() {
+		case reflect.Slice, reflect.Map, reflect.Chan, reflect.Func:
+			return v.IsNil()
+		}
+	}
+	return x == nil
+}
+
+// exprOperand converts v to the value expressions work with.
+func exprOperand(v reflect.Value) any {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Invalid:
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+	case reflect.String:
+		return v.String()
+	case reflect.Bool:
+		return v.Bool()
+	}
+	if v.CanInterface() {
+		if t, ok := v.Interface().(time.Time); ok {
+			return t
+		}
+	}
+	return v
+}
+
+func exprTypeName(x any) string {
+	switch x := x.(type) {
+	case nil:
+		return "nil"
+	case float64:
+		return "number"
+	case reflect.Value:
+		return x.Type().String()
+	}
+	return reflect.TypeOf(x).String()
+}
+
+// parseExpr parses the expression language of exprDirective.
+func parseExpr(s string) (exprNode, error) {
+	toks, err := lexExpr(s)
+	if err != nil {
+		return nil, err
+	}
+	p := &exprParser{toks: toks}
+	n, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.toks) {
+		return nil, fmt.Errorf("unexpected %q in expression", p.toks[p.pos])
+	}
+	return n, nil
+}
+
+type exprParser struct {
+	toks []string
+	pos  int
+}
+
+func (p *exprParser) peek() string {
+	if p.pos < len(p.toks) {
+		return p.toks[p.pos]
+	}
+	return ""
+}
+
+func (p *exprParser) accept(ops ...string) (string, bool) {
+	tok := p.peek()
+	for _, op := range ops {
+		if tok == op {
+			p.pos++
+			return op, true
+		}
+	}
+	return "", false
+}
+
+func (p *exprParser) binary(next func() (exprNode, error), ops ...string) (exprNode, error) {
+	x, err := next()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op, ok := p.accept(ops...)
+		if !ok {
+			return x, nil
+		}
+		y, err := next()
+		if err != nil {
+			return nil, err
+		}
+		x = exprBinary{op: op, x: x, y: y}
+	}
+}
+
+func (p *exprParser) or() (exprNode, error)  { return p.binary(p.and, "||") }
+func (p *exprParser) and() (exprNode, error) { return p.binary(p.cmp, "&&") }
+func (p *exprParser) sum() (exprNode, error) { return p.binary(p.prod, "+", "-") }
+
+func (p *exprParser) prod() (exprNode, error) {
+	return p.binary(p.unary, "*", "/", "%")
+}
+
+func (p *exprParser) cmp() (exprNode, error) {
+	x, err := p.sum()
+	if err != nil {
+		return nil, err
+	}
+	if op, ok := p.accept("==", "!=", "<", "<=", ">", ">="); ok {
+		y, err := p.sum()
+		if err != nil {
+			return nil, err
+		}
+		return exprBinary{op: op, x: x, y: y}, nil
+	}
+	return x, nil
+}
+
+func (p *exprParser) unary() (exprNode, error) {
+	if op, ok := p.accept("!", "-"); ok {
+		x, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return exprUnary{op: op, x: x}, nil
+	}
+	return p.primary()
+}
+
+func (p *exprParser) primary() (exprNode, error) {
+	tok := p.peek()
+	if tok == "" {
+		return nil, errors.New("unexpected end of expression")
+	}
+	p.pos++
+	switch {
+	case tok == "(":
+		x, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := p.accept(")"); !ok {
+			return nil, errors.New("missing ) in expression")
+		}
+		return x, nil
+	case tok == "len":
+		if _, ok := p.accept("("); !ok {
+			return nil, errors.New("expected ( after len")
+		}
+		x, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := p.accept(")"); !ok {
+			return nil, errors.New("missing ) in expression")
+		}
+		return exprLen{x: x}, nil
+	case tok == "value":
+		return exprValue{}, nil
+	case tok == "true" || tok == "false":
+		return exprLit{v: tok == "true"}, nil
+	case tok == "nil":
+		return exprLit{}, nil
+	case tok[0] == '"' || tok[0] == '\'':
+		return exprLit{v: tok[1 : len(tok)-1]}, nil
+	case tok[0] == '.':
+		names := strings.Split(tok[1:], ".")
+		if slices.Contains(names, "") {
+			return nil, fmt.Errorf("invalid field %q", tok)
+		}
+		return exprField{names: names}, nil
+	case tok[0] >= '0' && tok[0] <= '9':
+		f, err := strconv.ParseFloat(tok, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q", tok)
+		}
+		return exprLit{v: f}, nil
+	}
+	return nil, fmt.Errorf("unexpected %q in expression", tok)
+}
+
+func lexExpr(s string) ([]string, error) {
+	var toks []string
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == ' ' || c == '\t':
+			i++
+		case c == '"' || c == '\'':
+			end := strings.IndexByte(s[i+1:], c)
+			if end < 0 {
+				return nil, errors.New("unterminated string in expression")
+			}
+			toks = append(toks, s[i:i+end+2])
+			i += end + 2
+		case c == '.' || isExprIdent(rune(c)):
+			j := i + 1
+			for j < len(s) && (isExprIdent(rune(s[j])) || s[j] == '.') {
+				j++
+			}
+			toks = append(toks, s[i:j])
+			i = j
+		case strings.HasPrefix(s[i:], "&&") || strings.HasPrefix(s[i:], "||") ||
+			strings.HasPrefix(s[i:], "==") || strings.HasPrefix(s[i:], "!=") ||
+			strings.HasPrefix(s[i:], "<=") || strings.HasPrefix(s[i:], ">="):
+			toks = append(toks, s[i:i+2])
+			i += 2
+		case strings.ContainsRune("()!<>+-*/%", rune(c)):
+			toks = append(toks, s[i:i+1])
+			i++
+		default:
+			return nil, fmt.Errorf("unexpected %q in expression", c)
+		}
+	}
+	if len(toks) == 0 {
+		return nil, errors.New("empty expression")
+	}
+	return toks, nil
+}
+
+func isExprIdent(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
@@ -0,0 +1,107 @@
+package valex
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExprDirective(t *testing.T) {
+	type window struct {
+		Start time.Time
+		End   time.Time `val:"expr=.Start < value"`
+	}
+	type dummy struct {
+		Port  int      `val:"expr=value > 1024 && value % 2 == 0"`
+		Name  string   `val:"expr=len(value) >= 2 || value == 'x'"`
+		Ratio float64  `val:"expr=!(value < 0) && value * 2 <= 1"`
+		Min   int      `val:"expr=value <= .Max"`
+		Max   int      `val:"expr=value - .Min < 100"`
+		Tags  []string `val:"expr=value != nil && len(value) <= .Max,dive,expr=value != \"\""`
+		Ptr   *int     `val:"expr=value == nil || value > 0"`
+		Win   window   `val:"dive"`
+	}
+	now := time.Now()
+	valid := func() dummy {
+		return dummy{Port: 2048, Name: "ab", Ratio: 0.5, Min: 1, Max: 10, Tags: []string{"a"}, Win: window{Start: now, End: now.Add(time.Hour)}}
+	}
+
+	tests := []struct {
+		name   string
+		mutate func(d *dummy)
+		field  string
+	}{
+		{"valid", func(d *dummy) {}, ""},
+		{"literal", func(d *dummy) { d.Name = "x" }, ""},
+		{"and", func(d *dummy) { d.Port = 2049 }, "Port"},
+		{"or", func(d *dummy) { d.Name = "y" }, "Name"},
+		{"not", func(d *dummy) { d.Ratio = -1 }, "Ratio"},
+		{"arith", func(d *dummy) { d.Ratio = 0.6 }, "Ratio"},
+		{"cross field", func(d *dummy) { d.Min = 11 }, "Min"},
+		{"cross field sum", func(d *dummy) { d.Max = 200 }, "Max"},
+		{"nil slice", func(d *dummy) { d.Tags = nil }, "Tags"},
+		{"len cross field", func(d *dummy) { d.Max, d.Tags = 2, []string{"a", "b", "c"} }, "Tags"},
+		{"elem", func(d *dummy) { d.Tags = []string{"a", ""} }, "Tags[1]"},
+		{"nil pointer", func(d *dummy) { d.Ptr = nil }, ""},
+		{"pointer", func(d *dummy) { d.Ptr = new(int) }, "Ptr"},
+		{"time", func(d *dummy) { d.Win.End = now.Add(-time.Hour) }, "Win.End"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			d := valid()
+			tc.mutate(&d)
+			ok, err := ValidateStruct(&d)
+			if tc.field == "" {
+				if !ok {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			fe, isFE := err.(*FieldError)
+			if ok || !isFE || fe.Field != tc.field || fe.Directive != "expr" {
+				t.Fatalf("expected expr to fail on %s, got %v", tc.field, err)
+			}
+		})
+	}
+}
+
+func TestExprDirective_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		data any
+		err  string
+	}{
+		{"syntax", &struct {
+			A int `val:"expr=value >"`
+		}{}, "unexpected end of expression"},
+		{"paren", &struct {
+			A int `val:"expr=(value > 1"`
+		}{}, "missing )"},
+		{"char", &struct {
+			A int `val:"expr=value ^ 1"`
+		}{}, "unexpected '^'"},
+		{"not bool", &struct {
+			A int `val:"expr=value + 1"`
+		}{}, "does not yield a bool"},
+		{"types", &struct {
+			A int `val:"expr=value == 'a'"`
+		}{}, "cannot compare number and string"},
+		{"unknown field", &struct {
+			A int `val:"expr=.B > 0"`
+		}{}, "unknown field .B"},
+		{"modulo", &struct {
+			A float64 `val:"expr=value % 2 == 0"`
+		}{A: 1.5}, "needs whole numbers"},
+		{"division", &struct {
+			A int `val:"expr=1 / value > 0"`
+		}{}, "division by zero"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ValidateStruct(tc.data)
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Errorf("expected error containing %q, got %v", tc.err, err)
+			}
+		})
+	}
+}
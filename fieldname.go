@@ -0,0 +1,88 @@
+package valex
+
+import (
+	"reflect"
+)
+
+// FieldNameFunc returns the name a struct field is reported under in
+// errors. An empty name leaves out an embedded struct whose fields are
+// promoted, and reports other fields by their Go name.
+type FieldNameFunc func(field reflect.StructField) string
+
+// JSONFieldName reports fields by their json tag name, falling back to the
+// field name, so errors use the names clients send.
+func JSONFieldName(field reflect.StructField) string {
+	name, _ := jsonFieldName(field)
+	if name == "" && !field.Anonymous {
+		return field.Name
+	}
+	return name
+}
+
+type fieldNameBox struct {
+	fn FieldNameFunc
+}
+
+// SetFieldNameFunc makes the Field of the engine's FieldErrors use the
+// names fn returns, e.g. JSONFieldName. Their Path keeps the Go field names.
+// Names are resolved through the static types of the validated struct, so
+// fields below interface values keep their Go names. A nil fn restores the
+// Go field names.
+func (e *Engine) SetFieldNameFunc(fn FieldNameFunc) {
+	e.fieldNames.Store(fieldNameBox{fn})
+}
+
+func SetFieldNameFunc(fn FieldNameFunc) {
+	std.SetFieldNameFunc(fn)
+}
+
+func (e *Engine) fieldNameFunc() FieldNameFunc {
+	b, _ := e.fieldNames.Load().(fieldNameBox)
+	return b.fn
+}
+
+// fieldName returns how the engine reports the field at p in t.
+func (e *Engine) fieldName(t reflect.Type, p FieldPath) string {
+	if fn := e.fieldNameFunc(); fn != nil && t != nil {
+		return renamePath(t, p, fn).String()
+	}
+	return p.String()
+}
+
+// renamePath renames the fields in p to the names fn gives them in t.
+func renamePath(t reflect.Type, p FieldPath, fn FieldNameFunc) FieldPath {
+	var out FieldPath
+	for _, pe := range p {
+		for t != nil && t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		if !pe.IsField() {
+			out = append(out, pe)
+			if t != nil && (t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map) {
+				t = t.Elem()
+			} else if t != nil && t.Kind() != reflect.Struct {
+				t = nil
+			}
+			continue
+		}
+		if t == nil || t.Kind() != reflect.Struct {
+			out, t = out.Field(pe.Field), nil
+			continue
+		}
+		sf, ok := t.FieldByName(pe.Field)
+		if !ok {
+			out, t = out.Field(pe.Field), nil
+			continue
+		}
+		name := fn(sf)
+		t = sf.Type
+		if name == "" {
+			if sf.Anonymous {
+				continue
+			}
+			name = sf.Name
+		}
+		out = out.Field(name)
+	}
+	return out
+}
@@ -0,0 +1,84 @@
+package valex
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type fieldNameAddress struct {
+	Zip string `json:"zip_code" val:"len,min=6,max=6"`
+}
+
+type fieldNameUser struct {
+	UserName  string             `json:"user_name,omitempty" val:"min,size=3"`
+	Nick      string             `val:"max,size=3"`
+	Addresses []fieldNameAddress `json:"addresses" val:"dive"`
+	Any       any                `json:"any" val:"dive"`
+}
+
+func TestSetFieldNameFunc(t *testing.T) {
+	e := NewEngine()
+	e.SetFieldNameFunc(JSONFieldName)
+
+	data := &fieldNameUser{
+		UserName:  "Jo",
+		Nick:      "toolong",
+		Addresses: []fieldNameAddress{{Zip: "1234AB"}, {Zip: "1"}},
+		Any:       fieldNameAddress{Zip: "1"},
+	}
+	_, err := e.ValidateStruct(data, WithCollectAll())
+	var ve ValidationErrors
+	if !errors.As(err, &ve) {
+		t.Fatalf("expected ValidationErrors, got %v", err)
+	}
+	var fields []string
+	var paths []string
+	for _, fe := range ve {
+		fields = append(fields, fe.Field)
+		paths = append(paths, fe.Path.String())
+	}
+	wantFields := []string{"user_name", "Nick", "addresses[1].zip_code", "any.Zip"}
+	wantPaths := []string{"UserName", "Nick", "Addresses[1].Zip", "Any.Zip"}
+	if !reflect.DeepEqual(fields, wantFields) {
+		t.Errorf("expected fields %v, got %v", wantFields, fields)
+	}
+	if !reflect.DeepEqual(paths, wantPaths) {
+		t.Errorf("expected paths %v, got %v", wantPaths, paths)
+	}
+
+	e.SetFieldNameFunc(nil)
+	if _, err := e.ValidateStruct(data); !strings.Contains(err.Error(), `"UserName"`) {
+		t.Errorf("expected Go field names, got %v", err)
+	}
+}
+
+func TestSetFieldNameFunc_Decode(t *testing.T) {
+	e := NewEngine()
+	e.SetFieldNameFunc(JSONFieldName)
+
+	var u fieldNameUser
+	err := e.DecodeAndValidate(strings.NewReader(`{"user_name": 12}`), &u)
+	var fe *FieldError
+	if !errors.As(err, &fe) || fe.Field != "user_name" {
+		t.Errorf("expected an error for user_name, got %v", err)
+	}
+}
+
+func TestJSONFieldName(t *testing.T) {
+	type Embedded struct{}
+	typ := reflect.TypeFor[struct {
+		Embedded
+		A string `json:"a,omitempty"`
+		B string `json:",omitempty"`
+		C string `json:"-"`
+		D string
+	}]()
+	want := []string{"", "a", "B", "C", "D"}
+	for n, w := range want {
+		if got := JSONFieldName(typ.Field(n)); got != w {
+			t.Errorf("field %s: expected %q, got %q", typ.Field(n).Name, w, got)
+		}
+	}
+}
@@ -0,0 +1,198 @@
+package valex
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+type pathElemKind int
+
+const (
+	fieldElem pathElemKind = iota
+	indexElem
+	keyElem
+)
+
+// PathElem is one step of a FieldPath: a struct field, a slice or array
+// index, or a map key.
+type PathElem struct {
+	kind  pathElemKind
+	Field string
+	Index int
+	Key   string
+}
+
+func (pe PathElem) IsField() bool { return pe.kind == fieldElem }
+func (pe PathElem) IsIndex() bool { return pe.kind == indexElem }
+func (pe PathElem) IsKey() bool   { return pe.kind == keyElem }
+
+// FieldPath addresses a value inside a struct using Go field names, e.g.
+// Items[2].Address.Zip or Labels["env"].
+type FieldPath []PathElem
+
+func (p FieldPath) Field(name string) FieldPath {
+	return p.append(PathElem{kind: fieldElem, Field: name})
+}
+
+func (p FieldPath) Index(i int) FieldPath {
+	return p.append(PathElem{kind: indexElem, Index: i})
+}
+
+func (p FieldPath) Key(key string) FieldPath {
+	return p.append(PathElem{kind: keyElem, Key: key})
+}
+
+// append never shares the backing array with p, so sibling paths derived
+// from the same parent stay independent.
+func (p FieldPath) append(pe PathElem) FieldPath {
+	c := make(FieldPath, len(p), len(p)+1)
+	copy(c, p)
+	return append(c, pe)
+}
+
+func (p FieldPath) String() string {
+	var b strings.Builder
+	for n, pe := range p {
+		switch pe.kind {
+		case fieldElem:
+			if n > 0 {
+				b.WriteByte('.')
+			}
+			b.WriteString(pe.Field)
+		case indexElem:
+			fmt.Fprintf(&b, "[%d]", pe.Index)
+		case keyElem:
+			fmt.Fprintf(&b, "[%s]", strconv.Quote(pe.Key))
+		}
+	}
+	return b.String()
+}
+
+func (p FieldPath) Equal(q FieldPath) bool {
+	return len(p) == len(q) && p.HasPrefix(q)
+}
+
+// HasPrefix reports whether q is p itself or one of its ancestors.
+func (p FieldPath) HasPrefix(q FieldPath) bool {
+	if len(q) > len(p) {
+		return false
+	}
+	for n := range q {
+		if p[n] != q[n] {
+			return false
+		}
+	}
+	return true
+}
+
+func ParseFieldPath(s string) (FieldPath, error) {
+	var p FieldPath
+	rest := s
+	for rest != "" {
+		switch {
+		case rest[0] == '[':
+			end := strings.IndexByte(rest, ']')
+			if len(rest) > 1 && rest[1] == '"' {
+				unquoted, tail, err := cutQuoted(rest[1:])
+				if err != nil || !strings.HasPrefix(tail, "]") {
+					return nil, fmt.Errorf("invalid field path %q: malformed map key", s)
+				}
+				p = p.Key(unquoted)
+				rest = tail[1:]
+				continue
+			}
+			if end < 0 {
+				return nil, fmt.Errorf("invalid field path %q: missing \"]\"", s)
+			}
+			i, err := strconv.Atoi(rest[1:end])
+			if err != nil || i < 0 {
+				return nil, fmt.Errorf("invalid field path %q: invalid index %q", s, rest[1:end])
+			}
+			p = p.Index(i)
+			rest = rest[end+1:]
+		default:
+			if len(p) > 0 {
+				if rest[0] != '.' {
+					return nil, fmt.Errorf("invalid field path %q: expected \".\" or \"[\"", s)
+				}
+				rest = rest[1:]
+			}
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			if end == 0 {
+				return nil, fmt.Errorf("invalid field path %q: empty field name", s)
+			}
+			p = p.Field(rest[:end])
+			rest = rest[end:]
+		}
+	}
+	if len(p) == 0 {
+		return nil, fmt.Errorf("invalid field path %q: empty path", s)
+	}
+	return p, nil
+}
+
+func MustParseFieldPath(s string) FieldPath {
+	p, err := ParseFieldPath(s)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+func cutQuoted(s string) (unquoted, rest string, err error) {
+	prefix, err := strconv.QuotedPrefix(s)
+	if err != nil {
+		return "", "", err
+	}
+	unquoted, err = strconv.Unquote(prefix)
+	return unquoted, s[len(prefix):], err
+}
+
+// Resolve returns the value p addresses within v, following pointers and
+// interfaces along the way.
+func (p FieldPath) Resolve(v any) (reflect.Value, error) {
+	val := reflect.ValueOf(v)
+	for n, pe := range p {
+		for val.Kind() == reflect.Ptr || val.Kind() == reflect.Interface {
+			if val.IsNil() {
+				return reflect.Value{}, fmt.Errorf("cannot resolve %s: %s is nil", p, p[:n])
+			}
+			val = val.Elem()
+		}
+
+		switch pe.kind {
+		case fieldElem:
+			if val.Kind() != reflect.Struct {
+				return reflect.Value{}, fmt.Errorf("cannot resolve %s: %s is a %s, not a struct", p, p[:n], val.Kind())
+			}
+			f := val.FieldByName(pe.Field)
+			if !f.IsValid() {
+				return reflect.Value{}, fmt.Errorf("cannot resolve %s: no field %q", p, pe.Field)
+			}
+			val = f
+		case indexElem:
+			if val.Kind() != reflect.Slice && val.Kind() != reflect.Array {
+				return reflect.Value{}, fmt.Errorf("cannot resolve %s: %s is a %s, not a slice or array", p, p[:n], val.Kind())
+			}
+			if pe.Index >= val.Len() {
+				return reflect.Value{}, fmt.Errorf("cannot resolve %s: index %d out of range", p, pe.Index)
+			}
+			val = val.Index(pe.Index)
+		case keyElem:
+			if val.Kind() != reflect.Map || val.Type().Key().Kind() != reflect.String {
+				return reflect.Value{}, fmt.Errorf("cannot resolve %s: %s is not a map with string keys", p, p[:n])
+			}
+			key := reflect.ValueOf(pe.Key).Convert(val.Type().Key())
+			val = val.MapIndex(key)
+			if !val.IsValid() {
+				return reflect.Value{}, fmt.Errorf("cannot resolve %s: no key %q", p, pe.Key)
+			}
+		}
+	}
+	return val, nil
+}
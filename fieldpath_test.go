@@ -0,0 +1,130 @@
+package valex
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseFieldPath(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    FieldPath
+		wantErr string
+	}{
+		{in: "Name", want: FieldPath{}.Field("Name")},
+		{in: "Items[2].Address.Zip", want: FieldPath{}.Field("Items").Index(2).Field("Address").Field("Zip")},
+		{in: `Labels["env"]`, want: FieldPath{}.Field("Labels").Key("env")},
+		{in: `Labels["a.b]"].X`, want: FieldPath{}.Field("Labels").Key("a.b]").Field("X")},
+		{in: "Grid[1][0]", want: FieldPath{}.Field("Grid").Index(1).Index(0)},
+		{in: "", wantErr: "empty path"},
+		{in: "A..B", wantErr: "empty field name"},
+		{in: "A[x]", wantErr: "invalid index"},
+		{in: "A[-1]", wantErr: "invalid index"},
+		{in: "A[1", wantErr: "missing"},
+		{in: `A["x]`, wantErr: "malformed map key"},
+		{in: "A[1]B", wantErr: "expected"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.in, func(t *testing.T) {
+			got, err := ParseFieldPath(tc.in)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !got.Equal(tc.want) {
+				t.Errorf("expected %v, got %v", tc.want, got)
+			}
+			if got.String() != tc.in {
+				t.Errorf("expected %q to format back to itself, got %q", tc.in, got.String())
+			}
+		})
+	}
+}
+
+func TestFieldPath_HasPrefix(t *testing.T) {
+	p := MustParseFieldPath("Items[2].Address.Zip")
+	tests := []struct {
+		prefix string
+		want   bool
+	}{
+		{"Items", true},
+		{"Items[2]", true},
+		{"Items[2].Address.Zip", true},
+		{"Items[1]", false},
+		{"Items[2].Address.Zip.X", false},
+		{"Address", false},
+	}
+	for _, tc := range tests {
+		if got := p.HasPrefix(MustParseFieldPath(tc.prefix)); got != tc.want {
+			t.Errorf("HasPrefix(%q) = %v, want %v", tc.prefix, got, tc.want)
+		}
+	}
+}
+
+func TestFieldPath_BuildersDoNotAlias(t *testing.T) {
+	base := make(FieldPath, 0, 8).Field("Items")
+	a := base.Index(0)
+	b := base.Index(1)
+	if a.String() != "Items[0]" || b.String() != "Items[1]" {
+		t.Errorf("expected independent paths, got %s and %s", a, b)
+	}
+}
+
+type resolveAddress struct {
+	Zip string
+}
+
+type resolveItem struct {
+	Address *resolveAddress
+}
+
+type resolveDummy struct {
+	Items  []resolveItem
+	Labels map[string]int
+	Any    any
+}
+
+func TestFieldPath_Resolve(t *testing.T) {
+	data := &resolveDummy{
+		Items:  []resolveItem{{}, {Address: &resolveAddress{Zip: "1234AB"}}},
+		Labels: map[string]int{"env": 3},
+		Any:    resolveAddress{Zip: "9999"},
+	}
+	tests := []struct {
+		path    string
+		want    any
+		wantErr string
+	}{
+		{path: "Items[1].Address.Zip", want: "1234AB"},
+		{path: `Labels["env"]`, want: 3},
+		{path: "Any.Zip", want: "9999"},
+		{path: "Items[0].Address.Zip", wantErr: "Items[0].Address is nil"},
+		{path: "Items[5]", wantErr: "out of range"},
+		{path: `Labels["prod"]`, wantErr: "no key"},
+		{path: "Missing", wantErr: "no field"},
+		{path: "Labels.X", wantErr: "not a struct"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.path, func(t *testing.T) {
+			got, err := MustParseFieldPath(tc.path).Resolve(data)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.Interface() != tc.want {
+				t.Errorf("expected %v, got %v", tc.want, got.Interface())
+			}
+		})
+	}
+}
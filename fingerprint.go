@@ -0,0 +1,119 @@
+package valex
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+// Fingerprinter lets a validator provide its own fingerprint, for instance
+// when its configuration lives in unexported fields.
+type Fingerprinter interface {
+	Fingerprint() string
+}
+
+// Fingerprint returns a stable hash of v's type and configuration. Two
+// validators of the same type with equal exported fields, e.g. two
+// MinLengthValidators with Size 3, share a fingerprint regardless of where
+// they were created. Values holding funcs or channels cannot be fingerprinted.
+func Fingerprint(v any) (string, error) {
+	if f, ok := v.(Fingerprinter); ok {
+		return f.Fingerprint(), nil
+	}
+	h := sha256.New()
+	if err := writeCanonical(h, reflect.ValueOf(v), make(map[uintptr]bool)); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// SameConfig reports whether a and b have the same fingerprint.
+func SameConfig(a, b any) bool {
+	fa, err := Fingerprint(a)
+	if err != nil {
+		return false
+	}
+	fb, err := Fingerprint(b)
+	if err != nil {
+		return false
+	}
+	return fa == fb
+}
+
+var regexpType = reflect.TypeFor[*regexp.Regexp]()
+
+func writeCanonical(w io.Writer, v reflect.Value, seen map[uintptr]bool) error {
+	if !v.IsValid() {
+		_, err := io.WriteString(w, "nil;")
+		return err
+	}
+	t := v.Type()
+	fmt.Fprintf(w, "%s.%s:", t.PkgPath(), t.String())
+
+	if t == regexpType {
+		if v.IsNil() {
+			_, err := io.WriteString(w, "nil;")
+			return err
+		}
+		_, err := io.WriteString(w, strconv.Quote(v.Interface().(*regexp.Regexp).String())+";")
+		return err
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			_, err := io.WriteString(w, "nil;")
+			return err
+		}
+		if seen[v.Pointer()] {
+			return fmt.Errorf("cannot fingerprint cyclic value of type %s", t)
+		}
+		seen[v.Pointer()] = true
+		defer delete(seen, v.Pointer())
+		return writeCanonical(w, v.Elem(), seen)
+	case reflect.Interface:
+		return writeCanonical(w, v.Elem(), seen)
+	case reflect.Struct:
+		for n := 0; n < v.NumField(); n++ {
+			if !t.Field(n).IsExported() {
+				continue
+			}
+			io.WriteString(w, t.Field(n).Name+"=")
+			if err := writeCanonical(w, v.Field(n), seen); err != nil {
+				return err
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		fmt.Fprintf(w, "[%d]", v.Len())
+		for n := 0; n < v.Len(); n++ {
+			if err := writeCanonical(w, v.Index(n), seen); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+		})
+		fmt.Fprintf(w, "{%d}", len(keys))
+		for _, k := range keys {
+			if err := writeCanonical(w, k, seen); err != nil {
+				return err
+			}
+			if err := writeCanonical(w, v.MapIndex(k), seen); err != nil {
+				return err
+			}
+		}
+	case reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		return fmt.Errorf("cannot fingerprint value of type %s", t)
+	default:
+		fmt.Fprintf(w, "%q", fmt.Sprint(v.Interface()))
+	}
+	_, err := io.WriteString(w, ";")
+	return err
+}
@@ -0,0 +1,82 @@
+package valex
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+type fingerprintDummy struct {
+	Limits map[string]int
+	Next   *fingerprintDummy
+	hidden int
+}
+
+type selfDescribing struct {
+	id string
+}
+
+func (s selfDescribing) Fingerprint() string {
+	return "self:" + s.id
+}
+
+func TestFingerprint(t *testing.T) {
+	tests := []struct {
+		a, b any
+		same bool
+	}{
+		{&MinLengthValidator{Size: 3}, &MinLengthValidator{Size: 3}, true},
+		{&MinLengthValidator{Size: 3}, &MinLengthValidator{Size: 4}, false},
+		{&MinLengthValidator{Size: 3}, &MaxLengthValidator{Size: 3}, false},
+		{&CSVValidator{Header: []string{"a", "b"}}, &CSVValidator{Header: []string{"a", "b"}}, true},
+		{&CSVValidator{Header: []string{"a", "b"}}, &CSVValidator{Header: []string{"b", "a"}}, false},
+		{&RegexValidator{Pattern: regexp.MustCompile(`^\d+$`)}, &RegexValidator{Pattern: regexp.MustCompile(`^\d+$`)}, true},
+		{&RegexValidator{Pattern: regexp.MustCompile(`^\d+$`)}, &RegexValidator{Pattern: regexp.MustCompile(`^\w+$`)}, false},
+		{
+			&fingerprintDummy{Limits: map[string]int{"a": 1, "b": 2, "c": 3}, hidden: 1},
+			&fingerprintDummy{Limits: map[string]int{"c": 3, "b": 2, "a": 1}, hidden: 2},
+			true,
+		},
+		{&fingerprintDummy{Next: &fingerprintDummy{}}, &fingerprintDummy{}, false},
+		{selfDescribing{id: "x"}, selfDescribing{id: "x"}, true},
+		{selfDescribing{id: "x"}, selfDescribing{id: "y"}, false},
+	}
+	for _, tc := range tests {
+		if got := SameConfig(tc.a, tc.b); got != tc.same {
+			t.Errorf("SameConfig(%#v, %#v): expected %v, got %v", tc.a, tc.b, tc.same, got)
+		}
+	}
+}
+
+func TestFingerprint_Unsupported(t *testing.T) {
+	if _, err := Fingerprint(ValidatorFunc[int](func(int) (bool, error) { return true, nil })); err == nil {
+		t.Errorf("expected error for func value")
+	}
+
+	cyclic := &fingerprintDummy{}
+	cyclic.Next = cyclic
+	if _, err := Fingerprint(cyclic); err == nil {
+		t.Errorf("expected error for cyclic value")
+	}
+}
+
+func TestEngine_SharesIdenticalDirectives(t *testing.T) {
+	type a struct {
+		Name string `val:"min,size=3"`
+	}
+	type b struct {
+		Title string `val:"min, size=3"`
+		Code  string `val:"min,size=4"`
+	}
+
+	e := NewEngine()
+	pa, _ := e.plan(reflect.TypeOf(a{}), options{})
+	pb, _ := e.plan(reflect.TypeOf(b{}), options{})
+
+	if pa.fields[0].steps[0].inst != pb.fields[0].steps[0].inst {
+		t.Errorf("expected identically configured directives to be shared")
+	}
+	if pa.fields[0].steps[0].inst == pb.fields[1].steps[0].inst {
+		t.Errorf("expected differently configured directives not to be shared")
+	}
+}
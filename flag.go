@@ -0,0 +1,61 @@
+package valex
+
+import (
+	"flag"
+	"fmt"
+	"reflect"
+)
+
+// SetString parses s into T, the way Coerce parses values, and sets it.
+func (v *ValidatedValue[T]) SetString(s string) error {
+	var val T
+	if err := setFromString(reflect.ValueOf(&val).Elem(), s); err != nil {
+		return err
+	}
+	return v.Set(val)
+}
+
+// flagValue adapts a ValidatedValue to flag.Value, whose Set takes the
+// command line argument rather than a T.
+type flagValue[T any] struct {
+	v *ValidatedValue[T]
+}
+
+func (f *flagValue[T]) String() string {
+	if f.v == nil { // the zero value flag.PrintDefaults creates
+		var zero T
+		return fmt.Sprintf("%v", zero)
+	}
+	return f.v.String()
+}
+
+func (f *flagValue[T]) Set(s string) error {
+	return f.v.SetString(s)
+}
+
+func (f *flagValue[T]) Get() any {
+	return f.v.Get()
+}
+
+// FlagValue returns v as a flag.Getter that validates every value it is set
+// to.
+func (v *ValidatedValue[T]) FlagValue() flag.Getter {
+	return &flagValue[T]{v: v}
+}
+
+// FlagVar defines a flag on fs, or flag.CommandLine when fs is nil, that sets
+// v. The current value of v is the default and is not validated.
+func FlagVar[T any](fs *flag.FlagSet, v *ValidatedValue[T], name, usage string) {
+	if fs == nil {
+		fs = flag.CommandLine
+	}
+	fs.Var(v.FlagValue(), name, usage)
+}
+
+// Flag defines a flag like FlagVar with a new ValidatedValue holding value
+// as its default.
+func Flag[T any](fs *flag.FlagSet, name string, value T, validator Validator[T], usage string) *ValidatedValue[T] {
+	v := &ValidatedValue[T]{value: value, Validator: validator}
+	FlagVar(fs, v, name, usage)
+	return v
+}
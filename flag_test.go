@@ -0,0 +1,59 @@
+package valex
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFlagVar(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	var out bytes.Buffer
+	fs.SetOutput(&out)
+
+	port := Flag[int](fs, "port", 8080, &IntRangeValidator{Min: 1, Max: 65535}, "port to listen on")
+	timeout := &ValidatedValue[time.Duration]{value: time.Second, Validator: ValidatorFunc[time.Duration](func(d time.Duration) (bool, error) {
+		if d <= 0 {
+			return false, fmt.Errorf("timeout must be positive")
+		}
+		return true, nil
+	})}
+	FlagVar(fs, timeout, "timeout", "request timeout")
+
+	if err := fs.Parse([]string{"-port", "9000", "-timeout", "5s"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if port.Get() != 9000 || timeout.Get() != 5*time.Second {
+		t.Errorf("got port %d and timeout %s", port.Get(), timeout.Get())
+	}
+	if got := fs.Lookup("port").Value.(flag.Getter).Get(); got != 9000 {
+		t.Errorf("Get() = %v, want 9000", got)
+	}
+
+	tests := []struct {
+		args    []string
+		wantErr string
+	}{
+		{args: []string{"-port", "70000"}, wantErr: "invalid value"},
+		{args: []string{"-port", "high"}, wantErr: `invalid value "high" for int`},
+		{args: []string{"-timeout", "-1s"}, wantErr: "invalid value"},
+	}
+	for _, tc := range tests {
+		err := fs.Parse(tc.args)
+		if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+			t.Errorf("Parse(%v) = %v, want an error containing %q", tc.args, err, tc.wantErr)
+		}
+	}
+	if port.Get() != 9000 {
+		t.Errorf("invalid value was set: %d", port.Get())
+	}
+
+	out.Reset()
+	fs.PrintDefaults()
+	if !strings.Contains(out.String(), "(default 8080)") {
+		t.Errorf("defaults lack the port default:\n%s", out.String())
+	}
+}
@@ -2,4 +2,7 @@ module github.com/tedla-brandsema/valex
 
 go 1.23.2
 
-require github.com/tedla-brandsema/tagex v0.0.0-20250321080833-73c9743efe89
+require (
+	golang.org/x/net v0.38.0
+	golang.org/x/text v0.23.0
+)
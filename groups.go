@@ -0,0 +1,59 @@
+package valex
+
+import (
+	"slices"
+	"strings"
+)
+
+// groupsKey lists the groups the rules of a field belong to, e.g.
+// `val:"email,groups=create update"`.
+const groupsKey = "groups"
+
+// WithGroups selects the groups whose rules run. Rules without groups always
+// run; rules with groups only run when one of them is selected, so a single
+// struct can serve endpoints with different rule sets.
+func WithGroups(groups ...string) Option {
+	return func(o *options) {
+		o.groups = append(o.groups, groups...)
+	}
+}
+
+// splitGroups removes the groups element from tagValue and returns the
+// groups it lists.
+func splitGroups(tagValue string) (string, []string) {
+	if !strings.Contains(tagValue, groupsKey) {
+		return tagValue, nil
+	}
+	parts := splitTag(tagValue)
+	kept := parts[:0]
+	var groups []string
+	for _, part := range parts {
+		if k, v, ok := strings.Cut(part, "="); ok && strings.TrimSpace(k) == groupsKey {
+			groups = append(groups, strings.Fields(v)...)
+			continue
+		}
+		kept = append(kept, part)
+	}
+	return strings.Join(kept, ","), groups
+}
+
+// compileGroupedTag compiles a tag value that may list groups, returning
+// its steps and the groups.
+func (e *Engine) compileGroupedTag(tagValue string, tenant *Tenant) ([]step, []string, error) {
+	tagValue, groups := splitGroups(tagValue)
+	steps, err := e.compileTag(tagValue, tenant)
+	return steps, groups, err
+}
+
+// inGroups reports whether rules belonging to groups run.
+func (v *validation) inGroups(groups []string) bool {
+	if len(groups) == 0 {
+		return true
+	}
+	for _, g := range groups {
+		if slices.Contains(v.o.groups, g) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,67 @@
+package valex
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type groupsUser struct {
+	ID    int      `val:"pos,groups=update"`
+	Name  string   `val:"min,size=3,groups=create update"`
+	Email string   `val:"email"`
+	Tags  []string `val:"dive,max,size=3,groups=create"`
+}
+
+func TestWithGroups(t *testing.T) {
+	data := groupsUser{ID: -1, Name: "Jo", Email: "john@example.com", Tags: []string{"long"}}
+	tests := []struct {
+		name   string
+		groups []string
+		want   []string // failing fields
+	}{
+		{"no groups", nil, nil},
+		{"create", []string{"create"}, []string{"Name", "Tags[0]"}},
+		{"update", []string{"update"}, []string{"ID", "Name"}},
+		{"both", []string{"create", "update"}, []string{"ID", "Name", "Tags[0]"}},
+		{"unknown group", []string{"delete"}, nil},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ValidateStruct(&data, WithCollectAll(), WithGroups(tc.groups...))
+			var got []string
+			if ve, ok := err.(ValidationErrors); ok {
+				for _, fe := range ve {
+					got = append(got, fe.Field)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("expected failing fields %v, got %v", tc.want, got)
+			}
+		})
+	}
+
+	if _, err := ValidateStruct(&groupsUser{Name: "John", Email: "nope"}, WithGroups("create")); err == nil || !strings.Contains(err.Error(), `"Email"`) {
+		t.Errorf("expected ungrouped rules to run, got %v", err)
+	}
+}
+
+func TestSplitGroups(t *testing.T) {
+	tests := []struct {
+		tagValue   string
+		wantTag    string
+		wantGroups []string
+	}{
+		{"email", "email", nil},
+		{"email,groups=create", "email", []string{"create"}},
+		{"min,size=3, groups = create  update ,max,size=5", "min,size=3,max,size=5", []string{"create", "update"}},
+	}
+	for _, tc := range tests {
+		tagValue, groups := splitGroups(tc.tagValue)
+		if tagValue != tc.wantTag || !reflect.DeepEqual(groups, tc.wantGroups) {
+			t.Errorf("splitGroups(%q): expected %q %v, got %q %v", tc.tagValue, tc.wantTag, tc.wantGroups, tagValue, groups)
+		}
+	}
+}
@@ -0,0 +1,18 @@
+module github.com/tedla-brandsema/valex/grpcvalex
+
+go 1.23.2
+
+require (
+	github.com/tedla-brandsema/valex v0.0.0
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20240814211410-ddb44dafa142
+	google.golang.org/grpc v1.67.3
+)
+
+require (
+	golang.org/x/net v0.38.0 // indirect
+	golang.org/x/sys v0.31.0 // indirect
+	golang.org/x/text v0.23.0 // indirect
+	google.golang.org/protobuf v1.34.2 // indirect
+)
+
+replace github.com/tedla-brandsema/valex => ../
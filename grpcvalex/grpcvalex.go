@@ -0,0 +1,158 @@
+// Package grpcvalex provides gRPC server interceptors that validate incoming
+// request messages with valex.
+package grpcvalex
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+
+	"github.com/tedla-brandsema/valex"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type config struct {
+	engine *valex.Engine
+	opts   []valex.Option
+}
+
+type Option func(*config)
+
+// WithEngine validates with e instead of the default engine.
+func WithEngine(e *valex.Engine) Option {
+	return func(c *config) {
+		c.engine = e
+	}
+}
+
+// WithValidationOptions passes opts, e.g. valex.WithTenant, to every
+// validation.
+func WithValidationOptions(opts ...valex.Option) Option {
+	return func(c *config) {
+		c.opts = append(c.opts, opts...)
+	}
+}
+
+func newConfig(opts []Option) *config {
+	c := &config{engine: valex.Default()}
+	for _, opt := range opts {
+		opt(c)
+	}
+	c.opts = append(c.opts, valex.WithCollectAll())
+	return c
+}
+
+// UnaryServerInterceptor validates each request message before calling the
+// handler. Invalid requests are rejected with codes.InvalidArgument and a
+// BadRequest detail listing the field violations.
+func UnaryServerInterceptor(opts ...Option) grpc.UnaryServerInterceptor {
+	c := newConfig(opts)
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := c.validate(req); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor validates every message received on a stream.
+func StreamServerInterceptor(opts ...Option) grpc.StreamServerInterceptor {
+	c := newConfig(opts)
+	return func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &validatingStream{ServerStream: ss, c: c})
+	}
+}
+
+type validatingStream struct {
+	grpc.ServerStream
+	c *config
+}
+
+func (s *validatingStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return s.c.validate(m)
+}
+
+func (c *config) validate(msg any) error {
+	t := reflect.TypeOf(msg)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil
+	}
+	ok, err := c.engine.ValidateStruct(msg, c.opts...)
+	if ok {
+		return nil
+	}
+	if valex.Categorize(err) != valex.CategoryValidation {
+		return status.Error(codes.Internal, "request validation failed")
+	}
+
+	var errs valex.ValidationErrors
+	errors.As(err, &errs)
+	br := &errdetails.BadRequest{}
+	for _, fe := range errs {
+		br.FieldViolations = append(br.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       fieldName(t, fe.Path),
+			Description: fe.Err.Error(),
+		})
+	}
+	st, detailErr := status.New(codes.InvalidArgument, "invalid request").WithDetails(br)
+	if detailErr != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return st.Err()
+}
+
+// fieldName renders p using the protobuf field names of generated messages,
+// falling back to json and then Go field names.
+func fieldName(t reflect.Type, p valex.FieldPath) string {
+	var out valex.FieldPath
+	for _, pe := range p {
+		for t != nil && t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		switch {
+		case pe.IsIndex():
+			out = out.Index(pe.Index)
+		case pe.IsKey():
+			out = out.Key(pe.Key)
+		}
+		if !pe.IsField() {
+			if t != nil && (t.Kind() == reflect.Slice || t.Kind() == reflect.Map) {
+				t = t.Elem()
+			}
+			continue
+		}
+		if t == nil || t.Kind() != reflect.Struct {
+			out, t = out.Field(pe.Field), nil
+			continue
+		}
+		sf, ok := t.FieldByName(pe.Field)
+		if !ok {
+			out, t = out.Field(pe.Field), nil
+			continue
+		}
+		out, t = out.Field(protoName(sf)), sf.Type
+	}
+	return out.String()
+}
+
+func protoName(sf reflect.StructField) string {
+	for _, opt := range strings.Split(sf.Tag.Get("protobuf"), ",") {
+		if name, ok := strings.CutPrefix(opt, "name="); ok {
+			return name
+		}
+	}
+	if name, _, _ := strings.Cut(sf.Tag.Get("json"), ","); name != "" && name != "-" {
+		return name
+	}
+	return sf.Name
+}
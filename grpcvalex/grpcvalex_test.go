@@ -0,0 +1,138 @@
+package grpcvalex
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/tedla-brandsema/valex"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// createUserRequest mimics a generated message with val tags added.
+type createUserRequest struct {
+	UserName string   `protobuf:"bytes,1,opt,name=user_name,json=userName,proto3" json:"user_name,omitempty" val:"min,size=3"`
+	Email    string   `json:"email,omitempty" val:"email"`
+	Address  *address `protobuf:"bytes,3,opt,name=address,proto3" val:"dive"`
+}
+
+type address struct {
+	PostalCode string `protobuf:"bytes,1,opt,name=postal_code,proto3" val:"len,min=4,max=6"`
+}
+
+func violations(t *testing.T, err error) map[string]string {
+	t.Helper()
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument, got %v", err)
+	}
+	got := make(map[string]string)
+	for _, d := range st.Details() {
+		if br, ok := d.(*errdetails.BadRequest); ok {
+			for _, v := range br.FieldViolations {
+				got[v.Field] = v.Description
+			}
+		}
+	}
+	return got
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	intercept := UnaryServerInterceptor()
+	var called bool
+	handler := func(ctx context.Context, req any) (any, error) {
+		called = true
+		return "ok", nil
+	}
+
+	resp, err := intercept(context.Background(), &createUserRequest{UserName: "john", Email: "a@b.co"}, nil, handler)
+	if err != nil || resp != "ok" || !called {
+		t.Fatalf("expected a valid request to reach the handler, got %v, %v", resp, err)
+	}
+
+	called = false
+	req := &createUserRequest{UserName: "jo", Email: "nope", Address: &address{PostalCode: "1"}}
+	_, err = intercept(context.Background(), req, nil, handler)
+	if called {
+		t.Error("expected the handler not to be called")
+	}
+	got := violations(t, err)
+	for _, field := range []string{"user_name", "email", "address.postal_code"} {
+		if got[field] == "" {
+			t.Errorf("expected a violation for %q, got %v", field, got)
+		}
+	}
+	if len(got) != 3 {
+		t.Errorf("expected 3 violations, got %v", got)
+	}
+}
+
+func TestUnaryServerInterceptor_NonStructAndConfigErrors(t *testing.T) {
+	handler := func(ctx context.Context, req any) (any, error) { return req, nil }
+	if _, err := UnaryServerInterceptor()(context.Background(), "plain", nil, handler); err != nil {
+		t.Errorf("expected non-struct messages to pass, got %v", err)
+	}
+
+	type broken struct {
+		A string `val:"nope"`
+	}
+	_, err := UnaryServerInterceptor()(context.Background(), &broken{}, nil, handler)
+	if status.Code(err) != codes.Internal {
+		t.Errorf("expected Internal for a broken tag, got %v", err)
+	}
+}
+
+func TestWithEngineAndOptions(t *testing.T) {
+	e := valex.NewEngine()
+	if err := e.Alias("username", "min,size=10"); err != nil {
+		t.Fatal(err)
+	}
+	type req struct {
+		Name string `val:"username"`
+	}
+	_, err := UnaryServerInterceptor(WithEngine(e))(context.Background(), &req{Name: "short"}, nil,
+		func(ctx context.Context, req any) (any, error) { return nil, nil })
+	if got := violations(t, err); got["Name"] == "" {
+		t.Errorf("expected the custom engine's alias to apply, got %v", got)
+	}
+
+	_, err = UnaryServerInterceptor(WithValidationOptions(valex.WithTenant("missing")))(context.Background(), &req{}, nil,
+		func(ctx context.Context, req any) (any, error) { return nil, nil })
+	if status.Code(err) != codes.Internal {
+		t.Errorf("expected validation options to be passed, got %v", err)
+	}
+}
+
+type fakeStream struct {
+	grpc.ServerStream
+	msgs []*createUserRequest
+}
+
+func (s *fakeStream) RecvMsg(m any) error {
+	if len(s.msgs) == 0 {
+		return errors.New("EOF")
+	}
+	*m.(*createUserRequest) = *s.msgs[0]
+	s.msgs = s.msgs[1:]
+	return nil
+}
+
+func TestStreamServerInterceptor(t *testing.T) {
+	ss := &fakeStream{msgs: []*createUserRequest{
+		{UserName: "john", Email: "a@b.co"},
+		{UserName: "x", Email: "a@b.co"},
+	}}
+	err := StreamServerInterceptor()(nil, ss, nil, func(srv any, stream grpc.ServerStream) error {
+		var m createUserRequest
+		if err := stream.RecvMsg(&m); err != nil {
+			t.Fatalf("expected the first message to be valid, got %v", err)
+		}
+		return stream.RecvMsg(&m)
+	})
+	if got := violations(t, err); got["user_name"] == "" {
+		t.Errorf("expected the second message to be rejected, got %v", got)
+	}
+}
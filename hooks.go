@@ -0,0 +1,92 @@
+package valex
+
+import (
+	"context"
+	"reflect"
+	"slices"
+)
+
+// DirectiveCall describes a directive about to run on a field, or on an
+// element when diving. Value is nil for unexported fields. Secret is set
+// after the secret directive, hooks should not reveal Value then.
+type DirectiveCall struct {
+	Field     string
+	Path      FieldPath
+	Directive string
+	Value     any
+	Secret    bool
+}
+
+// BeforeHook is called before a directive runs.
+type BeforeHook func(ctx context.Context, call DirectiveCall)
+
+// AfterHook is called after a directive ran, with the error it reported,
+// e.g. to log, count or time directives. Values logged from hooks should be
+// redacted by the hook itself.
+type AfterHook func(ctx context.Context, call DirectiveCall, err error)
+
+type hooks struct {
+	before []BeforeHook
+	after  []AfterHook
+}
+
+// OnBefore adds a hook that runs before every directive of a struct
+// validation. Hooks run in the order they were added, on the goroutine
+// validating the field, so with WithConcurrency they must be safe for
+// concurrent use. Sanitizers do not run hooks.
+func (e *Engine) OnBefore(h BeforeHook) {
+	e.updateHooks(func(hs *hooks) { hs.before = append(hs.before, h) })
+}
+
+func OnBefore(h BeforeHook) {
+	std.OnBefore(h)
+}
+
+// OnAfter adds a hook that runs after every directive of a struct
+// validation, see OnBefore.
+func (e *Engine) OnAfter(h AfterHook) {
+	e.updateHooks(func(hs *hooks) { hs.after = append(hs.after, h) })
+}
+
+func OnAfter(h AfterHook) {
+	std.OnAfter(h)
+}
+
+// ClearHooks removes all hooks added with OnBefore and OnAfter.
+func (e *Engine) ClearHooks() {
+	e.hookMut.Lock()
+	e.hooks.Store(nil)
+	e.hookMut.Unlock()
+}
+
+func ClearHooks() {
+	std.ClearHooks()
+}
+
+func (e *Engine) updateHooks(fn func(*hooks)) {
+	e.hookMut.Lock()
+	defer e.hookMut.Unlock()
+
+	var next hooks
+	if cur := e.hooks.Load(); cur != nil {
+		next = hooks{before: slices.Clone(cur.before), after: slices.Clone(cur.after)}
+	}
+	fn(&next)
+	e.hooks.Store(&next)
+}
+
+func (v *validation) directiveCall(s *step, val reflect.Value, path FieldPath, secret bool) DirectiveCall {
+	call := DirectiveCall{Field: v.fieldName(path), Path: slices.Clone(path), Directive: s.name, Secret: secret}
+	if val.CanInterface() {
+		call.Value = val.Interface()
+	}
+	return call
+}
+
+// fieldName names path as it is reported in errors.
+func (v *validation) fieldName(path FieldPath) string {
+	if v.e.fieldNameFunc() != nil {
+		return v.e.fieldName(v.typ, path)
+	}
+	return path.String()
+}
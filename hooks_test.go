@@ -0,0 +1,66 @@
+package valex
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestEngine_Hooks(t *testing.T) {
+	type dummy struct {
+		Name string   `val:"min,size=2,alphanum" sane:"trim"`
+		Tags []string `val:"dive,alphanum"`
+	}
+	e := NewEngine()
+	var events []string
+	e.OnBefore(func(_ context.Context, c DirectiveCall) {
+		events = append(events, fmt.Sprintf("before %s:%s=%v", c.Field, c.Directive, c.Value))
+	})
+	e.OnAfter(func(_ context.Context, c DirectiveCall, err error) {
+		events = append(events, fmt.Sprintf("after %s:%s ok=%v", c.Field, c.Directive, err == nil))
+	})
+	e.OnAfter(func(_ context.Context, c DirectiveCall, err error) {
+		events = append(events, "second")
+	})
+
+	e.ValidateStruct(&dummy{Name: " ab ", Tags: []string{"a b"}})
+	want := []string{
+		"before Name:min=ab", "after Name:min ok=true", "second",
+		"before Name:alphanum=ab", "after Name:alphanum ok=true", "second",
+		"before Tags[0]:alphanum=a b", "after Tags[0]:alphanum ok=false", "second",
+	}
+	if !reflect.DeepEqual(events, want) {
+		t.Errorf("expected %v, got %v", want, events)
+	}
+
+	e.ClearHooks()
+	events = nil
+	e.ValidateStruct(&dummy{Name: "ab"})
+	if len(events) != 0 {
+		t.Errorf("expected no hooks after ClearHooks, got %v", events)
+	}
+}
+
+func TestEngine_HooksConcurrent(t *testing.T) {
+	type dummy struct {
+		A, B, C, D string `val:"alphanum"`
+	}
+	e := NewEngine()
+	var (
+		mu    sync.Mutex
+		count int
+	)
+	e.OnAfter(func(context.Context, DirectiveCall, error) {
+		mu.Lock()
+		count++
+		mu.Unlock()
+	})
+	raceRun(t, 8, func(int) {
+		e.ValidateStruct(dummy{"a", "b", "c", "d"}, WithConcurrency(4))
+	})
+	if count != 8*50*4 {
+		t.Errorf("expected %d hook calls, got %d", 8*50*4, count)
+	}
+}
@@ -0,0 +1,178 @@
+package valex
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+var voidElements = map[string]bool{
+	"area": true, "base": true, "br": true, "col": true, "embed": true, "hr": true, "img": true,
+	"input": true, "link": true, "meta": true, "source": true, "track": true, "wbr": true,
+}
+
+// walkHTML tokenizes val and calls visit for every start tag, failing unless
+// every non-void element is explicitly closed in the right order.
+func walkHTML(val string, visit func(tok html.Token) error) error {
+	z := html.NewTokenizer(strings.NewReader(val))
+	var open []string
+
+	for {
+		tt := z.Next()
+		switch tt {
+		case html.ErrorToken:
+			if err := z.Err(); !errors.Is(err, io.EOF) {
+				return fmt.Errorf("HTML parsing error: %w", err)
+			}
+			if len(open) > 0 {
+				return fmt.Errorf("element <%s> is not closed", open[len(open)-1])
+			}
+			return nil
+		case html.StartTagToken, html.SelfClosingTagToken:
+			tok := z.Token()
+			if visit != nil {
+				if err := visit(tok); err != nil {
+					return err
+				}
+			}
+			if tt == html.StartTagToken && !voidElements[tok.Data] {
+				open = append(open, tok.Data)
+			}
+		case html.EndTagToken:
+			tok := z.Token()
+			if voidElements[tok.Data] {
+				continue
+			}
+			if len(open) == 0 {
+				return fmt.Errorf("unexpected closing tag </%s>", tok.Data)
+			}
+			if top := open[len(open)-1]; top != tok.Data {
+				return fmt.Errorf("closing tag </%s> does not match open element <%s>", tok.Data, top)
+			}
+			open = open[:len(open)-1]
+		}
+	}
+}
+
+type HTMLValidator struct{}
+
+func (v *HTMLValidator) Validate(val string) (ok bool, err error) {
+	if err := walkHTML(val, nil); err != nil {
+		return false, failf(ErrInvalidFormat, "invalid HTML: %w", err)
+	}
+	return true, nil
+}
+
+func (v *HTMLValidator) Name() string {
+	return "html"
+}
+
+func (v *HTMLValidator) Handle(val string) error {
+	if ok, err := v.Validate(val); !ok {
+		return err
+	}
+	return nil
+}
+
+var defaultSafeHTMLTags = []string{
+	"a", "abbr", "b", "blockquote", "br", "code", "dd", "del", "div", "dl", "dt", "em",
+	"h1", "h2", "h3", "h4", "h5", "h6", "hr", "i", "img", "ins", "kbd", "li", "mark",
+	"ol", "p", "pre", "q", "s", "small", "span", "strong", "sub", "sup", "table",
+	"tbody", "td", "tfoot", "th", "thead", "tr", "u", "ul",
+}
+
+// alwaysUnsafeTags are rejected even when they appear in the allow-list.
+var alwaysUnsafeTags = map[string]bool{
+	"script": true, "iframe": true, "object": true, "embed": true, "frame": true, "frameset": true,
+}
+
+var urlAttributes = map[string]bool{
+	"href": true, "src": true, "action": true, "formaction": true, "background": true,
+	"cite": true, "poster": true, "xlink:href": true, "srcset": true,
+}
+
+// SafeHTMLValidator accepts well-formed HTML that only uses allowed tags and
+// contains no scripts, event handler attributes or script URLs. Without an
+// explicit allow-list a conservative set of formatting tags is allowed.
+type SafeHTMLValidator struct {
+	AllowedTags []string `param:"tags,optional"`
+}
+
+func (v *SafeHTMLValidator) Validate(val string) (ok bool, err error) {
+	allowed := v.AllowedTags
+	if len(allowed) == 0 {
+		allowed = defaultSafeHTMLTags
+	}
+
+	err = walkHTML(val, func(tok html.Token) error {
+		if alwaysUnsafeTags[tok.Data] {
+			return fmt.Errorf("element <%s> is not allowed", tok.Data)
+		}
+		if !containsFold(allowed, tok.Data) {
+			return fmt.Errorf("element <%s> is not in the allowed tags", tok.Data)
+		}
+		for _, attr := range tok.Attr {
+			key := strings.ToLower(attr.Key)
+			if strings.HasPrefix(key, "on") {
+				return fmt.Errorf("event handler attribute %q on <%s> is not allowed", attr.Key, tok.Data)
+			}
+			if urlAttributes[key] && isScriptURL(attr.Val) {
+				return fmt.Errorf("script URL in attribute %q on <%s> is not allowed", attr.Key, tok.Data)
+			}
+			if key == "style" && isScriptStyle(attr.Val) {
+				return fmt.Errorf("script in style attribute on <%s> is not allowed", tok.Data)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return false, failf(ErrNotAllowed, "unsafe HTML: %w", err)
+	}
+	return true, nil
+}
+
+func (v *SafeHTMLValidator) Name() string {
+	return "safehtml"
+}
+
+func (v *SafeHTMLValidator) Handle(val string) error {
+	if ok, err := v.Validate(val); !ok {
+		return err
+	}
+	return nil
+}
+
+func containsFold(list []string, s string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// isScriptURL reports whether u uses a scheme that executes code, ignoring
+// the whitespace and control characters browsers strip from URLs.
+func isScriptURL(u string) bool {
+	u = strings.Map(func(r rune) rune {
+		if r <= ' ' {
+			return -1
+		}
+		return r
+	}, u)
+	u = strings.ToLower(u)
+	for _, scheme := range []string{"javascript:", "vbscript:", "data:text/html"} {
+		if strings.HasPrefix(u, scheme) {
+			return true
+		}
+	}
+	return false
+}
+
+func isScriptStyle(style string) bool {
+	style = strings.ToLower(style)
+	return strings.Contains(style, "javascript:") || strings.Contains(style, "expression(")
+}
@@ -0,0 +1,52 @@
+package valex
+
+import "testing"
+
+func TestHTMLValidator(t *testing.T) {
+	v := &HTMLValidator{}
+	tests := []struct {
+		input string
+		ok    bool
+	}{
+		{"<p>Hello <b>world</b></p>", true},
+		{"plain text", true},
+		{"<p>line<br>break<br/></p><img src=\"a.png\">", true},
+		{"<p>Hello <b>world</p></b>", false},
+		{"<div><p>unclosed</div>", false},
+		{"<p>unclosed", false},
+		{"</p>", false},
+	}
+	for _, tc := range tests {
+		ok, err := v.Validate(tc.input)
+		if ok != tc.ok {
+			t.Errorf("%T(%q): expected ok=%v, got ok=%v, err=%v", *v, tc.input, tc.ok, ok, err)
+		}
+	}
+}
+
+func TestSafeHTMLValidator(t *testing.T) {
+	tests := []struct {
+		v     *SafeHTMLValidator
+		input string
+		ok    bool
+	}{
+		{&SafeHTMLValidator{}, `<p>Hello <a href="https://example.com">world</a></p>`, true},
+		{&SafeHTMLValidator{}, `<p style="color: red">red</p>`, true},
+		{&SafeHTMLValidator{}, `<script>alert(1)</script>`, false},
+		{&SafeHTMLValidator{}, `<img src="x.png" onerror="alert(1)">`, false},
+		{&SafeHTMLValidator{}, `<a href="javascript:alert(1)">x</a>`, false},
+		{&SafeHTMLValidator{}, `<a href=" JaVa&#x09;script:alert(1)">x</a>`, false},
+		{&SafeHTMLValidator{}, `<p style="background: url(javascript:alert(1))">x</p>`, false},
+		{&SafeHTMLValidator{}, `<form action="/"></form>`, false}, // not in the default allow-list
+		{&SafeHTMLValidator{}, `<p>unclosed`, false},
+		{&SafeHTMLValidator{AllowedTags: []string{"b", "i"}}, `<b>bold</b> <i>italic</i>`, true},
+		{&SafeHTMLValidator{AllowedTags: []string{"b", "i"}}, `<p>para</p>`, false},
+		{&SafeHTMLValidator{AllowedTags: []string{"script"}}, `<script></script>`, false},
+	}
+	for _, tc := range tests {
+		ok, err := tc.v.Validate(tc.input)
+		if ok != tc.ok {
+			t.Errorf("%T(%q): expected ok=%v, got ok=%v, err=%v", *tc.v, tc.input, tc.ok, ok, err)
+		}
+	}
+}
@@ -0,0 +1,174 @@
+// Package httpbind decodes HTTP requests into structs and validates them
+// with valex, turning failures into ready to send JSON error responses.
+package httpbind
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/tedla-brandsema/valex"
+)
+
+const (
+	defaultMaxBodyBytes = 1 << 20
+	defaultMaxMemory    = 1 << 20 // for multipart forms, larger files go to disk
+)
+
+// Error is returned by the Bind functions. Status is the code the engine's
+// StatusMapper assigns to Err, e.g. 422 for validation failures, and Body is
+// the matching JSON error payload.
+type Error struct {
+	Status int
+	Body   map[string]any
+	Err    error
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Write sends the error as a JSON response.
+func (e *Error) Write(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(e.Status)
+	_ = json.NewEncoder(w).Encode(e.Body)
+}
+
+// WriteError writes err as a JSON response. Errors other than *Error are
+// reported as 500 without exposing their message.
+func WriteError(w http.ResponseWriter, err error) {
+	var be *Error
+	if !errors.As(err, &be) {
+		be = &Error{
+			Status: http.StatusInternalServerError,
+			Body:   map[string]any{"": []string{http.StatusText(http.StatusInternalServerError)}},
+			Err:    err,
+		}
+	}
+	be.Write(w)
+}
+
+// Binder configures how requests are decoded and validated. The zero value
+// uses the default engine, a 1 MiB body limit and flat error bodies.
+type Binder struct {
+	Engine             *valex.Engine
+	MaxBodyBytes       int64
+	Shape              valex.ErrorShape
+	AllowUnknownFields bool
+}
+
+func (b *Binder) engine() *valex.Engine {
+	if b.Engine == nil {
+		return valex.Default()
+	}
+	return b.Engine
+}
+
+func (b *Binder) limit(r *http.Request) {
+	n := b.MaxBodyBytes
+	if n <= 0 {
+		n = defaultMaxBodyBytes
+	}
+	if r.Body != nil {
+		r.Body = http.MaxBytesReader(nil, r.Body, n)
+	}
+}
+
+func (b *Binder) fail(err error, v any) error {
+	if err == nil {
+		return nil
+	}
+	e := b.engine()
+	return &Error{Status: e.HTTPStatus(err), Body: valex.ErrorBody(err, v, b.Shape), Err: err}
+}
+
+// JSON decodes the request body into v, which must be a pointer to a
+// struct, and validates it the way valex.DecodeAndValidate does: unknown
+// fields are rejected unless AllowUnknownFields is set, and values of the
+// wrong type are reported together with the validation failures.
+func (b *Binder) JSON(r *http.Request, v any, opts ...valex.Option) error {
+	if r.Body == nil {
+		return b.fail(valex.WithCategory(errors.New("request body is empty"), valex.CategoryDecode), v)
+	}
+	n := b.MaxBodyBytes
+	if n <= 0 {
+		n = defaultMaxBodyBytes
+	}
+	opts = append([]valex.Option{valex.WithMaxBytes(n)}, opts...)
+	if b.AllowUnknownFields {
+		opts = append(opts, valex.WithUnknownFields())
+	}
+	return b.fail(b.engine().DecodeAndValidate(r.Body, v, opts...), v)
+}
+
+// Form parses the URL query and a urlencoded or multipart body and coerces
+// the values into v using its `form` tags. Repeated values are joined with
+// spaces, which is how list fields are read.
+func (b *Binder) Form(r *http.Request, v any, opts ...valex.Option) error {
+	b.limit(r)
+	var err error
+	if mt, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mt == "multipart/form-data" {
+		err = r.ParseMultipartForm(defaultMaxMemory)
+	} else {
+		err = r.ParseForm()
+	}
+	if err != nil {
+		return b.fail(decodeError(err), v)
+	}
+	return b.coerce(r.Form, v, "form", opts)
+}
+
+// Query coerces the URL query into v using its `query` tags.
+func (b *Binder) Query(r *http.Request, v any, opts ...valex.Option) error {
+	return b.coerce(r.URL.Query(), v, "query", opts)
+}
+
+// Headers coerces the request headers into v using its `header` tags, e.g.
+// `header:"X-Request-Id"`. Header names are matched case-insensitively.
+func (b *Binder) Headers(r *http.Request, v any, opts ...valex.Option) error {
+	return b.coerce(r.Header, v, "header", append([]valex.Option{valex.WithKeyFold()}, opts...))
+}
+
+func (b *Binder) coerce(values map[string][]string, v any, keyTag string, opts []valex.Option) error {
+	src := make(map[string]string, len(values))
+	for k, vals := range values {
+		src[k] = strings.Join(vals, " ")
+	}
+	err := b.engine().Coerce(v, src, append([]valex.Option{valex.WithKeyTag(keyTag)}, opts...)...)
+	return b.fail(err, v)
+}
+
+// decodeError marks err as a decode error unless it is a size violation.
+func decodeError(err error) error {
+	var maxBytes *http.MaxBytesError
+	if errors.As(err, &maxBytes) {
+		return fmt.Errorf("request body too large: %w", err)
+	}
+	return valex.WithCategory(err, valex.CategoryDecode)
+}
+
+var std Binder
+
+func BindJSON(r *http.Request, v any, opts ...valex.Option) error {
+	return std.JSON(r, v, opts...)
+}
+
+func BindForm(r *http.Request, v any, opts ...valex.Option) error {
+	return std.Form(r, v, opts...)
+}
+
+func BindQuery(r *http.Request, v any, opts ...valex.Option) error {
+	return std.Query(r, v, opts...)
+}
+
+func BindHeaders(r *http.Request, v any, opts ...valex.Option) error {
+	return std.Headers(r, v, opts...)
+}
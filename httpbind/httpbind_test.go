@@ -0,0 +1,192 @@
+package httpbind
+
+import (
+	"bytes"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/tedla-brandsema/valex"
+)
+
+type signup struct {
+	Email string   `json:"email" form:"email" query:"email" val:"email"`
+	Name  string   `json:"name" form:"name" query:"name" val:"min,size=3"`
+	Age   int      `json:"age" form:"age" query:"age" val:"range,min=18,max=130"`
+	Tags  []string `json:"tags" form:"tag" query:"tag"`
+}
+
+func TestBindJSON(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+		wantKeys   []string
+	}{
+		{name: "valid", body: `{"email":"a@b.co","name":"john","age":30}`},
+		{name: "invalid fields", body: `{"email":"nope","name":"jo","age":30}`, wantStatus: 422, wantKeys: []string{"email", "name"}},
+		{name: "malformed", body: `{"email":`, wantStatus: 400, wantKeys: []string{""}},
+		{name: "wrong type", body: `{"email":"nope","name":"john","age":"old"}`, wantStatus: 400, wantKeys: []string{"age", "email"}},
+		{name: "empty", body: ``, wantStatus: 400, wantKeys: []string{""}},
+		{name: "trailing data", body: `{"email":"a@b.co","name":"john","age":30} {}`, wantStatus: 400, wantKeys: []string{""}},
+		{name: "too large", body: `{"name":"` + strings.Repeat("x", 2<<20) + `"}`, wantStatus: 413, wantKeys: []string{""}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tc.body))
+			var got signup
+			err := BindJSON(r, &got)
+			if tc.wantStatus == 0 {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			var be *Error
+			if !errors.As(err, &be) {
+				t.Fatalf("expected *Error, got %v", err)
+			}
+			if be.Status != tc.wantStatus {
+				t.Errorf("expected status %d, got %d (%v)", tc.wantStatus, be.Status, err)
+			}
+			var keys []string
+			for k := range be.Body {
+				keys = append(keys, k)
+			}
+			if len(keys) != len(tc.wantKeys) {
+				t.Errorf("expected body keys %v, got %v", tc.wantKeys, be.Body)
+			}
+			for _, k := range tc.wantKeys {
+				if _, ok := be.Body[k]; !ok {
+					t.Errorf("expected body key %q, got %v", k, be.Body)
+				}
+			}
+		})
+	}
+}
+
+func TestBinder_UnknownFields(t *testing.T) {
+	body := `{"email":"a@b.co","name":"john","age":30,"extra":1}`
+	var be *Error
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	if err := BindJSON(r, &signup{}); !errors.As(err, &be) || be.Status != http.StatusBadRequest {
+		t.Errorf("expected a 400 for unknown fields, got %v", err)
+	}
+
+	b := &Binder{AllowUnknownFields: true}
+	r = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	if err := b.JSON(r, &signup{}); err != nil {
+		t.Errorf("expected unknown fields to be allowed, got %v", err)
+	}
+}
+
+func TestBinder_NestedShapeAndEngine(t *testing.T) {
+	e := valex.NewEngine()
+	e.SetStatusMapper(valex.NewStatusMapper().Set(valex.CategoryValidation, http.StatusBadRequest))
+	b := &Binder{Engine: e, Shape: valex.NestedErrors}
+
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"email":"a@b.co","name":"jo","age":30}`))
+	var be *Error
+	if err := b.JSON(r, &signup{}); !errors.As(err, &be) || be.Status != http.StatusBadRequest {
+		t.Fatalf("expected the engine's status mapping, got %v", err)
+	}
+	if _, ok := be.Body["name"].([]string); !ok {
+		t.Errorf("expected errors for name, got %v", be.Body)
+	}
+}
+
+func TestBindForm(t *testing.T) {
+	form := url.Values{"email": {"a@b.co"}, "name": {"john"}, "age": {"30"}, "tag": {"a", "b"}}
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	var got signup
+	if err := BindForm(r, &got); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := signup{Email: "a@b.co", Name: "john", Age: 30, Tags: []string{"a", "b"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %+v, got %+v", want, got)
+	}
+}
+
+func TestBindForm_Multipart(t *testing.T) {
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	_ = mw.WriteField("email", "a@b.co")
+	_ = mw.WriteField("name", "john")
+	_ = mw.WriteField("age", "abc")
+	_ = mw.Close()
+	r := httptest.NewRequest(http.MethodPost, "/", &buf)
+	r.Header.Set("Content-Type", mw.FormDataContentType())
+
+	var be *Error
+	if err := BindForm(r, &signup{}); !errors.As(err, &be) || be.Status != http.StatusUnprocessableEntity {
+		t.Fatalf("expected a 422, got %v", err)
+	}
+	if _, ok := be.Body["age"]; !ok || len(be.Body) != 1 {
+		t.Errorf("expected only age to fail, got %v", be.Body)
+	}
+}
+
+func TestBindQuery(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/?email=a@b.co&name=john&age=12", nil)
+	var be *Error
+	if err := BindQuery(r, &signup{}); !errors.As(err, &be) || be.Status != http.StatusUnprocessableEntity {
+		t.Fatalf("expected a 422, got %v", err)
+	}
+	if _, ok := be.Body["age"]; !ok {
+		t.Errorf("expected age to fail, got %v", be.Body)
+	}
+}
+
+func TestWriteError(t *testing.T) {
+	rec := httptest.NewRecorder()
+	WriteError(rec, errors.New("secret detail"))
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("expected 500, got %d", rec.Code)
+	}
+	if strings.Contains(rec.Body.String(), "secret") {
+		t.Errorf("expected internal errors to be hidden, got %s", rec.Body)
+	}
+
+	rec = httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"email":"x"}`))
+	WriteError(rec, BindJSON(r, &signup{}))
+	if rec.Code != http.StatusUnprocessableEntity || !strings.Contains(rec.Body.String(), `"email"`) {
+		t.Errorf("expected a 422 with field errors, got %d %s", rec.Code, rec.Body)
+	}
+}
+
+type requestMeta struct {
+	RequestID string `header:"x-request-id" val:"alphanum"`
+	Retries   int    `header:"X-Retries" val:"range,min=0,max=5"`
+}
+
+func TestBindHeaders(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("X-Request-Id", "abc123")
+	r.Header.Set("X-Retries", "2")
+
+	var got requestMeta
+	if err := BindHeaders(r, &got); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != (requestMeta{RequestID: "abc123", Retries: 2}) {
+		t.Errorf("unexpected result %+v", got)
+	}
+
+	r.Header.Set("X-Retries", "9")
+	var be *Error
+	if err := BindHeaders(r, &requestMeta{}); !errors.As(err, &be) || be.Status != http.StatusUnprocessableEntity {
+		t.Fatalf("expected a 422, got %v", err)
+	}
+	if _, ok := be.Body["X-Retries"]; !ok {
+		t.Errorf("expected the error keyed by header name, got %v", be.Body)
+	}
+}
@@ -0,0 +1,33 @@
+package httpbind
+
+import (
+	"context"
+	"net/http"
+)
+
+type bodyKey[T any] struct{}
+
+// ValidateBody decodes the JSON request body into a T and validates it. On
+// success the value is stored in the request context, see Body; on failure
+// a JSON error response is written and next is not called.
+func ValidateBody[T any](next http.Handler) http.Handler {
+	return ValidateBodyWith[T](&std, next)
+}
+
+// ValidateBodyWith is ValidateBody using b to decode and validate.
+func ValidateBodyWith[T any](b *Binder, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var v T
+		if err := b.JSON(r, &v); err != nil {
+			WriteError(w, err)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), bodyKey[T]{}, v)))
+	})
+}
+
+// Body returns the value stored by ValidateBody for type T.
+func Body[T any](ctx context.Context) (T, bool) {
+	v, ok := ctx.Value(bodyKey[T]{}).(T)
+	return v, ok
+}
@@ -0,0 +1,67 @@
+package httpbind
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestValidateBody(t *testing.T) {
+	var got signup
+	var called bool
+	h := ValidateBody[signup](http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		var ok bool
+		if got, ok = Body[signup](r.Context()); !ok {
+			t.Error("expected the body in the request context")
+		}
+		if _, ok := Body[*signup](r.Context()); ok {
+			t.Error("expected values to be keyed by type")
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+		wantCalled bool
+	}{
+		{name: "valid", body: `{"email":"a@b.co","name":"john","age":30}`, wantStatus: http.StatusNoContent, wantCalled: true},
+		{name: "invalid", body: `{"email":"a@b.co","name":"jo","age":30}`, wantStatus: http.StatusUnprocessableEntity},
+		{name: "malformed", body: `{`, wantStatus: http.StatusBadRequest},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			called, got = false, signup{}
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tc.body)))
+
+			if rec.Code != tc.wantStatus {
+				t.Errorf("expected status %d, got %d: %s", tc.wantStatus, rec.Code, rec.Body)
+			}
+			if called != tc.wantCalled {
+				t.Errorf("expected next called=%v, got %v", tc.wantCalled, called)
+			}
+			if tc.wantCalled && got.Name != "john" {
+				t.Errorf("expected the decoded body, got %+v", got)
+			}
+			if !tc.wantCalled && rec.Header().Get("Content-Type") != "application/json" {
+				t.Errorf("expected a JSON error response")
+			}
+		})
+	}
+}
+
+func TestValidateBodyWith(t *testing.T) {
+	b := &Binder{MaxBodyBytes: 8}
+	h := ValidateBodyWith[signup](b, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("expected next not to be called")
+	}))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"name":"john"}`)))
+	if rec.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("expected 413, got %d", rec.Code)
+	}
+}
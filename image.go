@@ -0,0 +1,183 @@
+package valex
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"slices"
+	"strings"
+)
+
+// ImageValidator checks that a payload is a PNG, JPEG, GIF or WebP image,
+// optionally limited to Formats, within MaxWidth, MaxHeight and MaxBytes,
+// e.g. `val:"image,formats=png jpeg,maxwidth=512,maxheight=512"` for
+// avatar uploads. Only the image header is read unless Decode is set, in
+// which case PNG, JPEG and GIF images within MaxWidth and MaxHeight are
+// decoded in full; WebP images are always checked by their header only. Zero
+// limits are not enforced, so decoding untrusted images should set both.
+type ImageValidator struct {
+	Formats   []string `param:"formats,optional"`
+	MaxWidth  int      `param:"maxwidth,optional"`
+	MaxHeight int      `param:"maxheight,optional"`
+	MaxBytes  ByteSize `param:"maxbytes,optional"`
+	Decode    bool     `param:"decode,optional"`
+}
+
+func (v *ImageValidator) Validate(val []byte) (ok bool, err error) {
+	if v.MaxBytes > 0 && ByteSize(len(val)) > v.MaxBytes {
+		return false, rangeFailf(ErrTooLong, len(val), nil, v.MaxBytes, "image of %d bytes exceeds the maximum of %d", len(val), v.MaxBytes)
+	}
+	return v.ValidateReader(bytes.NewReader(val))
+}
+
+// ValidateReader validates the image read from r without buffering it. With
+// MaxBytes set it stops reading once the limit is exceeded.
+func (v *ImageValidator) ValidateReader(r io.Reader) (ok bool, err error) {
+	cr := &countingReader{r: r}
+	if v.MaxBytes > 0 {
+		cr.r = io.LimitReader(r, int64(v.MaxBytes)+1)
+	}
+	br := bufio.NewReader(cr)
+	head, _ := br.Peek(12)
+	format := sniffImage(head)
+	if format == "" {
+		return false, failf(ErrInvalidFormat, "not a PNG, JPEG, GIF or WebP image")
+	}
+	if len(v.Formats) > 0 && !slices.Contains(v.Formats, format) {
+		return false, failf(ErrNotAllowed, "image format %s is not one of %s", format, strings.Join(v.Formats, ", "))
+	}
+
+	// The dimensions are checked before a full decode, which allocates
+	// memory for every pixel the header claims. The header is kept so the
+	// decoder can read the image from its start.
+	var header bytes.Buffer
+	decode := v.Decode && format != "webp"
+	var hr io.Reader = br
+	if decode {
+		hr = io.TeeReader(br, &header)
+	}
+	cfg, err := imageConfig(format, hr)
+	if err == nil {
+		if v.MaxWidth > 0 && cfg.Width > v.MaxWidth {
+			return false, rangeFailf(ErrTooLong, cfg.Width, nil, v.MaxWidth, "image width %d exceeds the maximum of %d", cfg.Width, v.MaxWidth)
+		}
+		if v.MaxHeight > 0 && cfg.Height > v.MaxHeight {
+			return false, rangeFailf(ErrTooLong, cfg.Height, nil, v.MaxHeight, "image height %d exceeds the maximum of %d", cfg.Height, v.MaxHeight)
+		}
+		if decode {
+			err = decodeImage(format, io.MultiReader(&header, br))
+		}
+	}
+	if err == nil {
+		_, err = io.Copy(io.Discard, br)
+	}
+	if v.MaxBytes > 0 && ByteSize(cr.n) > v.MaxBytes { // also cuts decoding short
+		// the size is unknown, as reading stopped past the limit
+		return false, rangeFailf(ErrTooLong, nil, nil, v.MaxBytes, "image exceeds the maximum of %d bytes", v.MaxBytes)
+	}
+	if err != nil {
+		return false, failf(ErrInvalidFormat, "invalid %s image: %w", format, err)
+	}
+	return true, nil
+}
+
+func (v *ImageValidator) Name() string {
+	return "image"
+}
+
+func (v *ImageValidator) Handle(val []byte) error {
+	if ok, err := v.Validate(val); !ok {
+		return err
+	}
+	return nil
+}
+
+// imageConfig reads the dimensions from the header of an image in format.
+func imageConfig(format string, r io.Reader) (image.Config, error) {
+	switch format {
+	case "png":
+		return png.DecodeConfig(r)
+	case "jpeg":
+		return jpeg.DecodeConfig(r)
+	case "gif":
+		return gif.DecodeConfig(r)
+	}
+	return webpConfig(r)
+}
+
+// decodeImage decodes a PNG, JPEG or GIF image in full.
+func decodeImage(format string, r io.Reader) (err error) {
+	switch format {
+	case "png":
+		_, err = png.Decode(r)
+	case "jpeg":
+		_, err = jpeg.Decode(r)
+	default:
+		_, err = gif.Decode(r)
+	}
+	return err
+}
+
+func sniffImage(head []byte) string {
+	switch {
+	case bytes.HasPrefix(head, []byte("\x89PNG\r\n\x1a\n")):
+		return "png"
+	case bytes.HasPrefix(head, []byte("\xff\xd8\xff")):
+		return "jpeg"
+	case bytes.HasPrefix(head, []byte("GIF87a")), bytes.HasPrefix(head, []byte("GIF89a")):
+		return "gif"
+	case len(head) >= 12 && string(head[:4]) == "RIFF" && string(head[8:12]) == "WEBP":
+		return "webp"
+	}
+	return ""
+}
+
+// webpConfig reads the dimensions from the header of a lossy (VP8), lossless
+// (VP8L) or extended (VP8X) WebP image.
+func webpConfig(r io.Reader) (image.Config, error) {
+	var h [30]byte
+	if _, err := io.ReadFull(r, h[:]); err != nil {
+		return image.Config{}, err
+	}
+	data := h[20:]
+	var w, ht int
+	switch string(h[12:16]) {
+	case "VP8 ":
+		if data[3] != 0x9d || data[4] != 0x01 || data[5] != 0x2a {
+			return image.Config{}, errors.New("missing VP8 start code")
+		}
+		w = int(binary.LittleEndian.Uint16(data[6:8]) & 0x3fff)
+		ht = int(binary.LittleEndian.Uint16(data[8:10]) & 0x3fff)
+	case "VP8L":
+		if data[0] != 0x2f {
+			return image.Config{}, errors.New("missing VP8L signature")
+		}
+		bits := binary.LittleEndian.Uint32(data[1:5])
+		w = int(bits&0x3fff) + 1
+		ht = int(bits>>14&0x3fff) + 1
+	case "VP8X":
+		w = int(uint32(data[4])|uint32(data[5])<<8|uint32(data[6])<<16) + 1
+		ht = int(uint32(data[7])|uint32(data[8])<<8|uint32(data[9])<<16) + 1
+	default:
+		return image.Config{}, fmt.Errorf("unknown chunk %q", h[12:16])
+	}
+	return image.Config{Width: w, Height: ht}, nil
+}
+
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,109 @@
+package valex
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+func encodeImage(t *testing.T, format string, w, h int) []byte {
+	t.Helper()
+	img := image.NewPaletted(image.Rect(0, 0, w, h), []color.Color{color.Black, color.White})
+	var buf bytes.Buffer
+	var err error
+	switch format {
+	case "png":
+		err = png.Encode(&buf, img)
+	case "jpeg":
+		err = jpeg.Encode(&buf, img, nil)
+	case "gif":
+		err = gif.Encode(&buf, img, nil)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// webpLossless returns the header of a lossless WebP image of w by h.
+func webpLossless(w, h int) []byte {
+	bits := uint32(w-1) | uint32(h-1)<<14
+	b := []byte("RIFF\x00\x00\x00\x00WEBPVP8L\x00\x00\x00\x00\x2f")
+	b = append(b, byte(bits), byte(bits>>8), byte(bits>>16), byte(bits>>24))
+	return append(b, make([]byte, 10)...)
+}
+
+// pngIHDR returns the signature and IHDR chunk of a w by h PNG image
+// without any pixel data.
+func pngIHDR(w, h int) []byte {
+	ihdr := binary.BigEndian.AppendUint32([]byte("IHDR"), uint32(w))
+	ihdr = binary.BigEndian.AppendUint32(ihdr, uint32(h))
+	ihdr = append(ihdr, 8, 0, 0, 0, 0) // 8-bit grayscale
+	b := binary.BigEndian.AppendUint32([]byte("\x89PNG\r\n\x1a\n"), uint32(len(ihdr)-4))
+	b = append(b, ihdr...)
+	return binary.BigEndian.AppendUint32(b, crc32.ChecksumIEEE(ihdr))
+}
+
+func TestImageValidator(t *testing.T) {
+	pngImg := encodeImage(t, "png", 40, 30)
+	truncated := pngImg[:len(pngImg)-20]
+
+	tests := []struct {
+		name  string
+		v     ImageValidator
+		input []byte
+		err   string
+	}{
+		{"png", ImageValidator{}, pngImg, ""},
+		{"jpeg", ImageValidator{}, encodeImage(t, "jpeg", 40, 30), ""},
+		{"gif", ImageValidator{}, encodeImage(t, "gif", 40, 30), ""},
+		{"webp", ImageValidator{MaxWidth: 40, MaxHeight: 30}, webpLossless(40, 30), ""},
+		{"webp too wide", ImageValidator{MaxWidth: 39}, webpLossless(40, 30), "width 40 exceeds"},
+		{"not an image", ImageValidator{}, []byte("hello"), "not a PNG, JPEG, GIF or WebP image"},
+		{"format", ImageValidator{Formats: []string{"jpeg", "webp"}}, pngImg, "image format png is not one of jpeg, webp"},
+		{"width", ImageValidator{MaxWidth: 32}, pngImg, "width 40 exceeds the maximum of 32"},
+		{"height", ImageValidator{MaxHeight: 16}, pngImg, "height 30 exceeds the maximum of 16"},
+		{"bytes", ImageValidator{MaxBytes: 10}, pngImg, "exceeds the maximum of 10"},
+		{"header only", ImageValidator{}, truncated, ""},
+		{"decode", ImageValidator{Decode: true}, truncated, "invalid png image"},
+		{"decode oversized", ImageValidator{Decode: true, MaxWidth: 512, MaxHeight: 512}, pngIHDR(100000, 100000), "width 100000 exceeds the maximum of 512"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, validate := range []func([]byte) (bool, error){
+				tc.v.Validate,
+				func(b []byte) (bool, error) { return tc.v.ValidateReader(bytes.NewReader(b)) },
+			} {
+				ok, err := validate(tc.input)
+				if tc.err == "" {
+					if !ok {
+						t.Errorf("unexpected error: %v", err)
+					}
+					continue
+				}
+				if ok || err == nil || !strings.Contains(err.Error(), tc.err) {
+					t.Errorf("expected error containing %q, got %v", tc.err, err)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateStruct_Image(t *testing.T) {
+	type profile struct {
+		Avatar []byte `val:"image,formats=png gif,maxwidth=64,maxheight=64,maxbytes=4096"`
+	}
+	if ok, err := ValidateStruct(profile{Avatar: encodeImage(t, "png", 64, 64)}); !ok {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ok, _ := ValidateStruct(profile{Avatar: encodeImage(t, "png", 65, 64)}); ok {
+		t.Errorf("expected a too wide avatar to fail")
+	}
+}
@@ -0,0 +1,79 @@
+package valex
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	imageDomain    = regexp.MustCompile(`^(?:[a-zA-Z0-9](?:[a-zA-Z0-9-]*[a-zA-Z0-9])?(?:\.[a-zA-Z0-9](?:[a-zA-Z0-9-]*[a-zA-Z0-9])?)*|\[[0-9a-fA-F:]+\])(?::[0-9]+)?$`)
+	imagePath      = regexp.MustCompile(`^[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*(?:/[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*)*$`)
+	imageTag       = regexp.MustCompile(`^[\w][\w.-]{0,127}$`)
+	imageDigest    = regexp.MustCompile(`^[a-z0-9]+(?:[.+_-][a-z0-9]+)*:[a-zA-Z0-9=_-]+$`)
+	digestHexSizes = map[string]int{"sha256": 64, "sha384": 96, "sha512": 128}
+)
+
+// ImageRefValidator accepts container image references of the form
+// [registry[:port]/]repository[:tag][@digest], e.g.
+// "ghcr.io/org/app:1.2@sha256:<64 hex digits>". Digests of sha256, sha384
+// and sha512 must have the matching number of lowercase hex digits. Digest
+// requires a digest, to pin deployments: `val:"imageref,digest"`.
+type ImageRefValidator struct {
+	Digest bool `param:"digest,optional"`
+}
+
+func (v *ImageRefValidator) Validate(val string) (ok bool, err error) {
+	name, digest, hasDigest := strings.Cut(val, "@")
+	if hasDigest {
+		if err := checkImageDigest(digest); err != nil {
+			return false, failf(ErrInvalidFormat, "image reference %q: %w", val, err)
+		}
+	} else if v.Digest {
+		return false, failf(ErrInvalidFormat, "image reference %q has no digest", val)
+	}
+
+	// a colon after the last slash starts the tag, one before it a port
+	if i := strings.LastIndexByte(name, ':'); i > strings.LastIndexByte(name, '/') {
+		if tag := name[i+1:]; !imageTag.MatchString(tag) {
+			return false, failf(ErrInvalidFormat, "image reference %q has an invalid tag %q", val, tag)
+		}
+		name = name[:i]
+	}
+	if len(name) > 255 {
+		return false, failf(ErrTooLong, "image reference %q has a name longer than 255 characters", val)
+	}
+	path := name
+	if domain, rest, ok := strings.Cut(name, "/"); ok && (strings.ContainsAny(domain, ".:[") || domain == "localhost" || strings.ToLower(domain) != domain) {
+		if !imageDomain.MatchString(domain) {
+			return false, failf(ErrInvalidFormat, "image reference %q has an invalid registry %q", val, domain)
+		}
+		path = rest
+	}
+	if !imagePath.MatchString(path) {
+		return false, failf(ErrInvalidFormat, "image reference %q has an invalid repository %q", val, path)
+	}
+	return true, nil
+}
+
+func checkImageDigest(digest string) error {
+	if !imageDigest.MatchString(digest) {
+		return fmt.Errorf("invalid digest %q", digest)
+	}
+	alg, hex, _ := strings.Cut(digest, ":")
+	if size, ok := digestHexSizes[alg]; ok && (len(hex) != size || strings.Trim(hex, "0123456789abcdef") != "") {
+		return fmt.Errorf("%s digest must be %d lowercase hex digits", alg, size)
+	}
+	return nil
+}
+
+func (v *ImageRefValidator) Name() string {
+	return "imageref"
+}
+
+func (v *ImageRefValidator) Handle(val string) error {
+	if ok, err := v.Validate(val); !ok {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package valex
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestImageRefValidator(t *testing.T) {
+	sha := strings.Repeat("a1", 32)
+	tests := []struct {
+		v         ImageRefValidator
+		input     string
+		errSubstr string
+	}{
+		{ImageRefValidator{}, "nginx", ""},
+		{ImageRefValidator{}, "nginx:1.25-alpine", ""},
+		{ImageRefValidator{}, "library/nginx:latest", ""},
+		{ImageRefValidator{}, "ghcr.io/org/app:v1.2.3", ""},
+		{ImageRefValidator{}, "localhost:5000/app", ""},
+		{ImageRefValidator{}, "localhost/app", ""},
+		{ImageRefValidator{}, "[::1]:5000/app:dev", ""},
+		{ImageRefValidator{}, "registry.example.com:443/team/my_app__x:1", ""},
+		{ImageRefValidator{}, "app@sha256:" + sha, ""},
+		{ImageRefValidator{}, "ghcr.io/org/app:1.2@sha256:" + sha, ""},
+		{ImageRefValidator{}, "MyApp", "invalid repository"},
+		{ImageRefValidator{}, "org/-app", "invalid repository"},
+		{ImageRefValidator{}, "app:", "invalid tag"},
+		{ImageRefValidator{}, "app:-dev", "invalid tag"},
+		{ImageRefValidator{}, "app:" + strings.Repeat("x", 129), "invalid tag"},
+		{ImageRefValidator{}, "bad_host.io/app", "invalid registry"},
+		{ImageRefValidator{}, "app@sha256:abc", "sha256 digest must be 64 lowercase hex digits"},
+		{ImageRefValidator{}, "app@sha256:" + strings.ToUpper(sha), "lowercase hex digits"},
+		{ImageRefValidator{}, "app@" + sha, "invalid digest"},
+		{ImageRefValidator{}, "", "invalid repository"},
+		{ImageRefValidator{Digest: true}, "app:1.0", "has no digest"},
+		{ImageRefValidator{Digest: true}, "app@sha256:" + sha, ""},
+	}
+	for _, tc := range tests {
+		ok, err := tc.v.Validate(tc.input)
+		if tc.errSubstr == "" {
+			if !ok {
+				t.Errorf("%+v(%q): unexpected error: %v", tc.v, tc.input, err)
+			}
+			continue
+		}
+		if ok || !strings.Contains(err.Error(), tc.errSubstr) {
+			t.Errorf("%+v(%q): expected error containing %q, got %v", tc.v, tc.input, tc.errSubstr, err)
+		}
+	}
+}
+
+func TestImageRefValidator_Tag(t *testing.T) {
+	type deploy struct {
+		Image string `val:"imageref,digest"`
+	}
+	if _, err := ValidateStruct(&deploy{Image: "app:latest"}); err == nil {
+		t.Error("expected an unpinned image to fail")
+	}
+}
@@ -0,0 +1,171 @@
+package valex
+
+import (
+	"errors"
+	"math/big"
+)
+
+// MultipleOfValidator accepts multiples of Factor, like JSON Schema's
+// multipleOf, e.g. `val:"multipleof=5"`.
+type MultipleOfValidator struct {
+	Factor int `param:"multipleof"`
+}
+
+func (v *MultipleOfValidator) Validate(val int) (ok bool, err error) {
+	if v.Factor == 0 {
+		return false, errors.New(`value of parameter "multipleof" cannot be 0`)
+	}
+	if val%v.Factor != 0 {
+		return false, failf(ErrOutOfRange, "value %d is not a multiple of %d", val, v.Factor)
+	}
+	return true, nil
+}
+
+func (v *MultipleOfValidator) Name() string {
+	return "multipleof"
+}
+
+func (v *MultipleOfValidator) Handle(val int) error {
+	if ok, err := v.Validate(val); !ok {
+		return err
+	}
+	return nil
+}
+
+// StepValidator accepts Min and the values Step, 2*Step, ... above it, e.g.
+// `val:"step=10,min=5"` for 5, 15, 25 and so on.
+type StepValidator struct {
+	Step int `param:"step"`
+	Min  int `param:"min,optional"`
+}
+
+func (v *StepValidator) Validate(val int) (ok bool, err error) {
+	if v.Step <= 0 {
+		return false, errors.New(`value of parameter "step" must be positive`)
+	}
+	if val < v.Min {
+		return false, rangeFailf(ErrOutOfRange, val, v.Min, nil, "value %d is less than the minimum of %d", val, v.Min)
+	}
+	if (val-v.Min)%v.Step != 0 {
+		return false, failf(ErrOutOfRange, "value %d is not %d plus a multiple of %d", val, v.Min, v.Step)
+	}
+	return true, nil
+}
+
+func (v *StepValidator) Name() string {
+	return "step"
+}
+
+func (v *StepValidator) Handle(val int) error {
+	if ok, err := v.Validate(val); !ok {
+		return err
+	}
+	return nil
+}
+
+type EvenValidator struct{}
+
+func (v *EvenValidator) Validate(val int) (ok bool, err error) {
+	if val%2 != 0 {
+		return false, failf(ErrOutOfRange, "value %d is not even", val)
+	}
+	return true, nil
+}
+
+func (v *EvenValidator) Name() string {
+	return "even"
+}
+
+func (v *EvenValidator) Handle(val int) error {
+	if ok, err := v.Validate(val); !ok {
+		return err
+	}
+	return nil
+}
+
+type OddValidator struct{}
+
+func (v *OddValidator) Validate(val int) (ok bool, err error) {
+	if val%2 == 0 {
+		return false, failf(ErrOutOfRange, "value %d is not odd", val)
+	}
+	return true, nil
+}
+
+func (v *OddValidator) Name() string {
+	return "odd"
+}
+
+func (v *OddValidator) Handle(val int) error {
+	if ok, err := v.Validate(val); !ok {
+		return err
+	}
+	return nil
+}
+
+// PowerOfTwoValidator accepts 1, 2, 4, 8 and so on, e.g. for buffer sizes
+// and shard counts.
+type PowerOfTwoValidator struct{}
+
+func (v *PowerOfTwoValidator) Validate(val int) (ok bool, err error) {
+	if val <= 0 || val&(val-1) != 0 {
+		return false, failf(ErrOutOfRange, "value %d is not a power of two", val)
+	}
+	return true, nil
+}
+
+func (v *PowerOfTwoValidator) Name() string {
+	return "pow2"
+}
+
+func (v *PowerOfTwoValidator) Handle(val int) error {
+	if ok, err := v.Validate(val); !ok {
+		return err
+	}
+	return nil
+}
+
+type PrimeValidator struct{}
+
+func (v *PrimeValidator) Validate(val int) (ok bool, err error) {
+	// ProbablyPrime(0) is exact below 2^64
+	if val < 2 || !big.NewInt(int64(val)).ProbablyPrime(0) {
+		return false, failf(ErrOutOfRange, "value %d is not a prime", val)
+	}
+	return true, nil
+}
+
+func (v *PrimeValidator) Name() string {
+	return "prime"
+}
+
+func (v *PrimeValidator) Handle(val int) error {
+	if ok, err := v.Validate(val); !ok {
+		return err
+	}
+	return nil
+}
+
+// BitmaskValidator accepts values with no bits set outside Mask, e.g.
+// `val:"mask=0x1F"` for flags in the lowest five bits.
+type BitmaskValidator struct {
+	Mask uint64 `param:"mask"`
+}
+
+func (v *BitmaskValidator) Validate(val int) (ok bool, err error) {
+	if extra := uint64(val) &^ v.Mask; extra != 0 {
+		return false, failf(ErrNotAllowed, "value %#x has bits %#x set outside the mask %#x", uint64(val), extra, v.Mask)
+	}
+	return true, nil
+}
+
+func (v *BitmaskValidator) Name() string {
+	return "mask"
+}
+
+func (v *BitmaskValidator) Handle(val int) error {
+	if ok, err := v.Validate(val); !ok {
+		return err
+	}
+	return nil
+}
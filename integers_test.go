@@ -0,0 +1,89 @@
+package valex
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestIntPredicateValidators(t *testing.T) {
+	tests := []struct {
+		name      string
+		v         interface{ Validate(int) (bool, error) }
+		input     int
+		errSubstr string
+	}{
+		{"multipleof", &MultipleOfValidator{Factor: 5}, 25, ""},
+		{"multipleof zero", &MultipleOfValidator{Factor: 5}, 0, ""},
+		{"multipleof negative", &MultipleOfValidator{Factor: 5}, -10, ""},
+		{"not multipleof", &MultipleOfValidator{Factor: 5}, 12, "not a multiple of 5"},
+		{"multipleof unset", &MultipleOfValidator{}, 5, "cannot be 0"},
+		{"step", &StepValidator{Step: 10, Min: 5}, 25, ""},
+		{"step min", &StepValidator{Step: 10, Min: 5}, 5, ""},
+		{"step below min", &StepValidator{Step: 10, Min: 5}, -5, "less than the minimum of 5"},
+		{"off step", &StepValidator{Step: 10, Min: 5}, 20, "not 5 plus a multiple of 10"},
+		{"step unset", &StepValidator{}, 5, "must be positive"},
+		{"even", &EvenValidator{}, -4, ""},
+		{"not even", &EvenValidator{}, 3, "not even"},
+		{"odd", &OddValidator{}, -3, ""},
+		{"not odd", &OddValidator{}, 0, "not odd"},
+		{"pow2", &PowerOfTwoValidator{}, 1, ""},
+		{"pow2 large", &PowerOfTwoValidator{}, 1 << 40, ""},
+		{"not pow2", &PowerOfTwoValidator{}, 12, "not a power of two"},
+		{"pow2 zero", &PowerOfTwoValidator{}, 0, "not a power of two"},
+		{"prime", &PrimeValidator{}, 2, ""},
+		{"prime large", &PrimeValidator{}, 2147483647, ""},
+		{"not prime", &PrimeValidator{}, 561, "not a prime"}, // a Carmichael number
+		{"prime one", &PrimeValidator{}, 1, "not a prime"},
+		{"prime negative", &PrimeValidator{}, -7, "not a prime"},
+		{"mask", &BitmaskValidator{Mask: 0x1F}, 0b10101, ""},
+		{"mask none", &BitmaskValidator{Mask: 0x1F}, 0, ""},
+		{"outside mask", &BitmaskValidator{Mask: 0x1F}, 0x21, "bits 0x20 set outside the mask 0x1f"},
+		{"mask negative", &BitmaskValidator{Mask: 0x1F}, -1, "set outside the mask"},
+	}
+	for _, tc := range tests {
+		ok, err := tc.v.Validate(tc.input)
+		if tc.errSubstr == "" {
+			if !ok {
+				t.Errorf("%s(%d): unexpected error: %v", tc.name, tc.input, err)
+			}
+			continue
+		}
+		if ok || !strings.Contains(err.Error(), tc.errSubstr) {
+			t.Errorf("%s(%d): expected error containing %q, got %v", tc.name, tc.input, tc.errSubstr, err)
+		}
+	}
+}
+
+func TestIntPredicateValidators_Tags(t *testing.T) {
+	type page struct {
+		Size  int `json:"size" val:"multipleof=5"`
+		Batch int `json:"batch" val:"step=10,min=0"`
+		Shard int `json:"shard" val:"pow2"`
+		Flags int `json:"flags" val:"mask=0b0110"`
+	}
+	if _, err := ValidateStruct(&page{Size: 20, Batch: 30, Shard: 8}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, err := ValidateStruct(&page{Size: 21, Batch: 30, Shard: 8}); err == nil {
+		t.Error("expected a size that is no multiple of 5 to fail")
+	}
+	if _, err := ValidateStruct(&page{Size: 20, Batch: 30, Shard: 6}); err == nil {
+		t.Error("expected a shard count that is no power of two to fail")
+	}
+	if _, err := ValidateStruct(&page{Size: 20, Batch: 30, Shard: 8, Flags: 1}); err == nil {
+		t.Error("expected a flag outside the mask to fail")
+	}
+
+	s, err := GenerateJSONSchema(&page{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	props := s["properties"].(map[string]any)
+	if want := map[string]any{"type": "integer", "multipleOf": 5}; !reflect.DeepEqual(props["size"], want) {
+		t.Errorf("expected %v, got %v", want, props["size"])
+	}
+	if want := map[string]any{"type": "integer", "minimum": 0, "multipleOf": 10}; !reflect.DeepEqual(props["batch"], want) {
+		t.Errorf("expected %v, got %v", want, props["batch"])
+	}
+}
@@ -0,0 +1,185 @@
+package valex
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"sort"
+)
+
+type ParamInfo struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Optional bool   `json:"optional,omitempty"`
+}
+
+type DirectiveInfo struct {
+	Name       string       `json:"name"`
+	Type       string       `json:"type"`
+	Params     []ParamInfo  `json:"params,omitempty"`
+	Deprecated *Deprecation `json:"deprecated,omitempty"`
+}
+
+// Directives lists the directives registered with the engine, sorted by name.
+func (e *Engine) Directives() []DirectiveInfo {
+	e.mut.RLock()
+	defer e.mut.RUnlock()
+
+	infos := directiveInfos(e.registry)
+	for n := range infos {
+		if d, ok := e.deprecations[infos[n].Name]; ok {
+			infos[n].Deprecated = &d
+		}
+	}
+	return infos
+}
+
+func directiveInfos(registry map[string]anyDirective) []DirectiveInfo {
+	infos := make([]DirectiveInfo, 0, len(registry))
+	for name, d := range registry {
+		info := DirectiveInfo{Name: name, Type: d.valueType().String()}
+		for _, p := range d.params() {
+			info.Params = append(info.Params, ParamInfo{
+				Name:     p.key,
+				Type:     p.typ.String(),
+				Optional: p.optional,
+			})
+		}
+		infos = append(infos, info)
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Name < infos[j].Name })
+	return infos
+}
+
+// TagDirective is a directive of a parsed `val` tag. Directive holds the
+// configured directive, e.g. a *MinLengthValidator with its Size set, and is
+// nil for dive, which starts the directives that apply to each element, and
+// for omitempty, keepgoing, warn and secret.
+type TagDirective struct {
+	Name      string
+	Directive any
+	ValueType reflect.Type
+}
+
+// ParseTag parses and configures the directives of a `val` tag value the way
+// validation does, expanding aliases, so tools can check tags or act on
+// them. Groups are not directives and are left out.
+func (e *Engine) ParseTag(tagValue string) ([]TagDirective, error) {
+	steps, _, err := e.compileGroupedTag(tagValue, nil)
+	if err != nil {
+		return nil, err
+	}
+	tds := make([]TagDirective, len(steps))
+	for n, s := range steps {
+		tds[n] = TagDirective{Name: s.name}
+		if s.d != nil {
+			tds[n].Directive = cloneDirective(s.inst) // instances are shared
+			tds[n].ValueType = s.d.valueType()
+		}
+	}
+	return tds, nil
+}
+
+func ParseTag(tagValue string) ([]TagDirective, error) {
+	return std.ParseTag(tagValue)
+}
+
+func (e *Engine) Aliases() map[string]string {
+	e.mut.RLock()
+	defer e.mut.RUnlock()
+
+	aliases := make(map[string]string, len(e.aliases))
+	for name, tagValue := range e.aliases {
+		aliases[name] = tagValue
+	}
+	return aliases
+}
+
+// TypeRules returns the tag values in effect per struct type and field: the
+// rules set on the engine, completed with the tags of every struct type the
+// engine has validated so far.
+func (e *Engine) TypeRules() Rules {
+	state := e.loadState()
+
+	rules := make(Rules)
+	for typeName, fields := range state.rules {
+		rules[typeName] = make(map[string]string, len(fields))
+		for field, tagValue := range fields {
+			rules[typeName][field] = tagValue
+		}
+	}
+	state.plans.Range(func(k, v any) bool {
+		key := k.(planKey)
+		if key.tenant != "" {
+			return true
+		}
+		typeName := key.typ.String()
+		if rules[typeName] == nil {
+			rules[typeName] = make(map[string]string)
+		}
+		for _, fp := range v.(*structPlan).fields {
+			rules[typeName][fp.name] = fp.tag
+		}
+		return true
+	})
+	return rules
+}
+
+type introspection struct {
+	Directives []DirectiveInfo       `json:"directives"`
+	Aliases    map[string]string     `json:"aliases"`
+	Types      Rules                 `json:"types"`
+	Tenants    map[string]tenantInfo `json:"tenants,omitempty"`
+}
+
+type tenantInfo struct {
+	Directives []DirectiveInfo              `json:"directives,omitempty"`
+	Overrides  map[string]map[string]string `json:"overrides,omitempty"`
+}
+
+// IntrospectionHandler serves the engine's directives, aliases, tenants and
+// per type rules as JSON. It performs no authentication or authorization,
+// wrap it accordingly before exposing it.
+func (e *Engine) IntrospectionHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+
+		body := introspection{
+			Directives: e.Directives(),
+			Aliases:    e.Aliases(),
+			Types:      e.TypeRules(),
+			Tenants:    e.tenantInfos(),
+		}
+		w.Header().Set("Content-Type", "application/json")
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(body)
+	})
+}
+
+func (e *Engine) tenantInfos() map[string]tenantInfo {
+	e.mut.RLock()
+	defer e.mut.RUnlock()
+
+	infos := make(map[string]tenantInfo, len(e.tenants))
+	for name, t := range e.tenants {
+		t.mut.RLock()
+		info := tenantInfo{Overrides: make(map[string]map[string]string, len(t.overrides))}
+		if len(t.registry) > 0 {
+			info.Directives = directiveInfos(t.registry)
+		}
+		for d, params := range t.overrides {
+			info.Overrides[d] = make(map[string]string, len(params))
+			for k, v := range params {
+				info.Overrides[d][k] = v
+			}
+		}
+		t.mut.RUnlock()
+		infos[name] = info
+	}
+	return infos
+}
@@ -0,0 +1,116 @@
+package valex
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+type introspectDummy struct {
+	Name string `val:"min,size=3"`
+	Age  int    `val:"range,min=0,max=120"`
+}
+
+func TestEngine_Directives(t *testing.T) {
+	e := NewEngine()
+	var found bool
+	for _, d := range e.Directives() {
+		if d.Name != "csv" {
+			continue
+		}
+		found = true
+		want := DirectiveInfo{
+			Name: "csv",
+			Type: "string",
+			Params: []ParamInfo{
+				{Name: "cols", Type: "int", Optional: true},
+				{Name: "header", Type: "[]string", Optional: true},
+			},
+		}
+		if !reflect.DeepEqual(d, want) {
+			t.Errorf("expected %+v, got %+v", want, d)
+		}
+	}
+	if !found {
+		t.Errorf("expected csv directive to be listed")
+	}
+}
+
+func TestEngine_TypeRules(t *testing.T) {
+	e := NewEngine()
+	if err := e.SetRules(Rules{"valex.introspectDummy": {"Name": "min,size=5"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	e.ValidateStruct(introspectDummy{})
+
+	want := map[string]string{"Name": "min,size=5", "Age": "range,min=0,max=120"}
+	if got := e.TypeRules()["valex.introspectDummy"]; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestEngine_IntrospectionHandler(t *testing.T) {
+	e := NewEngine()
+	if err := e.Alias("username", "alphanum,min,size=3"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	e.Tenant("acme").Override("min", "size", "5")
+	e.ValidateStruct(introspectDummy{})
+
+	rec := httptest.NewRecorder()
+	e.IntrospectionHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, rec.Code)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("expected JSON content type, got %q", ct)
+	}
+
+	var body introspection
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("unexpected error decoding body: %v", err)
+	}
+	if len(body.Directives) == 0 {
+		t.Errorf("expected directives to be listed")
+	}
+	if body.Aliases["username"] != "alphanum,min,size=3" {
+		t.Errorf("expected alias to be listed, got %v", body.Aliases)
+	}
+	if body.Types["valex.introspectDummy"]["Age"] != "range,min=0,max=120" {
+		t.Errorf("expected type rules to be listed, got %v", body.Types)
+	}
+	if body.Tenants["acme"].Overrides["min"]["size"] != "5" {
+		t.Errorf("expected tenant overrides to be listed, got %v", body.Tenants)
+	}
+
+	rec = httptest.NewRecorder()
+	e.IntrospectionHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected status %d, got %d", http.StatusMethodNotAllowed, rec.Code)
+	}
+}
+
+func TestParseTag(t *testing.T) {
+	tds, err := ParseTag("min,size=3,dive,email")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(tds) != 3 || tds[0].Name != "min" || tds[1].Name != "dive" || tds[2].Name != "email" {
+		t.Fatalf("unexpected directives: %+v", tds)
+	}
+	if v, ok := tds[0].Directive.(*MinLengthValidator); !ok || v.Size != 3 {
+		t.Errorf("got %#v, want a configured *MinLengthValidator", tds[0].Directive)
+	}
+	if tds[0].ValueType.Kind() != reflect.String || tds[1].Directive != nil {
+		t.Errorf("unexpected directives: %+v", tds)
+	}
+	if tds, err := ParseTag("omitempty,email,groups=create"); err != nil || len(tds) != 2 || tds[0].Name != "omitempty" || tds[0].Directive != nil {
+		t.Errorf("unexpected directives: %+v (error: %v)", tds, err)
+	}
+	if _, err := ParseTag("nope"); err == nil {
+		t.Error("expected an error for an unknown directive")
+	}
+}
@@ -0,0 +1,116 @@
+package valex
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// The validators in this file count the items of slices, arrays and maps,
+// and of what pointers to them point at; a nil pointer has no items. They
+// take values of any type and fail on others, e.g. strings, which have
+// length directives of their own.
+
+// itemCount returns the number of items in val.
+func itemCount(val any) (int, error) {
+	v := reflect.ValueOf(val)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return 0, nil
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return v.Len(), nil
+	}
+	return 0, fmt.Errorf("value of type %T has no items", val)
+}
+
+type MinItemsValidator struct {
+	Size int `param:"minitems"`
+}
+
+func (v *MinItemsValidator) Validate(val any) (ok bool, err error) {
+	n, err := itemCount(val)
+	if err != nil {
+		return false, err
+	}
+	if n < v.Size {
+		return false, rangeFailf(ErrTooShort, n, v.Size, nil, "%d items is less than the minimum of %d", n, v.Size)
+	}
+	return true, nil
+}
+
+func (v *MinItemsValidator) Name() string {
+	return "minitems"
+}
+
+func (v *MinItemsValidator) Handle(val any) error {
+	if ok, err := v.Validate(val); !ok {
+		return err
+	}
+	return nil
+}
+
+type MaxItemsValidator struct {
+	Size int `param:"maxitems"`
+}
+
+func (v *MaxItemsValidator) Validate(val any) (ok bool, err error) {
+	n, err := itemCount(val)
+	if err != nil {
+		return false, err
+	}
+	if n > v.Size {
+		return false, rangeFailf(ErrTooLong, n, nil, v.Size, "%d items exceeds the maximum of %d", n, v.Size)
+	}
+	return true, nil
+}
+
+func (v *MaxItemsValidator) Name() string {
+	return "maxitems"
+}
+
+func (v *MaxItemsValidator) Handle(val any) error {
+	if ok, err := v.Validate(val); !ok {
+		return err
+	}
+	return nil
+}
+
+// ItemsRangeValidator accepts between Min and Max items, e.g.
+// `val:"items,min=1,max=10"`.
+type ItemsRangeValidator struct {
+	Min int `param:"min"`
+	Max int `param:"max"`
+}
+
+func (v *ItemsRangeValidator) Validate(val any) (ok bool, err error) {
+	if v.Max == 0 {
+		return false, errors.New(`"max" value cannot be 0`)
+	}
+	n, err := itemCount(val)
+	if err != nil {
+		return false, err
+	}
+	if n < v.Min || n > v.Max {
+		sentinel := ErrTooLong
+		if n < v.Min {
+			sentinel = ErrTooShort
+		}
+		return false, rangeFailf(sentinel, n, v.Min, v.Max, "%d items is not in range [%d, %d]", n, v.Min, v.Max)
+	}
+	return true, nil
+}
+
+func (v *ItemsRangeValidator) Name() string {
+	return "items"
+}
+
+func (v *ItemsRangeValidator) Handle(val any) error {
+	if ok, err := v.Validate(val); !ok {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package valex
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestItemsValidators(t *testing.T) {
+	var nilSlice *[]int
+	tests := []struct {
+		name      string
+		v         interface{ Validate(any) (bool, error) }
+		input     any
+		errSubstr string
+	}{
+		{"min slice", &MinItemsValidator{Size: 2}, []int{1, 2}, ""},
+		{"min short", &MinItemsValidator{Size: 2}, []int{1}, "1 items is less than the minimum of 2"},
+		{"min map", &MinItemsValidator{Size: 1}, map[string]int{}, "less than the minimum"},
+		{"min nil pointer", &MinItemsValidator{Size: 1}, nilSlice, "0 items"},
+		{"max array", &MaxItemsValidator{Size: 3}, [3]string{}, ""},
+		{"max pointer", &MaxItemsValidator{Size: 1}, &[]int{1, 2}, "2 items exceeds the maximum of 1"},
+		{"range", &ItemsRangeValidator{Min: 1, Max: 3}, map[int]bool{1: true}, ""},
+		{"range empty", &ItemsRangeValidator{Min: 1, Max: 3}, []string{}, "not in range [1, 3]"},
+		{"range unset", &ItemsRangeValidator{}, []string{}, "cannot be 0"},
+		{"string", &MinItemsValidator{Size: 1}, "abc", "value of type string has no items"},
+	}
+	for _, tc := range tests {
+		ok, err := tc.v.Validate(tc.input)
+		if tc.errSubstr == "" {
+			if !ok {
+				t.Errorf("%s: unexpected error: %v", tc.name, err)
+			}
+			continue
+		}
+		if ok || !strings.Contains(err.Error(), tc.errSubstr) {
+			t.Errorf("%s: expected error containing %q, got %v", tc.name, tc.errSubstr, err)
+		}
+	}
+}
+
+func TestItemsValidators_Tags(t *testing.T) {
+	type order struct {
+		Lines  []string          `json:"lines" val:"items,min=1,max=10"`
+		Labels map[string]string `json:"labels" val:"maxitems=2"`
+		Tags   []string          `json:"tags" val:"minitems=1,dive,alphanum"`
+	}
+	if _, err := ValidateStruct(&order{Lines: []string{"a"}, Tags: []string{"x"}}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, err := ValidateStruct(&order{Tags: []string{"x"}}); err == nil {
+		t.Error("expected an order without lines to fail")
+	}
+	if _, err := ValidateStruct(&order{Lines: []string{"a"}, Labels: map[string]string{"a": "", "b": "", "c": ""}, Tags: []string{"x"}}); err == nil {
+		t.Error("expected too many labels to fail")
+	}
+
+	s, err := GenerateJSONSchema(&order{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	props := s["properties"].(map[string]any)
+	if got := props["lines"].(map[string]any); got["minItems"] != 1 || got["maxItems"] != 10 {
+		t.Errorf("expected minItems 1 and maxItems 10, got %v", got)
+	}
+	if got := props["labels"].(map[string]any); !reflect.DeepEqual(got["maxProperties"], 2) {
+		t.Errorf("expected maxProperties 2, got %v", got)
+	}
+}
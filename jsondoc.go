@@ -0,0 +1,325 @@
+package valex
+
+import (
+	"bytes"
+	"context"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ValidateJSON validates the JSON document doc without decoding it into a
+// struct. rules maps paths within doc to tag values, e.g.
+// {"user.age": "range,min=0,max=130"}. A path is either dotted, as in
+// "tags[0]", or a JSON pointer (RFC 6901), as in "/user/age".
+//
+// Values are converted to the type the directives of their tag expect. A
+// value missing from doc, or null, validates as the zero value of that type,
+// as it would when decoded into a struct. dive applies the directives
+// following it to the elements of an array or the members of an object,
+// and groups select rules as they do in struct tags.
+func (e *Engine) ValidateJSON(doc []byte, rules map[string]string, opts ...Option) (bool, error) {
+	return e.ValidateJSONContext(context.Background(), doc, rules, opts...)
+}
+
+func (e *Engine) ValidateJSONContext(ctx context.Context, doc []byte, rules map[string]string, opts ...Option) (bool, error) {
+	o := newOptions(opts)
+	docRules, err := e.jsonRules(rules, o)
+	if err != nil {
+		return false, WithCategory(err, CategoryConfig)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(doc))
+	dec.UseNumber()
+	var root any
+	if err := dec.Decode(&root); errors.Is(err, io.EOF) {
+		return false, WithCategory(errors.New("input is empty"), CategoryDecode)
+	} else if err != nil {
+		return false, decodeError(err)
+	}
+	if err := dec.Decode(&struct{}{}); !errors.Is(err, io.EOF) {
+		if err == nil {
+			err = errors.New("input must contain a single JSON value")
+		}
+		return false, decodeError(err)
+	}
+
+	v := e.newValidation(ctx, o, phaseAll)
+	for _, r := range docRules {
+		if !v.jsonRule(r, root) {
+			break
+		}
+	}
+	return v.result()
+}
+
+func ValidateJSON(doc []byte, rules map[string]string, opts ...Option) (bool, error) {
+	return std.ValidateJSON(doc, rules, opts...)
+}
+
+func ValidateJSONContext(ctx context.Context, doc []byte, rules map[string]string, opts ...Option) (bool, error) {
+	return std.ValidateJSONContext(ctx, doc, rules, opts...)
+}
+
+type jsonRule struct {
+	path   FieldPath
+	steps  []step
+	groups []string
+}
+
+// jsonRules compiles rules, ordered by path.
+func (e *Engine) jsonRules(rules map[string]string, o options) ([]jsonRule, error) {
+	e, err := e.forProfile(o)
+	if err != nil {
+		return nil, err
+	}
+	var tenant *Tenant
+	if o.tenant != "" {
+		var ok bool
+		if tenant, ok = e.lookupTenant(o.tenant); !ok {
+			return nil, fmt.Errorf("unknown tenant %q", o.tenant)
+		}
+	}
+
+	docRules := make([]jsonRule, 0, len(rules))
+	for key, tagValue := range rules {
+		path, err := parseJSONPath(key)
+		if err != nil {
+			return nil, err
+		}
+		steps, groups, err := e.compileGroupedTag(tagValue, tenant)
+		if err == nil {
+			err = checkJSONSteps(steps)
+		}
+		if err != nil {
+			return nil, &FieldError{Field: path.String(), Path: path, Err: err}
+		}
+		docRules = append(docRules, jsonRule{path: path, steps: steps, groups: groups})
+	}
+	sort.Slice(docRules, func(i, j int) bool {
+		return docRules[i].path.String() < docRules[j].path.String()
+	})
+	return docRules, nil
+}
+
+var pointerUnescaper = strings.NewReplacer("~1", "/", "~0", "~")
+
+// parseJSONPath parses a dotted path or a JSON pointer. Numeric reference
+// tokens of a pointer become indexes, which also address object members.
+func parseJSONPath(s string) (FieldPath, error) {
+	if !strings.HasPrefix(s, "/") {
+		return ParseFieldPath(s)
+	}
+	var p FieldPath
+	for _, token := range strings.Split(s[1:], "/") {
+		token = pointerUnescaper.Replace(token)
+		if i, err := strconv.Atoi(token); err == nil && i >= 0 && strconv.Itoa(i) == token {
+			p = p.Index(i)
+			continue
+		}
+		if token == "" {
+			return nil, fmt.Errorf("invalid JSON pointer %q: empty reference token", s)
+		}
+		p = p.Field(token)
+	}
+	return p, nil
+}
+
+// checkJSONSteps rejects directives that cannot validate JSON values: those
+// comparing struct fields, and those expecting different types of the same
+// value.
+func checkJSONSteps(steps []step) error {
+	fieldSteps, elemSteps, dive := splitDive(steps)
+	var typed *step
+	for n, s := range fieldSteps {
+		if s.d == nil {
+			continue
+		}
+		if s.structAware {
+			return fmt.Errorf("directive %q needs a struct and cannot validate JSON", s.name)
+		}
+		t := s.d.valueType()
+		if t.Kind() == reflect.Interface {
+			continue
+		}
+		if typed != nil && typed.d.valueType() != t {
+			return fmt.Errorf("directive %q expects %s but %q expects %s", typed.name, typed.d.valueType(), s.name, t)
+		}
+		typed = &fieldSteps[n]
+	}
+	if dive {
+		return checkJSONSteps(elemSteps)
+	}
+	return nil
+}
+
+// jsonRule resolves r.path in root and runs its steps, reporting whether
+// validation should continue.
+func (v *validation) jsonRule(r jsonRule, root any) bool {
+	if !v.inGroups(r.groups) {
+		return true
+	}
+	val, err := resolveJSON(root, r.path)
+	if err != nil {
+		return v.fail(keyError(r.path, "type", err))
+	}
+	return v.jsonValue(r.steps, val, r.path)
+}
+
+// resolveJSON returns the value p addresses within root, or nil when it is
+// missing.
+func resolveJSON(root any, p FieldPath) (any, error) {
+	val := root
+	for n, pe := range p {
+		switch cur := val.(type) {
+		case nil:
+			return nil, nil
+		case map[string]any:
+			key := pe.Field
+			switch {
+			case pe.IsKey():
+				key = pe.Key
+			case pe.IsIndex():
+				key = strconv.Itoa(pe.Index)
+			}
+			val = cur[key]
+		case []any:
+			if !pe.IsIndex() {
+				return nil, fmt.Errorf("cannot resolve %s: %s is an array", p, jsonPathName(p[:n]))
+			}
+			if pe.Index >= len(cur) {
+				return nil, nil
+			}
+			val = cur[pe.Index]
+		default:
+			return nil, fmt.Errorf("cannot resolve %s: %s is %s", p, jsonPathName(p[:n]), jsonTypeOf(val))
+		}
+	}
+	return val, nil
+}
+
+func jsonPathName(p FieldPath) string {
+	if len(p) == 0 {
+		return "the document"
+	}
+	return p.String()
+}
+
+func (v *validation) jsonValue(steps []step, val any, path FieldPath) bool {
+	fieldSteps, elemSteps, dive := splitDive(steps)
+	rv, err := convertJSON(val, jsonStepType(fieldSteps))
+	if err != nil {
+		return v.fail(keyError(path, "type", err))
+	}
+	if !v.runSteps(fieldSteps, rv, reflect.Value{}, path) {
+		return v.o.collectAll
+	}
+	if !dive || omitted(fieldSteps, rv) {
+		return true
+	}
+
+	switch elems := val.(type) {
+	case nil:
+	case []any:
+		for i, elem := range elems {
+			if !v.jsonValue(elemSteps, elem, path.Index(i)) && !v.o.collectAll {
+				return false
+			}
+		}
+	case map[string]any:
+		keys := make([]string, 0, len(elems))
+		for k := range elems {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if !v.jsonValue(elemSteps, elems[k], path.Key(k)) && !v.o.collectAll {
+				return false
+			}
+		}
+	default:
+		return v.fail(keyError(path, "type", fmt.Errorf("expected an array or object to dive into, got %s", jsonTypeOf(val))))
+	}
+	return true
+}
+
+// jsonStepType returns the type steps expect their value to have, which is
+// any when none of them expects a particular type.
+func jsonStepType(steps []step) reflect.Type {
+	for _, s := range steps {
+		if s.d != nil && s.d.valueType().Kind() != reflect.Interface {
+			return s.d.valueType()
+		}
+	}
+	return reflect.TypeFor[any]()
+}
+
+var textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+
+// convertJSON converts val, as decoded with json.Decoder.UseNumber, to t.
+func convertJSON(val any, t reflect.Type) (reflect.Value, error) {
+	rv := reflect.New(t).Elem()
+	if val == nil {
+		return rv, nil
+	}
+	if t.Kind() == reflect.Interface {
+		if !reflect.TypeOf(val).Implements(t) {
+			return reflect.Value{}, fmt.Errorf("expected %s, got %s", t, jsonTypeOf(val))
+		}
+		rv.Set(reflect.ValueOf(val))
+		return rv, nil
+	}
+
+	switch v := val.(type) {
+	case json.Number:
+		if isNumericKind(t.Kind()) && setFromString(rv, v.String()) == nil {
+			return rv, nil
+		}
+	case string:
+		if t.Kind() == reflect.String {
+			rv.SetString(v)
+			return rv, nil
+		}
+		if reflect.PointerTo(t).Implements(textUnmarshalerType) {
+			if err := rv.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(v)); err == nil {
+				return rv, nil
+			}
+		}
+	case bool:
+		if t.Kind() == reflect.Bool {
+			rv.SetBool(v)
+			return rv, nil
+		}
+	case []any:
+		if t.Kind() == reflect.Slice {
+			rv.Set(reflect.MakeSlice(t, len(v), len(v)))
+			for i, elem := range v {
+				ev, err := convertJSON(elem, t.Elem())
+				if err != nil {
+					return reflect.Value{}, fmt.Errorf("element %d: %w", i, err)
+				}
+				rv.Index(i).Set(ev)
+			}
+			return rv, nil
+		}
+	case map[string]any:
+		if t.Kind() == reflect.Map && t.Key().Kind() == reflect.String {
+			rv.Set(reflect.MakeMapWithSize(t, len(v)))
+			for k, elem := range v {
+				ev, err := convertJSON(elem, t.Elem())
+				if err != nil {
+					return reflect.Value{}, fmt.Errorf("member %q: %w", k, err)
+				}
+				rv.SetMapIndex(reflect.ValueOf(k).Convert(t.Key()), ev)
+			}
+			return rv, nil
+		}
+	}
+	return reflect.Value{}, fmt.Errorf("expected %s, got %s", t, jsonTypeOf(val))
+}
@@ -0,0 +1,104 @@
+package valex
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidateJSON(t *testing.T) {
+	rules := map[string]string{
+		"user.name":   "required,min,size=2",
+		"/user/age":   "range,min=0,max=130",
+		"user.email":  "omitempty,email",
+		"tags":        "omitempty,maxitems=3,dive,min,size=2",
+		"/scores/0":   "range,min=0,max=10",
+		"meta":        "dive,range,min=0,max=5",
+		"/a~1b/c~0d":  "required",
+		"items[1].id": "required",
+	}
+	tests := []struct {
+		name string
+		doc  string
+		want map[string]string // path -> error substring
+	}{
+		{"valid", `{"user":{"name":"Jane","age":42},"tags":["go","db"],"scores":[3],"meta":{"x":1},"a/b":{"c~d":true},"items":[{},{"id":7}]}`, nil},
+		{"missing", `{"a/b":{"c~d":1},"items":[{"id":1},{"id":2}]}`, map[string]string{"user.name": "value is required"}},
+		{"out of range", `{"user":{"name":"Jane","age":200},"a/b":{"c~d":1},"items":[{},{"id":"x"}]}`, map[string]string{"user.age": "out of range"}},
+		{"wrong type", `{"user":{"name":"Jane","age":"42"},"a/b":{"c~d":1},"items":[{},{"id":1}]}`, map[string]string{"user.age": "expected int, got a string"}},
+		{"fraction", `{"user":{"name":"Jane","age":4.5},"a/b":{"c~d":1},"items":[{},{"id":1}]}`, map[string]string{"user.age": "got the number 4.5"}},
+		{"dive", `{"user":{"name":"Jane"},"tags":["go","x"],"meta":{"x":9},"a/b":{"c~d":1},"items":[{},{"id":1}]}`, map[string]string{"tags[1]": "minimum length 2", `meta["x"]`: "out of range"}},
+		{"dive scalar", `{"user":{"name":"Jane"},"meta":"x","a/b":{"c~d":1},"items":[{},{"id":1}]}`, map[string]string{"meta": "expected an array or object to dive into, got a string"}},
+		{"no items", `{"user":{"name":"Jane"},"tags":"go","a/b":{"c~d":1},"items":[{},{"id":1}]}`, map[string]string{"tags": "has no items"}},
+		{"pointer index", `{"user":{"name":"Jane"},"scores":[11],"a/b":{"c~d":1},"items":[{},{"id":1}]}`, map[string]string{"scores[0]": "out of range"}},
+		{"not an object", `{"user":"Jane","a/b":{"c~d":1},"items":[{},{"id":1}]}`, map[string]string{"user.age": "user is a string", "user.email": "user is a string", "user.name": "user is a string"}},
+		{"array", `{"user":[],"a/b":{"c~d":1},"items":[{},{"id":1}]}`, map[string]string{"user.age": "user is an array", "user.email": "user is an array", "user.name": "user is an array"}},
+		{"index into object", `{"user":{"name":"Jane"},"a/b":{"c~d":1},"items":{"1":{"id":1}}}`, nil},
+		{"escaped", `{"user":{"name":"Jane"},"a/b":{},"items":[{},{"id":1}]}`, map[string]string{"a/b.c~d": "value is required"}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, err := ValidateJSON([]byte(tc.doc), rules, WithCollectAll())
+			if tc.want == nil {
+				if !ok || err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			var errs ValidationErrors
+			if ok || !errors.As(err, &errs) || len(errs) != len(tc.want) {
+				t.Fatalf("expected %d field errors, got %v", len(tc.want), err)
+			}
+			for _, fe := range errs {
+				if substr, ok := tc.want[fe.Path.String()]; !ok || !strings.Contains(fe.Err.Error(), substr) {
+					t.Errorf("unexpected error at %s: %v", fe.Path, fe.Err)
+				}
+			}
+			if Categorize(err) != CategoryValidation {
+				t.Errorf("expected a validation error, got %v", Categorize(err))
+			}
+		})
+	}
+}
+
+func TestValidateJSON_FirstFailure(t *testing.T) {
+	rules := map[string]string{"a": "range,min=0,max=1", "b": "range,min=0,max=1"}
+	ok, err := ValidateJSON([]byte(`{"a":2,"b":2}`), rules)
+	var fe *FieldError
+	if ok || !errors.As(err, &fe) || fe.Field != "a" || fe.Directive != "range" {
+		t.Errorf("expected only the error of a, got %v", err)
+	}
+}
+
+func TestValidateJSON_Groups(t *testing.T) {
+	rules := map[string]string{"a": "range,min=0,max=1,groups=create"}
+	if ok, err := ValidateJSON([]byte(`{"a":2}`), rules); !ok {
+		t.Errorf("expected the create rule to be skipped, got %v", err)
+	}
+	if ok, _ := ValidateJSON([]byte(`{"a":2}`), rules, WithGroups("create")); ok {
+		t.Error("expected the create rule to fail")
+	}
+}
+
+func TestValidateJSON_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		doc   string
+		rules map[string]string
+		want  ErrorCategory
+	}{
+		{"unknown directive", `{}`, map[string]string{"a": "nope"}, CategoryConfig},
+		{"mixed types", `{}`, map[string]string{"a": "min,size=2,range,min=0,max=1"}, CategoryConfig},
+		{"struct aware", `{}`, map[string]string{"a": "eqfield,field=B"}, CategoryConfig},
+		{"bad path", `{}`, map[string]string{"a..b": "required"}, CategoryConfig},
+		{"bad pointer", `{}`, map[string]string{"/a//b": "required"}, CategoryConfig},
+		{"malformed", `{"a":`, map[string]string{"a": "required"}, CategoryDecode},
+		{"empty", ``, map[string]string{"a": "required"}, CategoryDecode},
+		{"trailing data", `{} {}`, map[string]string{"a": "required"}, CategoryDecode},
+	}
+	for _, tc := range tests {
+		if ok, err := ValidateJSON([]byte(tc.doc), tc.rules); ok || Categorize(err) != tc.want {
+			t.Errorf("%s: expected a %v error, got %v", tc.name, tc.want, err)
+		}
+	}
+}
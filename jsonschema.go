@@ -0,0 +1,610 @@
+package valex
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const jsonSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// JSONSchemaValidator validates JSON documents against a JSON Schema. It
+// supports the draft 2020-12 validation vocabulary together with local
+// "$ref"s into the same document; format is asserted for "email", "uri",
+// "ipv4", "ipv6", "date-time", "date" and "uuid".
+type JSONSchemaValidator struct {
+	root *schemaNode
+}
+
+func NewJSONSchemaValidator(schema []byte) (*JSONSchemaValidator, error) {
+	var doc any
+	if err := json.Unmarshal(schema, &doc); err != nil {
+		return nil, fmt.Errorf("invalid JSON schema: %w", err)
+	}
+	c := &schemaCompiler{doc: doc, refs: make(map[string]*schemaNode)}
+	root, err := c.compile(doc, "#")
+	if err != nil {
+		return nil, fmt.Errorf("invalid JSON schema: %w", err)
+	}
+	return &JSONSchemaValidator{root: root}, nil
+}
+
+func MustJSONSchemaValidator(schema []byte) *JSONSchemaValidator {
+	v, err := NewJSONSchemaValidator(schema)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+func (v *JSONSchemaValidator) Validate(val string) (ok bool, err error) {
+	return v.ValidateBytes([]byte(val))
+}
+
+func (v *JSONSchemaValidator) ValidateBytes(val []byte) (ok bool, err error) {
+	var doc any
+	if err := json.Unmarshal(val, &doc); err != nil {
+		return false, failf(ErrInvalidFormat, "invalid JSON: %w", err)
+	}
+	if err := v.root.validate(doc, ""); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+type patternSchema struct {
+	pattern *regexp.Regexp
+	schema  *schemaNode
+}
+
+type schemaNode struct {
+	always *bool // set for the boolean schemas true and false
+
+	types    []string
+	enum     []any
+	constVal any
+	hasConst bool
+	format   string
+
+	minimum, maximum         *float64
+	exclMinimum, exclMaximum *float64
+	multipleOf               *float64
+
+	minLength, maxLength *int
+	pattern              *regexp.Regexp
+
+	prefixItems        []*schemaNode
+	items              *schemaNode
+	contains           *schemaNode
+	minItems, maxItems *int
+	uniqueItems        bool
+
+	properties           map[string]*schemaNode
+	patternProperties    []patternSchema
+	additionalProperties *schemaNode
+	required             []string
+	minProps, maxProps   *int
+
+	allOf, anyOf, oneOf []*schemaNode
+	not                 *schemaNode
+	ref                 *schemaNode
+}
+
+type schemaCompiler struct {
+	doc  any
+	refs map[string]*schemaNode
+}
+
+func (c *schemaCompiler) compile(raw any, loc string) (*schemaNode, error) {
+	if b, ok := raw.(bool); ok {
+		return &schemaNode{always: &b}, nil
+	}
+	m, ok := raw.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("%s: schema must be an object or a boolean", loc)
+	}
+
+	n := &schemaNode{}
+	var err error
+
+	if ref, ok := m["$ref"].(string); ok {
+		if n.ref, err = c.resolve(ref); err != nil {
+			return nil, fmt.Errorf("%s: %w", loc, err)
+		}
+	}
+
+	switch t := m["type"].(type) {
+	case string:
+		n.types = []string{t}
+	case []any:
+		for _, item := range t {
+			s, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("%s/type: expected strings", loc)
+			}
+			n.types = append(n.types, s)
+		}
+	case nil:
+	default:
+		return nil, fmt.Errorf("%s/type: expected a string or an array", loc)
+	}
+
+	if enum, ok := m["enum"].([]any); ok {
+		n.enum = enum
+	}
+	n.constVal, n.hasConst = m["const"]
+	n.format, _ = m["format"].(string)
+
+	n.minimum = schemaNumber(m, "minimum")
+	n.maximum = schemaNumber(m, "maximum")
+	n.exclMinimum = schemaNumber(m, "exclusiveMinimum")
+	n.exclMaximum = schemaNumber(m, "exclusiveMaximum")
+	if n.multipleOf = schemaNumber(m, "multipleOf"); n.multipleOf != nil && *n.multipleOf <= 0 {
+		return nil, fmt.Errorf("%s/multipleOf: must be greater than 0", loc)
+	}
+
+	n.minLength = schemaInt(m, "minLength")
+	n.maxLength = schemaInt(m, "maxLength")
+	if p, ok := m["pattern"].(string); ok {
+		if n.pattern, err = compileRegex(p); err != nil {
+			return nil, fmt.Errorf("%s/pattern: %w", loc, err)
+		}
+	}
+
+	if n.prefixItems, err = c.compileList(m, "prefixItems", loc); err != nil {
+		return nil, err
+	}
+	if n.items, err = c.compileKey(m, "items", loc); err != nil {
+		return nil, err
+	}
+	if n.contains, err = c.compileKey(m, "contains", loc); err != nil {
+		return nil, err
+	}
+	n.minItems = schemaInt(m, "minItems")
+	n.maxItems = schemaInt(m, "maxItems")
+	n.uniqueItems, _ = m["uniqueItems"].(bool)
+
+	if props, ok := m["properties"].(map[string]any); ok {
+		n.properties = make(map[string]*schemaNode, len(props))
+		for name, raw := range props {
+			if n.properties[name], err = c.compile(raw, loc+"/properties/"+escapePointer(name)); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if props, ok := m["patternProperties"].(map[string]any); ok {
+		patterns := make([]string, 0, len(props))
+		for p := range props {
+			patterns = append(patterns, p)
+		}
+		sort.Strings(patterns)
+		for _, p := range patterns {
+			re, err := compileRegex(p)
+			if err != nil {
+				return nil, fmt.Errorf("%s/patternProperties: %w", loc, err)
+			}
+			s, err := c.compile(props[p], loc+"/patternProperties/"+escapePointer(p))
+			if err != nil {
+				return nil, err
+			}
+			n.patternProperties = append(n.patternProperties, patternSchema{pattern: re, schema: s})
+		}
+	}
+	if n.additionalProperties, err = c.compileKey(m, "additionalProperties", loc); err != nil {
+		return nil, err
+	}
+	if req, ok := m["required"].([]any); ok {
+		for _, item := range req {
+			s, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("%s/required: expected strings", loc)
+			}
+			n.required = append(n.required, s)
+		}
+	}
+	n.minProps = schemaInt(m, "minProperties")
+	n.maxProps = schemaInt(m, "maxProperties")
+
+	if n.allOf, err = c.compileList(m, "allOf", loc); err != nil {
+		return nil, err
+	}
+	if n.anyOf, err = c.compileList(m, "anyOf", loc); err != nil {
+		return nil, err
+	}
+	if n.oneOf, err = c.compileList(m, "oneOf", loc); err != nil {
+		return nil, err
+	}
+	if n.not, err = c.compileKey(m, "not", loc); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+func (c *schemaCompiler) compileKey(m map[string]any, key, loc string) (*schemaNode, error) {
+	raw, ok := m[key]
+	if !ok {
+		return nil, nil
+	}
+	return c.compile(raw, loc+"/"+key)
+}
+
+func (c *schemaCompiler) compileList(m map[string]any, key, loc string) ([]*schemaNode, error) {
+	raw, ok := m[key]
+	if !ok {
+		return nil, nil
+	}
+	list, ok := raw.([]any)
+	if !ok {
+		return nil, fmt.Errorf("%s/%s: expected an array", loc, key)
+	}
+	nodes := make([]*schemaNode, len(list))
+	for i, item := range list {
+		var err error
+		if nodes[i], err = c.compile(item, fmt.Sprintf("%s/%s/%d", loc, key, i)); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// resolve compiles the subschema a local "$ref" points to. The node is cached
+// before it is compiled so recursive schemas terminate.
+func (c *schemaCompiler) resolve(ref string) (*schemaNode, error) {
+	if n, ok := c.refs[ref]; ok {
+		return n, nil
+	}
+	if !strings.HasPrefix(ref, "#") {
+		return nil, fmt.Errorf("unsupported $ref %q, only local references are supported", ref)
+	}
+
+	target := c.doc
+	if pointer := strings.TrimPrefix(ref, "#"); pointer != "" {
+		for _, token := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+			token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+			switch t := target.(type) {
+			case map[string]any:
+				target = t[token]
+			case []any:
+				i, err := strconv.Atoi(token)
+				if err != nil || i < 0 || i >= len(t) {
+					return nil, fmt.Errorf("unresolvable $ref %q", ref)
+				}
+				target = t[i]
+			default:
+				target = nil
+			}
+			if target == nil {
+				return nil, fmt.Errorf("unresolvable $ref %q", ref)
+			}
+		}
+	}
+
+	n := &schemaNode{}
+	c.refs[ref] = n
+	compiled, err := c.compile(target, ref)
+	if err != nil {
+		return nil, err
+	}
+	*n = *compiled
+	return n, nil
+}
+
+func schemaNumber(m map[string]any, key string) *float64 {
+	if f, ok := m[key].(float64); ok {
+		return &f
+	}
+	return nil
+}
+
+func schemaInt(m map[string]any, key string) *int {
+	if f, ok := m[key].(float64); ok {
+		i := int(f)
+		return &i
+	}
+	return nil
+}
+
+func escapePointer(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
+
+type schemaError struct {
+	path string
+	msg  string
+}
+
+func (e *schemaError) Error() string {
+	path := e.path
+	if path == "" {
+		path = "/"
+	}
+	return fmt.Sprintf("value at %q %s", path, e.msg)
+}
+
+// Unwrap makes errors.Is match schema violations with ErrInvalidFormat.
+func (e *schemaError) Unwrap() error {
+	return ErrInvalidFormat
+}
+
+func schemaErrorf(path, format string, args ...any) error {
+	return &schemaError{path: path, msg: fmt.Sprintf(format, args...)}
+}
+
+func (n *schemaNode) validate(val any, path string) error {
+	if n.always != nil {
+		if !*n.always {
+			return schemaErrorf(path, "is not allowed")
+		}
+		return nil
+	}
+	if n.ref != nil {
+		if err := n.ref.validate(val, path); err != nil {
+			return err
+		}
+	}
+
+	if len(n.types) > 0 && !n.matchesType(val) {
+		return schemaErrorf(path, "must be of type %s", strings.Join(n.types, " or "))
+	}
+	if n.enum != nil {
+		found := false
+		for _, e := range n.enum {
+			if reflect.DeepEqual(e, val) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return schemaErrorf(path, "must be one of the enumerated values")
+		}
+	}
+	if n.hasConst && !reflect.DeepEqual(n.constVal, val) {
+		return schemaErrorf(path, "must be equal to the constant value")
+	}
+
+	var err error
+	switch v := val.(type) {
+	case float64:
+		err = n.validateNumber(v, path)
+	case string:
+		err = n.validateString(v, path)
+	case []any:
+		err = n.validateArray(v, path)
+	case map[string]any:
+		err = n.validateObject(v, path)
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, s := range n.allOf {
+		if err := s.validate(val, path); err != nil {
+			return err
+		}
+	}
+	if len(n.anyOf) > 0 {
+		matched := false
+		for _, s := range n.anyOf {
+			if s.validate(val, path) == nil {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return schemaErrorf(path, "must match at least one schema in anyOf")
+		}
+	}
+	if len(n.oneOf) > 0 {
+		matches := 0
+		for _, s := range n.oneOf {
+			if s.validate(val, path) == nil {
+				matches++
+			}
+		}
+		if matches != 1 {
+			return schemaErrorf(path, "must match exactly one schema in oneOf, matched %d", matches)
+		}
+	}
+	if n.not != nil && n.not.validate(val, path) == nil {
+		return schemaErrorf(path, "must not match the schema in not")
+	}
+	return nil
+}
+
+func (n *schemaNode) matchesType(val any) bool {
+	for _, t := range n.types {
+		switch v := val.(type) {
+		case nil:
+			if t == "null" {
+				return true
+			}
+		case bool:
+			if t == "boolean" {
+				return true
+			}
+		case float64:
+			if t == "number" || (t == "integer" && v == math.Trunc(v)) {
+				return true
+			}
+		case string:
+			if t == "string" {
+				return true
+			}
+		case []any:
+			if t == "array" {
+				return true
+			}
+		case map[string]any:
+			if t == "object" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (n *schemaNode) validateNumber(v float64, path string) error {
+	if n.minimum != nil && v < *n.minimum {
+		return schemaErrorf(path, "must be greater than or equal to %v", *n.minimum)
+	}
+	if n.maximum != nil && v > *n.maximum {
+		return schemaErrorf(path, "must be less than or equal to %v", *n.maximum)
+	}
+	if n.exclMinimum != nil && v <= *n.exclMinimum {
+		return schemaErrorf(path, "must be greater than %v", *n.exclMinimum)
+	}
+	if n.exclMaximum != nil && v >= *n.exclMaximum {
+		return schemaErrorf(path, "must be less than %v", *n.exclMaximum)
+	}
+	if n.multipleOf != nil {
+		q := v / *n.multipleOf
+		if math.Abs(q-math.Round(q)) > 1e-9 {
+			return schemaErrorf(path, "must be a multiple of %v", *n.multipleOf)
+		}
+	}
+	return nil
+}
+
+var schemaUUIDPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+func (n *schemaNode) validateString(v string, path string) error {
+	l := utf8.RuneCountInString(v)
+	if n.minLength != nil && l < *n.minLength {
+		return schemaErrorf(path, "must be at least %d characters long", *n.minLength)
+	}
+	if n.maxLength != nil && l > *n.maxLength {
+		return schemaErrorf(path, "must be at most %d characters long", *n.maxLength)
+	}
+	if n.pattern != nil && !n.pattern.MatchString(v) {
+		return schemaErrorf(path, "must match pattern %q", n.pattern.String())
+	}
+
+	var ok bool
+	switch n.format {
+	case "email":
+		ok, _ = (&EmailValidator{}).Validate(v)
+	case "uri":
+		ok, _ = (&UrlValidator{}).Validate(v)
+	case "ipv4":
+		ok, _ = (&IPv4Validator{}).Validate(v)
+	case "ipv6":
+		ok, _ = (&IPv6Validator{}).Validate(v)
+	case "date-time":
+		_, err := time.Parse(time.RFC3339, v)
+		ok = err == nil
+	case "date":
+		_, err := time.Parse(time.DateOnly, v)
+		ok = err == nil
+	case "uuid":
+		ok = schemaUUIDPattern.MatchString(v)
+	default:
+		ok = true // unknown formats are annotations only
+	}
+	if !ok {
+		return schemaErrorf(path, "must be a valid %s", n.format)
+	}
+	return nil
+}
+
+func (n *schemaNode) validateArray(v []any, path string) error {
+	if n.minItems != nil && len(v) < *n.minItems {
+		return schemaErrorf(path, "must contain at least %d items", *n.minItems)
+	}
+	if n.maxItems != nil && len(v) > *n.maxItems {
+		return schemaErrorf(path, "must contain at most %d items", *n.maxItems)
+	}
+	if n.uniqueItems {
+		for i := range v {
+			for j := i + 1; j < len(v); j++ {
+				if reflect.DeepEqual(v[i], v[j]) {
+					return schemaErrorf(path, "must contain unique items, items %d and %d are equal", i, j)
+				}
+			}
+		}
+	}
+	for i, item := range v {
+		itemPath := path + "/" + strconv.Itoa(i)
+		var s *schemaNode
+		if i < len(n.prefixItems) {
+			s = n.prefixItems[i]
+		} else {
+			s = n.items
+		}
+		if s == nil {
+			continue
+		}
+		if err := s.validate(item, itemPath); err != nil {
+			return err
+		}
+	}
+	if n.contains != nil {
+		found := false
+		for i, item := range v {
+			if n.contains.validate(item, path+"/"+strconv.Itoa(i)) == nil {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return schemaErrorf(path, "must contain an item matching the schema in contains")
+		}
+	}
+	return nil
+}
+
+func (n *schemaNode) validateObject(v map[string]any, path string) error {
+	if n.minProps != nil && len(v) < *n.minProps {
+		return schemaErrorf(path, "must have at least %d properties", *n.minProps)
+	}
+	if n.maxProps != nil && len(v) > *n.maxProps {
+		return schemaErrorf(path, "must have at most %d properties", *n.maxProps)
+	}
+	for _, name := range n.required {
+		if _, ok := v[name]; !ok {
+			return schemaErrorf(path, "is missing required property %q", name)
+		}
+	}
+
+	names := make([]string, 0, len(v))
+	for name := range v {
+		names = append(names, name)
+	}
+	sort.Strings(names) // report the same error for the same document
+
+	for _, name := range names {
+		propPath := path + "/" + escapePointer(name)
+		matched := false
+		if s, ok := n.properties[name]; ok {
+			matched = true
+			if err := s.validate(v[name], propPath); err != nil {
+				return err
+			}
+		}
+		for _, ps := range n.patternProperties {
+			if ps.pattern.MatchString(name) {
+				matched = true
+				if err := ps.schema.validate(v[name], propPath); err != nil {
+					return err
+				}
+			}
+		}
+		if !matched && n.additionalProperties != nil {
+			if err := n.additionalProperties.validate(v[name], propPath); err != nil {
+				var se *schemaError
+				if n.additionalProperties.always != nil && errors.As(err, &se) {
+					return schemaErrorf(path, "has unexpected property %q", name)
+				}
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package valex
+
+import (
+	"strings"
+	"testing"
+)
+
+const testSchema = `{
+	"$schema": "https://json-schema.org/draft/2020-12/schema",
+	"type": "object",
+	"properties": {
+		"name": {"type": "string", "minLength": 3, "maxLength": 10},
+		"age": {"type": "integer", "minimum": 0, "exclusiveMaximum": 130},
+		"email": {"type": "string", "format": "email"},
+		"tags": {"type": "array", "items": {"type": "string"}, "uniqueItems": true, "maxItems": 3},
+		"address": {"$ref": "#/$defs/address"},
+		"kind": {"enum": ["user", "admin"]}
+	},
+	"required": ["name", "age"],
+	"additionalProperties": false,
+	"$defs": {
+		"address": {
+			"type": "object",
+			"properties": {
+				"zip": {"type": "string", "pattern": "^[0-9]{4}[A-Z]{2}$"},
+				"next": {"$ref": "#/$defs/address"}
+			}
+		}
+	}
+}`
+
+func TestJSONSchemaValidator(t *testing.T) {
+	v, err := NewJSONSchemaValidator([]byte(testSchema))
+	if err != nil {
+		t.Fatalf("unexpected error compiling schema: %v", err)
+	}
+
+	tests := []struct {
+		input     string
+		ok        bool
+		errSubstr string
+	}{
+		{`{"name": "John", "age": 30}`, true, ""},
+		{`{"name": "John", "age": 30, "email": "john@example.com", "tags": ["a", "b"], "kind": "admin"}`, true, ""},
+		{`{"name": "John", "age": 30, "address": {"zip": "1234AB", "next": {"zip": "5678CD"}}}`, true, ""},
+		{`{"name": "John"}`, false, `is missing required property "age"`},
+		{`{"name": "Jo", "age": 30}`, false, `value at "/name" must be at least 3 characters long`},
+		{`{"name": "John", "age": 30.5}`, false, `value at "/age" must be of type integer`},
+		{`{"name": "John", "age": 130}`, false, `must be less than 130`},
+		{`{"name": "John", "age": 30, "email": "nope"}`, false, `must be a valid email`},
+		{`{"name": "John", "age": 30, "tags": ["a", "a"]}`, false, `must contain unique items`},
+		{`{"name": "John", "age": 30, "address": {"next": {"zip": "1234"}}}`, false, `value at "/address/next/zip" must match pattern`},
+		{`{"name": "John", "age": 30, "kind": "root"}`, false, `must be one of the enumerated values`},
+		{`{"name": "John", "age": 30, "extra": true}`, false, `has unexpected property "extra"`},
+		{`{"name": "John", "age": 30`, false, `invalid JSON`},
+	}
+	for _, tc := range tests {
+		ok, err := v.Validate(tc.input)
+		if ok != tc.ok {
+			t.Errorf("%T(%q): expected ok=%v, got ok=%v, err=%v", *v, tc.input, tc.ok, ok, err)
+			continue
+		}
+		if !tc.ok && !strings.Contains(err.Error(), tc.errSubstr) {
+			t.Errorf("%T(%q): expected error containing %q, got %q", *v, tc.input, tc.errSubstr, err)
+		}
+	}
+}
+
+func TestJSONSchemaValidator_Combinators(t *testing.T) {
+	v := MustJSONSchemaValidator([]byte(`{
+		"oneOf": [{"type": "integer", "multipleOf": 5}, {"type": "integer", "multipleOf": 3}],
+		"not": {"const": 0}
+	}`))
+
+	tests := []struct {
+		input string
+		ok    bool
+	}{
+		{`5`, true},
+		{`9`, true},
+		{`15`, false}, // matches both
+		{`7`, false},
+		{`0`, false},
+	}
+	for _, tc := range tests {
+		ok, err := v.ValidateBytes([]byte(tc.input))
+		if ok != tc.ok {
+			t.Errorf("%T(%q): expected ok=%v, got ok=%v, err=%v", *v, tc.input, tc.ok, ok, err)
+		}
+	}
+}
+
+func TestNewJSONSchemaValidator_Invalid(t *testing.T) {
+	tests := []string{
+		`{"type": 1}`,
+		`{"pattern": "("}`,
+		`{"$ref": "#/$defs/missing"}`,
+		`{"$ref": "https://example.com/schema.json"}`,
+		`{"multipleOf": 0}`,
+		`[]`,
+	}
+	for _, schema := range tests {
+		if _, err := NewJSONSchemaValidator([]byte(schema)); err == nil {
+			t.Errorf("NewJSONSchemaValidator(%q): expected error", schema)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package valex
+
+import (
+	"regexp"
+	"strings"
+)
+
+// The validators in this file follow the Kubernetes API conventions, for
+// operators and controllers validating CRD-like structs.
+
+var (
+	dns1123Label      = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+	dns1123Subdomain  = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`)
+	k8sLabelValue     = regexp.MustCompile(`^(([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9])?$`)
+	k8sQuantitySuffix = regexp.MustCompile(`^(|[numkMGTPE]|[KMGTPE]i|[eE][-+]?[0-9]+)$`)
+)
+
+// K8sNameValidator accepts resource names: DNS-1123 subdomains of at most
+// 253 characters, or with Label DNS-1123 labels of at most 63 characters,
+// as required for e.g. namespaces and services: `val:"k8sname,label"`.
+type K8sNameValidator struct {
+	Label bool `param:"label,optional"`
+}
+
+func (v *K8sNameValidator) Validate(val string) (ok bool, err error) {
+	kind, max, re := "DNS-1123 subdomain", 253, dns1123Subdomain
+	if v.Label {
+		kind, max, re = "DNS-1123 label", 63, dns1123Label
+	}
+	if len(val) > max {
+		return false, failf(ErrTooLong, "name %q is longer than %d characters", val, max)
+	}
+	if !re.MatchString(val) {
+		return false, failf(ErrInvalidFormat, "name %q is not a %s: use lowercase letters, digits and '-', starting and ending with a letter or digit", val, kind)
+	}
+	return true, nil
+}
+
+func (v *K8sNameValidator) Name() string {
+	return "k8sname"
+}
+
+func (v *K8sNameValidator) Handle(val string) error {
+	if ok, err := v.Validate(val); !ok {
+		return err
+	}
+	return nil
+}
+
+// K8sLabelValueValidator accepts label values: empty, or at most 63
+// letters, digits, '-', '_' and '.', starting and ending with a letter or
+// digit.
+type K8sLabelValueValidator struct{}
+
+func (v *K8sLabelValueValidator) Validate(val string) (ok bool, err error) {
+	if len(val) > 63 {
+		return false, failf(ErrTooLong, "label value %q is longer than 63 characters", val)
+	}
+	if !k8sLabelValue.MatchString(val) {
+		return false, failf(ErrInvalidFormat, "label value %q may only hold letters, digits, '-', '_' and '.', starting and ending with a letter or digit", val)
+	}
+	return true, nil
+}
+
+func (v *K8sLabelValueValidator) Name() string {
+	return "k8slabel"
+}
+
+func (v *K8sLabelValueValidator) Handle(val string) error {
+	if ok, err := v.Validate(val); !ok {
+		return err
+	}
+	return nil
+}
+
+// K8sQuantityValidator accepts resource quantities such as "500m", "2Gi",
+// "1.5" and "1e3": a number with an optional binary (Ki to Ei) or decimal
+// (n to E) suffix or exponent.
+type K8sQuantityValidator struct{}
+
+func (v *K8sQuantityValidator) Validate(val string) (ok bool, err error) {
+	num := strings.TrimLeft(val, "+-")
+	if len(val)-len(num) > 1 {
+		return false, failf(ErrInvalidFormat, "quantity %q is not valid", val)
+	}
+	end := strings.IndexFunc(num, func(r rune) bool { return (r < '0' || r > '9') && r != '.' })
+	if end < 0 {
+		end = len(num)
+	}
+	digits, suffix := num[:end], num[end:]
+	if strings.Trim(digits, ".") == "" || strings.Count(digits, ".") > 1 || !k8sQuantitySuffix.MatchString(suffix) {
+		return false, failf(ErrInvalidFormat, "quantity %q is not valid, expected e.g. 500m or 2Gi", val)
+	}
+	return true, nil
+}
+
+func (v *K8sQuantityValidator) Name() string {
+	return "k8squantity"
+}
+
+func (v *K8sQuantityValidator) Handle(val string) error {
+	if ok, err := v.Validate(val); !ok {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package valex
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestK8sValidators(t *testing.T) {
+	tests := []struct {
+		v     interface{ Validate(string) (bool, error) }
+		input string
+		ok    bool
+	}{
+		{&K8sNameValidator{}, "my-app.example.com", true},
+		{&K8sNameValidator{}, "web-0", true},
+		{&K8sNameValidator{}, "My-App", false},
+		{&K8sNameValidator{}, "-app", false},
+		{&K8sNameValidator{}, "app.", false},
+		{&K8sNameValidator{}, "", false},
+		{&K8sNameValidator{}, strings.Repeat("a", 254), false},
+		{&K8sNameValidator{Label: true}, "kube-system", true},
+		{&K8sNameValidator{Label: true}, "my.app", false},
+		{&K8sNameValidator{Label: true}, strings.Repeat("a", 64), false},
+		{&K8sLabelValueValidator{}, "", true},
+		{&K8sLabelValueValidator{}, "v1.2_beta-3", true},
+		{&K8sLabelValueValidator{}, "Production", true},
+		{&K8sLabelValueValidator{}, "_private", false},
+		{&K8sLabelValueValidator{}, "a b", false},
+		{&K8sLabelValueValidator{}, strings.Repeat("a", 64), false},
+		{&K8sQuantityValidator{}, "500m", true},
+		{&K8sQuantityValidator{}, "2Gi", true},
+		{&K8sQuantityValidator{}, "1.5", true},
+		{&K8sQuantityValidator{}, "128974848", true},
+		{&K8sQuantityValidator{}, "1e3", true},
+		{&K8sQuantityValidator{}, "-1k", true},
+		{&K8sQuantityValidator{}, ".5", true},
+		{&K8sQuantityValidator{}, "2GB", false},
+		{&K8sQuantityValidator{}, "2gi", false},
+		{&K8sQuantityValidator{}, "1.2.3", false},
+		{&K8sQuantityValidator{}, "Gi", false},
+		{&K8sQuantityValidator{}, "--1", false},
+		{&K8sQuantityValidator{}, "", false},
+	}
+	for _, tc := range tests {
+		ok, err := tc.v.Validate(tc.input)
+		if ok != tc.ok {
+			t.Errorf("%+v(%q): expected ok=%v, got ok=%v, error: %v", tc.v, tc.input, tc.ok, ok, err)
+		}
+	}
+}
+
+func TestK8sValidators_Tags(t *testing.T) {
+	type deployment struct {
+		Name      string            `val:"k8sname"`
+		Namespace string            `val:"k8sname,label"`
+		Labels    map[string]string `val:"dive,k8slabel"`
+		CPU       string            `val:"k8squantity"`
+	}
+	d := deployment{Name: "web.v2", Namespace: "prod", Labels: map[string]string{"tier": "frontend"}, CPU: "250m"}
+	if _, err := ValidateStruct(&d); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	d.Namespace = "prod.eu"
+	if _, err := ValidateStruct(&d); err == nil {
+		t.Error("expected a namespace with a dot to fail")
+	}
+}
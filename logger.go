@@ -0,0 +1,72 @@
+package valex
+
+import (
+	"fmt"
+	"log/slog"
+	"reflect"
+)
+
+type loggerBox struct {
+	l     *slog.Logger
+	level slog.Level
+}
+
+// SetLogger logs every directive that fails during struct validation to l
+// at level, e.g. to spot clients that keep sending bad input. Records carry
+// the field, the directive, the error and a redacted value that only shows
+// its type and length, or nothing after the secret directive. A nil l stops
+// logging.
+func (e *Engine) SetLogger(l *slog.Logger, level slog.Level) {
+	e.logger.Store(loggerBox{l, level})
+}
+
+func SetLogger(l *slog.Logger, level slog.Level) {
+	std.SetLogger(l, level)
+}
+
+func (e *Engine) loadLogger() loggerBox {
+	b, _ := e.logger.Load().(loggerBox)
+	return b
+}
+
+// log logs the failure fe of val, which is a warning when warn is set.
+func (v *validation) log(fe *FieldError, val reflect.Value, warn, secret bool) {
+	b := v.e.loadLogger()
+	if b.l == nil || !b.l.Enabled(v.ctx, b.level) {
+		return
+	}
+	attrs := []slog.Attr{
+		slog.String("field", fe.Field),
+		slog.String("directive", fe.Directive),
+		slog.String("error", fe.Err.Error()),
+	}
+	if secret {
+		attrs = append(attrs, slog.String("value", redacted))
+	} else {
+		attrs = append(attrs, slog.String("value", redact(val)))
+	}
+	if v.typ != nil {
+		attrs = append(attrs, slog.String("type", v.typ.String()))
+	}
+	if warn {
+		attrs = append(attrs, slog.Bool("warning", true))
+	}
+	b.l.LogAttrs(v.ctx, b.level, "validation failed", attrs...)
+}
+
+// redact describes val without revealing it.
+func redact(val reflect.Value) string {
+	for val.Kind() == reflect.Ptr || val.Kind() == reflect.Interface {
+		if val.IsNil() {
+			return "nil"
+		}
+		val = val.Elem()
+	}
+	switch val.Kind() {
+	case reflect.Invalid:
+		return "nil"
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map:
+		return fmt.Sprintf("%s(len=%d)", val.Type(), val.Len())
+	}
+	return val.Type().String()
+}
@@ -0,0 +1,73 @@
+package valex
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestEngine_SetLogger(t *testing.T) {
+	type dummy struct {
+		Password string `val:"min,size=8"`
+		Note     string `val:"warn,max,size=2"`
+		Age      int    `val:"range,min=0,max=120"`
+	}
+	var buf bytes.Buffer
+	e := NewEngine()
+	e.SetLogger(slog.New(slog.NewJSONHandler(&buf, nil)), slog.LevelWarn)
+
+	e.ValidateStruct(&dummy{Password: "hunter2", Note: "long", Age: 130}, WithCollectAll())
+
+	var got []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var rec map[string]any
+		if err := json.Unmarshal([]byte(line), &rec); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		delete(rec, "time")
+		delete(rec, "error")
+		got = append(got, rec)
+	}
+	want := []map[string]any{
+		{"level": "WARN", "msg": "validation failed", "field": "Password", "directive": "min", "value": "string(len=7)", "type": "valex.dummy"},
+		{"level": "WARN", "msg": "validation failed", "field": "Note", "directive": "max", "value": "string(len=4)", "type": "valex.dummy", "warning": true},
+		{"level": "WARN", "msg": "validation failed", "field": "Age", "directive": "range", "value": "int", "type": "valex.dummy"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	buf.Reset()
+	e.SetLogger(slog.New(slog.NewJSONHandler(&buf, nil)), slog.LevelDebug)
+	e.ValidateStruct(&dummy{Password: "hunter2"})
+	if buf.Len() != 0 {
+		t.Errorf("expected nothing below the handler level, got %s", buf.String())
+	}
+	e.SetLogger(nil, slog.LevelError)
+	e.ValidateStruct(&dummy{Password: "hunter2"})
+	if buf.Len() != 0 {
+		t.Errorf("expected nothing without a logger, got %s", buf.String())
+	}
+}
+
+func TestRedact(t *testing.T) {
+	s := "secret"
+	tests := []struct {
+		val  any
+		want string
+	}{
+		{"secret", "string(len=6)"},
+		{&s, "string(len=6)"},
+		{[]int{1, 2}, "[]int(len=2)"},
+		{(*string)(nil), "nil"},
+		{3.5, "float64"},
+	}
+	for _, tc := range tests {
+		if got := redact(reflect.ValueOf(tc.val)); got != tc.want {
+			t.Errorf("redact(%v): expected %q, got %q", tc.val, tc.want, got)
+		}
+	}
+}
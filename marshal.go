@@ -0,0 +1,80 @@
+package valex
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// The marshalers let a ValidatedValue be used as a field of request and
+// response types. As decoding validates, the Validator has to be set before
+// decoding into the value:
+//
+//	req := Request{Name: ValidatedValue[string]{Validator: &MinLengthValidator{Size: 3}}}
+//	err := json.NewDecoder(r.Body).Decode(&req)
+
+func (v ValidatedValue[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v.value)
+}
+
+// UnmarshalJSON decodes data into T and sets it when it passes validation.
+// JSON null leaves the value unchanged, as it does for other Go values.
+func (v *ValidatedValue[T]) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	var val T
+	if err := json.Unmarshal(data, &val); err != nil {
+		return err
+	}
+	return v.Set(val)
+}
+
+// MarshalText uses the MarshalText method of T when it has one. Otherwise T
+// has to be a string, bool or number, which UnmarshalText parses back.
+func (v ValidatedValue[T]) MarshalText() ([]byte, error) {
+	return marshalText(v.value)
+}
+
+// UnmarshalText parses text into T and sets it when it passes validation. It
+// uses the UnmarshalText method of *T when it has one, and otherwise parses
+// strings, bools and numbers like SetString.
+func (v *ValidatedValue[T]) UnmarshalText(text []byte) error {
+	val, err := unmarshalText[T](text)
+	if err != nil {
+		return err
+	}
+	return v.Set(val)
+}
+
+func marshalText[T any](val T) ([]byte, error) {
+	if m, ok := any(val).(encoding.TextMarshaler); ok {
+		return m.MarshalText()
+	}
+	if !isTextKind(reflect.TypeFor[T]()) {
+		return nil, fmt.Errorf("cannot marshal %s as text", reflect.TypeFor[T]())
+	}
+	return []byte(fmt.Sprint(val)), nil
+}
+
+func unmarshalText[T any](text []byte) (T, error) {
+	var val T
+	if u, ok := any(&val).(encoding.TextUnmarshaler); ok {
+		err := u.UnmarshalText(text)
+		return val, err
+	}
+	if !isTextKind(reflect.TypeFor[T]()) {
+		return val, fmt.Errorf("cannot unmarshal text into %s", reflect.TypeFor[T]())
+	}
+	err := setFromString(reflect.ValueOf(&val).Elem(), string(text))
+	return val, err
+}
+
+// isTextKind reports whether values of t have a text form without a
+// TextMarshaler.
+func isTextKind(t reflect.Type) bool {
+	k := t.Kind()
+	return k == reflect.String || k == reflect.Bool || isNumericKind(k)
+}
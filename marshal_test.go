@@ -0,0 +1,123 @@
+package valex
+
+import (
+	"encoding"
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+	"time"
+)
+
+var (
+	_ json.Marshaler           = ValidatedValue[int]{}
+	_ json.Unmarshaler         = (*ValidatedValue[int])(nil)
+	_ encoding.TextMarshaler   = ValidatedValue[int]{}
+	_ encoding.TextUnmarshaler = (*ValidatedValue[int])(nil)
+)
+
+type marshalRequest struct {
+	Name ValidatedValue[string] `json:"name" xml:"name"`
+	Age  ValidatedValue[int]    `json:"age" xml:"age"`
+}
+
+func newMarshalRequest() marshalRequest {
+	return marshalRequest{
+		Name: ValidatedValue[string]{Validator: &MinLengthValidator{Size: 3}},
+		Age:  ValidatedValue[int]{Validator: &IntRangeValidator{Min: 0, Max: 130}},
+	}
+}
+
+func TestValidatedValue_JSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		wantName string
+		wantAge  int
+		wantErr  bool
+	}{
+		{name: "valid", data: `{"name":"john","age":30}`, wantName: "john", wantAge: 30},
+		{name: "null", data: `{"name":null,"age":30}`, wantAge: 30},
+		{name: "invalid name", data: `{"name":"jo","age":30}`, wantAge: 30, wantErr: true},
+		{name: "invalid age", data: `{"name":"john","age":200}`, wantName: "john", wantErr: true},
+		{name: "wrong type", data: `{"age":"old"}`, wantErr: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := newMarshalRequest()
+			err := json.Unmarshal([]byte(tc.data), &req)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("got error %v, wantErr %v", err, tc.wantErr)
+			}
+			if req.Name.Get() != tc.wantName || req.Age.Get() != tc.wantAge {
+				t.Errorf("got %q and %d, want %q and %d", req.Name.Get(), req.Age.Get(), tc.wantName, tc.wantAge)
+			}
+		})
+	}
+
+	req := newMarshalRequest()
+	req.Name.Set("john")
+	req.Age.Set(30)
+	data, err := json.Marshal(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := string(data), `{"name":"john","age":30}`; got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
+
+func TestValidatedValue_Text(t *testing.T) {
+	req := newMarshalRequest()
+	if err := xml.Unmarshal([]byte(`<r><name>john</name><age>30</age></r>`), &req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if req.Name.Get() != "john" || req.Age.Get() != 30 {
+		t.Errorf("got %q and %d", req.Name.Get(), req.Age.Get())
+	}
+	if err := xml.Unmarshal([]byte(`<r><age>-1</age></r>`), &req); err == nil {
+		t.Error("expected a validation error")
+	}
+
+	text, err := req.Age.MarshalText()
+	if err != nil || string(text) != "30" {
+		t.Errorf("MarshalText() = %q, %v", text, err)
+	}
+}
+
+func TestValidatedValue_TextRoundTrip(t *testing.T) {
+	when := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	tv := ValidatedValue[time.Time]{Validator: &PastValidator{}}
+	if err := tv.Set(when); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	text, err := tv.MarshalText()
+	if err != nil {
+		t.Fatalf("MarshalText() error: %v", err)
+	}
+	got := ValidatedValue[time.Time]{Validator: &PastValidator{}}
+	if err := got.UnmarshalText(text); err != nil {
+		t.Fatalf("UnmarshalText(%q) error: %v", text, err)
+	}
+	if !got.Get().Equal(when) {
+		t.Errorf("got %v, want %v", got.Get(), when)
+	}
+
+	dv := ValidatedValue[time.Duration]{Validator: ValidatorFunc[time.Duration](func(time.Duration) (bool, error) { return true, nil })}
+	if err := dv.UnmarshalText([]byte("1m30s")); err != nil || dv.Get() != 90*time.Second {
+		t.Errorf("UnmarshalText(1m30s) = %v, %v", dv.Get(), err)
+	}
+	if text, err := dv.MarshalText(); err != nil || string(text) != "1m30s" {
+		t.Errorf("MarshalText() = %q, %v", text, err)
+	}
+
+	sv := ValidatedValue[[]string]{Validator: ValidatorFunc[[]string](func([]string) (bool, error) { return true, nil })}
+	if err := sv.Set([]string{"a b", "c"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := sv.MarshalText(); err == nil {
+		t.Error("expected MarshalText of []string to fail")
+	}
+	if err := sv.UnmarshalText([]byte("a b")); err == nil {
+		t.Error("expected UnmarshalText into []string to fail")
+	}
+}
@@ -0,0 +1,96 @@
+package valex
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"strings"
+	"text/template"
+)
+
+// SetMessage replaces the error message of a directive with tmpl, a
+// text/template, e.g.
+//
+//	SetMessage("len", "{{.Field}} must have {{.Min}} to {{.Max}} characters")
+//
+// Templates see the exported fields of the configured directive, e.g. .Min
+// and .Size, and .Value, .Field, .Directive and .Err, the original error. An
+// empty tmpl restores the directive's own message. Errors keep wrapping the
+// original error, so their category is unchanged.
+func (e *Engine) SetMessage(directive, tmpl string) error {
+	var t *template.Template
+	if tmpl != "" {
+		var err error
+		if t, err = template.New(directive).Option("missingkey=error").Parse(tmpl); err != nil {
+			return fmt.Errorf("invalid message for directive %q: %w", directive, err)
+		}
+	}
+
+	e.msgMut.Lock()
+	defer e.msgMut.Unlock()
+
+	msgs := make(map[string]*template.Template)
+	if cur := e.messages.Load(); cur != nil {
+		maps.Copy(msgs, *cur)
+	}
+	if t == nil {
+		delete(msgs, directive)
+	} else {
+		msgs[directive] = t
+	}
+	e.messages.Store(&msgs)
+	return nil
+}
+
+func SetMessage(directive, tmpl string) error {
+	return std.SetMessage(directive, tmpl)
+}
+
+// messageError carries a templated message for err.
+type messageError struct {
+	msg string
+	err error
+}
+
+func (me *messageError) Error() string {
+	return me.msg
+}
+
+func (me *messageError) Unwrap() error {
+	return me.err
+}
+
+// message applies the message template of s, if any, to err.
+func (e *Engine) message(s step, val reflect.Value, path FieldPath, err error) error {
+	msgs := e.messages.Load()
+	if msgs == nil {
+		return err
+	}
+	t, ok := (*msgs)[s.name]
+	if !ok {
+		return err
+	}
+
+	data := map[string]any{
+		"Field":     path.String(),
+		"Directive": s.name,
+		"Err":       err,
+	}
+	if val.CanInterface() {
+		data["Value"] = val.Interface()
+	}
+	if d := reflect.ValueOf(s.inst); d.Kind() == reflect.Ptr && d.Elem().Kind() == reflect.Struct {
+		d = d.Elem()
+		for n := 0; n < d.NumField(); n++ {
+			if f := d.Type().Field(n); f.IsExported() {
+				data[f.Name] = d.Field(n).Interface()
+			}
+		}
+	}
+
+	var b strings.Builder
+	if terr := t.Execute(&b, data); terr != nil {
+		return err // a broken template must not hide the failure
+	}
+	return &messageError{msg: b.String(), err: err}
+}
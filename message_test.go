@@ -0,0 +1,107 @@
+package valex
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestSetMessage(t *testing.T) {
+	e := NewEngine()
+	type dummy struct {
+		Name  string `val:"len,min=3,max=5"`
+		Age   int    `val:"range,min=18,max=130"`
+		Email string `val:"email"`
+	}
+
+	tests := []struct {
+		directive string
+		tmpl      string
+		data      dummy
+		want      string
+	}{
+		{"len", "{{.Field}} must have {{.Min}} to {{.Max}} characters, not {{len .Value}}", dummy{Name: "Jo", Age: 20, Email: "a@b.c"}, "Name must have 3 to 5 characters, not 2"},
+		{"range", "age {{.Value}} is not allowed ({{.Directive}})", dummy{Name: "John", Age: 3, Email: "a@b.c"}, "age 3 is not allowed (range)"},
+		{"email", "bad email: {{.Err}}", dummy{Name: "John", Age: 20, Email: "nope"}, "bad email: mail: missing '@' or angle-addr"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.directive, func(t *testing.T) {
+			if err := e.SetMessage(tc.directive, tc.tmpl); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			_, err := e.ValidateStruct(tc.data)
+			var fe *FieldError
+			if !errors.As(err, &fe) {
+				t.Fatalf("expected a *FieldError, got %v", err)
+			}
+			if got := fe.Err.Error(); got != tc.want {
+				t.Errorf("expected message %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestSetMessage_Restore(t *testing.T) {
+	e := NewEngine()
+	data := struct {
+		Name string `val:"min,size=3"`
+	}{Name: "Jo"}
+
+	if err := e.SetMessage("min", "too short"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := e.ValidateStruct(data); !strings.HasSuffix(err.Error(), "too short") {
+		t.Errorf("expected the templated message, got %v", err)
+	}
+	if err := e.SetMessage("min", ""); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := e.ValidateStruct(data); !strings.Contains(err.Error(), "minimum length") {
+		t.Errorf("expected the original message, got %v", err)
+	}
+}
+
+func TestSetMessage_Errors(t *testing.T) {
+	e := NewEngine()
+	if err := e.SetMessage("min", "{{.Size"); err == nil {
+		t.Error("expected an error for an invalid template")
+	}
+
+	// a template failing to execute keeps the original message
+	if err := e.SetMessage("min", "{{.Nope}}"); err != nil {
+		t.Fatal(err)
+	}
+	_, err := e.ValidateStruct(struct {
+		Name string `val:"min,size=3"`
+	}{Name: "Jo"})
+	if err == nil || !strings.Contains(err.Error(), "minimum length") {
+		t.Errorf("expected the original message, got %v", err)
+	}
+}
+
+func TestSetMessage_KeepsCategory(t *testing.T) {
+	e := NewEngine()
+	sentinel := errors.New("sentinel")
+	RegisterDirective(e, &sentinelDirective{err: WithCategory(sentinel, CategorySize)})
+	if err := e.SetMessage("sentinel", "custom"); err != nil {
+		t.Fatal(err)
+	}
+	_, err := e.ValidateStruct(struct {
+		A string `val:"sentinel"`
+	}{})
+	if !errors.Is(err, sentinel) || Categorize(err) != CategorySize {
+		t.Errorf("expected the original error to be wrapped, got %v (%v)", err, Categorize(err))
+	}
+}
+
+type sentinelDirective struct {
+	err error
+}
+
+func (d *sentinelDirective) Name() string {
+	return "sentinel"
+}
+
+func (d *sentinelDirective) Handle(val string) error {
+	return d.err
+}
@@ -0,0 +1,117 @@
+package valex
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const currencyTable = "currencies"
+
+func init() {
+	declareTable(currencyTable)
+}
+
+// currencyDigits returns the digits after the decimal point of the ISO 4217
+// currencies, by code.
+func currencyDigits() (map[string]int, error) {
+	return tableData(currencyTable, func(lines []string) map[string]int {
+		digits := make(map[string]int, len(lines))
+		for _, line := range lines {
+			code, n, _ := strings.Cut(line, " ")
+			if d, err := strconv.Atoi(strings.TrimSpace(n)); err == nil {
+				digits[code] = d
+			}
+		}
+		return digits
+	})
+}
+
+// MoneyValidator checks amounts in Currency, an ISO 4217 code, written with
+// at most as many decimal places as the currency has, e.g. "19.99" for EUR
+// and "1999" for JPY. Negative amounts are rejected unless AllowNegative is
+// set, e.g. `val:"money,currency=EUR,negative"` for refunds. As a directive
+// it also takes integer fields, holding amounts in the minor unit of the
+// currency, which it checks with ValidateMinor.
+type MoneyValidator struct {
+	Currency      string `param:"currency"`
+	AllowNegative bool   `param:"negative,optional"`
+}
+
+func (v *MoneyValidator) Validate(val string) (ok bool, err error) {
+	digits, err := v.digits()
+	if err != nil {
+		return false, err
+	}
+	amount, negative := strings.CutPrefix(val, "-")
+	units, frac, hasPoint := strings.Cut(amount, ".")
+	if units == "" || strings.Trim(units, "0123456789") != "" ||
+		(hasPoint && (frac == "" || strings.Trim(frac, "0123456789") != "")) {
+		return false, failf(ErrInvalidFormat, "value %q is not an amount", val)
+	}
+	if len(frac) > digits {
+		return false, failf(ErrInvalidFormat, "amount %q has more than %d decimal places for %s", val, digits, v.Currency)
+	}
+	if negative && !v.AllowNegative && strings.Trim(units+frac, "0") != "" {
+		return false, failf(ErrOutOfRange, "amount %q is negative", val)
+	}
+	return true, nil
+}
+
+// ValidateMinor checks an amount given in the minor unit of the currency,
+// e.g. cents, which only leaves its sign to check.
+func (v *MoneyValidator) ValidateMinor(amount int64) (ok bool, err error) {
+	if _, err := v.digits(); err != nil {
+		return false, err
+	}
+	if amount < 0 && !v.AllowNegative {
+		return false, failf(ErrOutOfRange, "amount %d is negative", amount)
+	}
+	return true, nil
+}
+
+func (v *MoneyValidator) digits() (int, error) {
+	table, err := currencyDigits()
+	if err != nil {
+		return 0, err
+	}
+	d, ok := table[v.Currency]
+	if !ok {
+		return 0, fmt.Errorf("unknown currency %q", v.Currency)
+	}
+	return d, nil
+}
+
+// configure rejects an unknown currency when the directive is set up.
+func (v *MoneyValidator) configure() error {
+	if v.Currency == "" {
+		return errors.New("currency cannot be empty")
+	}
+	_, err := v.digits()
+	return err
+}
+
+func (v *MoneyValidator) Name() string {
+	return "money"
+}
+
+func (v *MoneyValidator) Handle(val any) error {
+	var (
+		ok  bool
+		err error
+	)
+	switch rv := reflect.ValueOf(val); rv.Kind() {
+	case reflect.String:
+		ok, err = v.Validate(rv.String())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		ok, err = v.ValidateMinor(rv.Int())
+	default:
+		return fmt.Errorf("money: expected a string or an integer amount, got %T", val)
+	}
+	if !ok {
+		return err
+	}
+	return nil
+}
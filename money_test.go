@@ -0,0 +1,94 @@
+package valex
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMoneyValidator(t *testing.T) {
+	provideTable(t, currencyTable, "currencies.txt")
+	tests := []struct {
+		v         MoneyValidator
+		input     string
+		errSubstr string
+	}{
+		{MoneyValidator{Currency: "EUR"}, "19.99", ""},
+		{MoneyValidator{Currency: "EUR"}, "19.9", ""},
+		{MoneyValidator{Currency: "EUR"}, "19", ""},
+		{MoneyValidator{Currency: "EUR"}, "19.999", "more than 2 decimal places for EUR"},
+		{MoneyValidator{Currency: "JPY"}, "1999", ""},
+		{MoneyValidator{Currency: "JPY"}, "19.99", "more than 0 decimal places for JPY"},
+		{MoneyValidator{Currency: "KWD"}, "1.500", ""},
+		{MoneyValidator{Currency: "EUR"}, "-5.00", "is negative"},
+		{MoneyValidator{Currency: "EUR"}, "-0.00", ""},
+		{MoneyValidator{Currency: "EUR", AllowNegative: true}, "-5.00", ""},
+		{MoneyValidator{Currency: "EUR"}, "1,000.00", "not an amount"},
+		{MoneyValidator{Currency: "EUR"}, "5.", "not an amount"},
+		{MoneyValidator{Currency: "EUR"}, "", "not an amount"},
+		{MoneyValidator{Currency: "XYZ"}, "5", `unknown currency "XYZ"`},
+	}
+	for _, tc := range tests {
+		ok, err := tc.v.Validate(tc.input)
+		if tc.errSubstr == "" {
+			if !ok {
+				t.Errorf("%+v(%q): unexpected error: %v", tc.v, tc.input, err)
+			}
+			continue
+		}
+		if ok || !strings.Contains(err.Error(), tc.errSubstr) {
+			t.Errorf("%+v(%q): expected error containing %q, got %v", tc.v, tc.input, tc.errSubstr, err)
+		}
+	}
+}
+
+func TestMoneyValidator_Minor(t *testing.T) {
+	provideTable(t, currencyTable, "currencies.txt")
+	v := &MoneyValidator{Currency: "USD"}
+	if ok, err := v.ValidateMinor(1999); !ok {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if ok, _ := v.ValidateMinor(-1); ok {
+		t.Error("expected a negative amount to fail")
+	}
+	v.AllowNegative = true
+	if ok, err := v.ValidateMinor(-1); !ok {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestMoneyValidator_Tag(t *testing.T) {
+	provideTable(t, currencyTable, "currencies.txt")
+	type refund struct {
+		Price  string `val:"money,currency=EUR"`
+		Refund string `val:"money,currency=EUR,negative"`
+	}
+	if _, err := ValidateStruct(&refund{Price: "10.00", Refund: "-10.00"}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, err := ValidateStruct(&refund{Price: "-10.00", Refund: "-10.00"}); err == nil {
+		t.Error("expected a negative price to fail")
+	}
+	if _, err := ParseTag("money,currency=EURO"); err == nil || !strings.Contains(err.Error(), "unknown currency") {
+		t.Errorf("expected an unknown currency error, got %v", err)
+	}
+}
+
+func TestMoneyValidator_TagMinor(t *testing.T) {
+	provideTable(t, currencyTable, "currencies.txt")
+	type order struct {
+		Total  int64 `val:"money,currency=EUR"`
+		Refund int   `val:"money,currency=EUR,negative"`
+	}
+	if _, err := ValidateStruct(&order{Total: 1999, Refund: -500}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, err := ValidateStruct(&order{Total: -1999}); err == nil || !strings.Contains(err.Error(), "is negative") {
+		t.Errorf("expected a negative total to fail, got %v", err)
+	}
+	type price struct {
+		Amount float64 `val:"money,currency=EUR"`
+	}
+	if _, err := ValidateStruct(&price{Amount: 19.99}); err == nil || !strings.Contains(err.Error(), "expected a string or an integer") {
+		t.Errorf("expected a float amount to fail, got %v", err)
+	}
+}
@@ -0,0 +1,166 @@
+package valex
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// Newtype makes immutable value objects of T that are known to be valid,
+// such as email addresses or order numbers. Tag, usually an empty struct
+// type, tells newtypes over the same T apart, so their values have
+// distinct types:
+//
+//	type emailAddress struct{}
+//
+//	type EmailAddress = valex.Typed[emailAddress, string]
+//
+//	var EmailAddresses = valex.Define[emailAddress, string]("EmailAddress", &valex.EmailValidator{NoDisplayName: true})
+//
+//	addr, err := EmailAddresses.New(input) // addr is an EmailAddress
+//
+// Values are only made by New and the decoding methods, so holding one that
+// is not IsZero proves validation took place.
+type Newtype[Tag, T any] struct {
+	name string
+	v    Validator[T]
+}
+
+// Define returns the newtype called name, whose values pass v.
+func Define[Tag, T any](name string, v Validator[T]) *Newtype[Tag, T] {
+	return &Newtype[Tag, T]{name: name, v: v}
+}
+
+func (n *Newtype[Tag, T]) Name() string {
+	return n.name
+}
+
+// New returns val as a value of n when it passes validation.
+func (n *Newtype[Tag, T]) New(val T) (Typed[Tag, T], error) {
+	if n.v == nil {
+		return Typed[Tag, T]{}, errors.New("no validator set")
+	}
+	if ok, err := n.v.Validate(val); !ok {
+		return Typed[Tag, T]{}, fmt.Errorf("invalid %s: %w", n.name, err)
+	}
+	return Typed[Tag, T]{nt: n, val: val, valid: true}, nil
+}
+
+// Must is like New but panics if val does not pass validation, for
+// constants and tests.
+func (n *Newtype[Tag, T]) Must(val T) Typed[Tag, T] {
+	t, err := n.New(val)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// Zero returns a value of n holding the zero value of T, which need not pass
+// validation, to decode into. It is not valid until a value is decoded.
+func (n *Newtype[Tag, T]) Zero() Typed[Tag, T] {
+	return Typed[Tag, T]{nt: n}
+}
+
+// ParseJSON decodes data into T and returns it as a value of n when it
+// passes validation.
+func (n *Newtype[Tag, T]) ParseJSON(data []byte) (Typed[Tag, T], error) {
+	var val T
+	if err := json.Unmarshal(data, &val); err != nil {
+		return Typed[Tag, T]{}, fmt.Errorf("invalid %s: %w", n.name, err)
+	}
+	return n.New(val)
+}
+
+// ParseText parses text into T, as ValidatedValue.UnmarshalText does, and
+// returns it as a value of n when it passes validation.
+func (n *Newtype[Tag, T]) ParseText(text []byte) (Typed[Tag, T], error) {
+	val, err := unmarshalText[T](text)
+	if err != nil {
+		return Typed[Tag, T]{}, fmt.Errorf("invalid %s: %w", n.name, err)
+	}
+	return n.New(val)
+}
+
+// Typed is a value of a Newtype. Typed values of comparable types compare
+// with ==, which takes their newtype into account.
+type Typed[Tag, T any] struct {
+	nt    *Newtype[Tag, T]
+	val   T
+	valid bool
+}
+
+func (t Typed[Tag, T]) Get() T {
+	return t.val
+}
+
+// Type returns the newtype of t, or nil for the zero Typed.
+func (t Typed[Tag, T]) Type() *Newtype[Tag, T] {
+	return t.nt
+}
+
+// IsZero reports whether t holds no validated value: it is the zero Typed,
+// or the Zero of its newtype.
+func (t Typed[Tag, T]) IsZero() bool {
+	return !t.valid
+}
+
+func (t Typed[Tag, T]) String() string {
+	return fmt.Sprintf("%v", t.val)
+}
+
+// Equal reports whether t and o are of the same newtype and hold equal
+// values, for values of types that are not comparable with ==.
+func (t Typed[Tag, T]) Equal(o Typed[Tag, T]) bool {
+	return t.nt == o.nt && t.valid == o.valid && reflect.DeepEqual(t.val, o.val)
+}
+
+func (t Typed[Tag, T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.val)
+}
+
+// MarshalText marshals the value as ValidatedValue.MarshalText does.
+func (t Typed[Tag, T]) MarshalText() ([]byte, error) {
+	return marshalText(t.val)
+}
+
+// UnmarshalJSON decodes into a Typed of a known newtype, replacing its
+// value when the decoded one passes validation. The newtype has to be set
+// before decoding, as with ValidatedValue, e.g. by starting from
+// EmailAddresses.Zero(). JSON null leaves t unchanged, as it does for
+// ValidatedValue.
+func (t *Typed[Tag, T]) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	if t.nt == nil {
+		return errors.New("cannot decode into a Typed without a newtype")
+	}
+	v, err := t.nt.ParseJSON(data)
+	if err != nil {
+		return err
+	}
+	*t = v
+	return nil
+}
+
+// UnmarshalText is like UnmarshalJSON for text, as parsed by ParseText.
+func (t *Typed[Tag, T]) UnmarshalText(text []byte) error {
+	if t.nt == nil {
+		return errors.New("cannot decode into a Typed without a newtype")
+	}
+	v, err := t.nt.ParseText(text)
+	if err != nil {
+		return err
+	}
+	*t = v
+	return nil
+}
+
+// CompareTyped orders values of ordered types like cmp.Compare.
+func CompareTyped[Tag any, T cmp.Ordered](a, b Typed[Tag, T]) int {
+	return cmp.Compare(a.val, b.val)
+}
@@ -0,0 +1,149 @@
+package valex
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+)
+
+type (
+	emailTag   struct{}
+	skuTag     struct{}
+	percentTag struct{}
+)
+
+var (
+	testEmail   = Define[emailTag, string]("EmailAddress", &EmailValidator{NoDisplayName: true})
+	testSKU     = Define[skuTag, string]("SKU", &MinLengthValidator{Size: 3})
+	testPercent = Define[percentTag, int]("Percent", &IntRangeValidator{Min: 0, Max: 100})
+)
+
+func TestNewtype(t *testing.T) {
+	addr, err := testEmail.New("jane@example.com")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if addr.Get() != "jane@example.com" || addr.String() != "jane@example.com" || addr.IsZero() {
+		t.Errorf("unexpected value %v", addr)
+	}
+	if addr.Type() != testEmail || addr.Type().Name() != "EmailAddress" {
+		t.Errorf("unexpected type %v", addr.Type())
+	}
+
+	_, err = testEmail.New("Jane <jane@example.com>")
+	if err == nil || !strings.HasPrefix(err.Error(), "invalid EmailAddress: ") {
+		t.Errorf("expected an invalid EmailAddress error, got %v", err)
+	}
+
+	var zero Typed[emailTag, string]
+	if !zero.IsZero() || zero.Type() != nil {
+		t.Error("expected the zero Typed to be zero")
+	}
+
+	if _, err := Define[emailTag, string]("Empty", nil).New("x"); err == nil {
+		t.Error("expected an error for a newtype without a validator")
+	}
+}
+
+func TestNewtype_Comparison(t *testing.T) {
+	a, b := testSKU.Must("abc"), testSKU.Must("abc")
+	if a != b || !a.Equal(b) {
+		t.Error("expected equal values of one newtype to be equal")
+	}
+	// same value, different newtype of the same tag
+	if other := Define[skuTag, string]("Other", &MinLengthValidator{Size: 3}).Must("abc"); a == other || a.Equal(other) {
+		t.Error("expected values of different newtypes to differ")
+	}
+	if a == testSKU.Zero() {
+		t.Error("expected a value to differ from the zero of its newtype")
+	}
+
+	ps := []Typed[percentTag, int]{testPercent.Must(50), testPercent.Must(5), testPercent.Must(100)}
+	slices.SortFunc(ps, CompareTyped[percentTag, int])
+	if ps[0].Get() != 5 || ps[2].Get() != 100 {
+		t.Errorf("unexpected order %v", ps)
+	}
+
+	tags := Define[struct{}, []string]("Tags", ValidatorFunc[[]string](func(s []string) (bool, error) {
+		return true, nil
+	}))
+	if !tags.Must([]string{"a"}).Equal(tags.Must([]string{"a"})) {
+		t.Error("expected Equal to compare slices")
+	}
+}
+
+func TestNewtype_Must(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("expected Must to panic")
+		}
+	}()
+	testPercent.Must(101)
+}
+
+func TestNewtype_JSON(t *testing.T) {
+	type order struct {
+		SKU      Typed[skuTag, string]  `json:"sku"`
+		Discount Typed[percentTag, int] `json:"discount"`
+	}
+	o := order{SKU: testSKU.Must("abc-1"), Discount: testPercent.Must(15)}
+	data, err := json.Marshal(o)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"sku":"abc-1","discount":15}` {
+		t.Errorf("unexpected JSON %s", data)
+	}
+
+	decoded := order{SKU: testSKU.Zero(), Discount: testPercent.Zero()}
+	if !decoded.SKU.IsZero() {
+		t.Error("expected the zero of a newtype to be zero")
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if decoded != o {
+		t.Errorf("expected %v, got %v", o, decoded)
+	}
+
+	decoded = order{SKU: testSKU.Zero(), Discount: testPercent.Zero()}
+	err = json.Unmarshal([]byte(`{"sku":"abc","discount":150}`), &decoded)
+	if err == nil || !strings.Contains(err.Error(), "invalid Percent") {
+		t.Errorf("expected an invalid Percent error, got %v", err)
+	}
+
+	decoded = order{SKU: testSKU.Must("abc"), Discount: testPercent.Zero()}
+	if err := json.Unmarshal([]byte(`{"sku":null}`), &decoded); err != nil || decoded.SKU != testSKU.Must("abc") {
+		t.Errorf("expected null to leave the value unchanged, got %v, %v", decoded.SKU, err)
+	}
+
+	var untyped order
+	if err := json.Unmarshal(data, &untyped); err == nil {
+		t.Error("expected decoding without a newtype to fail")
+	}
+
+	if _, err := testPercent.ParseJSON([]byte(`"ten"`)); err == nil || !strings.HasPrefix(err.Error(), "invalid Percent: ") {
+		t.Errorf("expected a decode error, got %v", err)
+	}
+}
+
+func TestNewtype_Text(t *testing.T) {
+	p := testPercent.Zero()
+	if err := p.UnmarshalText([]byte("15")); err != nil || p != testPercent.Must(15) {
+		t.Errorf("UnmarshalText(15) = %v, %v", p, err)
+	}
+	if text, err := p.MarshalText(); err != nil || string(text) != "15" {
+		t.Errorf("MarshalText() = %q, %v", text, err)
+	}
+	if err := p.UnmarshalText([]byte("150")); err == nil || !strings.Contains(err.Error(), "invalid Percent") {
+		t.Errorf("expected an invalid Percent error, got %v", err)
+	}
+	if err := p.UnmarshalText([]byte("ten")); err == nil || !strings.HasPrefix(err.Error(), "invalid Percent: ") {
+		t.Errorf("expected a parse error, got %v", err)
+	}
+	var untyped Typed[percentTag, int]
+	if err := untyped.UnmarshalText([]byte("15")); err == nil {
+		t.Error("expected decoding without a newtype to fail")
+	}
+}
@@ -0,0 +1,176 @@
+package valex
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+const confusablesTable = "confusables"
+
+func init() {
+	declareTable(confusablesTable)
+}
+
+var normForms = map[string]norm.Form{
+	"NFC":  norm.NFC,
+	"NFD":  norm.NFD,
+	"NFKC": norm.NFKC,
+	"NFKD": norm.NFKD,
+}
+
+// NormalizedValidator accepts values already in the Unicode normalization
+// Form, one of NFC, NFD, NFKC or NFKD, e.g. `val:"normalized=NFC"`, so that
+// equal looking values are stored as equal bytes.
+type NormalizedValidator struct {
+	Form string `param:"normalized"`
+}
+
+func (v *NormalizedValidator) Validate(val string) (ok bool, err error) {
+	f, ok := normForms[v.Form]
+	if !ok {
+		return false, fmt.Errorf("unknown normalization form %q", v.Form)
+	}
+	if !f.IsNormalString(val) {
+		return false, failf(ErrInvalidFormat, "value %q is not in %s", val, v.Form)
+	}
+	return true, nil
+}
+
+// configure rejects an unknown form when the directive is set up.
+func (v *NormalizedValidator) configure() error {
+	if _, ok := normForms[v.Form]; !ok {
+		return fmt.Errorf("unknown normalization form %q", v.Form)
+	}
+	return nil
+}
+
+func (v *NormalizedValidator) Name() string {
+	return "normalized"
+}
+
+func (v *NormalizedValidator) Handle(val string) error {
+	if ok, err := v.Validate(val); !ok {
+		return err
+	}
+	return nil
+}
+
+// EqualNormalized reports whether a and b are equal after NFC normalization,
+// e.g. "é" written as one code point or as "e" and a combining accent.
+func EqualNormalized(a, b string) bool {
+	return norm.NFC.String(a) == norm.NFC.String(b)
+}
+
+func confusables() (map[rune]string, error) {
+	return tableData(confusablesTable, func(lines []string) map[rune]string {
+		m := make(map[rune]string, len(lines))
+		for _, line := range lines {
+			cp, s, _ := strings.Cut(line, " ")
+			if r, err := strconv.ParseUint(cp, 16, 32); err == nil {
+				m[rune(r)] = strings.TrimSpace(s)
+			}
+		}
+		return m
+	})
+}
+
+// Skeleton returns the form of s used to compare look-alike strings: NFKC
+// normalized, with confusable characters replaced by the ASCII characters
+// they resemble and case folded. "pаypal" with a Cyrillic а, "PAYPAL" and
+// "paypa1" all have the skeleton "paypal".
+func Skeleton(s string) (string, error) {
+	table, err := confusables()
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	for _, r := range norm.NFKC.String(s) {
+		if c, ok := table[r]; ok {
+			b.WriteString(c)
+		} else {
+			b.WriteRune(r)
+		}
+	}
+	return foldWord(b.String()), nil
+}
+
+// Confusable reports whether a and b look alike, e.g. to reject a username
+// that spoofs one already taken.
+func Confusable(a, b string) (bool, error) {
+	sa, err := Skeleton(a)
+	if err != nil {
+		return false, err
+	}
+	sb, err := Skeleton(b)
+	if err != nil {
+		return false, err
+	}
+	return sa == sb, nil
+}
+
+// ConfusableValidator rejects values that mix letters of several scripts
+// when one of them is a look-alike, such as a Cyrillic а in a Latin name.
+// With WholeScript it also rejects values written entirely in look-alikes
+// of ASCII, e.g. "рорра" in Cyrillic: `val:"!confusable,wholescript"`.
+type ConfusableValidator struct {
+	WholeScript bool `param:"wholescript,optional"`
+}
+
+func (v *ConfusableValidator) Validate(val string) (ok bool, err error) {
+	table, err := confusables()
+	if err != nil {
+		return false, err
+	}
+	var scripts []string
+	confusable, ascii := false, true
+	for _, r := range norm.NFKC.String(val) {
+		if r > unicode.MaxASCII {
+			_, ok := table[r]
+			confusable = confusable || ok
+			ascii = ascii && ok
+		}
+		if s := scriptOf(r); s != "" && !slices.Contains(scripts, s) {
+			scripts = append(scripts, s)
+		}
+	}
+	if confusable && len(scripts) > 1 {
+		return false, failf(ErrNotAllowed, "value %q mixes %s with look-alike characters", val, strings.Join(scripts, " and "))
+	}
+	if v.WholeScript && confusable && ascii {
+		return false, failf(ErrNotAllowed, "value %q consists of look-alikes of ASCII characters", val)
+	}
+	return true, nil
+}
+
+// scriptOf returns the script of letter r, or "" for other characters and
+// those shared between scripts.
+func scriptOf(r rune) string {
+	if !unicode.IsLetter(r) {
+		return ""
+	}
+	for name, t := range unicode.Scripts {
+		if unicode.Is(t, r) {
+			if name == "Common" || name == "Inherited" {
+				return ""
+			}
+			return name
+		}
+	}
+	return ""
+}
+
+func (v *ConfusableValidator) Name() string {
+	return "!confusable"
+}
+
+func (v *ConfusableValidator) Handle(val string) error {
+	if ok, err := v.Validate(val); !ok {
+		return err
+	}
+	return nil
+}
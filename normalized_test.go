@@ -0,0 +1,115 @@
+package valex
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNormalizedValidator(t *testing.T) {
+	tests := []struct {
+		form      string
+		input     string
+		errSubstr string
+	}{
+		{"NFC", "café", ""},
+		{"NFC", "café", `is not in NFC`},
+		{"NFD", "café", ""},
+		{"NFD", "café", "is not in NFD"},
+		{"NFKC", "ﬁle", "is not in NFKC"},
+		{"NFC", "ﬁle", ""},
+		{"NFKC", "file", ""},
+		{"NFX", "a", `unknown normalization form "NFX"`},
+	}
+	for _, tc := range tests {
+		v := NormalizedValidator{Form: tc.form}
+		ok, err := v.Validate(tc.input)
+		if tc.errSubstr == "" {
+			if !ok {
+				t.Errorf("%s(%q): unexpected error: %v", tc.form, tc.input, err)
+			}
+			continue
+		}
+		if ok || !strings.Contains(err.Error(), tc.errSubstr) {
+			t.Errorf("%s(%q): expected error containing %q, got %v", tc.form, tc.input, tc.errSubstr, err)
+		}
+	}
+}
+
+func TestEqualNormalized(t *testing.T) {
+	if !EqualNormalized("café", "café") {
+		t.Error("expected composed and decomposed forms to be equal")
+	}
+	if EqualNormalized("café", "cafe") {
+		t.Error("expected different letters to differ")
+	}
+}
+
+func TestSkeleton(t *testing.T) {
+	provideTable(t, confusablesTable, "confusables.txt")
+
+	for _, s := range []string{"paypal", "pаypal", "PAYPAL", "paypa1", "ＰａｙＰａｌ", "PayPaI"} {
+		ok, err := Confusable(s, "paypal")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !ok {
+			sk, _ := Skeleton(s)
+			t.Errorf("expected %q to be confusable with paypal, skeleton %q", s, sk)
+		}
+	}
+	if ok, _ := Confusable("paypal", "payday"); ok {
+		t.Error("expected different names not to be confusable")
+	}
+}
+
+func TestConfusableValidator(t *testing.T) {
+	provideTable(t, confusablesTable, "confusables.txt")
+
+	tests := []struct {
+		v         ConfusableValidator
+		input     string
+		errSubstr string
+	}{
+		{ConfusableValidator{}, "alice", ""},
+		{ConfusableValidator{}, "Zoë_42", ""},
+		{ConfusableValidator{}, "алиса", ""},
+		{ConfusableValidator{}, "Ελένη", ""},
+		{ConfusableValidator{}, "аlice", "mixes Cyrillic and Latin"},
+		{ConfusableValidator{}, "pаypal", "mixes Latin and Cyrillic"},
+		{ConfusableValidator{}, "раура", ""},
+		{ConfusableValidator{WholeScript: true}, "раура", "look-alikes of ASCII"},
+		{ConfusableValidator{WholeScript: true}, "алиса", ""},
+	}
+	for _, tc := range tests {
+		ok, err := tc.v.Validate(tc.input)
+		if tc.errSubstr == "" {
+			if !ok {
+				t.Errorf("%q: unexpected error: %v", tc.input, err)
+			}
+			continue
+		}
+		if ok || !strings.Contains(err.Error(), tc.errSubstr) {
+			t.Errorf("%q: expected error containing %q, got %v", tc.input, tc.errSubstr, err)
+		}
+	}
+}
+
+func TestNormalizedValidators_Tags(t *testing.T) {
+	provideTable(t, confusablesTable, "confusables.txt")
+
+	type user struct {
+		Name string `val:"normalized=NFC,!confusable,wholescript"`
+	}
+	if _, err := ValidateStruct(&user{Name: "café"}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, err := ValidateStruct(&user{Name: "café"}); err == nil {
+		t.Error("expected a decomposed name to fail")
+	}
+	if _, err := ValidateStruct(&user{Name: "аdmin"}); err == nil {
+		t.Error("expected a spoofed name to fail")
+	}
+	if _, err := ParseTag("normalized=nfc"); err == nil {
+		t.Error("expected an unknown form to fail")
+	}
+}
@@ -0,0 +1,177 @@
+package valex
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// The validators in this file check numbers transported as strings, such as
+// IDs and amounts in JSON, without rounding them through a float64. Their
+// optional Min and Max bounds are written the same way, e.g.
+// `val:"decimal,precision=10,scale=2,min=0"`.
+
+// IntStringValidator accepts integers of any size with an optional sign.
+type IntStringValidator struct {
+	Min string `param:"min,optional"`
+	Max string `param:"max,optional"`
+}
+
+func (v *IntStringValidator) Validate(val string) (ok bool, err error) {
+	digits := strings.TrimPrefix(strings.TrimPrefix(val, "-"), "+")
+	if digits == "" || strings.Trim(digits, "0123456789") != "" {
+		return false, failf(ErrInvalidFormat, "value %q is not an integer", val)
+	}
+	return checkRatBounds(val, v.Min, v.Max)
+}
+
+func (v *IntStringValidator) configure() error {
+	return configureRatBounds(v.Min, v.Max)
+}
+
+func (v *IntStringValidator) Name() string {
+	return "intstr"
+}
+
+func (v *IntStringValidator) Handle(val string) error {
+	if ok, err := v.Validate(val); !ok {
+		return err
+	}
+	return nil
+}
+
+// FloatStringValidator accepts finite numbers in any notation
+// strconv.ParseFloat understands, e.g. "1.5", "-2e10" or "0x1p-2".
+type FloatStringValidator struct {
+	Min string `param:"min,optional"`
+	Max string `param:"max,optional"`
+}
+
+func (v *FloatStringValidator) Validate(val string) (ok bool, err error) {
+	f, err := strconv.ParseFloat(val, 64)
+	if err != nil || math.IsInf(f, 0) || math.IsNaN(f) {
+		return false, failf(ErrInvalidFormat, "value %q is not a finite number", val)
+	}
+	if min, err := strconv.ParseFloat(v.Min, 64); err == nil && f < min {
+		return false, rangeFailf(ErrOutOfRange, f, min, floatBound(v.Max), "value %q is less than the minimum of %s", val, v.Min)
+	}
+	if max, err := strconv.ParseFloat(v.Max, 64); err == nil && f > max {
+		return false, rangeFailf(ErrOutOfRange, f, floatBound(v.Min), max, "value %q exceeds the maximum of %s", val, v.Max)
+	}
+	return true, nil
+}
+
+// floatBound returns an optional bound for a RangeError: nil when it is not
+// set, a float64 otherwise.
+func floatBound(s string) any {
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		return f
+	}
+	return nil
+}
+
+func (v *FloatStringValidator) configure() error {
+	for _, b := range []string{v.Min, v.Max} {
+		if _, err := strconv.ParseFloat(b, 64); b != "" && err != nil {
+			return fmt.Errorf("bound %q is not a number", b)
+		}
+	}
+	return nil
+}
+
+func (v *FloatStringValidator) Name() string {
+	return "floatstr"
+}
+
+func (v *FloatStringValidator) Handle(val string) error {
+	if ok, err := v.Validate(val); !ok {
+		return err
+	}
+	return nil
+}
+
+// DecimalValidator accepts plain decimal notation, "-12.50" but not "1e3".
+// As with SQL's DECIMAL(precision, scale) a value has at most Scale digits
+// after the point and Precision digits in all. Without Precision only Scale
+// limits the value, when set.
+type DecimalValidator struct {
+	Precision int    `param:"precision,optional"`
+	Scale     int    `param:"scale,optional"`
+	Min       string `param:"min,optional"`
+	Max       string `param:"max,optional"`
+}
+
+func (v *DecimalValidator) Validate(val string) (ok bool, err error) {
+	intPart, frac, _ := strings.Cut(strings.TrimPrefix(strings.TrimPrefix(val, "-"), "+"), ".")
+	if intPart == "" || strings.Trim(intPart, "0123456789") != "" || strings.Trim(frac, "0123456789") != "" ||
+		strings.HasSuffix(val, ".") {
+		return false, failf(ErrInvalidFormat, "value %q is not a decimal number", val)
+	}
+	if (v.Precision > 0 || v.Scale > 0) && len(frac) > v.Scale {
+		return false, failf(ErrInvalidFormat, "value %q has more than %d decimal places", val, v.Scale)
+	}
+	if intDigits := len(strings.TrimLeft(intPart, "0")); v.Precision > 0 && intDigits > v.Precision-v.Scale {
+		return false, failf(ErrOutOfRange, "value %q has more than %d digits before the decimal point", val, v.Precision-v.Scale)
+	}
+	return checkRatBounds(val, v.Min, v.Max)
+}
+
+func (v *DecimalValidator) configure() error {
+	if v.Precision < 0 || v.Scale < 0 {
+		return errors.New("precision and scale cannot be negative")
+	}
+	if v.Precision > 0 && v.Scale > v.Precision {
+		return fmt.Errorf("scale %d exceeds precision %d", v.Scale, v.Precision)
+	}
+	return configureRatBounds(v.Min, v.Max)
+}
+
+func (v *DecimalValidator) Name() string {
+	return "decimal"
+}
+
+func (v *DecimalValidator) Handle(val string) error {
+	if ok, err := v.Validate(val); !ok {
+		return err
+	}
+	return nil
+}
+
+// checkRatBounds checks the number val, already known to be valid, against
+// the optional bounds min and max without losing precision.
+func checkRatBounds(val, min, max string) (bool, error) {
+	if min == "" && max == "" {
+		return true, nil
+	}
+	n, ok := new(big.Rat).SetString(val)
+	if !ok {
+		return false, fmt.Errorf("value %q is not a number", val)
+	}
+	if b, ok := new(big.Rat).SetString(min); ok && n.Cmp(b) < 0 {
+		return false, rangeFailf(ErrOutOfRange, val, optionalBound(min), optionalBound(max), "value %q is less than the minimum of %s", val, min)
+	}
+	if b, ok := new(big.Rat).SetString(max); ok && n.Cmp(b) > 0 {
+		return false, rangeFailf(ErrOutOfRange, val, optionalBound(min), optionalBound(max), "value %q exceeds the maximum of %s", val, max)
+	}
+	return true, nil
+}
+
+// optionalBound returns a bound for a RangeError, nil when it is not set.
+func optionalBound(s string) any {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+func configureRatBounds(bounds ...string) error {
+	for _, b := range bounds {
+		if _, ok := new(big.Rat).SetString(b); b != "" && !ok {
+			return fmt.Errorf("bound %q is not a number", b)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package valex
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNumericStringValidators(t *testing.T) {
+	tests := []struct {
+		name      string
+		v         interface{ Validate(string) (bool, error) }
+		input     string
+		errSubstr string
+	}{
+		{"int", &IntStringValidator{}, "123456789012345678901234567890", ""},
+		{"int signed", &IntStringValidator{}, "-42", ""},
+		{"int fraction", &IntStringValidator{}, "4.2", "not an integer"},
+		{"int empty", &IntStringValidator{}, "-", "not an integer"},
+		{"int min", &IntStringValidator{Min: "0"}, "-1", "less than the minimum of 0"},
+		{"int max", &IntStringValidator{Max: "99999999999999999999"}, "100000000000000000000", "exceeds the maximum"},
+		{"float", &FloatStringValidator{}, "-2.5e10", ""},
+		{"float hex", &FloatStringValidator{}, "0x1p-2", ""},
+		{"float nan", &FloatStringValidator{}, "NaN", "not a finite number"},
+		{"float text", &FloatStringValidator{}, "1,5", "not a finite number"},
+		{"float range", &FloatStringValidator{Min: "0", Max: "1"}, "1.5", "exceeds the maximum of 1"},
+		{"decimal", &DecimalValidator{Precision: 10, Scale: 2}, "-12345678.90", ""},
+		{"decimal scale", &DecimalValidator{Precision: 10, Scale: 2}, "1.234", "more than 2 decimal places"},
+		{"decimal precision", &DecimalValidator{Precision: 4, Scale: 2}, "123.4", "more than 2 digits before the decimal point"},
+		{"decimal leading zeros", &DecimalValidator{Precision: 4, Scale: 2}, "0012.34", ""},
+		{"decimal integer only", &DecimalValidator{Precision: 3}, "1.5", "more than 0 decimal places"},
+		{"decimal unlimited", &DecimalValidator{}, "1.23456789", ""},
+		{"decimal exponent", &DecimalValidator{}, "1e3", "not a decimal number"},
+		{"decimal trailing point", &DecimalValidator{}, "1.", "not a decimal number"},
+		{"decimal min", &DecimalValidator{Scale: 2, Min: "0.01"}, "0.00", "less than the minimum of 0.01"},
+	}
+	for _, tc := range tests {
+		ok, err := tc.v.Validate(tc.input)
+		if tc.errSubstr == "" {
+			if !ok {
+				t.Errorf("%s(%q): unexpected error: %v", tc.name, tc.input, err)
+			}
+			continue
+		}
+		if ok || !strings.Contains(err.Error(), tc.errSubstr) {
+			t.Errorf("%s(%q): expected error containing %q, got %v", tc.name, tc.input, tc.errSubstr, err)
+		}
+	}
+}
+
+func TestNumericStringValidators_Tags(t *testing.T) {
+	type order struct {
+		ID     string `val:"intstr,min=1"`
+		Amount string `val:"decimal,precision=10,scale=2,min=0"`
+		Rate   string `val:"omitempty,floatstr,max=1"`
+	}
+	if _, err := ValidateStruct(&order{ID: "7", Amount: "19.99"}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, err := ValidateStruct(&order{ID: "7", Amount: "-1.00"}); err == nil {
+		t.Error("expected a negative amount to fail")
+	}
+	for _, tag := range []string{"decimal,precision=2,scale=3", "intstr,min=abc", "floatstr,max=x"} {
+		if _, err := ParseTag(tag); err == nil {
+			t.Errorf("ParseTag(%q): expected a configuration error", tag)
+		}
+	}
+}
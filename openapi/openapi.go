@@ -0,0 +1,155 @@
+// Package openapi publishes the constraints of `val` tags as OpenAPI 3.0
+// schema objects.
+package openapi
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/tedla-brandsema/valex"
+)
+
+const refPrefix = "#/components/schemas/"
+
+// Components collects the schemas of struct types for the "components"
+// section of an OpenAPI document. Named struct types are added under their
+// type name and referenced from wherever they are used.
+type Components struct {
+	Engine  *valex.Engine // defaults to valex.Default()
+	schemas map[string]any
+}
+
+// Add adds the schema of v, a struct or a pointer to one, together with the
+// schemas of the named struct types it uses, and returns the schema to use
+// where v appears in an operation.
+func (c *Components) Add(v any, opts ...valex.Option) (map[string]any, error) {
+	e := c.Engine
+	if e == nil {
+		e = valex.Default()
+	}
+	s, err := e.GenerateJSONSchema(v, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if c.schemas == nil {
+		c.schemas = make(map[string]any)
+	}
+
+	t := reflect.TypeOf(v)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	root := ""
+	if t.Name() != "" {
+		root = refPrefix + t.Name()
+	}
+
+	if defs, ok := s["$defs"].(map[string]any); ok {
+		for name, def := range defs {
+			c.schemas[name] = convert(def, root)
+		}
+	}
+	delete(s, "$defs")
+	delete(s, "$schema")
+
+	converted := convert(s, root).(map[string]any)
+	if root == "" {
+		return converted, nil
+	}
+	c.schemas[t.Name()] = converted
+	return map[string]any{"$ref": root}, nil
+}
+
+func (c *Components) Schemas() map[string]any {
+	return c.schemas
+}
+
+// Schema returns the OpenAPI schema of v with the schemas it references.
+func Schema(v any, opts ...valex.Option) (schema map[string]any, components map[string]any, err error) {
+	c := &Components{}
+	schema, err = c.Add(v, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return schema, c.Schemas(), nil
+}
+
+// convert rewrites a JSON Schema 2020-12 value into its OpenAPI 3.0 form.
+// Keywords are only rewritten in schemas: the keys of properties and the
+// like are names, which may well be "type" or "$ref".
+func convert(v any, root string) any {
+	switch t := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(t))
+		for k, val := range t {
+			switch k {
+			case "$ref":
+				out[k] = convertRef(val.(string), root)
+			case "const":
+				out["enum"] = []any{val}
+			case "contentEncoding":
+				if val == "base64" {
+					out["format"] = "byte"
+				}
+			case "contentMediaType":
+				// not part of OpenAPI 3.0
+			case "exclusiveMinimum":
+				out["minimum"] = val
+				out["exclusiveMinimum"] = true
+			case "exclusiveMaximum":
+				out["maximum"] = val
+				out["exclusiveMaximum"] = true
+			case "type":
+				if types, ok := val.([]string); ok {
+					for _, typ := range types {
+						if typ == "null" {
+							out["nullable"] = true
+						} else {
+							out["type"] = typ
+						}
+					}
+					continue
+				}
+				out[k] = val
+			case "properties", "$defs", "patternProperties", "dependentSchemas":
+				out[k] = convertNamed(val, root)
+			case "enum", "default", "examples":
+				out[k] = val // values, not schemas
+			default:
+				out[k] = convert(val, root)
+			}
+		}
+		return out
+	case []any:
+		out := make([]any, len(t))
+		for i, val := range t {
+			out[i] = convert(val, root)
+		}
+		return out
+	}
+	return v
+}
+
+// convertNamed converts the schemas of a map from names to schemas.
+func convertNamed(v any, root string) any {
+	m, ok := v.(map[string]any)
+	if !ok {
+		return convert(v, root)
+	}
+	out := make(map[string]any, len(m))
+	for name, s := range m {
+		out[name] = convert(s, root)
+	}
+	return out
+}
+
+func convertRef(ref, root string) string {
+	if ref == "#" {
+		return root
+	}
+	if name, ok := strings.CutPrefix(ref, "#/$defs/"); ok {
+		return refPrefix + name
+	}
+	panic(fmt.Sprintf("openapi: unexpected reference %q", ref))
+}
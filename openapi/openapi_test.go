@@ -0,0 +1,125 @@
+package openapi
+
+import (
+	"reflect"
+	"testing"
+)
+
+type address struct {
+	Zip string `json:"zip" val:"len,min=6,max=6"`
+}
+
+type account struct {
+	Owner string `json:"owner" val:"email"`
+	Data  string `json:"data" val:"json"`
+}
+
+func TestSchema(t *testing.T) {
+	ref, components, err := Schema(account{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := map[string]any{"$ref": "#/components/schemas/account"}; !reflect.DeepEqual(ref, want) {
+		t.Errorf("expected %v, got %v", want, ref)
+	}
+
+	want := map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"owner": map[string]any{"type": "string", "format": "email"},
+			"data":  map[string]any{"type": "string"},
+		},
+	}
+	if got := components["account"]; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestComponents_Add(t *testing.T) {
+	type invalid struct {
+		Tags []string `val:"foo"`
+	}
+
+	c := &Components{}
+	if _, err := c.Add(invalid{}); err == nil {
+		t.Fatalf("expected error for unknown directive")
+	}
+
+	s, err := c.Add(struct {
+		Name string `json:"name" val:"min,size=2"`
+	}{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := s["$ref"]; ok {
+		t.Errorf("expected anonymous struct to be inlined, got %v", s)
+	}
+
+	if len(c.Schemas()) != 0 {
+		t.Errorf("expected no components, got %v", c.Schemas())
+	}
+}
+
+func TestComponents_References(t *testing.T) {
+	type profile struct {
+		Name    string   `json:"name" val:"!empty"`
+		Age     int      `json:"age" val:"range,min=0,max=130"`
+		Avatar  []byte   `json:"avatar"`
+		Address address  `json:"address"`
+		Parent  *profile `json:"parent,omitempty"`
+	}
+
+	_, components, err := Schema(&profile{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	p := components["profile"].(map[string]any)
+	props := p["properties"].(map[string]any)
+
+	tests := []struct {
+		prop string
+		want map[string]any
+	}{
+		{"age", map[string]any{"type": "integer", "minimum": 0, "maximum": 130}},
+		{"avatar", map[string]any{"type": "string", "format": "byte"}},
+		{"address", map[string]any{"$ref": "#/components/schemas/address"}},
+		{"parent", map[string]any{"$ref": "#/components/schemas/profile"}},
+	}
+	for _, tc := range tests {
+		if got := props[tc.prop]; !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("property %q: expected %v, got %v", tc.prop, tc.want, got)
+		}
+	}
+	if _, ok := components["address"]; !ok {
+		t.Errorf("expected address to be added to the components")
+	}
+	if !reflect.DeepEqual(p["required"], []string{"name"}) {
+		t.Errorf("expected required [name], got %v", p["required"])
+	}
+}
+
+func TestComponents_KeywordNames(t *testing.T) {
+	type keywords struct {
+		Type    address `json:"type"`
+		Const   address `json:"const"`
+		Ref     string  `json:"$ref" val:"email"`
+		Content string  `json:"contentMediaType"`
+	}
+
+	_, components, err := Schema(keywords{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	props := components["keywords"].(map[string]any)["properties"].(map[string]any)
+
+	want := map[string]any{
+		"type":             map[string]any{"$ref": "#/components/schemas/address"},
+		"const":            map[string]any{"$ref": "#/components/schemas/address"},
+		"$ref":             map[string]any{"type": "string", "format": "email"},
+		"contentMediaType": map[string]any{"type": "string"},
+	}
+	if !reflect.DeepEqual(props, want) {
+		t.Errorf("expected %v, got %v", want, props)
+	}
+}
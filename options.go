@@ -0,0 +1,120 @@
+package valex
+
+type options struct {
+	tenant     string
+	profile    string
+	collectAll bool
+	keepGoing  bool
+	paths      []FieldPath
+	groups     []string
+	keyTag     string
+	keyFold    bool
+
+	correlationID string
+	maxBytes      int64
+	unknownFields bool
+	concurrency   int
+	report        bool // set by ValidateStructReport
+	strict        bool
+}
+
+type Option func(*options)
+
+func WithTenant(name string) Option {
+	return func(o *options) {
+		o.tenant = name
+	}
+}
+
+// WithProfile validates with the engine registered under name by
+// NewProfile instead of the engine the call is made on.
+func WithProfile(name string) Option {
+	return func(o *options) {
+		o.profile = name
+	}
+}
+
+// WithCollectAll makes validation continue past the first failing field and
+// report every failure as ValidationErrors.
+func WithCollectAll() Option {
+	return func(o *options) {
+		o.collectAll = true
+	}
+}
+
+// WithKeepGoing runs every directive of a field even after one of them
+// failed, as if its tag started with keepgoing. Without WithCollectAll,
+// validation still stops after the first failing field, and its failures are
+// returned as ValidationErrors when there is more than one.
+func WithKeepGoing() Option {
+	return func(o *options) {
+		o.keepGoing = true
+	}
+}
+
+// WithPaths restricts validation to the given paths and everything below
+// them, e.g. to validate a partial update. Fields on the way to a path are
+// descended into without running their own directives.
+func WithPaths(paths ...FieldPath) Option {
+	return func(o *options) {
+		o.paths = append(o.paths, paths...)
+	}
+}
+
+// WithKeyTag sets the struct tag Coerce reads source keys from, e.g. "env"
+// or "query". It defaults to "form".
+func WithKeyTag(tag string) Option {
+	return func(o *options) {
+		o.keyTag = tag
+	}
+}
+
+// WithCorrelationID sets the ID ValidateAsync reports its verdict under,
+// e.g. a request ID. By default a random ID is generated.
+func WithCorrelationID(id string) Option {
+	return func(o *options) {
+		o.correlationID = id
+	}
+}
+
+// WithKeyFold makes Coerce match source keys case-insensitively, e.g. for
+// HTTP headers.
+func WithKeyFold() Option {
+	return func(o *options) {
+		o.keyFold = true
+	}
+}
+
+// WithMaxBytes limits how much DecodeAndValidate reads. A negative n
+// disables the limit.
+func WithMaxBytes(n int64) Option {
+	return func(o *options) {
+		o.maxBytes = n
+	}
+}
+
+// WithUnknownFields makes DecodeAndValidate ignore JSON object keys that
+// match no field instead of rejecting them.
+func WithUnknownFields() Option {
+	return func(o *options) {
+		o.unknownFields = true
+	}
+}
+
+// WithConcurrency validates the fields of a struct, and the elements of
+// collections it dives into, on up to n goroutines. Errors are reported in
+// the same order as without it. It pays off for large payloads and slow
+// directives; directives must be safe for concurrent use.
+func WithConcurrency(n int) Option {
+	return func(o *options) {
+		o.concurrency = n
+	}
+}
+
+func newOptions(opts []Option) options {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
@@ -0,0 +1,73 @@
+// Package otelvalex exports valex metrics through OpenTelemetry:
+//
+//	r, err := otelvalex.New(otel.GetMeterProvider())
+//	if err != nil {
+//		return err
+//	}
+//	valex.SetRecorder(r)
+package otelvalex
+
+import (
+	"context"
+	"time"
+
+	"github.com/tedla-brandsema/valex"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const scope = "github.com/tedla-brandsema/valex/otelvalex"
+
+// Recorder implements valex.Recorder with a counter of validations by
+// struct type and result, a counter of failures by struct type and
+// directive, and a histogram of validation latency by struct type. The
+// result is "ok" or the valex.ErrorCategory of the error.
+type Recorder struct {
+	validations metric.Int64Counter
+	failures    metric.Int64Counter
+	duration    metric.Float64Histogram
+}
+
+// New creates a Recorder with instruments from a meter of mp.
+func New(mp metric.MeterProvider) (*Recorder, error) {
+	m := mp.Meter(scope)
+	var (
+		r   Recorder
+		err error
+	)
+	if r.validations, err = m.Int64Counter("valex.validations",
+		metric.WithDescription("Struct validations by type and result."),
+		metric.WithUnit("{validation}")); err != nil {
+		return nil, err
+	}
+	if r.failures, err = m.Int64Counter("valex.directive.failures",
+		metric.WithDescription("Failed directives by type and directive."),
+		metric.WithUnit("{failure}")); err != nil {
+		return nil, err
+	}
+	if r.duration, err = m.Float64Histogram("valex.validation.duration",
+		metric.WithDescription("Latency of struct validations by type."),
+		metric.WithUnit("s")); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+func (r *Recorder) RecordValidation(ctx context.Context, typ string, took time.Duration, err error) {
+	result := "ok"
+	if err != nil {
+		result = valex.Categorize(err).String()
+	}
+	r.validations.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("type", typ),
+		attribute.String("result", result),
+	))
+	r.duration.Record(ctx, took.Seconds(), metric.WithAttributes(attribute.String("type", typ)))
+}
+
+func (r *Recorder) RecordFailure(ctx context.Context, typ, directive string) {
+	r.failures.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("type", typ),
+		attribute.String("directive", directive),
+	))
+}
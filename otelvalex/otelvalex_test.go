@@ -0,0 +1,70 @@
+package otelvalex
+
+import (
+	"context"
+	"testing"
+
+	"github.com/tedla-brandsema/valex"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+type user struct {
+	Name string `val:"min,size=3"`
+	Code string `val:"alphanum"`
+}
+
+func TestRecorder(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	r, err := New(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	e := valex.NewEngine()
+	e.SetRecorder(r)
+
+	e.ValidateStruct(user{Name: "John", Code: "a1"})
+	e.ValidateStruct(user{Name: "Jo", Code: "a 1"}, valex.WithCollectAll())
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	sums := make(map[string]int64)
+	var histograms uint64
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			switch data := m.Data.(type) {
+			case metricdata.Sum[int64]:
+				for _, dp := range data.DataPoints {
+					sums[m.Name+" "+label(dp.Attributes, "result")+label(dp.Attributes, "directive")] += dp.Value
+				}
+			case metricdata.Histogram[float64]:
+				for _, dp := range data.DataPoints {
+					histograms += dp.Count
+				}
+			}
+		}
+	}
+
+	want := map[string]int64{
+		"valex.validations ok":              1,
+		"valex.validations validation":      1,
+		"valex.directive.failures min":      1,
+		"valex.directive.failures alphanum": 1,
+	}
+	for k, v := range want {
+		if sums[k] != v {
+			t.Errorf("expected %s to be %d, got %d", k, v, sums[k])
+		}
+	}
+	if histograms != 2 {
+		t.Errorf("expected 2 latency observations, got %d", histograms)
+	}
+}
+
+func label(set attribute.Set, key attribute.Key) string {
+	v, _ := set.Value(key)
+	return v.AsString()
+}
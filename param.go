@@ -0,0 +1,375 @@
+package valex
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const paramTagKey = "param"
+
+type param struct {
+	key      string
+	field    int
+	optional bool
+	kind     reflect.Kind
+	typ      reflect.Type
+}
+
+type params []param
+
+func (ps params) lookup(key string) (param, bool) {
+	for _, p := range ps {
+		if p.key == key {
+			return p, true
+		}
+	}
+	return param{}, false
+}
+
+func paramsOf(d any) params {
+	var ps params
+
+	t := reflect.TypeOf(d)
+	if t.Kind() != reflect.Ptr || t.Elem().Kind() != reflect.Struct {
+		return ps
+	}
+	t = t.Elem()
+
+	for n := 0; n < t.NumField(); n++ {
+		field := t.Field(n)
+		tagValue, ok := field.Tag.Lookup(paramTagKey)
+		if !ok {
+			continue
+		}
+		key, opts, _ := strings.Cut(tagValue, ",")
+		key = strings.TrimSpace(key)
+		ps = append(ps, param{
+			key:      key,
+			field:    n,
+			optional: strings.TrimSpace(opts) == "optional",
+			kind:     field.Type.Kind(),
+			typ:      field.Type,
+		})
+	}
+	return ps
+}
+
+func processParams(d any, ps params, args map[string]string) error {
+	val := reflect.ValueOf(d)
+	if val.Kind() != reflect.Ptr || val.Elem().Kind() != reflect.Struct {
+		if len(args) > 0 {
+			return fmt.Errorf("expected a pointer to a struct but got %T", d)
+		}
+		return nil
+	}
+	val = val.Elem() // struct
+
+	for _, p := range ps {
+		raw, ok := args[p.key]
+		if !ok {
+			if p.optional {
+				continue
+			}
+			return fmt.Errorf("%q parameter not set", p.key)
+		}
+		field := val.Type().Field(p.field)
+		if err := setVal(val.Field(p.field), raw, field.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func setVal(fieldVal reflect.Value, rawVal string, fieldName string) error {
+	if !fieldVal.CanSet() {
+		return fmt.Errorf("cannot set field %q", fieldName)
+	}
+	if fieldVal.Kind() == reflect.Slice {
+		return setSlice(fieldVal, rawVal, fieldName)
+	}
+	conv, ok := converterFor(fieldVal.Type())
+	if !ok {
+		return fmt.Errorf("%q of type %s is unsupported", fieldName, fieldVal.Kind())
+	}
+	s, err := unquoteParam(rawVal)
+	if err != nil {
+		return err
+	}
+	return conv(fieldVal, s)
+}
+
+// setSlice fills a slice parameter from a space separated list of values,
+// which may be quoted to hold spaces or commas.
+func setSlice(fieldVal reflect.Value, rawVal string, fieldName string) error {
+	conv, ok := converterFor(fieldVal.Type().Elem())
+	if !ok {
+		return fmt.Errorf("%q of type %s is unsupported", fieldName, fieldVal.Type())
+	}
+	items := splitQuoted(rawVal, func(c byte) bool { return c == ' ' || c == '\t' })
+	slice := reflect.MakeSlice(fieldVal.Type(), 0, len(items))
+	for _, item := range items {
+		if item == "" {
+			continue
+		}
+		s, err := unquoteParam(item)
+		if err != nil {
+			return err
+		}
+		slice = reflect.Append(slice, reflect.New(fieldVal.Type().Elem()).Elem())
+		if err := conv(slice.Index(slice.Len()-1), s); err != nil {
+			return err
+		}
+	}
+	fieldVal.Set(slice)
+	return nil
+}
+
+type converter func(reflect.Value, string) error
+
+const convMsg = "unable to convert value %q to %s"
+
+var byteSizeType = reflect.TypeFor[ByteSize]()
+
+func converterFor(t reflect.Type) (converter, bool) {
+	switch t {
+	case durationType:
+		return convertDuration, true
+	case byteSizeType:
+		return convertByteSize, true
+	}
+	conv, ok := converters[t.Kind()]
+	return conv, ok
+}
+
+func convertDuration(v reflect.Value, s string) error {
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return fmt.Errorf(convMsg, s, "duration")
+	}
+	v.SetInt(int64(d))
+	return nil
+}
+
+func convertByteSize(v reflect.Value, s string) error {
+	b, err := ParseByteSize(s)
+	if err != nil {
+		return err
+	}
+	v.SetInt(int64(b))
+	return nil
+}
+
+// intBase returns the base of an integer parameter: 10, unless it has a
+// 0x, 0o or 0b prefix, e.g. `mask=0x1F`. A leading 0 alone does not make it
+// octal.
+func intBase(s string) int {
+	s = strings.TrimLeft(s, "+-")
+	if len(s) > 2 && s[0] == '0' && strings.ContainsRune("xXoObB", rune(s[1])) {
+		return 0
+	}
+	return 10
+}
+
+func convertInt(bits int) converter {
+	return func(v reflect.Value, s string) error {
+		i, err := strconv.ParseInt(s, intBase(s), bits)
+		if err != nil {
+			return fmt.Errorf(convMsg, s, v.Type())
+		}
+		v.SetInt(i)
+		return nil
+	}
+}
+
+func convertUint(bits int) converter {
+	return func(v reflect.Value, s string) error {
+		u, err := strconv.ParseUint(s, intBase(s), bits)
+		if err != nil {
+			return fmt.Errorf(convMsg, s, v.Type())
+		}
+		v.SetUint(u)
+		return nil
+	}
+}
+
+func convertFloat(bits int) converter {
+	return func(v reflect.Value, s string) error {
+		f, err := strconv.ParseFloat(s, bits)
+		if err != nil {
+			return fmt.Errorf(convMsg, s, v.Type())
+		}
+		v.SetFloat(f)
+		return nil
+	}
+}
+
+var converters = map[reflect.Kind]converter{
+	reflect.String: func(v reflect.Value, s string) error {
+		v.SetString(s)
+		return nil
+	},
+	reflect.Int:     convertInt(strconv.IntSize),
+	reflect.Int8:    convertInt(8),
+	reflect.Int16:   convertInt(16),
+	reflect.Int32:   convertInt(32),
+	reflect.Int64:   convertInt(64),
+	reflect.Uint:    convertUint(strconv.IntSize),
+	reflect.Uint8:   convertUint(8),
+	reflect.Uint16:  convertUint(16),
+	reflect.Uint32:  convertUint(32),
+	reflect.Uint64:  convertUint(64),
+	reflect.Float32: convertFloat(32),
+	reflect.Float64: convertFloat(64),
+	reflect.Bool: func(v reflect.Value, s string) error {
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return fmt.Errorf(convMsg, s, "bool")
+		}
+		v.SetBool(b)
+		return nil
+	},
+}
+
+// ByteSize is a size in bytes. As a directive parameter it takes an integer
+// with an optional unit, e.g. `max=2MiB`, see ParseByteSize.
+type ByteSize int64
+
+var byteUnits = map[string]float64{
+	"":    1,
+	"b":   1,
+	"kb":  1e3,
+	"mb":  1e6,
+	"gb":  1e9,
+	"tb":  1e12,
+	"kib": 1 << 10,
+	"mib": 1 << 20,
+	"gib": 1 << 30,
+	"tib": 1 << 40,
+}
+
+// ParseByteSize parses a size such as "512", "64KB" or "1.5GiB". KB, MB, GB
+// and TB are powers of 1000, KiB, MiB, GiB and TiB powers of 1024. Units are
+// case insensitive.
+func ParseByteSize(s string) (ByteSize, error) {
+	s = strings.TrimSpace(s)
+	end := 0
+	for end < len(s) && (s[end] >= '0' && s[end] <= '9' || s[end] == '.') {
+		end++
+	}
+	unit, ok := byteUnits[strings.ToLower(strings.TrimSpace(s[end:]))]
+	if end == 0 || !ok {
+		return 0, fmt.Errorf(convMsg, s, "byte size")
+	}
+	n, err := strconv.ParseFloat(s[:end], 64)
+	if err != nil {
+		return 0, fmt.Errorf(convMsg, s, "byte size")
+	}
+	size := math.Round(n * unit)
+	if size >= math.MaxInt64 {
+		return 0, fmt.Errorf("byte size %q is out of range", s)
+	}
+	return ByteSize(size), nil
+}
+
+// splitTag splits a tag value on commas outside quoted strings.
+func splitTag(s string) []string {
+	return splitQuoted(s, func(c byte) bool { return c == ',' })
+}
+
+// splitQuoted splits s at each separator outside a quoted string. A single or
+// double quote starts a quoted string when it opens a value, i.e. follows a
+// separator, "=" or whitespace; a quote inside a word, as in "O'Brien", is
+// an ordinary character. Quotes are kept, see unquoteParam.
+func splitQuoted(s string, sep func(byte) bool) []string {
+	var parts []string
+	start := 0
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case sep(c):
+			parts = append(parts, s[start:i])
+			start = i + 1
+		case (c == '"' || c == '\'') && opensValue(s, start, i):
+			i = quoteEnd(s, i)
+		}
+	}
+	return append(parts, s[start:])
+}
+
+func opensValue(s string, start, i int) bool {
+	if i == start {
+		return true
+	}
+	switch s[i-1] {
+	case '=', ' ', '\t', '(':
+		return true
+	}
+	return false
+}
+
+// quoteEnd returns the index of the quote closing the string opened at i, or
+// the last index of s when it is unterminated.
+func quoteEnd(s string, i int) int {
+	q := s[i]
+	for i++; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case q:
+			return i
+		}
+	}
+	return len(s) - 1
+}
+
+// unquoteParam returns the contents of a parameter value that is a single
+// quoted string, interpreting Go escape sequences, and any other value as
+// is. Single quoted strings may hold any number of characters.
+func unquoteParam(s string) (string, error) {
+	if s == "" || (s[0] != '"' && s[0] != '\'') {
+		return s, nil
+	}
+	end := quoteEnd(s, 0)
+	if end == 0 || s[end] != s[0] {
+		return "", fmt.Errorf("unterminated string %s", s)
+	}
+	if end != len(s)-1 {
+		return s, nil // e.g. an expression starting with a string
+	}
+	if s[0] == '\'' {
+		s = doubleQuote(s[1:end])
+	}
+	u, err := strconv.Unquote(s)
+	if err != nil {
+		return "", errors.New("invalid escape in string " + s)
+	}
+	return u, nil
+}
+
+// doubleQuote turns the body of a single quoted string into a double quoted
+// one for strconv.Unquote.
+func doubleQuote(body string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for i := 0; i < len(body); i++ {
+		switch c := body[i]; {
+		case c == '\\' && i+1 < len(body) && body[i+1] == '\'':
+			b.WriteByte('\'')
+			i++
+		case c == '\\' && i+1 < len(body):
+			b.WriteString(body[i : i+2])
+			i++
+		case c == '"':
+			b.WriteString(`\"`)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
@@ -0,0 +1,158 @@
+package valex
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type dummyParams struct {
+	Name    string   `param:"name"`
+	Age     int      `param:"age"`
+	Score   float64  `param:"score,optional"`
+	Active  bool     `param:"active,optional"`
+	Aliases []string `param:"aliases,optional"`
+}
+
+func TestProcessParams_Success(t *testing.T) {
+	d := &dummyParams{}
+	args := map[string]string{
+		"name":    "Alice",
+		"age":     "30",
+		"score":   "95.5",
+		"active":  "true",
+		"aliases": "al  ali",
+	}
+	if err := processParams(d, paramsOf(d), args); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if d.Name != "Alice" || d.Age != 30 || d.Score != 95.5 || !d.Active {
+		t.Errorf("unexpected params: %+v", *d)
+	}
+	if len(d.Aliases) != 2 || d.Aliases[0] != "al" || d.Aliases[1] != "ali" {
+		t.Errorf("expected aliases [al ali], got %v", d.Aliases)
+	}
+}
+
+func TestProcessParams_Optional(t *testing.T) {
+	d := &dummyParams{}
+	if err := processParams(d, paramsOf(d), map[string]string{"name": "Bob", "age": "4"}); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if d.Score != 0 || d.Active || d.Aliases != nil {
+		t.Errorf("expected optional params to keep their zero value, got %+v", *d)
+	}
+}
+
+func TestProcessParams_Failure(t *testing.T) {
+	tests := []struct {
+		args      map[string]string
+		errSubstr string
+	}{
+		{map[string]string{"name": "Bob"}, `"age" parameter not set`},
+		{map[string]string{"name": "Bob", "age": "old"}, `unable to convert value "old" to int`},
+		{map[string]string{"name": "Bob", "age": "4", "score": "high"}, `unable to convert value "high" to float64`},
+		{map[string]string{"name": "Bob", "age": "4", "active": "yes"}, `unable to convert value "yes" to bool`},
+	}
+	for _, tc := range tests {
+		d := &dummyParams{}
+		err := processParams(d, paramsOf(d), tc.args)
+		if err == nil || !strings.Contains(err.Error(), tc.errSubstr) {
+			t.Errorf("processParams(%v): expected error containing %q, got %v", tc.args, tc.errSubstr, err)
+		}
+	}
+}
+
+type richParams struct {
+	Timeout time.Duration `param:"timeout,optional"`
+	Size    ByteSize      `param:"size,optional"`
+	Ratio   float32       `param:"ratio,optional"`
+	Port    uint16        `param:"port,optional"`
+	Label   string        `param:"label,optional"`
+	Words   []string      `param:"words,optional"`
+	Limits  []ByteSize    `param:"limits,optional"`
+}
+
+func TestProcessParams_Types(t *testing.T) {
+	tests := []struct {
+		args      map[string]string
+		want      richParams
+		errSubstr string
+	}{
+		{args: map[string]string{"timeout": "5m"}, want: richParams{Timeout: 5 * time.Minute}},
+		{args: map[string]string{"size": "2MiB"}, want: richParams{Size: 2 << 20}},
+		{args: map[string]string{"ratio": "0.25", "port": "8080"}, want: richParams{Ratio: 0.25, Port: 8080}},
+		{args: map[string]string{"port": "0x1F"}, want: richParams{Port: 31}},
+		{args: map[string]string{"port": "0b101"}, want: richParams{Port: 5}},
+		{args: map[string]string{"port": "010"}, want: richParams{Port: 10}},
+		{args: map[string]string{"label": `"a, \"b\"\n"`}, want: richParams{Label: "a, \"b\"\n"}},
+		{args: map[string]string{"label": `'it\'s'`}, want: richParams{Label: "it's"}},
+		{args: map[string]string{"label": `O'Brien`}, want: richParams{Label: "O'Brien"}},
+		{args: map[string]string{"words": `one 'two three' "four"`}, want: richParams{Words: []string{"one", "two three", "four"}}},
+		{args: map[string]string{"limits": "1KB 1KiB"}, want: richParams{Limits: []ByteSize{1000, 1024}}},
+		{args: map[string]string{"timeout": "5"}, errSubstr: `unable to convert value "5" to duration`},
+		{args: map[string]string{"size": "2MB/s"}, errSubstr: "to byte size"},
+		{args: map[string]string{"port": "70000"}, errSubstr: "to uint16"},
+		{args: map[string]string{"label": `"open`}, errSubstr: "unterminated string"},
+		{args: map[string]string{"label": `"\q"`}, errSubstr: "invalid escape"},
+	}
+	for _, tc := range tests {
+		var d richParams
+		err := processParams(&d, paramsOf(&d), tc.args)
+		if tc.errSubstr != "" {
+			if err == nil || !strings.Contains(err.Error(), tc.errSubstr) {
+				t.Errorf("processParams(%v): expected error containing %q, got %v", tc.args, tc.errSubstr, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("processParams(%v): unexpected error: %v", tc.args, err)
+			continue
+		}
+		if !reflect.DeepEqual(d, tc.want) {
+			t.Errorf("processParams(%v): expected %+v, got %+v", tc.args, tc.want, d)
+		}
+	}
+}
+
+func TestParseByteSize(t *testing.T) {
+	tests := []struct {
+		in   string
+		want ByteSize
+		ok   bool
+	}{
+		{"512", 512, true},
+		{"512B", 512, true},
+		{"64kb", 64000, true},
+		{"1.5GiB", 3 << 29, true},
+		{"2 TB", 2e12, true},
+		{"", 0, false},
+		{"MiB", 0, false},
+		{"1.2.3MB", 0, false},
+		{"10XB", 0, false},
+		{"9999999TiB", 0, false},
+	}
+	for _, tc := range tests {
+		got, err := ParseByteSize(tc.in)
+		if (err == nil) != tc.ok || got != tc.want {
+			t.Errorf("ParseByteSize(%q) = %d, %v; expected %d, ok %v", tc.in, got, err, tc.want, tc.ok)
+		}
+	}
+}
+
+func TestQuotedTagParams(t *testing.T) {
+	type form struct {
+		Code string `val:"regex='^[a-z]{2,4}$'"`
+		Name string `val:"expr=\"value != 'a,b'\""`
+	}
+	if _, err := ValidateStruct(&form{Code: "abc", Name: "x"}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, err := ValidateStruct(&form{Code: "abcde", Name: "x"}); err == nil {
+		t.Error("expected the quoted pattern to be applied")
+	}
+	if _, err := ValidateStruct(&form{Code: "abc", Name: "a,b"}); err == nil {
+		t.Error("expected the quoted expression to be applied")
+	}
+}
@@ -0,0 +1,115 @@
+package valex
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ValidatePatch validates only the fields touched by a JSON merge patch
+// (RFC 7386). data is the value after the patch has been applied; fields the
+// patch leaves alone are not validated.
+func (e *Engine) ValidatePatch(data any, patch []byte, opts ...Option) (bool, error) {
+	paths, err := PatchPaths(data, patch)
+	if err != nil {
+		return false, err
+	}
+	if len(paths) == 0 {
+		return true, nil
+	}
+	return e.ValidateStruct(data, append(opts, WithPaths(paths...))...)
+}
+
+func ValidatePatch(data any, patch []byte, opts ...Option) (bool, error) {
+	return std.ValidatePatch(data, patch, opts...)
+}
+
+// PatchPaths maps the members of a JSON merge patch onto the fields of v's
+// struct type, matching keys against json tags the way encoding/json does.
+// Nested objects yield paths to the nested members; unknown keys are ignored.
+func PatchPaths(v any, patch []byte) ([]FieldPath, error) {
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("expected a struct but got %T", v)
+	}
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(patch, &obj); err != nil {
+		return nil, fmt.Errorf("invalid merge patch: %w", err)
+	}
+	return patchPaths(t, obj, nil), nil
+}
+
+func patchPaths(t reflect.Type, obj map[string]json.RawMessage, prefix FieldPath) []FieldPath {
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var paths []FieldPath
+	for _, k := range keys {
+		path, ft, ok := jsonField(t, k, prefix)
+		if !ok {
+			continue
+		}
+		for ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		if ft.Kind() == reflect.Struct && bytes.HasPrefix(bytes.TrimSpace(obj[k]), []byte("{")) {
+			var nested map[string]json.RawMessage
+			if err := json.Unmarshal(obj[k], &nested); err == nil {
+				paths = append(paths, patchPaths(ft, nested, path)...)
+				continue
+			}
+		}
+		paths = append(paths, path)
+	}
+	return paths
+}
+
+// jsonField finds the field that the JSON member key decodes into, looking
+// through embedded structs without json names.
+func jsonField(t reflect.Type, key string, prefix FieldPath) (FieldPath, reflect.Type, bool) {
+	var fold *reflect.StructField
+	for n := 0; n < t.NumField(); n++ {
+		field := t.Field(n)
+		name, skip := jsonFieldName(field)
+		if skip {
+			continue
+		}
+		if field.Anonymous && name == "" {
+			et := field.Type
+			if et.Kind() == reflect.Ptr {
+				et = et.Elem()
+			}
+			if et.Kind() == reflect.Struct {
+				if path, ft, ok := jsonField(et, key, prefix.Field(field.Name)); ok {
+					return path, ft, true
+				}
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if name == key {
+			return prefix.Field(field.Name), field.Type, true
+		}
+		if fold == nil && strings.EqualFold(name, key) {
+			fold = &field
+		}
+	}
+	if fold != nil {
+		return prefix.Field(fold.Name), fold.Type, true
+	}
+	return nil, nil, false
+}
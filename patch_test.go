@@ -0,0 +1,79 @@
+package valex
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type patchBase struct {
+	ID string `json:"id" val:"min,size=3"`
+}
+
+type patchAddress struct {
+	Zip  string `json:"zip" val:"min,size=4"`
+	City string `json:"city" val:"!empty"`
+}
+
+type patchDummy struct {
+	patchBase
+	Name    string        `json:"name" val:"min,size=3"`
+	Email   string        `json:"email" val:"email"`
+	Address *patchAddress `json:"address" val:"dive"`
+	Ignored string        `json:"-" val:"!empty"`
+}
+
+func TestPatchPaths(t *testing.T) {
+	tests := []struct {
+		name  string
+		patch string
+		want  []string
+	}{
+		{name: "top level", patch: `{"name":"x","email":null}`, want: []string{"Email", "Name"}},
+		{name: "case insensitive", patch: `{"NAME":"x"}`, want: []string{"Name"}},
+		{name: "nested", patch: `{"address":{"zip":"1"}}`, want: []string{"Address.Zip"}},
+		{name: "nested null", patch: `{"address":null}`, want: []string{"Address"}},
+		{name: "embedded", patch: `{"id":"x"}`, want: []string{"patchBase.ID"}},
+		{name: "unknown and skipped", patch: `{"nope":1,"Ignored":"x"}`, want: nil},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			paths, err := PatchPaths(&patchDummy{}, []byte(tc.patch))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var got []string
+			for _, p := range paths {
+				got = append(got, p.String())
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+
+	if _, err := PatchPaths(&patchDummy{}, []byte(`[1]`)); err == nil {
+		t.Error("expected an error for a non-object patch")
+	}
+}
+
+func TestValidatePatch(t *testing.T) {
+	// Name and Email are invalid, but only Address.Zip is patched.
+	data := &patchDummy{Address: &patchAddress{Zip: "1234AB"}}
+	if ok, err := ValidatePatch(data, []byte(`{"address":{"zip":"1234AB"}}`)); !ok {
+		t.Errorf("expected untouched fields to be skipped, got %v", err)
+	}
+
+	data.Address.Zip = "12"
+	ok, err := ValidatePatch(data, []byte(`{"address":{"zip":"12"}}`), WithCollectAll())
+	if ok {
+		t.Fatal("expected validation to fail")
+	}
+	var errs ValidationErrors
+	if !errors.As(err, &errs) || len(errs) != 1 {
+		t.Fatalf("expected a single error, got %v", err)
+	}
+	if want := MustParseFieldPath("Address.Zip"); !errs[0].Path.Equal(want) || errs[0].Field != "Address.Zip" {
+		t.Errorf("expected error for %s, got %s", want, errs[0].Field)
+	}
+}
@@ -0,0 +1,61 @@
+package valex
+
+// Stage is one step of a Pipeline. It may transform the value, validate it,
+// or both; the value it returns is passed on to the next stage.
+type Stage[T any] func(val T) (T, error)
+
+// Sanitize turns a Sanitizer into a Stage.
+func Sanitize[T any](s Sanitizer[T]) Stage[T] {
+	return s.Sanitize
+}
+
+// Check turns a Validator into a Stage that passes the value on unchanged.
+func Check[T any](v Validator[T]) Stage[T] {
+	return func(val T) (T, error) {
+		if ok, err := v.Validate(val); !ok {
+			return val, validationFailed(err)
+		}
+		return val, nil
+	}
+}
+
+// Pipeline runs its stages in order and stops at the first error, e.g.
+//
+//	p := Pipe(Sanitize[string](&TrimSanitizer{}), Sanitize[string](&LowerSanitizer{}), Check[string](&EmailValidator{}))
+//	email, err := p.Process("  John@Example.com ")
+type Pipeline[T any] struct {
+	stages []Stage[T]
+}
+
+func Pipe[T any](stages ...Stage[T]) *Pipeline[T] {
+	return &Pipeline[T]{stages: stages}
+}
+
+// Then returns a new pipeline with stages appended to p's.
+func (p *Pipeline[T]) Then(stages ...Stage[T]) *Pipeline[T] {
+	all := make([]Stage[T], 0, len(p.stages)+len(stages))
+	all = append(all, p.stages...)
+	return &Pipeline[T]{stages: append(all, stages...)}
+}
+
+// Process returns the value produced by the last stage. Being a Stage
+// itself, it lets pipelines be nested.
+func (p *Pipeline[T]) Process(val T) (T, error) {
+	for _, stage := range p.stages {
+		var err error
+		if val, err = stage(val); err != nil {
+			var zero T
+			return zero, err
+		}
+	}
+	return val, nil
+}
+
+// Validate runs the pipeline and discards the transformed value, so a
+// Pipeline can be used wherever a Validator is expected.
+func (p *Pipeline[T]) Validate(val T) (ok bool, err error) {
+	if _, err := p.Process(val); err != nil {
+		return false, err
+	}
+	return true, nil
+}
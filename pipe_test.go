@@ -0,0 +1,83 @@
+package valex
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPipe(t *testing.T) {
+	email := Pipe(
+		Sanitize[string](&TrimSanitizer{}),
+		Sanitize[string](&LowerSanitizer{}),
+		Check[string](&EmailValidator{}),
+	)
+	tests := []struct {
+		in        string
+		want      string
+		errSubstr string
+	}{
+		{in: "  John@Example.COM ", want: "john@example.com"},
+		{in: "  not an email ", errSubstr: "mail:"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.in, func(t *testing.T) {
+			got, err := email.Process(tc.in)
+			if tc.errSubstr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.errSubstr) {
+					t.Fatalf("expected error containing %q, got %v", tc.errSubstr, err)
+				}
+				if got != "" {
+					t.Errorf("expected the zero value on error, got %q", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestPipe_StopsAtFirstError(t *testing.T) {
+	var calls int
+	count := Stage[int](func(val int) (int, error) {
+		calls++
+		return val, nil
+	})
+	p := Pipe(count, Check[int](&NonNegativeIntValidator{}), count)
+	if _, err := p.Process(-1); err == nil {
+		t.Fatal("expected an error")
+	}
+	if calls != 1 {
+		t.Errorf("expected stages after the failure to be skipped, got %d calls", calls)
+	}
+}
+
+func TestPipe_CheckWithoutError(t *testing.T) {
+	p := Pipe(Check[int](ValidatorFunc[int](func(int) (bool, error) { return false, nil })))
+	if _, err := p.Process(1); err == nil {
+		t.Error("expected a failing validator without error to fail the pipeline")
+	}
+}
+
+func TestPipeline_ThenAndNesting(t *testing.T) {
+	trim := Pipe(Sanitize[string](&TrimSanitizer{}))
+	upper := trim.Then(Sanitize[string](&UpperSanitizer{}))
+	outer := Pipe(upper.Process, Sanitize[string](&TruncateSanitizer{Size: 3}))
+
+	if got, _ := trim.Process(" ab "); got != "ab" {
+		t.Errorf("expected Then to leave the original pipeline unchanged, got %q", got)
+	}
+	if got, _ := outer.Process(" abcd "); got != "ABC" {
+		t.Errorf("expected %q, got %q", "ABC", got)
+	}
+	// a pipeline is a Validator
+	vv := ValidatedValue[string]{Validator: upper}
+	if err := vv.Set("x"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	var _ Validator[string] = upper
+}
@@ -0,0 +1,100 @@
+package valex
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"sync/atomic"
+)
+
+// Plan validates values of struct type T. It holds the compiled plan of T,
+// so hot paths skip looking it up and checking the type of their argument
+// on every call. A Plan picks up rule and directive changes of its engine.
+type Plan[T any] struct {
+	e     *Engine
+	o     options
+	typ   reflect.Type
+	cache atomic.Pointer[planCache]
+}
+
+type planCache struct {
+	state *ruleState
+	plan  *structPlan
+}
+
+// CompileWith compiles the plan of T, and of the struct types it dives into,
+// on e. Invalid tags are reported here rather than on first use.
+func CompileWith[T any](e *Engine, opts ...Option) (*Plan[T], error) {
+	t := reflect.TypeFor[T]()
+	if t.Kind() != reflect.Struct {
+		return nil, WithCategory(fmt.Errorf("expected a struct type but got %s", t), CategoryConfig)
+	}
+	o := newOptions(opts)
+	e, err := e.forProfile(o)
+	if err != nil {
+		return nil, WithCategory(err, CategoryConfig)
+	}
+	p := &Plan[T]{e: e, o: o, typ: t}
+	// options added on Validate must not write into these
+	p.o.paths, p.o.groups = slices.Clip(p.o.paths), slices.Clip(p.o.groups)
+	if err := e.checkTags(t, p.o); err != nil {
+		return nil, WithCategory(err, CategoryConfig)
+	}
+	return p, nil
+}
+
+func Compile[T any](opts ...Option) (*Plan[T], error) {
+	return CompileWith[T](std, opts...)
+}
+
+func MustCompile[T any](opts ...Option) *Plan[T] {
+	p, err := Compile[T](opts...)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+func (p *Plan[T]) plan() (*structPlan, error) {
+	state := p.e.loadState()
+	if c := p.cache.Load(); c != nil && c.state == state {
+		return c.plan, nil
+	}
+	sp, err := p.e.plan(p.typ, p.o)
+	if err != nil {
+		return nil, err
+	}
+	p.cache.Store(&planCache{state: state, plan: sp})
+	return sp, nil
+}
+
+// Validate works like ValidateStruct with the options the plan was compiled
+// with; opts are added to those.
+func (p *Plan[T]) Validate(v *T, opts ...Option) (bool, error) {
+	return p.ValidateContext(context.Background(), v, opts...)
+}
+
+func (p *Plan[T]) ValidateContext(ctx context.Context, v *T, opts ...Option) (bool, error) {
+	if v == nil {
+		return false, WithCategory(fmt.Errorf("cannot validate a nil %s", p.typ), CategoryConfig)
+	}
+	o := p.o
+	if len(opts) > 0 {
+		o = newOptions(append([]Option{func(o *options) { *o = p.o }}, opts...))
+	}
+	var sp *structPlan
+	var err error
+	if o.tenant == p.o.tenant && o.profile == p.o.profile {
+		sp, err = p.plan()
+	} else {
+		sp, err = p.e.plan(p.typ, o)
+	}
+	if err != nil {
+		return false, WithCategory(err, CategoryConfig)
+	}
+
+	vd := p.e.newValidation(ctx, o, phaseAll)
+	vd.rootPlan(sp, reflect.ValueOf(v).Elem(), nil)
+	return vd.result()
+}
@@ -0,0 +1,125 @@
+package valex
+
+import (
+	"errors"
+	"testing"
+)
+
+type planAddress struct {
+	Zip string `val:"len,min=4,max=6"`
+}
+
+type planUser struct {
+	Name    string      `val:"min,size=3"`
+	Email   string      `val:"email"`
+	Age     int         `val:"range,min=18,max=130"`
+	Address planAddress `val:"dive"`
+	Note    string
+}
+
+type planBroken struct {
+	Inner []struct {
+		A string `val:"nope"`
+	} `val:"dive"`
+}
+
+func TestCompile(t *testing.T) {
+	p, err := Compile[planUser]()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	valid := planUser{Name: "john", Email: "j@b.co", Age: 30, Address: planAddress{Zip: "1234"}}
+	if ok, err := p.Validate(&valid); !ok {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	invalid := planUser{Name: "jo", Email: "j@b.co", Age: 30, Address: planAddress{Zip: "1"}}
+	ok, err := p.Validate(&invalid, WithCollectAll())
+	var errs ValidationErrors
+	if ok || !errors.As(err, &errs) || len(errs) != 2 || errs[1].Field != "Address.Zip" {
+		t.Errorf("got %v, want errors for Name and Address.Zip", err)
+	}
+	_, want := ValidateStruct(invalid, WithCollectAll())
+	if err.Error() != want.Error() {
+		t.Errorf("plan and ValidateStruct disagree: %v vs %v", err, want)
+	}
+
+	if _, err := p.Validate(nil); Categorize(err) != CategoryConfig {
+		t.Errorf("expected a config error for nil, got %v", err)
+	}
+}
+
+func TestCompile_Errors(t *testing.T) {
+	if _, err := Compile[int](); Categorize(err) != CategoryConfig {
+		t.Errorf("expected a config error for a non-struct, got %v", err)
+	}
+	if _, err := Compile[planBroken](); Categorize(err) != CategoryConfig {
+		t.Errorf("expected a config error for an unknown directive, got %v", err)
+	}
+	if _, err := Compile[planUser](WithTenant("nope")); err == nil {
+		t.Error("expected an error for an unknown tenant")
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic")
+		}
+	}()
+	MustCompile[planBroken]()
+}
+
+func TestPlan_FollowsRules(t *testing.T) {
+	e := NewEngine()
+	p, err := CompileWith[planUser](e)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	u := planUser{Name: "john", Email: "j@b.co", Age: 30, Address: planAddress{Zip: "1234"}}
+	if ok, err := p.Validate(&u); !ok {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := e.SetRules(Rules{"valex.planUser": {"Name": "min,size=5"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ok, _ := p.Validate(&u); ok {
+		t.Error("expected the new rule to apply")
+	}
+}
+
+func benchmarkUser() planUser {
+	return planUser{Name: "john", Email: "john@example.com", Age: 30, Address: planAddress{Zip: "1234AB"}}
+}
+
+func BenchmarkValidateStruct(b *testing.B) {
+	u := benchmarkUser()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if ok, err := ValidateStruct(&u); !ok {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkPlan_Validate(b *testing.B) {
+	u := benchmarkUser()
+	p := MustCompile[planUser]()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if ok, err := p.Validate(&u); !ok {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkPlan_ValidateParallel(b *testing.B) {
+	p := MustCompile[planUser]()
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		u := benchmarkUser()
+		for pb.Next() {
+			if ok, err := p.Validate(&u); !ok {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -0,0 +1,332 @@
+package valex
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// playgroundTagKey is the struct tag read by go-playground/validator.
+const playgroundTagKey = "validate"
+
+// SetPlaygroundTags makes e read the `validate` tags of go-playground/validator
+// on fields without a `val` tag, so structs tagged for that package validate
+// without retagging. Tags are translated with TranslatePlaygroundTag, and
+// nested structs are validated whether tagged or not, as that package does.
+// Tags that cannot be translated fail validation with a config error.
+func (e *Engine) SetPlaygroundTags(on bool) {
+	if e.playground.Swap(on) != on {
+		e.resetPlans()
+	}
+}
+
+func SetPlaygroundTags(on bool) {
+	std.SetPlaygroundTags(on)
+}
+
+// playgroundTag returns the `val` tag value equivalent to the `validate` tag
+// of field.
+func playgroundTag(field reflect.StructField) (string, bool, error) {
+	tagValue, ok := field.Tag.Lookup(playgroundTagKey)
+	if tagValue == "-" || !field.IsExported() {
+		return "", false, nil
+	}
+	nested := isNestedStruct(field.Type)
+	if !ok {
+		if nested {
+			return diveDirective, true, nil
+		}
+		return "", false, nil
+	}
+	translated, err := TranslatePlaygroundTag(field.Type, tagValue)
+	if err != nil {
+		return "", true, fmt.Errorf("%s tag: %w", playgroundTagKey, err)
+	}
+	if nested && !strings.Contains(","+translated+",", ","+diveDirective+",") {
+		translated = strings.TrimPrefix(translated+","+diveDirective, ",")
+	}
+	return translated, true, nil
+}
+
+// isNestedStruct reports whether t is a struct, or a pointer to one, that
+// go-playground/validator descends into.
+func isNestedStruct(t reflect.Type) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct && t != timeType
+}
+
+// TranslatePlaygroundTag returns the `val` tag value equivalent to tagValue,
+// a `validate` tag of go-playground/validator on a field of type t. It
+// understands the common vocabulary of that package:
+//
+//	required omitempty dive
+//	min max len eq ne gt gte lt lte oneof
+//	eqfield nefield gtfield gtefield ltfield ltefield
+//	email url uri alpha alphanum numeric number uuid ip ipv4 ipv6 mac json
+//	lowercase uppercase contains excludes startswith endswith
+//
+// The lengths of strings count runes, as they do in go-playground, and the
+// constraints on pointer fields apply to the values they point to, nil
+// pointers being left to omitempty and required. Alternatives ("a|b") and
+// cross-field rules other than the above are not supported.
+func TranslatePlaygroundTag(t reflect.Type, tagValue string) (string, error) {
+	var out []string
+	for _, part := range splitTag(tagValue) {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		if strings.Contains(part, "|") {
+			return "", fmt.Errorf("alternatives are not supported: %q", part)
+		}
+		name, param, _ := strings.Cut(part, "=")
+		param = strings.ReplaceAll(param, "0x2C", ",")
+		param = strings.ReplaceAll(param, "0x7C", "|")
+
+		if name == diveDirective {
+			for t.Kind() == reflect.Ptr {
+				t = t.Elem()
+			}
+			switch t.Kind() {
+			case reflect.Slice, reflect.Array, reflect.Map:
+				t = t.Elem()
+			default:
+				return "", fmt.Errorf("cannot dive into %s", t)
+			}
+			out = append(out, diveDirective)
+			continue
+		}
+		d, err := playgroundDirective(t, name, param)
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", part, err)
+		}
+		if d != "" {
+			out = append(out, d)
+		}
+	}
+	return strings.Join(out, ","), nil
+}
+
+var playgroundNames = map[string]string{
+	"email":      "email",
+	"url":        "url",
+	"uri":        "url",
+	"alpha":      "regex=^[a-zA-Z]+$",
+	"alphanum":   "alphanum",
+	"numeric":    "floatstr",
+	"number":     "regex=^[0-9]+$",
+	"uuid":       "regex=^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$",
+	"ip":         "ip",
+	"ipv4":       "ipv4",
+	"ipv6":       "ipv6",
+	"mac":        "mac",
+	"json":       "json",
+	"lowercase":  "lowercase",
+	"uppercase":  "uppercase",
+	"contains":   "contains",
+	"excludes":   "!contains",
+	"startswith": "prefix",
+	"endswith":   "suffix",
+	"required":   requiredDirectiveName,
+	"omitempty":  omitEmptyDirective,
+}
+
+var playgroundOps = map[string]string{
+	"min": ">=", "max": "<=", "len": "==", "eq": "==", "ne": "!=",
+	"gt": ">", "gte": ">=", "lt": "<", "lte": "<=",
+}
+
+var playgroundFieldOps = map[string]string{
+	"eqfield": "==", "nefield": "!=", "gtfield": ">", "gtefield": ">=", "ltfield": "<", "ltefield": "<=",
+}
+
+func playgroundDirective(t reflect.Type, name, param string) (string, error) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem() // nil pointers are left to omitempty and required
+	}
+	if op, ok := playgroundOps[name]; ok {
+		return playgroundCompare(t, name, op, param)
+	}
+	if op, ok := playgroundFieldOps[name]; ok {
+		if !isIdent(param) {
+			return "", fmt.Errorf("invalid field name %q", param)
+		}
+		return fmt.Sprintf("expr=value %s .%s", op, param), nil
+	}
+	if name == "oneof" {
+		return playgroundOneOf(t, param)
+	}
+
+	d := playgroundNames[name]
+	if d == "" {
+		return "", errors.New("not supported")
+	}
+	switch name {
+	case "contains", "excludes", "startswith", "endswith":
+		if param == "" {
+			return "", errors.New("missing value")
+		}
+		return d + "=" + quoteParam(param), nil
+	}
+	return d, nil
+}
+
+// playgroundCompare translates the comparisons, which compare the length of
+// strings and collections and the value of numbers.
+func playgroundCompare(t reflect.Type, name, op, param string) (string, error) {
+	switch {
+	case t == timeType:
+		switch {
+		case param != "":
+			return "", errors.New("comparing times to a value is not supported")
+		case name == "gt" || name == "gte":
+			return "future", nil
+		case name == "lt" || name == "lte":
+			return "past", nil
+		}
+		return "", errors.New("not supported for time.Time")
+	case t.Kind() == reflect.String && (name == "eq" || name == "ne"):
+		lit, err := exprString(param)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("expr=value %s %s", op, lit), nil
+	case t.Kind() == reflect.String:
+		n, err := strconv.Atoi(param)
+		if err != nil || n < 0 {
+			return "", fmt.Errorf("invalid length %q", param)
+		}
+		return playgroundRunes(op, n)
+	case t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map:
+		n, err := strconv.Atoi(param)
+		if err != nil || n < 0 {
+			return "", fmt.Errorf("invalid length %q", param)
+		}
+		switch name {
+		case "min":
+			return fmt.Sprintf("minitems=%d", n), nil
+		case "max":
+			return fmt.Sprintf("maxitems=%d", n), nil
+		case "len":
+			return fmt.Sprintf("items,min=%d,max=%d", n, n), nil
+		}
+		return fmt.Sprintf("expr=len(value) %s %d", op, n), nil
+	case isNumericKind(t.Kind()):
+		n, err := exprNumber(t, param)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("expr=value %s %s", op, n), nil
+	}
+	return "", fmt.Errorf("not supported for %s", t)
+}
+
+// playgroundRunes translates a comparison of the length of a string, which
+// go-playground counts in runes, to the length validators.
+func playgroundRunes(op string, n int) (string, error) {
+	lo, hi := 0, -1
+	switch op {
+	case ">=":
+		lo = n
+	case ">":
+		lo = n + 1
+	case "<=":
+		hi = n
+	case "<":
+		if n == 0 {
+			return "", errors.New("no length is less than 0")
+		}
+		hi = n - 1
+	case "==":
+		lo, hi = n, n
+	}
+	switch {
+	case hi == 0:
+		return `expr=value == ""`, nil
+	case lo > 0 && hi > 0:
+		return fmt.Sprintf("len,min=%d,max=%d,runes", lo, hi), nil
+	case lo > 0:
+		return fmt.Sprintf("min,size=%d,runes", lo), nil
+	case hi > 0:
+		return fmt.Sprintf("max,size=%d,runes", hi), nil
+	}
+	return "", nil // any length
+}
+
+func playgroundOneOf(t reflect.Type, param string) (string, error) {
+	var terms []string
+	for _, v := range splitQuoted(param, func(c byte) bool { return c == ' ' }) {
+		if v == "" {
+			continue
+		}
+		var lit string
+		var err error
+		switch {
+		case t.Kind() == reflect.String:
+			if u, uerr := unquoteParam(v); uerr == nil {
+				v = u
+			}
+			lit, err = exprString(v)
+		case isNumericKind(t.Kind()):
+			lit, err = exprNumber(t, v)
+		default:
+			err = fmt.Errorf("not supported for %s", t)
+		}
+		if err != nil {
+			return "", err
+		}
+		terms = append(terms, "value == "+lit)
+	}
+	if len(terms) == 0 {
+		return "", errors.New("missing values")
+	}
+	return "expr=" + strings.Join(terms, " || "), nil
+}
+
+// exprString returns s as a string literal of an expression.
+func exprString(s string) (string, error) {
+	if strings.Contains(s, "'") {
+		return "", fmt.Errorf("value %q holds a single quote", s)
+	}
+	return "'" + s + "'", nil
+}
+
+// exprNumber returns s as a number literal of an expression comparing values
+// of type t, for which durations are given as in time.ParseDuration.
+func exprNumber(t reflect.Type, s string) (string, error) {
+	if t == durationType {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return "", fmt.Errorf("invalid duration %q", s)
+		}
+		return strconv.FormatInt(int64(d), 10), nil
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return "", fmt.Errorf("invalid number %q", s)
+	}
+	return strconv.FormatFloat(f, 'f', -1, 64), nil
+}
+
+// quoteParam quotes a parameter value when splitting the tag would not keep
+// it intact.
+func quoteParam(s string) string {
+	if strings.ContainsAny(s, `,'"`) || strings.TrimSpace(s) != s {
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+func isIdent(s string) bool {
+	for i, r := range s {
+		if r != '_' && !('a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' || i > 0 && '0' <= r && r <= '9') {
+			return false
+		}
+	}
+	return s != ""
+}
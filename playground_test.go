@@ -0,0 +1,170 @@
+package valex
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTranslatePlaygroundTag(t *testing.T) {
+	tests := []struct {
+		typ     reflect.Type
+		tag     string
+		want    string
+		wantErr string
+	}{
+		{reflect.TypeFor[string](), "required,min=3,max=20", "required,min,size=3,runes,max,size=20,runes", ""},
+		{reflect.TypeFor[string](), "omitempty,email", "omitempty,email", ""},
+		{reflect.TypeFor[string](), "len=4", "len,min=4,max=4,runes", ""},
+		{reflect.TypeFor[string](), "gt=2", "min,size=3,runes", ""},
+		{reflect.TypeFor[string](), "gte=1,lt=5", "min,size=1,runes,max,size=4,runes", ""},
+		{reflect.TypeFor[string](), "min=0,max=0", `expr=value == ""`, ""},
+		{reflect.TypeFor[string](), "eq=yes", "expr=value == 'yes'", ""},
+		{reflect.TypeFor[string](), "oneof=red green 'light blue'", "expr=value == 'red' || value == 'green' || value == 'light blue'", ""},
+		{reflect.TypeFor[string](), "contains=a0x2Cb", `contains="a,b"`, ""},
+		{reflect.TypeFor[int](), "gte=0,lte=130", "expr=value >= 0,expr=value <= 130", ""},
+		{reflect.TypeFor[int](), "oneof=1 2 3", "expr=value == 1 || value == 2 || value == 3", ""},
+		{reflect.TypeFor[float64](), "gt=0.5", "expr=value > 0.5", ""},
+		{reflect.TypeFor[time.Duration](), "min=1s", "expr=value >= 1000000000", ""},
+		{reflect.TypeFor[time.Time](), "gt", "future", ""},
+		{reflect.TypeFor[[]string](), "min=1,dive,max=5", "minitems=1,dive,max,size=5,runes", ""},
+		{reflect.TypeFor[map[string]int](), "len=2,dive,min=1", "items,min=2,max=2,dive,expr=value >= 1", ""},
+		{reflect.TypeFor[int](), "gtfield=Min", "expr=value > .Min", ""},
+		{reflect.TypeFor[*string](), "omitempty,required", "omitempty,required", ""},
+		{reflect.TypeFor[string](), "rgb|rgba", "", "alternatives are not supported"},
+		{reflect.TypeFor[string](), "hostname_rfc1123", "", "not supported"},
+		{reflect.TypeFor[string](), "min=x", "", `invalid length "x"`},
+		{reflect.TypeFor[int](), "max=x", "", `invalid number "x"`},
+		{reflect.TypeFor[int](), "dive", "", "cannot dive into int"},
+		{reflect.TypeFor[*string](), "omitempty,min=3", "omitempty,min,size=3,runes", ""},
+		{reflect.TypeFor[*int](), "gte=1", "expr=value >= 1", ""},
+		{reflect.TypeFor[string](), "lt=0", "", "less than 0"},
+		{reflect.TypeFor[string](), "eq=it's", "", "single quote"},
+	}
+	for _, tc := range tests {
+		got, err := TranslatePlaygroundTag(tc.typ, tc.tag)
+		if tc.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("%s on %s: expected error containing %q, got %q, %v", tc.tag, tc.typ, tc.wantErr, got, err)
+			}
+			continue
+		}
+		if err != nil || got != tc.want {
+			t.Errorf("%s on %s: got %q, %v, want %q", tc.tag, tc.typ, got, err, tc.want)
+		}
+	}
+}
+
+type playgroundAddress struct {
+	Zip string `validate:"required,len=4"`
+}
+
+type playgroundUser struct {
+	Name     string             `validate:"required,min=2"`
+	Role     string             `validate:"oneof=admin user"`
+	Age      int                `validate:"gte=18"`
+	Tags     []string           `validate:"max=2,dive,min=2"`
+	Password string             `validate:"required"`
+	Confirm  string             `validate:"eqfield=Password"`
+	Nick     string             `val:"max,size=4" validate:"max=100"`
+	Address  playgroundAddress  // validated without a tag
+	Billing  *playgroundAddress `validate:"omitempty"`
+	Skipped  string             `validate:"-"`
+	internal string             `validate:"required"`
+	Created  time.Time
+}
+
+func TestSetPlaygroundTags(t *testing.T) {
+	valid := playgroundUser{Name: "Jane", Role: "admin", Age: 30, Tags: []string{"go"}, Password: "pw", Confirm: "pw", Address: playgroundAddress{Zip: "1234"}}
+
+	e := NewEngine()
+	invalid := playgroundUser{}
+	if ok, err := e.ValidateStruct(&invalid); !ok || err != nil {
+		t.Fatalf("expected validate tags to be ignored by default, got %v", err)
+	}
+
+	e.SetPlaygroundTags(true)
+	if ok, err := e.ValidateStruct(&valid); !ok {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	invalid = valid
+	invalid.Name, invalid.Role, invalid.Age = "J", "root", 17
+	invalid.Tags = []string{"go", "x"}
+	invalid.Confirm = "other"
+	invalid.Nick = "toolong"
+	invalid.Address.Zip = "1"
+	invalid.Billing = &playgroundAddress{Zip: "12"}
+	_, err := e.ValidateStruct(&invalid, WithCollectAll())
+	var errs ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("expected ValidationErrors, got %v", err)
+	}
+	var got []string
+	for _, fe := range errs {
+		got = append(got, fe.Path.String())
+	}
+	want := []string{"Name", "Role", "Age", "Tags[1]", "Confirm", "Nick", "Address.Zip", "Billing.Zip"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected errors at %v, got %v", want, got)
+	}
+
+	e.SetPlaygroundTags(false)
+	if ok, err := e.ValidateStruct(&invalid); ok || !strings.Contains(err.Error(), "Nick") {
+		t.Errorf("expected only val tags to apply, got %v", err)
+	}
+}
+
+func TestSetPlaygroundTags_Pointers(t *testing.T) {
+	type user struct {
+		Name *string `validate:"omitempty,min=3,max=3"`
+		Age  *int    `validate:"required,gte=18"`
+	}
+	e := NewEngine()
+	e.SetPlaygroundTags(true)
+
+	name, age := "日本語", 30
+	if ok, err := e.ValidateStruct(&user{Name: &name, Age: &age}); !ok {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if ok, err := e.ValidateStruct(&user{Age: &age}); !ok {
+		t.Errorf("expected a nil Name to be skipped, got %v", err)
+	}
+	short, young := "日本", 17
+	_, err := e.ValidateStruct(&user{Name: &short, Age: &young}, WithCollectAll())
+	var errs ValidationErrors
+	if !errors.As(err, &errs) || len(errs) != 2 {
+		t.Errorf("expected errors on Name and Age, got %v", err)
+	}
+	if _, err := e.ValidateStruct(&user{Name: &name}); err == nil || !strings.Contains(err.Error(), "Age") {
+		t.Errorf("expected a nil Age to be required, got %v", err)
+	}
+}
+
+func TestSetPlaygroundTags_Runes(t *testing.T) {
+	v := struct {
+		Name string `validate:"max=3"`
+	}{Name: "日本"}
+	e := NewEngine()
+	e.SetPlaygroundTags(true)
+	if ok, err := e.ValidateStruct(&v); !ok {
+		t.Errorf("expected 2 runes to pass max=3, got %v", err)
+	}
+	v.Name = "日本語!"
+	if ok, _ := e.ValidateStruct(&v); ok {
+		t.Error("expected 4 runes to fail max=3")
+	}
+}
+
+func TestSetPlaygroundTags_Unsupported(t *testing.T) {
+	e := NewEngine()
+	e.SetPlaygroundTags(true)
+	v := struct {
+		Color string `validate:"iscolor"`
+	}{}
+	if _, err := e.ValidateStruct(&v); Categorize(err) != CategoryConfig || !strings.Contains(err.Error(), "validate tag") {
+		t.Errorf("expected a config error, got %v", err)
+	}
+}
@@ -0,0 +1,127 @@
+package valex
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+var (
+	ErrPoolClosed    = errors.New("validation pool is closed")
+	ErrPoolQueueFull = errors.New("validation pool queue is full")
+)
+
+type PoolResult[T any] struct {
+	Value T
+	OK    bool
+	Err   error
+}
+
+type poolJob[T any] struct {
+	ctx    context.Context
+	val    T
+	result chan PoolResult[T]
+}
+
+// Pool validates values on a fixed number of workers fed by a bounded queue.
+// Every worker owns the validator returned by its own call to newValidator, so
+// validators holding non thread-safe state never see concurrent calls.
+type Pool[T any] struct {
+	jobs   chan poolJob[T]
+	mut    sync.RWMutex
+	closed bool
+	wg     sync.WaitGroup
+}
+
+func NewPool[T any](workers, queueSize int, newValidator func() Validator[T]) *Pool[T] {
+	if workers < 1 {
+		workers = 1
+	}
+	if queueSize < 0 {
+		queueSize = 0
+	}
+	p := &Pool[T]{
+		jobs: make(chan poolJob[T], queueSize),
+	}
+	p.wg.Add(workers)
+	for n := 0; n < workers; n++ {
+		go p.work(newValidator())
+	}
+	return p
+}
+
+func (p *Pool[T]) work(v Validator[T]) {
+	defer p.wg.Done()
+
+	for job := range p.jobs {
+		res := PoolResult[T]{Value: job.val}
+		if err := job.ctx.Err(); err != nil {
+			res.Err = err
+		} else {
+			res.OK, res.Err = v.Validate(job.val)
+		}
+		job.result <- res
+	}
+}
+
+// Submit queues val for validation, blocking while the queue is full until
+// either a slot frees up or ctx is done.
+func (p *Pool[T]) Submit(ctx context.Context, val T) (<-chan PoolResult[T], error) {
+	p.mut.RLock()
+	defer p.mut.RUnlock()
+
+	if p.closed {
+		return nil, ErrPoolClosed
+	}
+	job := poolJob[T]{ctx: ctx, val: val, result: make(chan PoolResult[T], 1)}
+	select {
+	case p.jobs <- job:
+		return job.result, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// TrySubmit is like Submit but returns ErrPoolQueueFull instead of blocking.
+func (p *Pool[T]) TrySubmit(ctx context.Context, val T) (<-chan PoolResult[T], error) {
+	p.mut.RLock()
+	defer p.mut.RUnlock()
+
+	if p.closed {
+		return nil, ErrPoolClosed
+	}
+	job := poolJob[T]{ctx: ctx, val: val, result: make(chan PoolResult[T], 1)}
+	select {
+	case p.jobs <- job:
+		return job.result, nil
+	default:
+		return nil, ErrPoolQueueFull
+	}
+}
+
+func (p *Pool[T]) Validate(ctx context.Context, val T) (ok bool, err error) {
+	result, err := p.Submit(ctx, val)
+	if err != nil {
+		return false, err
+	}
+	select {
+	case res := <-result:
+		return res.OK, res.Err
+	case <-ctx.Done():
+		return false, ctx.Err()
+	}
+}
+
+// Close stops accepting new values and waits for queued ones to finish.
+func (p *Pool[T]) Close() {
+	p.mut.Lock()
+	if p.closed {
+		p.mut.Unlock()
+		return
+	}
+	p.closed = true
+	close(p.jobs)
+	p.mut.Unlock()
+
+	p.wg.Wait()
+}
@@ -0,0 +1,104 @@
+package valex
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+)
+
+type countingValidator struct {
+	calls int // deliberately unsynchronized; each worker owns its instance
+}
+
+func (v *countingValidator) Validate(val int) (ok bool, err error) {
+	v.calls++
+	return (&NonNegativeIntValidator{}).Validate(val)
+}
+
+func TestPool_Validate(t *testing.T) {
+	p := NewPool[int](4, 8, func() Validator[int] { return &countingValidator{} })
+	defer p.Close()
+
+	tests := []struct {
+		input int
+		ok    bool
+	}{
+		{-1, false},
+		{0, true},
+		{1, true},
+	}
+
+	var wg sync.WaitGroup
+	for _, tc := range tests {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ok, err := p.Validate(context.Background(), tc.input)
+			if ok != tc.ok {
+				t.Errorf("Pool.Validate(%d): expected ok=%v, got ok=%v (err: %v)", tc.input, tc.ok, ok, err)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestPool_TrySubmitQueueFull(t *testing.T) {
+	block := make(chan struct{})
+	started := make(chan struct{}, 1)
+	p := NewPool[int](1, 1, func() Validator[int] {
+		return ValidatorFunc[int](func(val int) (bool, error) {
+			started <- struct{}{}
+			<-block
+			return true, nil
+		})
+	})
+
+	ctx := context.Background()
+	if _, err := p.Submit(ctx, 1); err != nil { // picked up by the worker
+		t.Fatalf("unexpected error: %v", err)
+	}
+	<-started
+	if _, err := p.Submit(ctx, 2); err != nil { // fills the queue
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := p.TrySubmit(ctx, 3); !errors.Is(err, ErrPoolQueueFull) {
+		t.Errorf("expected %v, got %v", ErrPoolQueueFull, err)
+	}
+
+	close(block)
+	p.Close()
+}
+
+func TestPool_SubmitCanceled(t *testing.T) {
+	block := make(chan struct{})
+	p := NewPool[int](1, 0, func() Validator[int] {
+		return ValidatorFunc[int](func(val int) (bool, error) {
+			<-block
+			return true, nil
+		})
+	})
+
+	if _, err := p.Submit(context.Background(), 1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := p.Submit(ctx, 2); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected %v, got %v", context.Canceled, err)
+	}
+
+	close(block)
+	p.Close()
+}
+
+func TestPool_Closed(t *testing.T) {
+	p := NewPool[int](1, 1, func() Validator[int] { return &NonNegativeIntValidator{} })
+	p.Close()
+	p.Close() // must not panic
+
+	if _, err := p.Submit(context.Background(), 1); !errors.Is(err, ErrPoolClosed) {
+		t.Errorf("expected %v, got %v", ErrPoolClosed, err)
+	}
+}
@@ -0,0 +1,102 @@
+package valex
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// MarshalJSON encodes the error as
+// {"field": ..., "path": ..., "directive": ..., "category": ..., "message": ...},
+// leaving out empty members.
+func (fe *FieldError) MarshalJSON() ([]byte, error) {
+	msg := ""
+	if fe.Err != nil {
+		msg = fe.Err.Error()
+	}
+	path := ""
+	if fe.Path != nil {
+		path = fe.Path.String()
+	}
+	return json.Marshal(struct {
+		Field     string `json:"field,omitempty"`
+		Path      string `json:"path,omitempty"`
+		Directive string `json:"directive,omitempty"`
+		Category  string `json:"category"`
+		Message   string `json:"message"`
+	}{fe.Field, path, fe.Directive, Categorize(fe).String(), msg})
+}
+
+// ProblemDetails is an RFC 7807 problem document. Errors lists the field
+// errors as an extension member.
+type ProblemDetails struct {
+	Type     string        `json:"type"`
+	Title    string        `json:"title"`
+	Status   int           `json:"status"`
+	Detail   string        `json:"detail,omitempty"`
+	Instance string        `json:"instance,omitempty"`
+	Errors   []*FieldError `json:"errors,omitempty"`
+}
+
+// ToProblemDetails describes err as a problem document, with the status the
+// engine's StatusMapper assigns to it. The detail of errors mapped to a 5xx
+// status is left out, so internals are not exposed to clients.
+func (e *Engine) ToProblemDetails(err error) *ProblemDetails {
+	status := e.HTTPStatus(err)
+	p := &ProblemDetails{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+	}
+	if err == nil {
+		return p
+	}
+
+	var cerrs CoercionErrors
+	var errs ValidationErrors
+	var fe *FieldError
+	switch {
+	case errors.As(err, &cerrs):
+		// keyed by source key, like ErrorBody
+		for _, key := range cerrs.keys() {
+			ke := &FieldError{Field: key, Err: cerrs[key]}
+			if errors.As(cerrs[key], &fe) {
+				ke = &FieldError{Field: key, Path: fe.Path, Directive: fe.Directive, Err: fe.Err}
+			}
+			p.Errors = append(p.Errors, ke)
+		}
+	case errors.As(err, &errs):
+		p.Errors = errs
+	case errors.As(err, &fe):
+		p.Errors = []*FieldError{fe}
+	}
+
+	switch {
+	case status >= 500:
+		p.Errors = nil
+	case len(p.Errors) == 1:
+		p.Detail = p.Errors[0].Error()
+	case len(p.Errors) > 1:
+		p.Detail = fmt.Sprintf("%d fields failed validation", len(p.Errors))
+	default:
+		p.Detail = err.Error()
+	}
+	return p
+}
+
+func ToProblemDetails(err error) *ProblemDetails {
+	return std.ToProblemDetails(err)
+}
+
+// WriteProblem writes err as an application/problem+json response.
+func (e *Engine) WriteProblem(w http.ResponseWriter, err error) {
+	p := e.ToProblemDetails(err)
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(p.Status)
+	_ = json.NewEncoder(w).Encode(p)
+}
+
+func WriteProblem(w http.ResponseWriter, err error) {
+	std.WriteProblem(w, err)
+}
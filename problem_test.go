@@ -0,0 +1,111 @@
+package valex
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestFieldError_MarshalJSON(t *testing.T) {
+	tests := []struct {
+		err  *FieldError
+		want string
+	}{
+		{
+			&FieldError{Field: "user_name", Path: FieldPath{}.Field("UserName"), Directive: "min", Err: errors.New("too short")},
+			`{"field":"user_name","path":"UserName","directive":"min","category":"validation","message":"too short"}`,
+		},
+		{
+			&FieldError{Field: "Name", Err: errors.New(`unknown directive "nope"`)},
+			`{"field":"Name","category":"config","message":"unknown directive \"nope\""}`,
+		},
+	}
+	for _, tc := range tests {
+		got, err := json.Marshal(tc.err)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if string(got) != tc.want {
+			t.Errorf("expected %s, got %s", tc.want, got)
+		}
+	}
+
+	ve := ValidationErrors{tests[0].err, tests[1].err}
+	got, _ := json.Marshal(ve)
+	if want := "[" + tests[0].want + "," + tests[1].want + "]"; string(got) != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+}
+
+func TestToProblemDetails(t *testing.T) {
+	type dummy struct {
+		Name string `val:"min,size=3"`
+		Age  int    `val:"range,min=0,max=130"`
+	}
+	_, single := ValidateStruct(dummy{Name: "Jo"})
+	_, multi := ValidateStruct(dummy{Name: "Jo", Age: -1}, WithCollectAll())
+	_, config := ValidateStruct(struct {
+		A string `val:"nope"`
+	}{})
+	coerced := Coerce(&struct {
+		Age int `form:"age"`
+	}{}, map[string]string{"age": "x"})
+
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantDetail string
+		wantErrors int
+	}{
+		{"nil", nil, http.StatusOK, "", 0},
+		{"single", single, http.StatusUnprocessableEntity, single.Error(), 1},
+		{"multiple", multi, http.StatusUnprocessableEntity, "2 fields failed validation", 2},
+		{"decode", WithCategory(errors.New("bad json"), CategoryDecode), http.StatusBadRequest, "bad json", 0},
+		{"config", config, http.StatusInternalServerError, "", 0},
+		{"coercion", coerced, http.StatusUnprocessableEntity, "", 1},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			p := ToProblemDetails(tc.err)
+			if p.Type != "about:blank" || p.Status != tc.wantStatus || p.Title != http.StatusText(tc.wantStatus) {
+				t.Errorf("unexpected problem %+v", p)
+			}
+			if tc.wantDetail != "" && p.Detail != tc.wantDetail {
+				t.Errorf("expected detail %q, got %q", tc.wantDetail, p.Detail)
+			}
+			if len(p.Errors) != tc.wantErrors {
+				t.Errorf("expected %d errors, got %d", tc.wantErrors, len(p.Errors))
+			}
+		})
+	}
+
+	if p := ToProblemDetails(coerced); p.Errors[0].Field != "age" {
+		t.Errorf("expected coercion errors to keep their source key, got %q", p.Errors[0].Field)
+	}
+}
+
+func TestWriteProblem(t *testing.T) {
+	_, err := ValidateStruct(struct {
+		Name string `val:"min,size=3"`
+	}{Name: "Jo"})
+
+	rec := httptest.NewRecorder()
+	WriteProblem(rec, err)
+	if rec.Code != http.StatusUnprocessableEntity {
+		t.Errorf("expected status 422, got %d", rec.Code)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/problem+json" {
+		t.Errorf("unexpected content type %q", ct)
+	}
+	var body map[string]any
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("invalid body: %v", err)
+	}
+	if body["status"] != float64(422) || !strings.Contains(rec.Body.String(), `"directive":"min"`) {
+		t.Errorf("unexpected body %s", rec.Body)
+	}
+}
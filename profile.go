@@ -0,0 +1,72 @@
+package valex
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+var profiles = struct {
+	mut sync.RWMutex
+	m   map[string]*Engine
+}{m: make(map[string]*Engine)}
+
+// NewProfile returns a new engine registered under name, replacing any
+// profile registered before, so it can be selected per call with
+// WithProfile. A profile has its own directives, aliases, rules and tenants.
+//
+// A field's `val.<name>` tag takes the place of its `val` tag in the
+// profile, e.g. to require a field on creation only:
+//
+//	Name string `val:"!empty" val.update:""`
+//
+// An empty profile tag leaves the field unvalidated in that profile.
+func NewProfile(name string) *Engine {
+	e := NewEngine()
+	e.profile = name
+
+	profiles.mut.Lock()
+	profiles.m[name] = e
+	profiles.mut.Unlock()
+	return e
+}
+
+// Profile returns the name the engine was created with by NewProfile, or ""
+// for other engines.
+func (e *Engine) Profile() string {
+	return e.profile
+}
+
+func lookupProfile(name string) (*Engine, error) {
+	profiles.mut.RLock()
+	defer profiles.mut.RUnlock()
+
+	e, ok := profiles.m[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown profile %q", name)
+	}
+	return e, nil
+}
+
+// forProfile returns the engine that validates with o: the profile selected
+// with WithProfile, or e itself.
+func (e *Engine) forProfile(o options) (*Engine, error) {
+	if o.profile == "" || o.profile == e.profile {
+		return e, nil
+	}
+	return lookupProfile(o.profile)
+}
+
+// fieldTag returns the tag value of field for the engine's profile, falling
+// back to a translated `validate` tag with SetPlaygroundTags.
+func (e *Engine) fieldTag(field reflect.StructField) (string, bool, error) {
+	if e.profile != "" {
+		if tagValue, ok := field.Tag.Lookup(tagKey + "." + e.profile); ok {
+			return tagValue, tagValue != "", nil
+		}
+	}
+	if tagValue, ok := field.Tag.Lookup(tagKey); ok || !e.playground.Load() {
+		return tagValue, ok, nil
+	}
+	return playgroundTag(field)
+}
@@ -0,0 +1,95 @@
+package valex
+
+import (
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+type profileUser struct {
+	Name  string `val:"min,size=3" val.update:""`
+	Email string `val:"email" val.create:"email,allcaps"`
+	Age   int    `val:"range,min=0,max=130"`
+}
+
+func TestNewProfile(t *testing.T) {
+	create := NewProfile("create")
+	RegisterDirective(create, &allCapsValidator{})
+	NewProfile("update")
+
+	tests := []struct {
+		name      string
+		profile   string
+		data      profileUser
+		wantValid bool
+		errSubstr string
+	}{
+		{"default", "", profileUser{Name: "John", Email: "john@example.com"}, true, ""},
+		{"default short name", "", profileUser{Name: "Jo", Email: "john@example.com"}, false, `"Name"`},
+		{"create profile tag", "create", profileUser{Name: "John", Email: "john@example.com"}, false, `directive "allcaps" failed`},
+		{"create valid", "create", profileUser{Name: "John", Email: "JOHN@EXAMPLE.COM"}, true, ""},
+		{"update skips name", "update", profileUser{Name: "", Email: "john@example.com"}, true, ""},
+		{"update keeps val tag", "update", profileUser{Email: "john@example.com", Age: 200}, false, `"Age"`},
+		{"unknown profile", "delete", profileUser{}, false, `unknown profile "delete"`},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var opts []Option
+			if tc.profile != "" {
+				opts = append(opts, WithProfile(tc.profile))
+			}
+			ok, err := ValidateStruct(&tc.data, opts...)
+			if ok != tc.wantValid {
+				t.Fatalf("expected valid=%v, got %v (error: %v)", tc.wantValid, ok, err)
+			}
+			if !ok && !strings.Contains(err.Error(), tc.errSubstr) {
+				t.Errorf("expected error containing %q, got %q", tc.errSubstr, err)
+			}
+		})
+	}
+}
+
+func TestNewProfile_Independent(t *testing.T) {
+	p := NewProfile("independent")
+	RegisterDirective(p, &gaugeDirective{cur: new(atomic.Int32), max: new(atomic.Int32)})
+	if p.Profile() != "independent" || Default().Profile() != "" {
+		t.Errorf("unexpected profile names %q and %q", p.Profile(), Default().Profile())
+	}
+
+	data := struct {
+		Code string `val:"gauge"`
+	}{Code: "ABC"}
+	if ok, err := ValidateStruct(data, WithProfile("independent")); !ok {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if ok, _ := ValidateStruct(data); ok {
+		t.Error("expected the default engine not to know the profile's directive")
+	}
+}
+
+func TestNewProfile_Plan(t *testing.T) {
+	NewProfile("update")
+
+	plan, err := Compile[profileUser](WithProfile("update"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ok, err := plan.Validate(&profileUser{Email: "john@example.com"}); !ok {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if ok, _ := plan.Validate(&profileUser{Email: "john@example.com"}, WithProfile("")); !ok {
+		t.Error("expected an empty profile name to keep the compiled profile")
+	}
+
+	plain := MustCompile[profileUser]()
+	if ok, _ := plain.Validate(&profileUser{Email: "john@example.com"}); ok {
+		t.Error("expected the default engine to require a name")
+	}
+	if ok, err := plain.Validate(&profileUser{Email: "john@example.com"}, WithProfile("update")); !ok {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	if _, err := Compile[profileUser](WithProfile("nope")); Categorize(err) != CategoryConfig {
+		t.Errorf("expected a config error, got %v", err)
+	}
+}
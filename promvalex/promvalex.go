@@ -0,0 +1,67 @@
+// Package promvalex exports valex metrics to Prometheus:
+//
+//	r, err := promvalex.New(prometheus.DefaultRegisterer)
+//	if err != nil {
+//		return err
+//	}
+//	valex.SetRecorder(r)
+package promvalex
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/tedla-brandsema/valex"
+)
+
+// Recorder implements valex.Recorder with a counter of validations by
+// struct type and result, a counter of failures by struct type and
+// directive, and a histogram of validation latency by struct type. The
+// result is "ok" or the valex.ErrorCategory of the error.
+type Recorder struct {
+	validations *prometheus.CounterVec
+	failures    *prometheus.CounterVec
+	duration    *prometheus.HistogramVec
+}
+
+// New creates a Recorder and registers its metrics with reg.
+func New(reg prometheus.Registerer) (*Recorder, error) {
+	r := &Recorder{
+		validations: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "valex",
+			Name:      "validations_total",
+			Help:      "Struct validations by type and result.",
+		}, []string{"type", "result"}),
+		failures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "valex",
+			Name:      "directive_failures_total",
+			Help:      "Failed directives by type and directive.",
+		}, []string{"type", "directive"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "valex",
+			Name:      "validation_duration_seconds",
+			Help:      "Latency of struct validations by type.",
+			Buckets:   []float64{.00001, .000025, .00005, .0001, .00025, .0005, .001, .0025, .005, .01, .1, 1},
+		}, []string{"type"}),
+	}
+	for _, c := range []prometheus.Collector{r.validations, r.failures, r.duration} {
+		if err := reg.Register(c); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+func (r *Recorder) RecordValidation(_ context.Context, typ string, took time.Duration, err error) {
+	result := "ok"
+	if err != nil {
+		result = valex.Categorize(err).String()
+	}
+	r.validations.WithLabelValues(typ, result).Inc()
+	r.duration.WithLabelValues(typ).Observe(took.Seconds())
+}
+
+func (r *Recorder) RecordFailure(_ context.Context, typ, directive string) {
+	r.failures.WithLabelValues(typ, directive).Inc()
+}
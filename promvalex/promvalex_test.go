@@ -0,0 +1,53 @@
+package promvalex
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/tedla-brandsema/valex"
+)
+
+type user struct {
+	Name string `val:"min,size=3"`
+	Code string `val:"alphanum"`
+}
+
+func TestRecorder(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	r, err := New(reg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	e := valex.NewEngine()
+	e.SetRecorder(r)
+
+	e.ValidateStruct(user{Name: "John", Code: "a1"})
+	e.ValidateStruct(user{Name: "Jo", Code: "a 1"}, valex.WithCollectAll())
+	e.ValidateStruct(user{Name: "Jo", Code: "a1"})
+
+	tests := []struct {
+		name string
+		c    prometheus.Collector
+		want float64
+	}{
+		{"ok", r.validations.WithLabelValues("promvalex.user", "ok"), 1},
+		{"failed", r.validations.WithLabelValues("promvalex.user", "validation"), 2},
+		{"min", r.failures.WithLabelValues("promvalex.user", "min"), 2},
+		{"alphanum", r.failures.WithLabelValues("promvalex.user", "alphanum"), 1},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := testutil.ToFloat64(tc.c); got != tc.want {
+				t.Errorf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+	if n := testutil.CollectAndCount(r.duration); n != 1 {
+		t.Errorf("expected one latency histogram, got %d", n)
+	}
+
+	if _, err := New(reg); err == nil {
+		t.Errorf("expected registering twice to fail")
+	}
+}
@@ -0,0 +1,109 @@
+package valex
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+// These tests exercise the engine from many goroutines at once and are
+// meant to be run with the race detector: go test -race.
+
+type raceDummy struct {
+	Name  string   `val:"min,size=3" sane:"trim"`
+	Email string   `val:"email"`
+	Tags  []string `val:"dive,max,size=5"`
+}
+
+func raceRun(t *testing.T, workers int, fn func(n int)) {
+	t.Helper()
+	var wg sync.WaitGroup
+	for n := 0; n < workers; n++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				fn(n*1000 + i)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestRace_RegisterWhileValidating(t *testing.T) {
+	e := NewEngine()
+	raceRun(t, 8, func(i int) {
+		switch i % 4 {
+		case 0:
+			RegisterDirective(e, &allCapsValidator{})
+		case 1:
+			e.Batch(func(r Registrar) {
+				RegisterDirective(r, &allCapsValidator{})
+				RegisterSanitizer(r, &shoutSanitizer{})
+			})
+		case 2:
+			if err := e.Alias(fmt.Sprintf("short%d", i), "max,size=5"); err != nil {
+				t.Error(err)
+			}
+		default:
+			d := &raceDummy{Name: " John ", Email: "john@example.com", Tags: []string{"a"}}
+			if ok, err := e.ValidateStruct(d, WithCollectAll()); !ok {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}
+	})
+}
+
+func TestRace_TenantsAndRules(t *testing.T) {
+	e := NewEngine()
+	raceRun(t, 8, func(i int) {
+		switch i % 5 {
+		case 0:
+			e.Tenant("acme").Override("min", "size", "2")
+		case 1:
+			RegisterDirective(e.Tenant(fmt.Sprintf("t%d", i%3)), &allCapsValidator{})
+		case 2:
+			if err := e.SetRules(Rules{"valex.raceDummy": {"Name": "min,size=2"}}); err != nil {
+				t.Error(err)
+			}
+		case 3:
+			e.ValidateStruct(&raceDummy{Name: "Jo"}, WithTenant("acme"))
+		default:
+			e.ValidateStruct(&raceDummy{Name: "Jo"})
+			e.TypeRules()
+			e.Directives()
+		}
+	})
+}
+
+func TestRace_CoverageAndDeprecations(t *testing.T) {
+	e := NewEngine()
+	e.StartCoverage()
+	raceRun(t, 8, func(i int) {
+		switch i % 4 {
+		case 0:
+			e.Deprecate("email", "", "")
+		case 1:
+			e.SetDeprecationHandler(func(DeprecationUse) {})
+		case 2:
+			e.ValidateStruct(&raceDummy{Name: "John", Tags: make([]string, 10)}, WithConcurrency(4))
+		default:
+			if c := e.StartCoverage(); c != nil {
+				c.Report()
+			}
+		}
+	})
+	e.StopCoverage()
+}
+
+func TestRace_SharedDefaultEngine(t *testing.T) {
+	raceRun(t, 8, func(i int) {
+		d := &raceDummy{Name: "John", Email: "john@example.com"}
+		if ok, err := ValidateStruct(d); !ok {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if _, err := ParseTag("len,min=1,max=5"); err != nil {
+			t.Error(err)
+		}
+	})
+}
@@ -0,0 +1,82 @@
+package valex
+
+import (
+	"context"
+	"math/rand/v2"
+	"sync"
+)
+
+// Rand is the source of randomness for sampling. Engines use the global
+// math/rand/v2 source unless another one is set with SetRand, e.g. a seeded
+// one in tests. Implementations must be safe for concurrent use.
+type Rand interface {
+	Float64() float64
+	IntN(n int) int
+}
+
+type globalRand struct{}
+
+func (globalRand) Float64() float64 { return rand.Float64() }
+func (globalRand) IntN(n int) int   { return rand.IntN(n) }
+
+type lockedRand struct {
+	mut sync.Mutex
+	r   *rand.Rand
+}
+
+func (l *lockedRand) Float64() float64 {
+	l.mut.Lock()
+	defer l.mut.Unlock()
+	return l.r.Float64()
+}
+
+func (l *lockedRand) IntN(n int) int {
+	l.mut.Lock()
+	defer l.mut.Unlock()
+	return l.r.IntN(n)
+}
+
+// LockedRand makes r safe for concurrent use.
+func LockedRand(r *rand.Rand) Rand {
+	return &lockedRand{r: r}
+}
+
+// SeededRand returns a reproducible Rand.
+func SeededRand(seed uint64) Rand {
+	return LockedRand(rand.New(rand.NewPCG(seed, seed)))
+}
+
+type randBox struct {
+	Rand
+}
+
+// SetRand sets the source of randomness used for sampling and handed to
+// directives. A nil Rand restores the global source.
+func (e *Engine) SetRand(r Rand) {
+	if r == nil {
+		r = globalRand{}
+	}
+	e.rand.Store(randBox{r})
+}
+
+func (e *Engine) Rand() Rand {
+	if b, ok := e.rand.Load().(randBox); ok {
+		return b.Rand
+	}
+	return globalRand{}
+}
+
+type randKey struct{}
+
+func withRand(ctx context.Context, r Rand) context.Context {
+	return context.WithValue(ctx, randKey{}, r)
+}
+
+// RandFromContext returns the Rand of the engine running the validation,
+// for directives implementing ContextHandler, or the global source.
+func RandFromContext(ctx context.Context) Rand {
+	if r, ok := ctx.Value(randKey{}).(Rand); ok {
+		return r
+	}
+	return globalRand{}
+}
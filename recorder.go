@@ -0,0 +1,53 @@
+package valex
+
+import (
+	"context"
+	"time"
+)
+
+// Recorder receives metrics of the engine's validations, e.g. to export
+// them to Prometheus or OpenTelemetry. Implementations must be safe for
+// concurrent use and should return quickly.
+type Recorder interface {
+	// RecordValidation is called once per validation of a struct type, as
+	// printed by reflect, with how long it took and the error it returned.
+	RecordValidation(ctx context.Context, typ string, took time.Duration, err error)
+	// RecordFailure is called for every directive that failed.
+	RecordFailure(ctx context.Context, typ, directive string)
+}
+
+type recorderBox struct {
+	r Recorder
+}
+
+// SetRecorder makes the engine report its validations to r. A nil r stops
+// recording.
+func (e *Engine) SetRecorder(r Recorder) {
+	e.recorder.Store(recorderBox{r})
+}
+
+func SetRecorder(r Recorder) {
+	std.SetRecorder(r)
+}
+
+func (e *Engine) loadRecorder() Recorder {
+	b, _ := e.recorder.Load().(recorderBox)
+	return b.r
+}
+
+// record reports the outcome of v to its recorder, if any.
+func (v *validation) record(err error) {
+	if v.rec == nil {
+		return
+	}
+	var typ string
+	if v.typ != nil {
+		typ = v.typ.String()
+	}
+	for _, fe := range v.errs {
+		if fe.Directive != "" {
+			v.rec.RecordFailure(v.ctx, typ, fe.Directive)
+		}
+	}
+	v.rec.RecordValidation(v.ctx, typ, time.Since(v.start), err)
+}
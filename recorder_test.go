@@ -0,0 +1,68 @@
+package valex
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+type testRecorder struct {
+	mu          sync.Mutex
+	validations []string
+	failures    []string
+}
+
+func (r *testRecorder) RecordValidation(_ context.Context, typ string, took time.Duration, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	result := "ok"
+	if err != nil {
+		result = "fail"
+	}
+	if took < 0 {
+		result = "negative"
+	}
+	r.validations = append(r.validations, typ+":"+result)
+}
+
+func (r *testRecorder) RecordFailure(_ context.Context, typ, directive string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.failures = append(r.failures, typ+":"+directive)
+}
+
+func TestEngine_SetRecorder(t *testing.T) {
+	type dummy struct {
+		Name string `val:"min,size=3"`
+		Code string `val:"alphanum"`
+	}
+	e := NewEngine()
+	r := &testRecorder{}
+	e.SetRecorder(r)
+
+	e.ValidateStruct(dummy{Name: "John", Code: "a1"})
+	e.ValidateStruct(dummy{Name: "Jo", Code: "a 1"}, WithCollectAll())
+	e.ValidateStruct(42)
+	p, err := CompileWith[dummy](e)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.Validate(&dummy{Name: "Jo", Code: "a"})
+
+	wantValidations := []string{"valex.dummy:ok", "valex.dummy:fail", "valex.dummy:fail"}
+	wantFailures := []string{"valex.dummy:min", "valex.dummy:alphanum", "valex.dummy:min"}
+	if !slices.Equal(r.validations, wantValidations) {
+		t.Errorf("expected validations %v, got %v", wantValidations, r.validations)
+	}
+	if !slices.Equal(r.failures, wantFailures) {
+		t.Errorf("expected failures %v, got %v", wantFailures, r.failures)
+	}
+
+	e.SetRecorder(nil)
+	e.ValidateStruct(dummy{Name: "Jo"})
+	if len(r.validations) != 3 {
+		t.Errorf("expected no recording after SetRecorder(nil)")
+	}
+}
@@ -0,0 +1,80 @@
+package valex
+
+import (
+	"container/list"
+	"regexp"
+	"sync"
+)
+
+// regexCacheSize bounds the number of compiled patterns kept around, so tags
+// built from user input cannot grow the cache without limit.
+const regexCacheSize = 256
+
+type regexEntry struct {
+	expr string
+	re   *regexp.Regexp
+}
+
+// regexCache is an LRU cache of compiled patterns shared by all engines.
+type regexCache struct {
+	mut     sync.Mutex
+	entries map[string]*list.Element
+	order   list.List // most recently used first
+}
+
+var regexes = &regexCache{entries: make(map[string]*list.Element)}
+
+func (c *regexCache) compile(expr string) (*regexp.Regexp, error) {
+	c.mut.Lock()
+	if el, ok := c.entries[expr]; ok {
+		c.order.MoveToFront(el)
+		c.mut.Unlock()
+		return el.Value.(*regexEntry).re, nil
+	}
+	c.mut.Unlock()
+
+	// compile outside the lock, a concurrent compile of expr just loses
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	if el, ok := c.entries[expr]; ok {
+		c.order.MoveToFront(el)
+		return el.Value.(*regexEntry).re, nil
+	}
+	c.entries[expr] = c.order.PushFront(&regexEntry{expr: expr, re: re})
+	if c.order.Len() > regexCacheSize {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*regexEntry).expr)
+	}
+	return re, nil
+}
+
+func (c *regexCache) len() int {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	return c.order.Len()
+}
+
+func (c *regexCache) clear() {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	clear(c.entries)
+	c.order.Init()
+}
+
+// compileRegex compiles expr once and returns the cached *regexp.Regexp on
+// later calls.
+func compileRegex(expr string) (*regexp.Regexp, error) {
+	return regexes.compile(expr)
+}
+
+// ClearRegexCache drops all compiled patterns, e.g. between tests.
+// Directives configured earlier keep the patterns they already hold.
+func ClearRegexCache() {
+	regexes.clear()
+}
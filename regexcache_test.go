@@ -0,0 +1,69 @@
+package valex
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestCompileRegex(t *testing.T) {
+	ClearRegexCache()
+	t.Cleanup(ClearRegexCache)
+
+	a, err := compileRegex(`^\d+$`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := compileRegex(`^\d+$`)
+	if a != b {
+		t.Error("expected the cached pattern to be returned")
+	}
+	if _, err := compileRegex(`(`); err == nil {
+		t.Error("expected an error for an invalid pattern")
+	}
+	if n := regexes.len(); n != 1 {
+		t.Errorf("expected 1 cached pattern, got %d", n)
+	}
+
+	ClearRegexCache()
+	if n := regexes.len(); n != 0 {
+		t.Errorf("expected an empty cache, got %d", n)
+	}
+	if c, _ := compileRegex(`^\d+$`); c == a {
+		t.Error("expected a fresh pattern after clearing")
+	}
+}
+
+func TestCompileRegex_Evicts(t *testing.T) {
+	ClearRegexCache()
+	t.Cleanup(ClearRegexCache)
+
+	first, _ := compileRegex("^0$")
+	for n := 1; n <= regexCacheSize; n++ {
+		if n == regexCacheSize/2 {
+			compileRegex("^0$") // keep the first pattern in use
+		}
+		compileRegex(fmt.Sprintf("^%d$", n))
+	}
+	if n := regexes.len(); n != regexCacheSize {
+		t.Errorf("expected %d cached patterns, got %d", regexCacheSize, n)
+	}
+	if re, _ := compileRegex("^0$"); re != first {
+		t.Error("expected a recently used pattern to survive eviction")
+	}
+	if _, ok := regexes.entries["^1$"]; ok {
+		t.Error("expected the least recently used pattern to be evicted")
+	}
+}
+
+func BenchmarkRegexDirective(b *testing.B) {
+	type code struct {
+		Code string `val:"regex=^[A-Z]{3}$"`
+	}
+	c := code{Code: "ABC"}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if ok, err := ValidateStruct(&c); !ok {
+			b.Fatal(err)
+		}
+	}
+}
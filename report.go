@@ -0,0 +1,97 @@
+package valex
+
+import (
+	"context"
+	"slices"
+)
+
+// Report lists every field ValidateStructReport checked, in the order the
+// fields were validated, with the outcome of each directive.
+type Report struct {
+	Fields []FieldReport `json:"fields"`
+}
+
+// Passed reports whether every field passed.
+func (r *Report) Passed() bool {
+	for i := range r.Fields {
+		if !r.Fields[i].Passed() {
+			return false
+		}
+	}
+	return true
+}
+
+// Failed returns the fields that did not pass.
+func (r *Report) Failed() []FieldReport {
+	var failed []FieldReport
+	for _, f := range r.Fields {
+		if !f.Passed() {
+			failed = append(failed, f)
+		}
+	}
+	return failed
+}
+
+// FieldReport holds the directives run on a field or on a dived into
+// element. Directives that were not reached, because an earlier one failed
+// or omitempty skipped the value, are not listed.
+type FieldReport struct {
+	Field      string            `json:"field"`
+	Path       FieldPath         `json:"-"`
+	Directives []DirectiveResult `json:"directives"`
+}
+
+// Passed reports whether no directive failed. Warnings do not count.
+func (f FieldReport) Passed() bool {
+	for _, d := range f.Directives {
+		if !d.Passed && !d.Warning {
+			return false
+		}
+	}
+	return true
+}
+
+func (f *FieldReport) add(directive string, warn bool, err error) {
+	d := DirectiveResult{Directive: directive, Passed: err == nil, Warning: warn, Err: err}
+	if err != nil {
+		d.Message = err.Error()
+	}
+	f.Directives = append(f.Directives, d)
+}
+
+// DirectiveResult is the outcome of a single directive. Warning is set for
+// directives that follow the warn directive.
+type DirectiveResult struct {
+	Directive string `json:"directive"`
+	Passed    bool   `json:"passed"`
+	Warning   bool   `json:"warning,omitempty"`
+	Message   string `json:"message,omitempty"`
+	Err       error  `json:"-"`
+}
+
+// ValidateStructReport validates data with WithCollectAll and reports every
+// field and directive checked, e.g. to debug rule sets or for audit
+// logging. The error is the one ValidateStruct would return.
+func (e *Engine) ValidateStructReport(data any, opts ...Option) (*Report, error) {
+	o := newOptions(append(opts, WithCollectAll()))
+	o.report = true
+	v, err := e.run(context.Background(), data, o, phaseAll)
+	if err != nil {
+		return nil, err
+	}
+	_, err = v.result()
+	return &Report{Fields: v.reports}, err
+}
+
+func ValidateStructReport(data any, opts ...Option) (*Report, error) {
+	return std.ValidateStructReport(data, opts...)
+}
+
+// report starts the report of path, or returns nil when not reporting.
+func (v *validation) report(path FieldPath) *FieldReport {
+	if !v.o.report || v.sanitizing {
+		return nil
+	}
+	v.reports = append(v.reports, FieldReport{Field: v.fieldName(path), Path: slices.Clone(path)})
+	return &v.reports[len(v.reports)-1]
+}
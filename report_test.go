@@ -0,0 +1,94 @@
+package valex
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestValidateStructReport(t *testing.T) {
+	type inner struct {
+		Code string `val:"alphanum"`
+	}
+	type dummy struct {
+		Name  string   `val:"min,size=2,alphanum"`
+		Email string   `val:"omitempty,email"`
+		Note  string   `val:"warn,max,size=3"`
+		Tags  []string `val:"dive,alphanum"`
+		Inner inner    `val:"dive"`
+	}
+
+	summary := func(r *Report) []string {
+		var got []string
+		for _, f := range r.Fields {
+			s := f.Field + ":"
+			for _, d := range f.Directives {
+				switch {
+				case d.Warning && !d.Passed:
+					s += d.Directive + "~"
+				case d.Passed:
+					s += d.Directive + "+"
+				default:
+					s += d.Directive + "-"
+				}
+			}
+			got = append(got, s)
+		}
+		return got
+	}
+
+	tests := []struct {
+		name   string
+		data   dummy
+		want   []string
+		passed bool
+	}{
+		{
+			name:   "pass",
+			data:   dummy{Name: "ab", Note: "ok", Tags: []string{"a"}, Inner: inner{Code: "x"}},
+			want:   []string{"Name:min+alphanum+", "Email:", "Note:max+", "Tags:", "Tags[0]:alphanum+", "Inner:", "Inner.Code:alphanum+"},
+			passed: true,
+		},
+		{
+			name:   "fail",
+			data:   dummy{Name: "a", Email: "nope", Note: "long", Tags: []string{"a", "b c"}, Inner: inner{Code: "x y"}},
+			want:   []string{"Name:min-", "Email:email-", "Note:max~", "Tags:", "Tags[0]:alphanum+", "Tags[1]:alphanum-", "Inner:", "Inner.Code:alphanum-"},
+			passed: false,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r, err := ValidateStructReport(&tc.data)
+			if (err == nil) != tc.passed {
+				t.Errorf("expected passed %v, got error %v", tc.passed, err)
+			}
+			if r.Passed() != tc.passed {
+				t.Errorf("expected Passed %v", tc.passed)
+			}
+			if got := summary(r); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("expected %v, got %v", tc.want, got)
+			}
+			if !tc.passed && len(r.Failed()) != 4 {
+				t.Errorf("expected 4 failed fields, got %d", len(r.Failed()))
+			}
+		})
+	}
+
+	r, _ := ValidateStructReport(&dummy{Name: "a"}, WithConcurrency(4))
+	seq, _ := ValidateStructReport(&dummy{Name: "a"})
+	if !reflect.DeepEqual(summary(r), summary(seq)) {
+		t.Errorf("expected concurrent report %v, got %v", summary(seq), summary(r))
+	}
+	b, err := json.Marshal(r.Fields[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	const want = `{"field":"Name","directives":[{"directive":"min","passed":false,"message":"value a exeeds minimum length 2"}]}`
+	if string(b) != want {
+		t.Errorf("expected %s, got %s", want, b)
+	}
+
+	if _, err := ValidateStructReport(42); err == nil {
+		t.Error("expected an error for a non-struct")
+	}
+}
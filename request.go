@@ -0,0 +1,52 @@
+package valex
+
+import (
+	"errors"
+	"net/http"
+	"net/textproto"
+	"net/url"
+)
+
+// ValidateQuery validates every value of each query parameter in rules. A
+// missing parameter is validated as the empty string, so rules decide
+// whether it is required. Failures are reported per parameter.
+func ValidateQuery(q url.Values, rules map[string]Validator[string]) error {
+	return validateValues(q, rules, func(key string) string { return key })
+}
+
+// ValidateHeaders is ValidateQuery for headers. Header names in rules are
+// matched case-insensitively.
+func ValidateHeaders(h http.Header, rules map[string]Validator[string]) error {
+	return validateValues(h, rules, textproto.CanonicalMIMEHeaderKey)
+}
+
+func validateValues(values map[string][]string, rules map[string]Validator[string], canonical func(string) string) error {
+	errs := make(CoercionErrors)
+	for key, v := range rules {
+		vals := values[canonical(key)]
+		if len(vals) == 0 {
+			vals = []string{""}
+		}
+		for _, val := range vals {
+			if ok, err := v.Validate(val); !ok {
+				errs[key] = WithCategory(validationFailed(err), CategoryValidation)
+				break
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+var errValidationFailed = errors.New("validation failed")
+
+// validationFailed returns err, or a generic error for validators that
+// report failure without one.
+func validationFailed(err error) error {
+	if err == nil {
+		return errValidationFailed
+	}
+	return err
+}
@@ -0,0 +1,73 @@
+package valex
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestValidateQuery(t *testing.T) {
+	rules := map[string]Validator[string]{
+		"page":  ValidatorFunc[string](func(s string) (bool, error) { return s != "0", errors.New("page must not be 0") }),
+		"q":     &NonEmptyStringValidator{},
+		"email": &EmailValidator{},
+	}
+	tests := []struct {
+		name     string
+		query    string
+		wantKeys []string
+	}{
+		{name: "valid", query: "page=2&q=shoes&email=a@b.co"},
+		{name: "missing required", query: "page=2&email=a@b.co", wantKeys: []string{"q"}},
+		{name: "repeated value", query: "page=2&q=shoes&email=a@b.co&email=nope", wantKeys: []string{"email"}},
+		{name: "several", query: "page=0&email=nope", wantKeys: []string{"email", "page", "q"}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			q, _ := url.ParseQuery(tc.query)
+			err := ValidateQuery(q, rules)
+			if tc.wantKeys == nil {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			var errs CoercionErrors
+			if !errors.As(err, &errs) {
+				t.Fatalf("expected CoercionErrors, got %v", err)
+			}
+			if got := errs.keys(); !reflect.DeepEqual(got, tc.wantKeys) {
+				t.Errorf("expected errors for %v, got %v", tc.wantKeys, errs)
+			}
+			if c := Categorize(err); c != CategoryValidation {
+				t.Errorf("expected a validation error, got %v", c)
+			}
+		})
+	}
+}
+
+func TestValidateHeaders(t *testing.T) {
+	h := http.Header{}
+	h.Set("X-Request-Id", "abc123")
+	rules := map[string]Validator[string]{
+		"x-request-id": &AlphaNumericValidator{},
+		"X-Tenant":     &NonEmptyStringValidator{},
+	}
+	err := ValidateHeaders(h, rules)
+	var errs CoercionErrors
+	if !errors.As(err, &errs) || len(errs) != 1 || errs["X-Tenant"] == nil {
+		t.Errorf("expected only X-Tenant to fail, got %v", err)
+	}
+}
+
+func TestValidateQuery_FailureWithoutError(t *testing.T) {
+	rules := map[string]Validator[string]{
+		"a": ValidatorFunc[string](func(string) (bool, error) { return false, nil }),
+	}
+	err := ValidateQuery(url.Values{}, rules)
+	if err == nil || err.Error() != "a: validation failed" {
+		t.Errorf("expected a generic failure, got %v", err)
+	}
+}
@@ -0,0 +1,58 @@
+package valex
+
+import (
+	"context"
+	"errors"
+	"reflect"
+)
+
+const requiredDirectiveName = "required"
+
+// requiredDirective fails on missing values, e.g. `val:"required"`. Unlike
+// !empty it works on fields of any kind: strings, slices and maps must not be
+// empty, pointers, interfaces, channels and funcs must not be nil, and other
+// values must not be the zero value of their type.
+type requiredDirective struct{}
+
+func (requiredDirective) valueType() reflect.Type {
+	return reflect.TypeFor[any]()
+}
+
+func (requiredDirective) params() params {
+	return nil
+}
+
+func (d requiredDirective) instance(args map[string]string) (any, error) {
+	return d, nil
+}
+
+func (requiredDirective) handleAny(_ context.Context, _ any, val reflect.Value) error {
+	if !present(val) {
+		return errors.New("value is required")
+	}
+	return nil
+}
+
+func present(val reflect.Value) bool {
+	switch val.Kind() {
+	case reflect.String, reflect.Slice, reflect.Map:
+		return val.Len() > 0
+	case reflect.Ptr, reflect.Interface, reflect.Chan, reflect.Func:
+		return !val.IsNil()
+	}
+	return !val.IsZero()
+}
+
+func (requiredDirective) describeSchema(f *schemaField) {
+	f.required = true
+	switch f.schema["type"] {
+	case "string":
+		f.schema["minLength"] = 1
+	case "array":
+		f.schema["minItems"] = 1
+	case "object":
+		if _, ok := f.schema["additionalProperties"]; ok {
+			f.schema["minProperties"] = 1
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package valex
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type requiredItem struct {
+	Name string
+}
+
+type requiredDummy struct {
+	Name    string            `json:"name" val:"required"`
+	Age     int               `json:"age" val:"required"`
+	Score   float64           `json:"score" val:"required"`
+	Active  bool              `json:"active" val:"required"`
+	Tags    []string          `json:"tags" val:"required"`
+	Labels  map[string]string `json:"labels" val:"required"`
+	Item    *requiredItem     `json:"item" val:"required"`
+	Any     any               `json:"any" val:"required"`
+	Created time.Time         `json:"created" val:"required"`
+	Nested  requiredItem      `json:"nested" val:"required"`
+}
+
+func validRequiredDummy() requiredDummy {
+	return requiredDummy{
+		Name:    "John",
+		Age:     30,
+		Score:   0.5,
+		Active:  true,
+		Tags:    []string{"a"},
+		Labels:  map[string]string{"a": "b"},
+		Item:    &requiredItem{},
+		Any:     0, // a non-nil interface holding a zero value
+		Created: time.Unix(0, 1),
+		Nested:  requiredItem{Name: "x"},
+	}
+}
+
+func TestRequiredDirective(t *testing.T) {
+	tests := []struct {
+		field  string
+		modify func(d *requiredDummy)
+	}{
+		{"Name", func(d *requiredDummy) { d.Name = "" }},
+		{"Age", func(d *requiredDummy) { d.Age = 0 }},
+		{"Score", func(d *requiredDummy) { d.Score = 0 }},
+		{"Active", func(d *requiredDummy) { d.Active = false }},
+		{"Tags", func(d *requiredDummy) { d.Tags = []string{} }},
+		{"Labels", func(d *requiredDummy) { d.Labels = map[string]string{} }},
+		{"Item", func(d *requiredDummy) { d.Item = nil }},
+		{"Any", func(d *requiredDummy) { d.Any = nil }},
+		{"Created", func(d *requiredDummy) { d.Created = time.Time{} }},
+		{"Nested", func(d *requiredDummy) { d.Nested = requiredItem{} }},
+	}
+
+	d := validRequiredDummy()
+	if ok, err := ValidateStruct(&d); !ok {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, tc := range tests {
+		t.Run(tc.field, func(t *testing.T) {
+			d := validRequiredDummy()
+			tc.modify(&d)
+			ok, err := ValidateStruct(&d)
+			if ok || !strings.Contains(err.Error(), `"`+tc.field+`"`) || !strings.Contains(err.Error(), "value is required") {
+				t.Errorf("expected field %s to be required, got %v", tc.field, err)
+			}
+		})
+	}
+}
+
+func TestRequiredDirective_Schema(t *testing.T) {
+	s, err := GenerateJSONSchema(requiredDummy{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	props := s["properties"].(map[string]any)
+	for prop, want := range map[string]map[string]any{
+		"name":   {"type": "string", "minLength": 1},
+		"tags":   {"type": "array", "items": map[string]any{"type": "string"}, "minItems": 1},
+		"labels": {"type": "object", "additionalProperties": map[string]any{"type": "string"}, "minProperties": 1},
+	} {
+		if got := props[prop]; !reflect.DeepEqual(got, want) {
+			t.Errorf("property %q: expected %v, got %v", prop, want, got)
+		}
+	}
+	if req := s["required"].([]string); len(req) != 10 {
+		t.Errorf("expected all properties to be required, got %v", req)
+	}
+}
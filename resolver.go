@@ -0,0 +1,169 @@
+package valex
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Resolver looks up DNS records for the network-backed directives. Engines
+// use net.DefaultResolver unless another one is set with SetResolver, e.g. a
+// fake one in tests.
+type Resolver interface {
+	LookupMX(ctx context.Context, name string) ([]*net.MX, error)
+	LookupHost(ctx context.Context, host string) ([]string, error)
+}
+
+type resolverBox struct {
+	Resolver
+}
+
+// SetResolver sets the resolver handed to directives. A nil resolver
+// restores net.DefaultResolver.
+func (e *Engine) SetResolver(r Resolver) {
+	if r == nil {
+		r = net.DefaultResolver
+	}
+	e.resolver.Store(resolverBox{r})
+}
+
+func (e *Engine) Resolver() Resolver {
+	if b, ok := e.resolver.Load().(resolverBox); ok {
+		return b.Resolver
+	}
+	return net.DefaultResolver
+}
+
+type resolverKey struct{}
+
+func withResolver(ctx context.Context, r Resolver) context.Context {
+	return context.WithValue(ctx, resolverKey{}, r)
+}
+
+// ResolverFromContext returns the resolver of the engine running the
+// validation, for directives implementing ContextHandler, or
+// net.DefaultResolver.
+func ResolverFromContext(ctx context.Context) Resolver {
+	if r, ok := ctx.Value(resolverKey{}).(Resolver); ok {
+		return r
+	}
+	return net.DefaultResolver
+}
+
+const defaultLookupTimeout = 5 * time.Second
+
+// The directives below query DNS, so they only run when named in a tag and
+// are deferred by ValidateAsync. Each lookup is bounded by Timeout, five
+// seconds by default. A lookup that fails for other reasons than the name
+// not existing, e.g. a timeout, fails the value unless FailOpen is set.
+
+// EmailMXValidator accepts email addresses whose domain has MX records,
+// e.g. `val:"email,emailmx,timeout=2s"`.
+type EmailMXValidator struct {
+	Timeout  time.Duration `param:"timeout,optional"`
+	FailOpen bool          `param:"failopen,optional"`
+}
+
+func (v *EmailMXValidator) HandleContext(ctx context.Context, val string) error {
+	addr, err := mail.ParseAddress(val)
+	if err != nil {
+		return err
+	}
+	domain := addr.Address[strings.LastIndexByte(addr.Address, '@')+1:]
+
+	ctx, cancel := lookupContext(ctx, v.Timeout)
+	defer cancel()
+	mxs, err := ResolverFromContext(ctx).LookupMX(ctx, domain)
+	if err != nil {
+		return lookupError(err, v.FailOpen, "domain %q has no mail servers", domain)
+	}
+	if len(mxs) == 0 || (len(mxs) == 1 && mxs[0].Host == ".") {
+		return fmt.Errorf("domain %q does not accept mail", domain) // RFC 7505 null MX
+	}
+	return nil
+}
+
+func (v *EmailMXValidator) Handle(val string) error {
+	return v.HandleContext(context.Background(), val)
+}
+
+func (v *EmailMXValidator) Deferred() bool {
+	return true
+}
+
+func (v *EmailMXValidator) Name() string {
+	return "emailmx"
+}
+
+// ResolvableHostValidator accepts host names, and URLs with a host, that
+// resolve to at least one address, e.g. `val:"url,resolvable"`. IP
+// addresses are accepted without a lookup.
+type ResolvableHostValidator struct {
+	Timeout  time.Duration `param:"timeout,optional"`
+	FailOpen bool          `param:"failopen,optional"`
+}
+
+func (v *ResolvableHostValidator) HandleContext(ctx context.Context, val string) error {
+	host := val
+	if strings.Contains(val, "://") {
+		u, err := url.Parse(val)
+		if err != nil {
+			return err
+		}
+		host = u.Hostname()
+	}
+	if host == "" {
+		return fmt.Errorf("value %q has no host", val)
+	}
+	if net.ParseIP(strings.Trim(host, "[]")) != nil {
+		return nil
+	}
+
+	ctx, cancel := lookupContext(ctx, v.Timeout)
+	defer cancel()
+	addrs, err := ResolverFromContext(ctx).LookupHost(ctx, host)
+	if err != nil {
+		return lookupError(err, v.FailOpen, "host %q does not resolve", host)
+	}
+	if len(addrs) == 0 {
+		return fmt.Errorf("host %q does not resolve", host)
+	}
+	return nil
+}
+
+func (v *ResolvableHostValidator) Handle(val string) error {
+	return v.HandleContext(context.Background(), val)
+}
+
+func (v *ResolvableHostValidator) Deferred() bool {
+	return true
+}
+
+func (v *ResolvableHostValidator) Name() string {
+	return "resolvable"
+}
+
+func lookupContext(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		timeout = defaultLookupTimeout
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// lookupError reports a name that does not exist with the message format,
+// and other lookup failures as such, or not at all with failOpen.
+func lookupError(err error, failOpen bool, format string, args ...any) error {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+		return fmt.Errorf(format, args...)
+	}
+	if failOpen {
+		return nil
+	}
+	return fmt.Errorf("lookup failed: %w", err)
+}
@@ -0,0 +1,135 @@
+package valex
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+type fakeResolver struct {
+	mx    map[string][]*net.MX
+	hosts map[string][]string
+	err   error
+}
+
+func (r *fakeResolver) LookupMX(ctx context.Context, name string) ([]*net.MX, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	if mxs, ok := r.mx[name]; ok {
+		return mxs, nil
+	}
+	return nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+}
+
+func (r *fakeResolver) LookupHost(ctx context.Context, host string) ([]string, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	if addrs, ok := r.hosts[host]; ok {
+		return addrs, nil
+	}
+	return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+}
+
+// blockingResolver waits for the lookup context to end.
+type blockingResolver struct{}
+
+func (blockingResolver) LookupMX(ctx context.Context, name string) ([]*net.MX, error) {
+	<-ctx.Done()
+	return nil, &net.DNSError{Err: ctx.Err().Error(), Name: name, IsTimeout: true}
+}
+
+func (blockingResolver) LookupHost(ctx context.Context, host string) ([]string, error) {
+	<-ctx.Done()
+	return nil, &net.DNSError{Err: ctx.Err().Error(), Name: host, IsTimeout: true}
+}
+
+var testResolver = &fakeResolver{
+	mx: map[string][]*net.MX{
+		"example.com": {{Host: "mx.example.com.", Pref: 10}},
+		"null.test":   {{Host: ".", Pref: 0}},
+	},
+	hosts: map[string][]string{
+		"example.com": {"93.184.215.14"},
+	},
+}
+
+func TestNetworkValidators(t *testing.T) {
+	ctx := withResolver(context.Background(), testResolver)
+	tests := []struct {
+		name      string
+		v         ContextHandler[string]
+		input     string
+		errSubstr string
+	}{
+		{"mx", &EmailMXValidator{}, "jane@example.com", ""},
+		{"mx display name", &EmailMXValidator{}, "Jane <jane@example.com>", ""},
+		{"no mx", &EmailMXValidator{}, "jane@nowhere.test", `domain "nowhere.test" has no mail servers`},
+		{"null mx", &EmailMXValidator{}, "jane@null.test", "does not accept mail"},
+		{"not an address", &EmailMXValidator{}, "jane", "missing '@'"},
+		{"host", &ResolvableHostValidator{}, "example.com", ""},
+		{"url", &ResolvableHostValidator{}, "https://example.com:8443/path", ""},
+		{"ip", &ResolvableHostValidator{}, "http://[::1]/", ""},
+		{"unknown host", &ResolvableHostValidator{}, "https://nowhere.test/", `host "nowhere.test" does not resolve`},
+		{"no host", &ResolvableHostValidator{}, "file:///etc/hosts", "has no host"},
+	}
+	for _, tc := range tests {
+		err := tc.v.HandleContext(ctx, tc.input)
+		if tc.errSubstr == "" {
+			if err != nil {
+				t.Errorf("%s(%q): unexpected error: %v", tc.name, tc.input, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tc.errSubstr) {
+			t.Errorf("%s(%q): expected error containing %q, got %v", tc.name, tc.input, tc.errSubstr, err)
+		}
+	}
+}
+
+func TestNetworkValidators_LookupFailure(t *testing.T) {
+	failing := &fakeResolver{err: errors.New("server misbehaving")}
+	ctx := withResolver(context.Background(), failing)
+
+	err := (&EmailMXValidator{}).HandleContext(ctx, "jane@example.com")
+	if err == nil || !strings.Contains(err.Error(), "lookup failed: server misbehaving") {
+		t.Errorf("expected a lookup error, got %v", err)
+	}
+	if err := (&EmailMXValidator{FailOpen: true}).HandleContext(ctx, "jane@example.com"); err != nil {
+		t.Errorf("expected failopen to accept, got %v", err)
+	}
+
+	ctx = withResolver(context.Background(), blockingResolver{})
+	start := time.Now()
+	err = (&ResolvableHostValidator{Timeout: 10 * time.Millisecond}).HandleContext(ctx, "example.com")
+	if err == nil || !strings.Contains(err.Error(), "lookup failed") {
+		t.Errorf("expected a timeout, got %v", err)
+	}
+	if took := time.Since(start); took > time.Second {
+		t.Errorf("lookup took %v, expected the timeout to end it", took)
+	}
+}
+
+func TestNetworkValidators_Engine(t *testing.T) {
+	e := NewEngine()
+	e.SetResolver(testResolver)
+	type signup struct {
+		Email   string `val:"email,emailmx,timeout=1s"`
+		Website string `val:"url,resolvable"`
+	}
+	if _, err := e.ValidateStruct(&signup{Email: "jane@example.com", Website: "https://example.com"}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, err := e.ValidateStruct(&signup{Email: "jane@nowhere.test", Website: "https://example.com"}); err == nil {
+		t.Error("expected a domain without mail servers to fail")
+	}
+
+	e.SetResolver(nil)
+	if _, ok := e.Resolver().(*net.Resolver); !ok {
+		t.Errorf("expected the default resolver, got %T", e.Resolver())
+	}
+}
@@ -0,0 +1,31 @@
+package valex
+
+import "context"
+
+// Result is the outcome of ValidateStructResult. Warnings holds the failures
+// of directives that follow the warn directive; they never make Valid false.
+type Result struct {
+	Valid    bool
+	Err      error
+	Warnings ValidationErrors
+}
+
+// HasWarnings reports whether any directive reported a warning.
+func (r *Result) HasWarnings() bool {
+	return len(r.Warnings) > 0
+}
+
+// ValidateStructResult validates data like ValidateStruct and also reports
+// the warnings collected along the way, e.g. to lint user configuration.
+func (e *Engine) ValidateStructResult(data any, opts ...Option) *Result {
+	v, err := e.run(context.Background(), data, newOptions(opts), phaseAll)
+	if err != nil {
+		return &Result{Err: err}
+	}
+	ok, err := v.result()
+	return &Result{Valid: ok, Err: err, Warnings: v.warnings}
+}
+
+func ValidateStructResult(data any, opts ...Option) *Result {
+	return std.ValidateStructResult(data, opts...)
+}
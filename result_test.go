@@ -0,0 +1,50 @@
+package valex
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestValidateStructResult(t *testing.T) {
+	type dummy struct {
+		Name  string   `val:"min,size=2,warn,alphanum"`
+		Email string   `val:"warn,email"`
+		Tags  []string `val:"dive,warn,max,size=3"`
+	}
+
+	tests := []struct {
+		name     string
+		data     dummy
+		valid    bool
+		warnings []string
+	}{
+		{"clean", dummy{Name: "ab", Email: "a@b.io", Tags: []string{"go"}}, true, nil},
+		{"warnings only", dummy{Name: "a b", Email: "nope", Tags: []string{"gopher"}}, true, []string{"Name:alphanum", "Email:email", "Tags[0]:max"}},
+		{"error and warning", dummy{Name: "a", Email: "nope"}, false, []string{"Email:email"}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := ValidateStructResult(&tc.data, WithCollectAll())
+			if r.Valid != tc.valid {
+				t.Errorf("expected valid %v, got %v (%v)", tc.valid, r.Valid, r.Err)
+			}
+			var got []string
+			for _, fe := range r.Warnings {
+				got = append(got, fe.Field+":"+fe.Directive)
+			}
+			if !reflect.DeepEqual(got, tc.warnings) {
+				t.Errorf("expected warnings %v, got %v", tc.warnings, got)
+			}
+			if r.HasWarnings() != (len(tc.warnings) > 0) {
+				t.Errorf("HasWarnings mismatch")
+			}
+			if ok, _ := ValidateStruct(&tc.data); ok != tc.valid {
+				t.Errorf("ValidateStruct: expected %v, got %v", tc.valid, ok)
+			}
+		})
+	}
+
+	if r := ValidateStructResult(42); r.Valid || r.Err == nil {
+		t.Errorf("expected an error for a non-struct, got %+v", r)
+	}
+}
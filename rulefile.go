@@ -0,0 +1,201 @@
+package valex
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// RulesFile returns a RulesProvider that reads rules from the given JSON or
+// YAML files, chosen by extension (.json, .yaml or .yml), every time the
+// engine loads its rules. Files are merged in order: a field in a later file
+// replaces the same field in an earlier one. Both formats map type names to
+// field names and tag values:
+//
+//	api.User:
+//	  Name: min,size=3
+//	  Email: "email,max,size=254"
+//
+// Only this shape of YAML is understood: block mappings two levels deep with
+// plain, single or double quoted scalars, and comments.
+func RulesFile(paths ...string) RulesProvider {
+	return RulesProviderFunc(func(ctx context.Context) (Rules, error) {
+		rules := make(Rules)
+		for _, path := range paths {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			r, err := LoadRulesFile(path)
+			if err != nil {
+				return nil, err
+			}
+			rules.merge(r)
+		}
+		return rules, nil
+	})
+}
+
+// LoadRulesFile reads the rules of a single JSON or YAML file, see RulesFile.
+func LoadRulesFile(path string) (Rules, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var rules Rules
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".json":
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		err = dec.Decode(&rules)
+	case ".yaml", ".yml":
+		rules, err = parseYAMLRules(data)
+	default:
+		return nil, fmt.Errorf("%s: unsupported rules file extension %q", path, ext)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return rules, nil
+}
+
+func (r Rules) merge(other Rules) {
+	for typeName, fields := range other {
+		if r[typeName] == nil {
+			r[typeName] = make(map[string]string, len(fields))
+		}
+		for field, tagValue := range fields {
+			r[typeName][field] = tagValue
+		}
+	}
+}
+
+func parseYAMLRules(data []byte) (Rules, error) {
+	rules := make(Rules)
+	var (
+		fields      map[string]string
+		fieldIndent int
+	)
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	for n := 1; sc.Scan(); n++ {
+		line := strings.TrimRight(sc.Text(), " \t\r")
+		trimmed := strings.TrimLeft(line, " ")
+		if trimmed == "" || trimmed[0] == '#' || (n == 1 && line == "---") {
+			continue
+		}
+		if strings.HasPrefix(trimmed, "\t") {
+			return nil, fmt.Errorf("line %d: tabs are not allowed for indentation", n)
+		}
+		indent := len(line) - len(trimmed)
+		key, value, err := yamlKeyValue(trimmed)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n, err)
+		}
+
+		switch {
+		case indent == 0:
+			if value != "" {
+				return nil, fmt.Errorf("line %d: expected a mapping of fields for type %q", n, key)
+			}
+			if _, ok := rules[key]; ok {
+				return nil, fmt.Errorf("line %d: duplicate type %q", n, key)
+			}
+			fields, fieldIndent = make(map[string]string), 0
+			rules[key] = fields
+		case fields == nil:
+			return nil, fmt.Errorf("line %d: field %q outside of a type", n, key)
+		default:
+			if fieldIndent == 0 {
+				fieldIndent = indent
+			}
+			if indent != fieldIndent {
+				return nil, fmt.Errorf("line %d: inconsistent indentation", n)
+			}
+			if _, ok := fields[key]; ok {
+				return nil, fmt.Errorf("line %d: duplicate field %q", n, key)
+			}
+			fields[key] = value
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// yamlKeyValue splits a `key: value` line, unquoting both and dropping a
+// trailing comment.
+func yamlKeyValue(s string) (key, value string, err error) {
+	key, rest, err := yamlScalar(s, true)
+	if err != nil {
+		return "", "", err
+	}
+	if !strings.HasPrefix(rest, ":") {
+		return "", "", fmt.Errorf("expected \"key: value\" but got %q", s)
+	}
+	rest = strings.TrimLeft(rest[1:], " ")
+	if rest == "" || rest[0] == '#' {
+		return key, "", nil
+	}
+	value, rest, err = yamlScalar(rest, false)
+	if err != nil {
+		return "", "", err
+	}
+	if rest = strings.TrimLeft(rest, " "); rest != "" && rest[0] != '#' {
+		return "", "", fmt.Errorf("unexpected %q after value", rest)
+	}
+	return key, value, nil
+}
+
+// yamlScalar reads a scalar from the start of s and returns it with the
+// remainder of s. A plain key ends at the first ": " or trailing ":", a
+// plain value at a " #" comment.
+func yamlScalar(s string, isKey bool) (scalar, rest string, err error) {
+	switch s[0] {
+	case '"':
+		end := 1
+		for ; end < len(s) && s[end] != '"'; end++ {
+			if s[end] == '\\' {
+				end++
+			}
+		}
+		if end >= len(s) {
+			return "", "", fmt.Errorf("unterminated string %s", s)
+		}
+		scalar, err = strconv.Unquote(s[:end+1])
+		return scalar, s[end+1:], err
+	case '\'':
+		var b strings.Builder
+		for i := 1; i < len(s); i++ {
+			if s[i] != '\'' {
+				b.WriteByte(s[i])
+				continue
+			}
+			if i+1 < len(s) && s[i+1] == '\'' {
+				b.WriteByte('\'')
+				i++
+				continue
+			}
+			return b.String(), s[i+1:], nil
+		}
+		return "", "", fmt.Errorf("unterminated string %s", s)
+	case '[', '{', '&', '*', '!', '|', '>', '-':
+		return "", "", fmt.Errorf("unsupported YAML %q, only plain and quoted scalars are allowed", s)
+	}
+	end := len(s)
+	if isKey {
+		if i := strings.Index(s, ": "); i >= 0 {
+			end = i
+		} else if strings.HasSuffix(s, ":") {
+			end = len(s) - 1
+		}
+	} else if i := strings.Index(s, " #"); i >= 0 {
+		end = i
+	}
+	return strings.TrimRight(s[:end], " "), s[end:], nil
+}
@@ -0,0 +1,107 @@
+package valex
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func writeRulesFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadRulesFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		want    Rules
+		err     string
+	}{
+		{
+			name: "yaml",
+			file: "rules.yaml",
+			content: `---
+# tightened for production
+valex.rulesDummy:
+  Name: min,size=5 # was 3
+  Code: "alphanum"
+'api.User':
+    Email: 'email,max,size=254'
+    "Note": ""
+`,
+			want: Rules{
+				"valex.rulesDummy": {"Name": "min,size=5", "Code": "alphanum"},
+				"api.User":         {"Email": "email,max,size=254", "Note": ""},
+			},
+		},
+		{
+			name:    "json",
+			file:    "rules.json",
+			content: `{"valex.rulesDummy": {"Name": "min,size=5"}}`,
+			want:    Rules{"valex.rulesDummy": {"Name": "min,size=5"}},
+		},
+		{name: "extension", file: "rules.toml", content: "", err: "unsupported rules file extension"},
+		{name: "json syntax", file: "rules.json", content: `{"a": 1}`, err: "rules.json"},
+		{name: "value on type", file: "rules.yml", content: "api.User: email\n", err: "line 1: expected a mapping"},
+		{name: "field outside type", file: "rules.yml", content: "  Name: email\n", err: "line 1: field \"Name\" outside of a type"},
+		{name: "indentation", file: "rules.yml", content: "api.User:\n  Name: email\n    Code: alphanum\n", err: "line 3: inconsistent indentation"},
+		{name: "duplicate field", file: "rules.yml", content: "api.User:\n  Name: email\n  Name: alphanum\n", err: "line 3: duplicate field"},
+		{name: "sequence", file: "rules.yml", content: "api.User:\n  Name: [email]\n", err: "line 2: unsupported YAML"},
+		{name: "unterminated", file: "rules.yml", content: "api.User:\n  Name: \"email\n", err: "line 2: unterminated string"},
+		{name: "no colon", file: "rules.yml", content: "api.User\n", err: "line 1: expected \"key: value\""},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := LoadRulesFile(writeRulesFile(t, tc.file, tc.content))
+			if tc.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.err) {
+					t.Fatalf("expected error containing %q, got %v", tc.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestRulesFile(t *testing.T) {
+	base := writeRulesFile(t, "base.yaml", "valex.rulesDummy:\n  Name: min,size=3\n  Code: alphanum\n")
+	prod := writeRulesFile(t, "prod.json", `{"valex.rulesDummy": {"Name": "min,size=5"}}`)
+
+	e := NewEngine()
+	e.SetRulesProvider(RulesFile(base, prod))
+	if err := e.ReloadRules(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[string]string{"Name": "min,size=5", "Code": "alphanum"}
+	if got := e.TypeRules()["valex.rulesDummy"]; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+	if ok, _ := e.ValidateStruct(rulesDummy{Name: "John", Code: "a1"}); ok {
+		t.Errorf("expected the file rules to override the tag")
+	}
+
+	if err := os.WriteFile(prod, []byte(`{"valex.rulesDummy": {"Name": "nosuchdirective"}}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := e.ReloadRules(context.Background()); err == nil {
+		t.Errorf("expected an invalid rule to fail the reload")
+	}
+	if ok, err := e.ValidateStruct(rulesDummy{Name: "Johnny", Code: "a1"}); !ok {
+		t.Errorf("expected the previous rules to stay in effect, got %v", err)
+	}
+}
@@ -0,0 +1,156 @@
+package valex
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Rules maps a struct type name (as printed by reflect, e.g. "api.User") to
+// field names and the `val` tag value that replaces the field's own tag.
+type Rules map[string]map[string]string
+
+func (r Rules) lookup(t reflect.Type, field string) (string, bool) {
+	fields, ok := r[t.String()]
+	if !ok {
+		return "", false
+	}
+	tagValue, ok := fields[field]
+	return tagValue, ok
+}
+
+type RulesProvider interface {
+	Rules(ctx context.Context) (Rules, error)
+}
+
+type RulesProviderFunc func(ctx context.Context) (Rules, error)
+
+func (f RulesProviderFunc) Rules(ctx context.Context) (Rules, error) {
+	return f(ctx)
+}
+
+// RulesNotifier is implemented by providers that can signal rule changes, in
+// which case WatchRules reloads on every signal in addition to polling.
+type RulesNotifier interface {
+	RulesProvider
+	Changes(ctx context.Context) <-chan struct{}
+}
+
+// ruleState pairs a rule set with the plans compiled from it, so both are
+// swapped together.
+type ruleState struct {
+	rules Rules
+	plans *sync.Map // planKey -> *structPlan
+}
+
+func (e *Engine) loadState() *ruleState {
+	return e.state.Load()
+}
+
+func (e *Engine) resetPlans() {
+	e.swapMut.Lock()
+	defer e.swapMut.Unlock()
+
+	e.state.Store(&ruleState{rules: e.loadState().rules, plans: &sync.Map{}})
+}
+
+func (e *Engine) SetRulesProvider(p RulesProvider) {
+	e.mut.Lock()
+	defer e.mut.Unlock()
+
+	e.provider = p
+}
+
+// ReloadRules fetches the rules from the provider and, when every rule parses,
+// recompiles the plans of all struct types seen so far and swaps them in
+// atomically. On error the current rules stay in effect.
+func (e *Engine) ReloadRules(ctx context.Context) error {
+	e.mut.RLock()
+	p := e.provider
+	e.mut.RUnlock()
+
+	if p == nil {
+		return fmt.Errorf("no rules provider set")
+	}
+	rules, err := p.Rules(ctx)
+	if err != nil {
+		return fmt.Errorf("error loading rules: %w", err)
+	}
+	return e.SetRules(rules)
+}
+
+func (e *Engine) SetRules(rules Rules) error {
+	if err := e.checkRules(rules); err != nil {
+		return err
+	}
+
+	e.swapMut.Lock()
+	defer e.swapMut.Unlock()
+
+	next := &ruleState{rules: rules, plans: &sync.Map{}}
+	e.loadState().plans.Range(func(k, _ any) bool {
+		key := k.(planKey)
+		if tenant, ok := e.lookupTenant(key.tenant); ok || key.tenant == "" {
+			p := e.compile(key.typ, tenant, rules)
+			next.plans.Store(key, p)
+			e.compiled(p)
+		}
+		return true
+	})
+	e.state.Store(next)
+	return nil
+}
+
+func (e *Engine) checkRules(rules Rules) error {
+	typeNames := make([]string, 0, len(rules))
+	for typeName := range rules {
+		typeNames = append(typeNames, typeName)
+	}
+	sort.Strings(typeNames)
+
+	for _, typeName := range typeNames {
+		for field, tagValue := range rules[typeName] {
+			if _, _, err := e.compileGroupedTag(tagValue, nil); err != nil {
+				return fmt.Errorf("invalid rule for %s.%s: %w", typeName, field, err)
+			}
+		}
+	}
+	return nil
+}
+
+// WatchRules reloads the rules every interval, and whenever the provider
+// signals a change, until ctx is done. Reload errors are passed to onError,
+// which may be nil. It returns ctx.Err() once ctx is done, and an error
+// right away if interval is not positive.
+func (e *Engine) WatchRules(ctx context.Context, interval time.Duration, onError func(error)) error {
+	if interval <= 0 {
+		return fmt.Errorf("invalid rules reload interval %v: must be positive", interval)
+	}
+
+	e.mut.RLock()
+	p := e.provider
+	e.mut.RUnlock()
+
+	var changes <-chan struct{}
+	if n, ok := p.(RulesNotifier); ok {
+		changes = n.Changes(ctx)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		case <-changes:
+		}
+		if err := e.ReloadRules(ctx); err != nil && onError != nil {
+			onError(err)
+		}
+	}
+}
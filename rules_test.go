@@ -0,0 +1,136 @@
+package valex
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type rulesDummy struct {
+	Name string `val:"min,size=3"`
+	Code string
+}
+
+func TestEngine_ReloadRules(t *testing.T) {
+	e := NewEngine()
+	data := rulesDummy{Name: "John", Code: "a-1"}
+
+	if ok, err := e.ValidateStruct(data); !ok {
+		t.Fatalf("expected tag rules to pass, got %v", err)
+	}
+
+	e.SetRulesProvider(RulesProviderFunc(func(ctx context.Context) (Rules, error) {
+		return Rules{
+			"valex.rulesDummy": {
+				"Name": "min,size=5",
+				"Code": "alphanum",
+			},
+		}, nil
+	}))
+	if err := e.ReloadRules(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ok, err := e.ValidateStruct(data)
+	if ok || err == nil || !strings.Contains(err.Error(), `field "Name"`) {
+		t.Errorf("expected overridden Name rule to fail, got ok=%v err=%v", ok, err)
+	}
+	data.Name = "Johnny"
+	ok, err = e.ValidateStruct(data)
+	if ok || err == nil || !strings.Contains(err.Error(), `field "Code"`) {
+		t.Errorf("expected rule on untagged Code field to fail, got ok=%v err=%v", ok, err)
+	}
+}
+
+func TestEngine_SetRules_Groups(t *testing.T) {
+	e := NewEngine()
+	err := e.SetRules(Rules{"valex.rulesDummy": {"Code": "alphanum,groups=create"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data := rulesDummy{Name: "John", Code: "a-1"}
+	if ok, err := e.ValidateStruct(data); !ok {
+		t.Errorf("expected the create rule to be skipped, got %v", err)
+	}
+	if ok, err := e.ValidateStruct(data, WithGroups("create")); ok || !strings.Contains(err.Error(), `field "Code"`) {
+		t.Errorf("expected the create rule to fail, got ok=%v err=%v", ok, err)
+	}
+}
+
+func TestEngine_ReloadRulesInvalidKeepsCurrent(t *testing.T) {
+	e := NewEngine()
+	if err := e.SetRules(Rules{"valex.rulesDummy": {"Name": "min,size=5"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err := e.SetRules(Rules{"valex.rulesDummy": {"Name": "foobar"}})
+	if err == nil || !strings.Contains(err.Error(), "invalid rule for valex.rulesDummy.Name") {
+		t.Fatalf("expected invalid rule error, got %v", err)
+	}
+	if ok, _ := e.ValidateStruct(rulesDummy{Name: "John"}); ok {
+		t.Errorf("expected previous rules to stay in effect")
+	}
+}
+
+func TestEngine_ReloadRulesProviderError(t *testing.T) {
+	e := NewEngine()
+	if err := e.ReloadRules(context.Background()); err == nil {
+		t.Errorf("expected error without a provider")
+	}
+
+	e.SetRulesProvider(RulesProviderFunc(func(ctx context.Context) (Rules, error) {
+		return nil, errors.New("database unavailable")
+	}))
+	if err := e.ReloadRules(context.Background()); err == nil || !strings.Contains(err.Error(), "database unavailable") {
+		t.Errorf("expected provider error, got %v", err)
+	}
+}
+
+type notifyingProvider struct {
+	size    atomic.Value
+	changes chan struct{}
+}
+
+func (p *notifyingProvider) Rules(ctx context.Context) (Rules, error) {
+	return Rules{"valex.rulesDummy": {"Name": "min,size=" + p.size.Load().(string)}}, nil
+}
+
+func (p *notifyingProvider) Changes(ctx context.Context) <-chan struct{} {
+	return p.changes
+}
+
+func TestEngine_WatchRules(t *testing.T) {
+	e := NewEngine()
+	p := &notifyingProvider{changes: make(chan struct{})}
+	p.size.Store("5")
+	e.SetRulesProvider(p)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go e.WatchRules(ctx, time.Hour, nil)
+
+	p.changes <- struct{}{}
+	deadline := time.Now().Add(time.Second)
+	for {
+		if ok, _ := e.ValidateStruct(rulesDummy{Name: "John"}); !ok {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("expected rules to be reloaded after change notification")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestEngine_WatchRules_InvalidInterval(t *testing.T) {
+	e := NewEngine()
+	e.SetRulesProvider(&notifyingProvider{changes: make(chan struct{})})
+	for _, interval := range []time.Duration{0, -time.Second} {
+		if err := e.WatchRules(context.Background(), interval, nil); err == nil {
+			t.Errorf("interval %v: expected error", interval)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package valex
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// ValidateSample validates a random sample of items, a slice or array of
+// structs or struct pointers, selecting each element with probability rate.
+// Sampling draws from the engine's Rand, one draw per element in order, so a
+// seeded Rand always selects the same elements. Errors carry the element
+// index in their path, e.g. [12].Email.
+func (e *Engine) ValidateSample(items any, rate float64, opts ...Option) (bool, error) {
+	val := reflect.ValueOf(items)
+	if val.Kind() != reflect.Slice && val.Kind() != reflect.Array {
+		return false, WithCategory(fmt.Errorf("expected a slice or array but got %T", items), CategoryConfig)
+	}
+	if rate < 0 || rate > 1 {
+		return false, WithCategory(fmt.Errorf("sample rate %v is not in range [0, 1]", rate), CategoryConfig)
+	}
+
+	r := e.Rand()
+	v := e.newValidation(context.Background(), newOptions(opts), phaseAll)
+	for i := 0; i < val.Len(); i++ {
+		if r.Float64() >= rate {
+			continue
+		}
+		elem := val.Index(i)
+		for elem.Kind() == reflect.Ptr || elem.Kind() == reflect.Interface {
+			if elem.IsNil() {
+				break
+			}
+			elem = elem.Elem()
+		}
+		if elem.Kind() != reflect.Struct {
+			continue
+		}
+		if !v.root(elem, FieldPath{}.Index(i)) {
+			break
+		}
+	}
+	return v.result()
+}
+
+func ValidateSample(items any, rate float64, opts ...Option) (bool, error) {
+	return std.ValidateSample(items, rate, opts...)
+}
+
+// SampleIndexes picks n distinct indexes out of [0, size) using the engine's
+// Rand, e.g. to choose which records to probe with expensive checks. The
+// indexes are returned in the order they were drawn.
+func (e *Engine) SampleIndexes(size, n int) []int {
+	if n > size {
+		n = size
+	}
+	if n <= 0 {
+		return nil
+	}
+	// partial Fisher-Yates over a sparse permutation
+	r := e.Rand()
+	swapped := make(map[int]int, n)
+	at := func(i int) int {
+		if j, ok := swapped[i]; ok {
+			return j
+		}
+		return i
+	}
+	picked := make([]int, n)
+	for i := 0; i < n; i++ {
+		j := i + r.IntN(size-i)
+		picked[i] = at(j)
+		swapped[j] = at(i)
+	}
+	return picked
+}
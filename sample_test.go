@@ -0,0 +1,95 @@
+package valex
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+type sampleItem struct {
+	Name string `val:"min,size=3"`
+}
+
+func sampledIndexes(t *testing.T, e *Engine, items []sampleItem, rate float64) []int {
+	t.Helper()
+	_, err := e.ValidateSample(items, rate, WithCollectAll())
+	var errs ValidationErrors
+	if err != nil && !errors.As(err, &errs) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var idx []int
+	for _, fe := range errs {
+		idx = append(idx, fe.Path[0].Index)
+	}
+	return idx
+}
+
+func TestValidateSample(t *testing.T) {
+	items := make([]sampleItem, 200) // every item is invalid
+
+	e := NewEngine()
+	e.SetRand(SeededRand(1))
+	first := sampledIndexes(t, e, items, 0.1)
+	e.SetRand(SeededRand(1))
+	second := sampledIndexes(t, e, items, 0.1)
+
+	if !reflect.DeepEqual(first, second) {
+		t.Errorf("expected the same seed to sample the same items, got %v and %v", first, second)
+	}
+	if len(first) == 0 || len(first) > 60 {
+		t.Errorf("expected roughly 10%% of 200 items to be sampled, got %d", len(first))
+	}
+	if got := sampledIndexes(t, e, items, 0); len(got) != 0 {
+		t.Errorf("expected rate 0 to sample nothing, got %v", got)
+	}
+	if got := sampledIndexes(t, e, items, 1); len(got) != len(items) {
+		t.Errorf("expected rate 1 to sample everything, got %d", len(got))
+	}
+}
+
+func TestValidateSample_PathsAndPointers(t *testing.T) {
+	items := []*sampleItem{{Name: "john"}, nil, {Name: "x"}}
+	_, err := ValidateSample(items, 1)
+	var fe *FieldError
+	if !errors.As(err, &fe) || fe.Field != "[2].Name" {
+		t.Errorf("expected an error at [2].Name, got %v", err)
+	}
+}
+
+func TestValidateSample_Errors(t *testing.T) {
+	if _, err := ValidateSample(sampleItem{}, 1); Categorize(err) != CategoryConfig {
+		t.Errorf("expected a config error for a non-slice, got %v", err)
+	}
+	if _, err := ValidateSample([]sampleItem{}, 1.5); Categorize(err) != CategoryConfig {
+		t.Errorf("expected a config error for an invalid rate, got %v", err)
+	}
+}
+
+func TestSampleIndexes(t *testing.T) {
+	e := NewEngine()
+	e.SetRand(SeededRand(7))
+	got := e.SampleIndexes(10, 4)
+	e.SetRand(SeededRand(7))
+	if again := e.SampleIndexes(10, 4); !reflect.DeepEqual(got, again) {
+		t.Errorf("expected reproducible samples, got %v and %v", got, again)
+	}
+
+	all := e.SampleIndexes(5, 10)
+	sort.Ints(all)
+	if !reflect.DeepEqual(all, []int{0, 1, 2, 3, 4}) {
+		t.Errorf("expected a permutation of all indexes, got %v", all)
+	}
+	if got := e.SampleIndexes(5, 0); got != nil {
+		t.Errorf("expected no indexes, got %v", got)
+	}
+}
+
+func TestEngine_SetRandNil(t *testing.T) {
+	e := NewEngine()
+	e.SetRand(SeededRand(1))
+	e.SetRand(nil)
+	if _, ok := e.Rand().(globalRand); !ok {
+		t.Errorf("expected the global source after SetRand(nil), got %T", e.Rand())
+	}
+}
@@ -0,0 +1,146 @@
+package valex
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+)
+
+// Sanitizer transforms a field value before it is validated. Sanitizers are
+// listed in the `sane` struct tag and applied in order, e.g.
+// `sane:"trim,lower,truncate=64"`. Since they write back to the struct,
+// ValidateStruct must be given a pointer to it.
+type Sanitizer[T any] interface {
+	Name() string
+	Sanitize(val T) (T, error)
+}
+
+func RegisterSanitizer[T any](r Registrar, s Sanitizer[T]) {
+	r.setSanitizer(s.Name(), wrapSanitizer(s))
+}
+
+func (e *Engine) setSanitizer(name string, s anyDirective) {
+	e.mut.Lock()
+	e.sanitizers[name] = s
+	e.mut.Unlock()
+
+	e.resetPlans()
+}
+
+func (e *Engine) getSanitizer(name string) (anyDirective, bool) {
+	e.mut.RLock()
+	defer e.mut.RUnlock()
+
+	s, ok := e.sanitizers[name]
+	return s, ok
+}
+
+// sanitizerWrapper adapts a Sanitizer to the directive machinery, so tags,
+// params, tenants and plans treat both alike.
+type sanitizerWrapper[T any] struct {
+	proto Sanitizer[T]
+	ps    params
+}
+
+func wrapSanitizer[T any](s Sanitizer[T]) *sanitizerWrapper[T] {
+	return &sanitizerWrapper[T]{proto: s, ps: paramsOf(s)}
+}
+
+func (sw *sanitizerWrapper[T]) valueType() reflect.Type {
+	return reflect.TypeFor[T]()
+}
+
+func (sw *sanitizerWrapper[T]) params() params {
+	return sw.ps
+}
+
+func (sw *sanitizerWrapper[T]) instance(args map[string]string) (any, error) {
+	s := cloneDirective(sw.proto)
+	if err := processParams(s, sw.ps, args); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (sw *sanitizerWrapper[T]) handleAny(_ context.Context, s any, val reflect.Value) error {
+	v, err := valParse[T](val)
+	if err != nil {
+		return err
+	}
+	if !val.CanSet() {
+		return WithCategory(fmt.Errorf("cannot write sanitized value, pass a pointer to the struct"), CategoryConfig)
+	}
+	out, err := s.(Sanitizer[T]).Sanitize(v)
+	if err != nil {
+		return err
+	}
+	val.Set(reflect.ValueOf(&out).Elem())
+	return nil
+}
+
+type TrimSanitizer struct{}
+
+func (s *TrimSanitizer) Sanitize(val string) (string, error) {
+	return strings.TrimSpace(val), nil
+}
+
+func (s *TrimSanitizer) Name() string {
+	return "trim"
+}
+
+type LowerSanitizer struct{}
+
+func (s *LowerSanitizer) Sanitize(val string) (string, error) {
+	return strings.ToLower(val), nil
+}
+
+func (s *LowerSanitizer) Name() string {
+	return "lower"
+}
+
+type UpperSanitizer struct{}
+
+func (s *UpperSanitizer) Sanitize(val string) (string, error) {
+	return strings.ToUpper(val), nil
+}
+
+func (s *UpperSanitizer) Name() string {
+	return "upper"
+}
+
+type TitleSanitizer struct{}
+
+func (s *TitleSanitizer) Sanitize(val string) (string, error) {
+	return cases.Title(language.Und).String(val), nil
+}
+
+func (s *TitleSanitizer) Name() string {
+	return "title"
+}
+
+// TruncateSanitizer cuts strings down to at most Size runes.
+type TruncateSanitizer struct {
+	Size int `param:"truncate"`
+}
+
+func (s *TruncateSanitizer) Sanitize(val string) (string, error) {
+	if s.Size < 0 {
+		return "", fmt.Errorf("truncate size must be non-negative, got %d", s.Size)
+	}
+	n := 0
+	for i := range val {
+		if n == s.Size {
+			return val[:i], nil
+		}
+		n++
+	}
+	return val, nil
+}
+
+func (s *TruncateSanitizer) Name() string {
+	return "truncate"
+}
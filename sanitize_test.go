@@ -0,0 +1,140 @@
+package valex
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSanitizers(t *testing.T) {
+	tests := []struct {
+		name string
+		s    Sanitizer[string]
+		in   string
+		want string
+	}{
+		{name: "trim", s: &TrimSanitizer{}, in: "  a b \t\n", want: "a b"},
+		{name: "lower", s: &LowerSanitizer{}, in: "MiXeD", want: "mixed"},
+		{name: "upper", s: &UpperSanitizer{}, in: "MiXeD", want: "MIXED"},
+		{name: "title", s: &TitleSanitizer{}, in: "hello wORLD", want: "Hello World"},
+		{name: "truncate", s: &TruncateSanitizer{Size: 3}, in: "abcdef", want: "abc"},
+		{name: "truncate runes", s: &TruncateSanitizer{Size: 2}, in: "héllo", want: "hé"},
+		{name: "truncate short", s: &TruncateSanitizer{Size: 10}, in: "abc", want: "abc"},
+		{name: "truncate zero", s: &TruncateSanitizer{Size: 0}, in: "abc", want: ""},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.s.Sanitize(tc.in)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+type saneDummy struct {
+	Email string   `sane:"trim,lower" val:"email"`
+	Name  string   `sane:"trim,title,truncate=8" val:"min,size=3"`
+	Tags  []string `sane:"dive,trim,upper"`
+	Note  string
+}
+
+func TestValidateStruct_Sanitize(t *testing.T) {
+	data := &saneDummy{
+		Email: "  John@Example.COM ",
+		Name:  "  john jacob jingleheimer ",
+		Tags:  []string{" a ", "b"},
+		Note:  "  untouched ",
+	}
+	ok, err := ValidateStruct(data)
+	if !ok {
+		t.Fatalf("expected sanitized struct to be valid, got %v", err)
+	}
+	want := &saneDummy{Email: "john@example.com", Name: "John Jac", Tags: []string{"A", "B"}, Note: "  untouched "}
+	if !reflect.DeepEqual(data, want) {
+		t.Errorf("expected %+v, got %+v", want, data)
+	}
+}
+
+func TestValidateStruct_SanitizeBeforeValidation(t *testing.T) {
+	// Without trimming the name would pass the min length check.
+	data := &saneDummy{Email: "a@b.co", Name: "  x  "}
+	_, err := ValidateStruct(data)
+	var fe *FieldError
+	if !errors.As(err, &fe) || fe.Field != "Name" || fe.Directive != "min" {
+		t.Errorf("expected min to fail on the trimmed name, got %v", err)
+	}
+}
+
+func TestValidateStruct_SanitizeNeedsPointer(t *testing.T) {
+	_, err := ValidateStruct(saneDummy{})
+	if err == nil || !strings.Contains(err.Error(), "pass a pointer") {
+		t.Fatalf("expected an error asking for a pointer, got %v", err)
+	}
+	if c := Categorize(err); c != CategoryConfig {
+		t.Errorf("expected a config error, got %v", c)
+	}
+}
+
+func TestValidateStruct_SanitizeTagErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		data      any
+		errSubstr string
+	}{
+		{
+			name: "unknown sanitizer",
+			data: &struct {
+				A string `sane:"shout"`
+			}{},
+			errSubstr: `sane tag: unknown directive "shout"`,
+		},
+		{
+			name: "wrong type",
+			data: &struct {
+				A int `sane:"trim"`
+			}{},
+			errSubstr: "type mismatch",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ValidateStruct(tc.data)
+			if err == nil || !strings.Contains(err.Error(), tc.errSubstr) {
+				t.Errorf("expected error containing %q, got %v", tc.errSubstr, err)
+			}
+		})
+	}
+}
+
+type shoutSanitizer struct{}
+
+func (s *shoutSanitizer) Sanitize(val string) (string, error) {
+	return val + "!", nil
+}
+
+func (s *shoutSanitizer) Name() string {
+	return "shout"
+}
+
+func TestRegisterSanitizer_Tenant(t *testing.T) {
+	e := NewEngine()
+	RegisterSanitizer(e.Tenant("loud"), &shoutSanitizer{})
+
+	data := &struct {
+		A string `sane:"shout"`
+	}{A: "hi"}
+	if ok, err := e.ValidateStruct(data, WithTenant("loud")); !ok {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if data.A != "hi!" {
+		t.Errorf("expected tenant sanitizer to run, got %q", data.A)
+	}
+	if _, err := e.ValidateStruct(data); err == nil {
+		t.Error("expected the sanitizer to be unknown outside the tenant")
+	}
+}
@@ -0,0 +1,204 @@
+package valex
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+)
+
+// Schema validates map[string]any payloads, such as decoded JSON, for
+// dynamic data where defining a struct is impractical. Rules are declared
+// per key:
+//
+//	s := valex.NewSchema().
+//		Field("name", valex.String().NonEmpty().MaxLen(64)).
+//		Field("age", valex.Int().Min(0)).
+//		Optional("address", valex.NewSchema().Field("zip", valex.String().Len(4, 10)))
+//
+// Numbers decoded into float64 or json.Number are accepted for integer
+// rules when they are whole and fit. Like ValidateStruct, Validate reports
+// every failing key, with paths such as "address.zip", as ValidationErrors.
+type Schema struct {
+	keys   []schemaKey
+	strict bool
+}
+
+type schemaKey struct {
+	name     string
+	rule     SchemaRule
+	optional bool
+}
+
+// SchemaRule checks the value of a key. Builders, Schemas for nested
+// objects and validators wrapped by RuleOf or Each are rules.
+type SchemaRule interface {
+	checkValue(val any, path FieldPath, errs *ValidationErrors)
+}
+
+func NewSchema() *Schema {
+	return &Schema{}
+}
+
+// Field adds a required key. A missing key, or one that is null, fails.
+func (s *Schema) Field(key string, r SchemaRule) *Schema {
+	s.keys = append(s.keys, schemaKey{name: key, rule: r})
+	return s
+}
+
+// Optional adds a key that is only checked when present and not null.
+func (s *Schema) Optional(key string, r SchemaRule) *Schema {
+	s.keys = append(s.keys, schemaKey{name: key, rule: r, optional: true})
+	return s
+}
+
+// Strict rejects keys the schema does not declare.
+func (s *Schema) Strict() *Schema {
+	s.strict = true
+	return s
+}
+
+// Validate checks m against the schema.
+func (s *Schema) Validate(m map[string]any) error {
+	var errs ValidationErrors
+	s.checkObject(m, nil, &errs)
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func (s *Schema) checkValue(val any, path FieldPath, errs *ValidationErrors) {
+	m, ok := val.(map[string]any)
+	if !ok {
+		*errs = append(*errs, keyError(path, "type", fmt.Errorf("expected an object, got %s", jsonTypeOf(val))))
+		return
+	}
+	s.checkObject(m, path, errs)
+}
+
+func (s *Schema) checkObject(m map[string]any, path FieldPath, errs *ValidationErrors) {
+	for _, k := range s.keys {
+		val, ok := m[k.name]
+		if !ok || val == nil {
+			if !k.optional {
+				*errs = append(*errs, keyError(path.Field(k.name), requiredDirectiveName, errors.New("value is required")))
+			}
+			continue
+		}
+		k.rule.checkValue(val, path.Field(k.name), errs)
+	}
+	if s.strict {
+		for _, name := range slices.Sorted(maps.Keys(m)) {
+			if !s.declares(name) {
+				*errs = append(*errs, keyError(path.Field(name), "strict", failf(ErrNotAllowed, "unknown key %q", name)))
+			}
+		}
+	}
+}
+
+func (s *Schema) declares(name string) bool {
+	return slices.ContainsFunc(s.keys, func(k schemaKey) bool { return k.name == name })
+}
+
+// RuleOf makes a SchemaRule of v, converting the value to T first.
+func RuleOf[T any](v Validator[T]) SchemaRule {
+	return validatorRule[T]{v: v}
+}
+
+type validatorRule[T any] struct {
+	v Validator[T]
+}
+
+func (r validatorRule[T]) checkValue(val any, path FieldPath, errs *ValidationErrors) {
+	t, err := convertValue[T](val)
+	if err != nil {
+		*errs = append(*errs, keyError(path, "type", err))
+		return
+	}
+	if ok, err := r.v.Validate(t); !ok {
+		*errs = append(*errs, keyError(path, schemaDirective, err))
+	}
+}
+
+// Each applies r to every element of an array.
+func Each(r SchemaRule) SchemaRule {
+	return eachRule{r: r}
+}
+
+type eachRule struct {
+	r SchemaRule
+}
+
+func (e eachRule) checkValue(val any, path FieldPath, errs *ValidationErrors) {
+	elems, ok := val.([]any)
+	if !ok {
+		*errs = append(*errs, keyError(path, "type", fmt.Errorf("expected an array, got %s", jsonTypeOf(val))))
+		return
+	}
+	for i, elem := range elems {
+		e.r.checkValue(elem, path.Index(i), errs)
+	}
+}
+
+func (b *StringBuilder) checkValue(val any, path FieldPath, errs *ValidationErrors) {
+	RuleOf(b.Build()).checkValue(val, path, errs)
+}
+
+func (b *IntBuilder) checkValue(val any, path FieldPath, errs *ValidationErrors) {
+	RuleOf(b.Build()).checkValue(val, path, errs)
+}
+
+// schemaDirective names the directive of field errors from validators,
+// which have no name of their own in a schema.
+const schemaDirective = "schema"
+
+func keyError(path FieldPath, directive string, err error) *FieldError {
+	return &FieldError{Field: path.String(), Path: path, Directive: directive, Err: err}
+}
+
+// convertValue converts a decoded JSON value to T. Numbers convert to any
+// numeric T they fit without loss.
+func convertValue[T any](val any) (T, error) {
+	if t, ok := val.(T); ok {
+		return t, nil
+	}
+	var t T
+	target := reflect.ValueOf(&t).Elem()
+	var raw string
+	switch n := val.(type) {
+	case float64:
+		raw = strconv.FormatFloat(n, 'f', -1, 64)
+	case json.Number:
+		raw = n.String()
+	}
+	if raw != "" && isNumericKind(target.Kind()) && setFromString(target, raw) == nil {
+		return t, nil
+	}
+	return t, fmt.Errorf("expected %s, got %s", target.Type(), jsonTypeOf(val))
+}
+
+func isNumericKind(k reflect.Kind) bool {
+	return k >= reflect.Int && k <= reflect.Float64
+}
+
+func jsonTypeOf(val any) string {
+	switch v := val.(type) {
+	case nil:
+		return "null"
+	case map[string]any:
+		return "an object"
+	case []any:
+		return "an array"
+	case string:
+		return "a string"
+	case bool:
+		return "a boolean"
+	case float64, json.Number:
+		return fmt.Sprintf("the number %v", v)
+	}
+	return fmt.Sprintf("%T", val)
+}
@@ -0,0 +1,97 @@
+package valex
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func newTestSchema() *Schema {
+	return NewSchema().
+		Field("name", String().NonEmpty().MaxLen(16)).
+		Field("age", Int().Min(0).Max(130)).
+		Optional("email", String().Email()).
+		Optional("tags", Each(String().MinLen(2))).
+		Optional("address", NewSchema().Field("zip", String().Len(4, 10)))
+}
+
+func decodeJSON(t *testing.T, doc string) map[string]any {
+	t.Helper()
+	var m map[string]any
+	if err := json.Unmarshal([]byte(doc), &m); err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func TestSchema(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  string
+		want map[string]string // path -> error substring
+	}{
+		{"valid", `{"name":"Jane","age":42,"tags":["go","db"],"address":{"zip":"1234AB"}}`, nil},
+		{"optional null", `{"name":"Jane","age":42,"email":null}`, nil},
+		{"missing", `{"name":"Jane"}`, map[string]string{"age": "value is required"}},
+		{"required null", `{"name":null,"age":1}`, map[string]string{"name": "value is required"}},
+		{"out of range", `{"name":"Jane","age":200}`, map[string]string{"age": "exceeds the maximum of 130"}},
+		{"fraction", `{"name":"Jane","age":4.5}`, map[string]string{"age": "expected int, got the number 4.5"}},
+		{"wrong type", `{"name":42,"age":"42"}`, map[string]string{"name": "expected string, got the number 42", "age": "expected int, got a string"}},
+		{"nested", `{"name":"Jane","age":1,"address":{"zip":"1"}}`, map[string]string{"address.zip": "not in range [4, 10]"}},
+		{"nested type", `{"name":"Jane","age":1,"address":"here"}`, map[string]string{"address": "expected an object, got a string"}},
+		{"each", `{"name":"Jane","age":1,"tags":["go","x"]}`, map[string]string{"tags[1]": "minimum length 2"}},
+		{"each type", `{"name":"Jane","age":1,"tags":"go"}`, map[string]string{"tags": "expected an array"}},
+	}
+	s := newTestSchema()
+	for _, tc := range tests {
+		err := s.Validate(decodeJSON(t, tc.doc))
+		if tc.want == nil {
+			if err != nil {
+				t.Errorf("%s: unexpected error: %v", tc.name, err)
+			}
+			continue
+		}
+		var errs ValidationErrors
+		if !errors.As(err, &errs) || len(errs) != len(tc.want) {
+			t.Errorf("%s: expected %d field errors, got %v", tc.name, len(tc.want), err)
+			continue
+		}
+		for _, fe := range errs {
+			if substr, ok := tc.want[fe.Path.String()]; !ok || !strings.Contains(fe.Err.Error(), substr) {
+				t.Errorf("%s: unexpected error at %s: %v", tc.name, fe.Path, fe.Err)
+			}
+		}
+		if Categorize(err) != CategoryValidation {
+			t.Errorf("%s: expected a validation error, got %v", tc.name, Categorize(err))
+		}
+	}
+}
+
+func TestSchema_Strict(t *testing.T) {
+	s := NewSchema().Field("id", Int()).Strict()
+	if err := s.Validate(map[string]any{"id": 1}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	err := s.Validate(map[string]any{"id": 1, "admin": true})
+	if !errors.Is(err, ErrNotAllowed) || !strings.Contains(err.Error(), `unknown key "admin"`) {
+		t.Errorf("expected an unknown key error, got %v", err)
+	}
+}
+
+func TestSchema_RuleOf(t *testing.T) {
+	s := NewSchema().
+		Field("count", RuleOf(ValidatorFunc[uint8](func(n uint8) (bool, error) { return true, nil }))).
+		Field("ratio", RuleOf(Validator[float64](&CmpRangeValidator[float64]{Min: 0, Max: 1})))
+
+	dec := json.NewDecoder(strings.NewReader(`{"count":300,"ratio":0.5}`))
+	dec.UseNumber()
+	var m map[string]any
+	if err := dec.Decode(&m); err != nil {
+		t.Fatal(err)
+	}
+	err := s.Validate(m)
+	if err == nil || !strings.Contains(err.Error(), "expected uint8, got the number 300") || strings.Contains(err.Error(), "ratio") {
+		t.Errorf("expected only an overflow error, got %v", err)
+	}
+}
@@ -0,0 +1,333 @@
+package valex
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+type schemaField struct {
+	schema   map[string]any
+	required bool
+}
+
+// schemaDescriber is implemented by directives that translate into JSON
+// Schema keywords.
+type schemaDescriber interface {
+	describeSchema(f *schemaField)
+}
+
+// GenerateJSONSchema returns a draft 2020-12 JSON Schema describing the shape
+// of v, which must be a struct or a pointer to one, including the constraints
+// of its `val` tags. Property names follow the struct's json tags.
+func (e *Engine) GenerateJSONSchema(v any, opts ...Option) (map[string]any, error) {
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("expected a struct but got %T", v)
+	}
+
+	g := &schemaGenerator{
+		e:    e,
+		o:    newOptions(opts),
+		defs: make(map[string]any),
+		refs: make(map[reflect.Type]string),
+	}
+	g.refs[t] = "#"
+
+	s, err := g.objectSchema(t)
+	if err != nil {
+		return nil, err
+	}
+	s["$schema"] = jsonSchemaDialect
+	if len(g.defs) > 0 {
+		s["$defs"] = g.defs
+	}
+	return s, nil
+}
+
+func GenerateJSONSchema(v any, opts ...Option) (map[string]any, error) {
+	return std.GenerateJSONSchema(v, opts...)
+}
+
+type schemaGenerator struct {
+	e    *Engine
+	o    options
+	defs map[string]any
+	refs map[reflect.Type]string
+}
+
+var timeType = reflect.TypeFor[time.Time]()
+
+func (g *schemaGenerator) typeSchema(t reflect.Type) (map[string]any, error) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == timeType {
+		return map[string]any{"type": "string", "format": "date-time"}, nil
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]any{"type": "string"}, nil
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}, nil
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}, nil
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]any{"type": "string", "contentEncoding": "base64"}, nil
+		}
+		items, err := g.typeSchema(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{"type": "array", "items": items}, nil
+	case reflect.Map:
+		values, err := g.typeSchema(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{"type": "object", "additionalProperties": values}, nil
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.objectSchema(t)
+		}
+		if ref, ok := g.refs[t]; ok {
+			return map[string]any{"$ref": ref}, nil
+		}
+		ref := "#/$defs/" + t.Name()
+		g.refs[t] = ref
+		s, err := g.objectSchema(t)
+		if err != nil {
+			return nil, err
+		}
+		g.defs[t.Name()] = s
+		return map[string]any{"$ref": ref}, nil
+	case reflect.Interface:
+		return map[string]any{}, nil
+	}
+	return nil, fmt.Errorf("type %s cannot be described by a JSON schema", t)
+}
+
+func (g *schemaGenerator) objectSchema(t reflect.Type) (map[string]any, error) {
+	p, err := g.e.plan(t, g.o)
+	if err != nil {
+		return nil, err
+	}
+	plans := make(map[int]fieldPlan, len(p.fields))
+	for _, fp := range p.fields {
+		plans[fp.index] = fp
+	}
+
+	props := make(map[string]any)
+	var required []string
+
+	for n := 0; n < t.NumField(); n++ {
+		field := t.Field(n)
+		if !field.IsExported() && !field.Anonymous {
+			continue
+		}
+		name, skip := jsonFieldName(field)
+		if skip {
+			continue
+		}
+
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			embedded, err := g.objectSchema(field.Type)
+			if err != nil {
+				return nil, err
+			}
+			for k, v := range embedded["properties"].(map[string]any) {
+				props[k] = v
+			}
+			if req, ok := embedded["required"].([]string); ok {
+				required = append(required, req...)
+			}
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		s, err := g.typeSchema(field.Type)
+		if err != nil {
+			return nil, fmt.Errorf("field %q: %w", field.Name, err)
+		}
+		f := &schemaField{schema: s}
+		if fp, ok := plans[n]; ok {
+			if fp.err != nil {
+				return nil, &FieldError{Field: fp.name, Path: FieldPath{}.Field(fp.name), Err: fp.err}
+			}
+			for _, st := range append(fp.sanitizers[:len(fp.sanitizers):len(fp.sanitizers)], fp.steps...) {
+				if d, ok := st.inst.(schemaDescriber); ok {
+					d.describeSchema(f)
+				}
+			}
+		}
+		props[name] = f.schema
+		if f.required {
+			required = append(required, name)
+		}
+	}
+
+	s := map[string]any{
+		"type":       "object",
+		"properties": props,
+	}
+	if len(required) > 0 {
+		s["required"] = required
+	}
+	return s, nil
+}
+
+func jsonFieldName(field reflect.StructField) (name string, skip bool) {
+	tagValue, ok := field.Tag.Lookup("json")
+	if !ok {
+		return "", false
+	}
+	name, _, _ = strings.Cut(tagValue, ",")
+	if name == "-" && !strings.Contains(tagValue, ",") {
+		return "", true
+	}
+	return name, false
+}
+
+func (v *IntRangeValidator) describeSchema(f *schemaField) {
+	f.schema["minimum"] = v.Min
+	f.schema["maximum"] = v.Max
+}
+
+func (v *NonNegativeIntValidator) describeSchema(f *schemaField) {
+	f.schema["minimum"] = 0
+}
+
+func (v *NonPositiveIntValidator) describeSchema(f *schemaField) {
+	f.schema["maximum"] = 0
+}
+
+func (v *MultipleOfValidator) describeSchema(f *schemaField) {
+	f.schema["multipleOf"] = v.Factor
+}
+
+func (v *StepValidator) describeSchema(f *schemaField) {
+	f.schema["minimum"] = v.Min
+	if v.Step > 0 && v.Min%v.Step == 0 {
+		f.schema["multipleOf"] = v.Step
+	}
+}
+
+// itemsKeywords returns the schema keywords counting the items of f.
+func itemsKeywords(f *schemaField) (min, max string) {
+	if f.schema["type"] == "object" {
+		return "minProperties", "maxProperties"
+	}
+	return "minItems", "maxItems"
+}
+
+func (v *MinItemsValidator) describeSchema(f *schemaField) {
+	min, _ := itemsKeywords(f)
+	f.schema[min] = v.Size
+}
+
+func (v *MaxItemsValidator) describeSchema(f *schemaField) {
+	_, max := itemsKeywords(f)
+	f.schema[max] = v.Size
+}
+
+func (v *ItemsRangeValidator) describeSchema(f *schemaField) {
+	min, max := itemsKeywords(f)
+	f.schema[min] = v.Min
+	f.schema[max] = v.Max
+}
+
+func (v *CamelCaseValidator) describeSchema(f *schemaField) {
+	f.schema["pattern"] = camelCasePattern.String()
+}
+
+func (v *PascalCaseValidator) describeSchema(f *schemaField) {
+	f.schema["pattern"] = pascalCasePattern.String()
+}
+
+func (v *SnakeCaseValidator) describeSchema(f *schemaField) {
+	f.schema["pattern"] = snakeCasePattern.String()
+}
+
+func (v *KebabCaseValidator) describeSchema(f *schemaField) {
+	f.schema["pattern"] = kebabCasePattern.String()
+}
+
+func (v *UrlValidator) describeSchema(f *schemaField) {
+	f.schema["format"] = "uri"
+}
+
+func (v *EmailValidator) describeSchema(f *schemaField) {
+	f.schema["format"] = "email"
+	if v.Max > 0 {
+		f.schema["maxLength"] = v.Max
+	}
+}
+
+func (v *NonEmptyStringValidator) describeSchema(f *schemaField) {
+	f.schema["minLength"] = 1
+	f.required = true
+}
+
+func (v *MinLengthValidator) describeSchema(f *schemaField) {
+	f.schema["minLength"] = v.Size
+}
+
+func (v *MaxLengthValidator) describeSchema(f *schemaField) {
+	f.schema["maxLength"] = v.Size
+}
+
+func (v *LengthRangeValidator) describeSchema(f *schemaField) {
+	f.schema["minLength"] = v.Min
+	f.schema["maxLength"] = v.Max
+}
+
+func (v *AlphaNumericValidator) describeSchema(f *schemaField) {
+	f.schema["pattern"] = `^[a-zA-Z0-9]+$`
+}
+
+func (v *RegexValidator) describeSchema(f *schemaField) {
+	if v.Pattern != nil {
+		f.schema["pattern"] = v.Pattern.String()
+	}
+}
+
+func (v *IpValidator) describeSchema(f *schemaField) {
+	f.schema["anyOf"] = []any{
+		map[string]any{"format": "ipv4"},
+		map[string]any{"format": "ipv6"},
+	}
+}
+
+func (v *IPv4Validator) describeSchema(f *schemaField) {
+	f.schema["format"] = "ipv4"
+}
+
+func (v *IPv6Validator) describeSchema(f *schemaField) {
+	f.schema["format"] = "ipv6"
+}
+
+func (v *XMLValidator) describeSchema(f *schemaField) {
+	f.schema["contentMediaType"] = "application/xml"
+}
+
+func (v *JSONValidator) describeSchema(f *schemaField) {
+	f.schema["contentMediaType"] = "application/json"
+}
+
+func (v *CSVValidator) describeSchema(f *schemaField) {
+	f.schema["contentMediaType"] = "text/csv"
+}
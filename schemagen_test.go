@@ -0,0 +1,111 @@
+package valex
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+type schemaAddress struct {
+	Zip  string         `json:"zip" val:"len,min=6,max=6"`
+	Next *schemaAddress `json:"next,omitempty"`
+}
+
+type schemaBase struct {
+	ID int `json:"id" val:"pos"`
+}
+
+type schemaUser struct {
+	schemaBase
+	Name    string            `json:"name" val:"!empty"`
+	Age     int               `json:"age" val:"range,min=0,max=130"`
+	Email   string            `json:"email,omitempty" val:"email"`
+	Code    string            `json:"code,omitempty" val:"regex=^[A-Z]{3}$"`
+	Tags    []string          `json:"tags"`
+	Labels  map[string]string `json:"labels"`
+	Address schemaAddress     `json:"address"`
+	Secret  string            `json:"-"`
+	private int
+}
+
+func TestGenerateJSONSchema(t *testing.T) {
+	s, err := GenerateJSONSchema(&schemaUser{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	props := s["properties"].(map[string]any)
+	tests := []struct {
+		prop string
+		want map[string]any
+	}{
+		{"id", map[string]any{"type": "integer", "minimum": 0}},
+		{"name", map[string]any{"type": "string", "minLength": 1}},
+		{"age", map[string]any{"type": "integer", "minimum": 0, "maximum": 130}},
+		{"email", map[string]any{"type": "string", "format": "email"}},
+		{"code", map[string]any{"type": "string", "pattern": "^[A-Z]{3}$"}},
+		{"tags", map[string]any{"type": "array", "items": map[string]any{"type": "string"}}},
+		{"labels", map[string]any{"type": "object", "additionalProperties": map[string]any{"type": "string"}}},
+		{"address", map[string]any{"$ref": "#/$defs/schemaAddress"}},
+	}
+	for _, tc := range tests {
+		if got := props[tc.prop]; !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("property %q: expected %v, got %v", tc.prop, tc.want, got)
+		}
+	}
+	for _, prop := range []string{"Secret", "private"} {
+		if _, ok := props[prop]; ok {
+			t.Errorf("expected property %q to be omitted", prop)
+		}
+	}
+	if req := s["required"]; !reflect.DeepEqual(req, []string{"name"}) {
+		t.Errorf("expected required [name], got %v", req)
+	}
+	if s["$schema"] != jsonSchemaDialect {
+		t.Errorf("expected $schema %q, got %v", jsonSchemaDialect, s["$schema"])
+	}
+}
+
+func TestGenerateJSONSchema_RoundTrip(t *testing.T) {
+	s, err := GenerateJSONSchema(schemaUser{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	raw, err := json.Marshal(s)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	v, err := NewJSONSchemaValidator(raw)
+	if err != nil {
+		t.Fatalf("generated schema does not compile: %v", err)
+	}
+
+	tests := []struct {
+		input string
+		ok    bool
+	}{
+		{`{"id": 1, "name": "John", "age": 30, "address": {"zip": "1234AB", "next": {"zip": "5678CD"}}}`, true},
+		{`{"id": 1, "name": "", "age": 30}`, false},
+		{`{"id": 1, "name": "John", "age": 131}`, false},
+		{`{"id": 1, "name": "John", "age": 30, "code": "abc"}`, false},
+		{`{"id": 1, "name": "John", "age": 30, "address": {"next": {"zip": "123"}}}`, false},
+	}
+	for _, tc := range tests {
+		ok, err := v.Validate(tc.input)
+		if ok != tc.ok {
+			t.Errorf("%T(%q): expected ok=%v, got ok=%v, err=%v", *v, tc.input, tc.ok, ok, err)
+		}
+	}
+}
+
+func TestGenerateJSONSchema_InvalidTag(t *testing.T) {
+	_, err := GenerateJSONSchema(struct {
+		Name string `val:"foobar"`
+	}{})
+	if err == nil {
+		t.Errorf("expected error for unknown directive")
+	}
+	if _, err := GenerateJSONSchema(42); err == nil {
+		t.Errorf("expected error for non-struct value")
+	}
+}
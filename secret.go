@@ -0,0 +1,87 @@
+package valex
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// secretDirective keeps the value of a field out of the errors and logs of
+// the directives following it, e.g. `val:"secret,min,size=12"` for
+// passwords and tokens. Wherever a failing directive's message contains the
+// value it reads [REDACTED] instead, message templates do not get the value
+// and SetLogger does not describe it. The original error stays reachable
+// with errors.Is and errors.As, e.g. for ErrTooShort or a RangeError, but
+// its own message is not redacted.
+const secretDirective = "secret"
+
+const redacted = "[REDACTED]"
+
+// redactedError is an error with a redacted message that unwraps to the
+// original.
+type redactedError struct {
+	msg string
+	err error
+}
+
+func (re *redactedError) Error() string {
+	return re.msg
+}
+
+func (re *redactedError) Unwrap() error {
+	return re.err
+}
+
+// redactError returns err with every occurrence of val in its message
+// replaced.
+func redactError(err error, val reflect.Value) error {
+	msg := err.Error()
+	for val.Kind() == reflect.Ptr || val.Kind() == reflect.Interface {
+		if val.IsNil() {
+			break
+		}
+		val = val.Elem()
+	}
+	if val.IsValid() && val.CanInterface() {
+		s := fmt.Sprint(val.Interface())
+		if val.Kind() == reflect.String {
+			s = val.String()
+			msg = strings.ReplaceAll(msg, strconv.Quote(s), strconv.Quote(redacted))
+		}
+		msg = replaceWord(msg, s, redacted)
+	}
+	return &redactedError{msg: msg, err: err}
+}
+
+// replaceWord replaces the occurrences of old in s that are not part of a
+// longer word or number, so that redacting "1" leaves "120" alone.
+func replaceWord(s, old, repl string) string {
+	if old == "" {
+		return s
+	}
+	var b strings.Builder
+	for {
+		i := strings.Index(s, old)
+		if i < 0 {
+			b.WriteString(s)
+			return b.String()
+		}
+		end := i + len(old)
+		before, _ := utf8.DecodeLastRuneInString(s[:i])
+		after, _ := utf8.DecodeRuneInString(s[end:])
+		b.WriteString(s[:i])
+		if isWordRune(before) || isWordRune(after) {
+			b.WriteString(old)
+		} else {
+			b.WriteString(repl)
+		}
+		s = s[end:]
+	}
+}
+
+func isWordRune(r rune) bool {
+	return r != utf8.RuneError && (unicode.IsLetter(r) || unicode.IsDigit(r))
+}
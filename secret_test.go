@@ -0,0 +1,92 @@
+package valex
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestSecretDirective(t *testing.T) {
+	type dummy struct {
+		Password string   `val:"secret,min,size=8"`
+		Token    string   `val:"secret,alphanum"`
+		PIN      int      `val:"secret,range,min=1000,max=9999"`
+		Keys     []string `val:"dive,secret,min,size=4"`
+		Plain    string   `val:"min,size=8"`
+	}
+	data := dummy{Password: "hunter2", Token: "a b", PIN: 12, Keys: []string{"abc"}, Plain: "short"}
+
+	var buf bytes.Buffer
+	e := NewEngine()
+	e.SetLogger(slog.New(slog.NewTextHandler(&buf, nil)), slog.LevelInfo)
+	var hookSecret []bool
+	e.OnAfter(func(_ context.Context, c DirectiveCall, _ error) {
+		hookSecret = append(hookSecret, c.Secret)
+	})
+
+	_, err := e.ValidateStruct(&data, WithCollectAll())
+	var ve ValidationErrors
+	if !errors.As(err, &ve) || len(ve) != 5 {
+		t.Fatalf("expected 5 errors, got %v", err)
+	}
+	for _, fe := range ve[:4] {
+		msg := fe.Error()
+		for _, secret := range []string{"hunter2", "a b", "12", "abc"} {
+			if strings.Contains(msg, secret) {
+				t.Errorf("expected %q to be redacted from %q", secret, msg)
+			}
+		}
+		if !strings.Contains(msg, redacted) {
+			t.Errorf("expected %q to contain %s", msg, redacted)
+		}
+		if Categorize(fe) != CategoryValidation {
+			t.Errorf("expected the category to be kept, got %v", Categorize(fe))
+		}
+	}
+	if !strings.Contains(ve[4].Error(), "short") {
+		t.Errorf("expected fields without secret to keep their value, got %q", ve[4])
+	}
+	if strings.Contains(buf.String(), "hunter2") || strings.Contains(buf.String(), "len=7") {
+		t.Errorf("expected the log to not reveal secrets, got %s", buf.String())
+	}
+	if want := []bool{true, true, true, true, false}; !slices.Equal(hookSecret, want) {
+		t.Errorf("expected hook secrets %v, got %v", want, hookSecret)
+	}
+}
+
+func TestSecretDirective_Unwrap(t *testing.T) {
+	type dummy struct {
+		Password string `val:"secret,min,size=12"`
+	}
+	_, err := ValidateStruct(&dummy{Password: "hunter2"})
+	if err == nil || strings.Contains(err.Error(), "hunter2") {
+		t.Fatalf("expected a redacted error, got %v", err)
+	}
+	if !errors.Is(err, ErrTooShort) {
+		t.Errorf("expected the error to match ErrTooShort, got %v", err)
+	}
+	var re *RangeError
+	if !errors.As(err, &re) || re.Min != 12 {
+		t.Errorf("expected a RangeError with a minimum of 12, got %v", err)
+	}
+}
+
+func TestReplaceWord(t *testing.T) {
+	tests := []struct {
+		s, old, want string
+	}{
+		{"value 1 is out of range [1, 120]", "1", "value X is out of range [X, 120]"},
+		{"value a exeeds maximum length", "a", "value X exeeds maximum length"},
+		{`"p@ss" is invalid`, "p@ss", `"X" is invalid`},
+		{"nothing here", "", "nothing here"},
+	}
+	for _, tc := range tests {
+		if got := replaceWord(tc.s, tc.old, "X"); got != tc.want {
+			t.Errorf("replaceWord(%q, %q): expected %q, got %q", tc.s, tc.old, tc.want, got)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package valex
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Sentinel errors let callers branch on why a value failed with errors.Is,
+// without matching messages:
+//
+//	if errors.Is(err, valex.ErrTooShort) { ... }
+//
+// Built-in validators wrap the one that fits: range and other numeric
+// checks ErrOutOfRange, length, size and item count checks ErrTooShort and
+// ErrTooLong, empty values ErrEmpty, syntax checks, such as those of
+// emails, addresses, numbers and documents, ErrInvalidFormat, and checks
+// rejecting content, such as blocked words or characters, ErrNotAllowed.
+// The messages are unchanged. Errors of misconfigured validators, such as
+// an unknown currency, wrap none of them.
+var (
+	ErrOutOfRange    = errors.New("out of range")
+	ErrTooShort      = errors.New("too short")
+	ErrTooLong       = errors.New("too long")
+	ErrEmpty         = errors.New("empty")
+	ErrInvalidFormat = errors.New("invalid format")
+	ErrNotAllowed    = errors.New("not allowed")
+)
+
+type sentinelError struct {
+	err      error
+	sentinel error
+}
+
+func (se *sentinelError) Error() string {
+	return se.err.Error()
+}
+
+func (se *sentinelError) Unwrap() error {
+	return se.err
+}
+
+func (se *sentinelError) Is(target error) bool {
+	return target == se.sentinel
+}
+
+// failf formats an error like fmt.Errorf that errors.Is also matches with
+// sentinel.
+func failf(sentinel error, format string, args ...any) error {
+	return &sentinelError{err: fmt.Errorf(format, args...), sentinel: sentinel}
+}
+
+// withSentinel makes errors.Is match err with sentinel.
+func withSentinel(err error, sentinel error) error {
+	if err == nil {
+		return nil
+	}
+	return &sentinelError{err: err, sentinel: sentinel}
+}
+
+// RangeError is the error of a value, or of its length, size or number of
+// items, outside the bounds of a rule. It carries the bounds, so clients
+// can render hints from them, e.g. the ends of a slider:
+//
+//	var re *valex.RangeError
+//	if errors.As(err, &re) {
+//		hint = fmt.Sprintf("between %v and %v", re.Min, re.Max)
+//	}
+//
+// Value holds what was measured, the value itself or its length, and Min
+// and Max are nil for bounds the rule does not have. The sentinel the error
+// matches tells what was measured.
+type RangeError struct {
+	Value any
+	Min   any
+	Max   any
+	err   error
+}
+
+func (re *RangeError) Error() string {
+	return re.err.Error()
+}
+
+func (re *RangeError) Unwrap() error {
+	return re.err
+}
+
+// rangeFailf is failf for bounds checks, returning a *RangeError.
+func rangeFailf(sentinel error, val, min, max any, format string, args ...any) error {
+	return &RangeError{Value: val, Min: min, Max: max, err: failf(sentinel, format, args...)}
+}
@@ -0,0 +1,184 @@
+package valex
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSentinelErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		ok   func() (bool, error)
+		want error
+	}{
+		{"int range", func() (bool, error) { return (&IntRangeValidator{Min: 1, Max: 5}).Validate(9) }, ErrOutOfRange},
+		{"cmp range", func() (bool, error) { return (&CmpRangeValidator[float64]{Min: 0, Max: 1}).Validate(2) }, ErrOutOfRange},
+		{"non-negative", func() (bool, error) { return (&NonNegativeIntValidator{}).Validate(-1) }, ErrOutOfRange},
+		{"min length", func() (bool, error) { return (&MinLengthValidator{Size: 3}).Validate("ab") }, ErrTooShort},
+		{"max length", func() (bool, error) { return (&MaxLengthValidator{Size: 3}).Validate("abcd") }, ErrTooLong},
+		{"len short", func() (bool, error) { return (&LengthRangeValidator{Min: 2, Max: 3}).Validate("a") }, ErrTooShort},
+		{"len long", func() (bool, error) { return (&LengthRangeValidator{Min: 2, Max: 3}).Validate("abcd") }, ErrTooLong},
+		{"empty", func() (bool, error) { return (&NonEmptyStringValidator{}).Validate("") }, ErrEmpty},
+		{"email", func() (bool, error) { return (&EmailValidator{}).Validate("nope") }, ErrInvalidFormat},
+		{"url", func() (bool, error) { return (&UrlValidator{}).Validate("nope") }, ErrInvalidFormat},
+		{"ip", func() (bool, error) { return (&IpValidator{}).Validate("nope") }, ErrInvalidFormat},
+		{"regex", func() (bool, error) { return (&RegexValidator{Expr: "^a$"}).Validate("b") }, ErrInvalidFormat},
+		{"json", func() (bool, error) { return (&JSONValidator{}).Validate("{") }, ErrInvalidFormat},
+		{"xml", func() (bool, error) { return (&XMLValidator{}).Validate("<a>") }, ErrInvalidFormat},
+		{"csv", func() (bool, error) { return (&CSVValidator{}).Validate("") }, ErrInvalidFormat},
+		{"xml tokens", func() (bool, error) { return (&XMLValidator{MaxTokens: 2}).Validate("<a><b/></a>") }, ErrTooLong},
+		{"xml depth", func() (bool, error) { return (&XMLValidator{MaxDepth: 1}).Validate("<a><b/></a>") }, ErrTooLong},
+		{"json depth", func() (bool, error) { return (&JSONValidator{MaxDepth: 1}).Validate("[[1]]") }, ErrTooLong},
+		{"json stream", func() (bool, error) { return (&JSONStreamValidator{}).ValidateReader(strings.NewReader("{")) }, ErrInvalidFormat},
+		{"json stream empty", func() (bool, error) { return (&JSONStreamValidator{}).ValidateReader(strings.NewReader("")) }, ErrInvalidFormat},
+		{"json stream limit", func() (bool, error) {
+			return (&JSONStreamValidator{MaxBytes: 4}).ValidateReader(strings.NewReader(`["abcdef"]`))
+		}, ErrTooLong},
+		{"xml stream limit", func() (bool, error) {
+			return (&XMLStreamValidator{MaxBytes: 4}).ValidateReader(strings.NewReader("<a><b/></a>"))
+		}, ErrTooLong},
+		{"json schema", func() (bool, error) {
+			return MustJSONSchemaValidator([]byte(`{"type":"object"}`)).Validate("[]")
+		}, ErrInvalidFormat},
+		{"json schema syntax", func() (bool, error) {
+			return MustJSONSchemaValidator([]byte(`{"type":"object"}`)).Validate("{")
+		}, ErrInvalidFormat},
+		{"decimal", func() (bool, error) { return (&DecimalValidator{}).Validate("1e3") }, ErrInvalidFormat},
+		{"decimal bound", func() (bool, error) { return (&DecimalValidator{Max: "10"}).Validate("11") }, ErrOutOfRange},
+		{"min bytes", func() (bool, error) { return (&MinBytesValidator{Size: 2}).Validate([]byte("a")) }, ErrTooShort},
+		{"max items", func() (bool, error) { return (&MaxItemsValidator{Size: 1}).Validate([]int{1, 2}) }, ErrTooLong},
+		{"shell", func() (bool, error) { return (&NoShellMetaValidator{}).Validate("a;b") }, ErrNotAllowed},
+		{"even", func() (bool, error) { return (&EvenValidator{}).Validate(3) }, ErrOutOfRange},
+		{"bitmask", func() (bool, error) { return (&BitmaskValidator{Mask: 1}).Validate(2) }, ErrNotAllowed},
+		{"ssh key", func() (bool, error) { return (&SSHPublicKeyValidator{}).Validate("nope") }, ErrInvalidFormat},
+		{"image", func() (bool, error) { return (&ImageValidator{}).Validate([]byte("nope")) }, ErrInvalidFormat},
+		{"image ref", func() (bool, error) { return (&ImageRefValidator{Digest: true}).Validate("nginx") }, ErrInvalidFormat},
+		{"k8s name", func() (bool, error) { return (&K8sNameValidator{}).Validate(strings.Repeat("a", 254)) }, ErrTooLong},
+		{"contains", func() (bool, error) { return (&ContainsValidator{Substr: "@"}).Validate("a") }, ErrInvalidFormat},
+		{"!contains", func() (bool, error) { return (&NotContainsValidator{Substr: " "}).Validate("a b") }, ErrNotAllowed},
+		{"luhn", func() (bool, error) { return (&LuhnValidator{}).Validate("4111111111111112") }, ErrInvalidFormat},
+		{"past", func() (bool, error) { return (&PastValidator{}).Validate(time.Now().Add(time.Hour)) }, ErrOutOfRange},
+		{"token length", func() (bool, error) { return (&TokenValidator{Length: 4}).Validate("abc") }, ErrTooShort},
+		{"emoji", func() (bool, error) { return (&NoEmojiValidator{}).Validate("hi 👋") }, ErrNotAllowed},
+		{"normalized", func() (bool, error) { return (&NormalizedValidator{Form: "NFC"}).Validate("e\u0301") }, ErrInvalidFormat},
+		{"html", func() (bool, error) { return (&HTMLValidator{}).Validate("<p>") }, ErrInvalidFormat},
+		{"casing", func() (bool, error) { return (&SnakeCaseValidator{}).Validate("NotSnake") }, ErrInvalidFormat},
+	}
+	for _, tc := range tests {
+		ok, err := tc.ok()
+		if ok || !errors.Is(err, tc.want) {
+			t.Errorf("%s: expected an error matching %v, got %v", tc.name, tc.want, err)
+		}
+		for _, other := range []error{ErrOutOfRange, ErrTooShort, ErrTooLong, ErrEmpty, ErrInvalidFormat, ErrNotAllowed} {
+			if other != tc.want && errors.Is(err, other) {
+				t.Errorf("%s: error %v also matches %v", tc.name, err, other)
+			}
+		}
+	}
+}
+
+func TestSentinelErrors_Tables(t *testing.T) {
+	provideTable(t, currencyTable, "currencies.txt")
+	provideTable(t, tldTable, "tlds.txt")
+	if _, err := (&MoneyValidator{Currency: "EUR"}).Validate("1.999"); !errors.Is(err, ErrInvalidFormat) {
+		t.Errorf("money: expected an error matching %v, got %v", ErrInvalidFormat, err)
+	}
+	if _, err := (&MoneyValidator{Currency: "EUR"}).ValidateMinor(-1); !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("money: expected an error matching %v, got %v", ErrOutOfRange, err)
+	}
+	if _, err := (&TLDValidator{}).Validate("example.invalidtld"); !errors.Is(err, ErrInvalidFormat) {
+		t.Errorf("tld: expected an error matching %v, got %v", ErrInvalidFormat, err)
+	}
+}
+
+func TestSentinelErrors_Struct(t *testing.T) {
+	type user struct {
+		Name string `val:"min,size=3"`
+	}
+	_, err := ValidateStruct(&user{Name: "ab"})
+	if !errors.Is(err, ErrTooShort) {
+		t.Errorf("expected a field error matching ErrTooShort, got %v", err)
+	}
+	if err.Error() == "" || errors.Is(err, ErrTooLong) {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+func TestFailf(t *testing.T) {
+	cause := errors.New("cause")
+	err := failf(ErrInvalidFormat, "value %q: %w", "x", cause)
+	if err.Error() != `value "x": cause` {
+		t.Errorf("unexpected message %q", err)
+	}
+	if !errors.Is(err, ErrInvalidFormat) || !errors.Is(err, cause) {
+		t.Error("expected the error to match its sentinel and cause")
+	}
+	if withSentinel(nil, ErrEmpty) != nil {
+		t.Error("expected withSentinel to keep nil")
+	}
+	if wrapped := fmt.Errorf("field: %w", err); !errors.Is(wrapped, ErrInvalidFormat) {
+		t.Error("expected wrapping to keep the sentinel")
+	}
+}
+
+var ageNow = time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func TestRangeError(t *testing.T) {
+	tests := []struct {
+		name      string
+		ok        func() (bool, error)
+		value     any
+		min, max  any
+		sentinel  error
+		errSubstr string
+	}{
+		{"int range", func() (bool, error) { return (&IntRangeValidator{Min: 1, Max: 5}).Validate(9) }, 9, 1, 5, ErrOutOfRange, "out of range [1, 5]"},
+		{"non-negative", func() (bool, error) { return (&NonNegativeIntValidator{}).Validate(-2) }, -2, 0, nil, ErrOutOfRange, "negative"},
+		{"min length", func() (bool, error) { return (&MinLengthValidator{Size: 3}).Validate("ab") }, 2, 3, nil, ErrTooShort, "minimum length 3"},
+		{"len", func() (bool, error) { return (&LengthRangeValidator{Min: 2, Max: 3}).Validate("abcd") }, 4, 2, 3, ErrTooLong, "not in range [2, 3]"},
+		{"max bytes", func() (bool, error) { return (&MaxBytesValidator{Size: 2}).Validate([]byte("abc")) }, 3, nil, ByteSize(2), ErrTooLong, "maximum of 2"},
+		{"items", func() (bool, error) { return (&ItemsRangeValidator{Min: 2, Max: 4}).Validate([]int{1}) }, 1, 2, 4, ErrTooShort, "not in range [2, 4]"},
+		{"floatstr", func() (bool, error) { return (&FloatStringValidator{Min: "0", Max: "1.5"}).Validate("2") }, 2.0, 0.0, 1.5, ErrOutOfRange, "maximum of 1.5"},
+		{"intstr", func() (bool, error) { return (&IntStringValidator{Min: "10"}).Validate("9") }, "9", "10", nil, ErrOutOfRange, "minimum of 10"},
+		{"age min", func() (bool, error) { return (&AgeValidator{Min: 18}).validateAt(ageNow, ageNow.AddDate(-16, 0, 0)) }, 16, 18, nil, ErrOutOfRange, "below the minimum of 18"},
+		{"age max", func() (bool, error) {
+			return (&AgeValidator{Min: 18, Max: 65}).validateAt(ageNow, ageNow.AddDate(-70, 0, 0))
+		}, 70, 18, 65, ErrOutOfRange, "above the maximum of 65"},
+		{"image width", func() (bool, error) { return (&ImageValidator{MaxWidth: 1000}).Validate(pngIHDR(1200, 10)) }, 1200, nil, 1000, ErrTooLong, "width 1200 exceeds"},
+		{"image height", func() (bool, error) { return (&ImageValidator{MaxHeight: 5}).Validate(pngIHDR(10, 20)) }, 20, nil, 5, ErrTooLong, "height 20 exceeds"},
+		{"image bytes", func() (bool, error) { return (&ImageValidator{MaxBytes: 10}).Validate(pngIHDR(10, 10)) }, 33, nil, ByteSize(10), ErrTooLong, "maximum of 10"},
+		{"image bytes reader", func() (bool, error) {
+			return (&ImageValidator{MaxBytes: 10}).ValidateReader(bytes.NewReader(pngIHDR(10, 10)))
+		}, nil, nil, ByteSize(10), ErrTooLong, "maximum of 10 bytes"},
+		{"step min", func() (bool, error) { return (&StepValidator{Step: 5, Min: 10}).Validate(5) }, 5, 10, nil, ErrOutOfRange, "minimum of 10"},
+	}
+	for _, tc := range tests {
+		_, err := tc.ok()
+		var re *RangeError
+		if !errors.As(err, &re) {
+			t.Errorf("%s: expected a RangeError, got %v", tc.name, err)
+			continue
+		}
+		if re.Value != tc.value || re.Min != tc.min || re.Max != tc.max {
+			t.Errorf("%s: got value %v, min %v, max %v, expected %v, %v, %v", tc.name, re.Value, re.Min, re.Max, tc.value, tc.min, tc.max)
+		}
+		if !errors.Is(err, tc.sentinel) || !strings.Contains(re.Error(), tc.errSubstr) {
+			t.Errorf("%s: unexpected error %v", tc.name, err)
+		}
+	}
+}
+
+func TestRangeError_Struct(t *testing.T) {
+	type survey struct {
+		Score int `val:"range,min=1,max=10"`
+	}
+	_, err := ValidateStruct(&survey{Score: 11})
+	var re *RangeError
+	if !errors.As(err, &re) || re.Min != 1 || re.Max != 10 {
+		t.Errorf("expected the bounds of the violated rule, got %v", err)
+	}
+}
@@ -0,0 +1,33 @@
+package valex
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+)
+
+// Value implements driver.Valuer, so a ValidatedValue can be passed to
+// database/sql as a query argument.
+func (v ValidatedValue[T]) Value() (driver.Value, error) {
+	return driver.DefaultParameterConverter.ConvertValue(v.value)
+}
+
+// Scan implements sql.Scanner. The scanned value is converted to T and set
+// only when it passes validation. NULL cannot be scanned; use a
+// sql.Null[ValidatedValue[T]] for nullable columns.
+func (v *ValidatedValue[T]) Scan(src any) error {
+	switch s := src.(type) {
+	case nil:
+		return errors.New("cannot scan NULL into a ValidatedValue")
+	case T:
+		return v.Set(s)
+	case []byte:
+		return v.SetString(string(s))
+	case string:
+		return v.SetString(s)
+	case int64, float64, bool:
+		// parsing the text catches overflow and fractions
+		return v.SetString(fmt.Sprint(s))
+	}
+	return fmt.Errorf("cannot scan %T into a ValidatedValue[%T]", src, v.value)
+}
@@ -0,0 +1,74 @@
+package valex
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+)
+
+var (
+	_ driver.Valuer = ValidatedValue[string]{}
+	_ sql.Scanner   = (*ValidatedValue[string])(nil)
+)
+
+func TestValidatedValue_Value(t *testing.T) {
+	type port int
+	tests := []struct {
+		v    driver.Valuer
+		want driver.Value
+	}{
+		{ValidatedValue[string]{value: "john"}, "john"},
+		{ValidatedValue[int]{value: 42}, int64(42)},
+		{ValidatedValue[port]{value: 8080}, int64(8080)},
+		{ValidatedValue[float32]{value: 1.5}, float64(1.5)},
+	}
+	for _, tc := range tests {
+		got, err := tc.v.Value()
+		if err != nil {
+			t.Errorf("%T: unexpected error: %v", tc.v, err)
+		}
+		if got != tc.want {
+			t.Errorf("%T: got %#v, want %#v", tc.v, got, tc.want)
+		}
+	}
+}
+
+func TestValidatedValue_Scan(t *testing.T) {
+	tests := []struct {
+		src     any
+		want    int8
+		wantErr bool
+	}{
+		{src: int64(42), want: 42},
+		{src: []byte("12"), want: 12},
+		{src: "7", want: 7},
+		{src: int64(-1), wantErr: true},    // fails validation
+		{src: int64(300), wantErr: true},   // overflows int8
+		{src: float64(1.5), wantErr: true}, // not an integer
+		{src: nil, wantErr: true},
+		{src: true, wantErr: true},
+	}
+	for _, tc := range tests {
+		v := ValidatedValue[int8]{Validator: ValidatorFunc[int8](func(i int8) (bool, error) {
+			if i < 0 {
+				return false, errValidationFailed
+			}
+			return true, nil
+		})}
+		err := v.Scan(tc.src)
+		if (err != nil) != tc.wantErr {
+			t.Errorf("Scan(%#v) = %v, wantErr %v", tc.src, err, tc.wantErr)
+		}
+		if v.Get() != tc.want {
+			t.Errorf("Scan(%#v) set %d, want %d", tc.src, v.Get(), tc.want)
+		}
+	}
+
+	s := ValidatedValue[string]{Validator: &MinLengthValidator{Size: 3}}
+	if err := s.Scan("jo"); err == nil {
+		t.Error("expected a validation error")
+	}
+	if err := s.Scan("john"); err != nil || s.Get() != "john" {
+		t.Errorf("got %q, err %v", s.Get(), err)
+	}
+}
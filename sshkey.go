@@ -0,0 +1,125 @@
+package valex
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+)
+
+// sshKeyTypes maps the supported key types to the number of fields of their
+// public key blob after the type name.
+var sshKeyTypes = map[string]int{
+	"ssh-rsa":                            2, // e, n
+	"ssh-dss":                            4, // p, q, g, y
+	"ssh-ed25519":                        1,
+	"ecdsa-sha2-nistp256":                2, // curve, point
+	"ecdsa-sha2-nistp384":                2,
+	"ecdsa-sha2-nistp521":                2,
+	"sk-ssh-ed25519@openssh.com":         2, // key, application
+	"sk-ecdsa-sha2-nistp256@openssh.com": 3, // curve, point, application
+}
+
+// SSHPublicKeyValidator accepts public keys in the authorized_keys format,
+// "type base64-key [comment]", optionally preceded by options such as
+// `from="10.0.0.0/8"`. Types restricts the key types, and MinBits the size
+// of RSA and DSA keys, e.g. `val:"sshkey,types=ssh-ed25519 ssh-rsa,minbits=3072"`.
+type SSHPublicKeyValidator struct {
+	Types   []string `param:"types,optional"`
+	MinBits int      `param:"minbits,optional"`
+}
+
+func (v *SSHPublicKeyValidator) Validate(val string) (ok bool, err error) {
+	fields := slices.DeleteFunc(splitQuoted(val, func(c byte) bool {
+		return c == ' ' || c == '\t'
+	}), func(f string) bool { return f == "" })
+	for len(fields) > 0 {
+		if _, known := sshKeyTypes[fields[0]]; known {
+			break
+		}
+		fields = fields[1:] // options
+	}
+	if len(fields) < 2 {
+		return false, failf(ErrInvalidFormat, "value is not an SSH public key")
+	}
+	keyType := fields[0]
+	if len(v.Types) > 0 && !slices.Contains(v.Types, keyType) {
+		return false, failf(ErrNotAllowed, "SSH key type %s is not allowed", keyType)
+	}
+
+	blob, err := base64.StdEncoding.DecodeString(fields[1])
+	if err != nil {
+		return false, failf(ErrInvalidFormat, "SSH key is not valid base64")
+	}
+	name, blob, err := sshString(blob)
+	if err == nil && string(name) != keyType {
+		return false, failf(ErrInvalidFormat, "SSH key claims type %s but holds %q", keyType, name)
+	}
+	parts := [][]byte{name}
+	for i := 0; err == nil && i < sshKeyTypes[keyType]; i++ {
+		var part []byte
+		part, blob, err = sshString(blob)
+		parts = append(parts, part)
+	}
+	if err == nil && len(blob) > 0 {
+		err = errors.New("trailing data")
+	}
+	if err != nil {
+		return false, failf(ErrInvalidFormat, "SSH %s key: %w", keyType, err)
+	}
+
+	var bits int
+	switch keyType {
+	case "ssh-rsa":
+		bits = new(big.Int).SetBytes(parts[2]).BitLen()
+	case "ssh-dss":
+		bits = new(big.Int).SetBytes(parts[1]).BitLen()
+	case "ssh-ed25519", "sk-ssh-ed25519@openssh.com":
+		if len(parts[1]) != 32 {
+			return false, failf(ErrInvalidFormat, "SSH %s key has %d bytes, expected 32", keyType, len(parts[1]))
+		}
+	default:
+		if curve := strings.TrimPrefix(strings.TrimSuffix(keyType, "@openssh.com"), "sk-"); "ecdsa-sha2-"+string(parts[1]) != curve {
+			return false, failf(ErrInvalidFormat, "SSH %s key is on curve %q", keyType, parts[1])
+		}
+	}
+	if bits > 0 && bits < v.MinBits {
+		return false, failf(ErrTooShort, "SSH %s key has %d bits, less than the minimum of %d", keyType, bits, v.MinBits)
+	}
+	return true, nil
+}
+
+// sshString reads a length-prefixed string of the SSH wire format.
+func sshString(b []byte) (s, rest []byte, err error) {
+	if len(b) < 4 {
+		return nil, nil, errors.New("truncated key")
+	}
+	size := binary.BigEndian.Uint32(b)
+	if uint64(size) > uint64(len(b)-4) {
+		return nil, nil, errors.New("truncated key")
+	}
+	return b[4 : 4+size], b[4+size:], nil
+}
+
+func (v *SSHPublicKeyValidator) configure() error {
+	for _, t := range v.Types {
+		if _, ok := sshKeyTypes[t]; !ok {
+			return fmt.Errorf("unknown SSH key type %q", t)
+		}
+	}
+	return nil
+}
+
+func (v *SSHPublicKeyValidator) Name() string {
+	return "sshkey"
+}
+
+func (v *SSHPublicKeyValidator) Handle(val string) error {
+	if ok, err := v.Validate(val); !ok {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,170 @@
+package valex
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// ErrorCategory classifies the errors produced while handling a request so
+// that they can be mapped to HTTP status codes in one place.
+type ErrorCategory int
+
+const (
+	CategoryUnknown    ErrorCategory = iota
+	CategoryValidation               // a value failed a directive
+	CategoryDecode                   // the input could not be decoded
+	CategorySize                     // the input exceeds a size limit
+	CategoryConfig                   // invalid tags, rules or options
+)
+
+func (c ErrorCategory) String() string {
+	switch c {
+	case CategoryValidation:
+		return "validation"
+	case CategoryDecode:
+		return "decode"
+	case CategorySize:
+		return "size"
+	case CategoryConfig:
+		return "config"
+	default:
+		return "unknown"
+	}
+}
+
+type categoryError struct {
+	category ErrorCategory
+	err      error
+}
+
+func (ce *categoryError) Error() string {
+	return ce.err.Error()
+}
+
+func (ce *categoryError) Unwrap() error {
+	return ce.err
+}
+
+// WithCategory wraps err so that Categorize reports c for it. The error
+// message is left unchanged.
+func WithCategory(err error, c ErrorCategory) error {
+	if err == nil {
+		return nil
+	}
+	return &categoryError{category: c, err: err}
+}
+
+// Categorize reports the category of err. Errors wrapped with WithCategory
+// keep their category; otherwise field errors count as validation errors,
+// except for tags that failed to compile, which are configuration errors.
+func Categorize(err error) ErrorCategory {
+	var cerrs CoercionErrors
+	if errors.As(err, &cerrs) {
+		for _, err := range cerrs {
+			if Categorize(err) == CategoryConfig {
+				return CategoryConfig
+			}
+		}
+		return CategoryValidation
+	}
+
+	var ce *categoryError
+	if errors.As(err, &ce) {
+		return ce.category
+	}
+
+	var maxBytes *http.MaxBytesError
+	if errors.As(err, &maxBytes) {
+		return CategorySize
+	}
+
+	var errs ValidationErrors
+	if errors.As(err, &errs) {
+		for _, fe := range errs {
+			if fe.Directive == "" {
+				return CategoryConfig
+			}
+		}
+		return CategoryValidation
+	}
+	var fe *FieldError
+	if errors.As(err, &fe) {
+		if fe.Directive == "" {
+			return CategoryConfig
+		}
+		return CategoryValidation
+	}
+
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &syntaxErr) || errors.As(err, &typeErr) ||
+		errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF) {
+		return CategoryDecode
+	}
+	return CategoryUnknown
+}
+
+// StatusMapper maps error categories to HTTP status codes. The zero value is
+// not usable, create one with NewStatusMapper.
+type StatusMapper struct {
+	mut      sync.RWMutex
+	statuses map[ErrorCategory]int
+}
+
+// NewStatusMapper returns a mapper using 422 for validation errors, 400 for
+// decode errors, 413 for size violations and 500 for everything else.
+func NewStatusMapper() *StatusMapper {
+	return &StatusMapper{
+		statuses: map[ErrorCategory]int{
+			CategoryUnknown:    http.StatusInternalServerError,
+			CategoryValidation: http.StatusUnprocessableEntity,
+			CategoryDecode:     http.StatusBadRequest,
+			CategorySize:       http.StatusRequestEntityTooLarge,
+			CategoryConfig:     http.StatusInternalServerError,
+		},
+	}
+}
+
+func (m *StatusMapper) Set(c ErrorCategory, status int) *StatusMapper {
+	m.mut.Lock()
+	m.statuses[c] = status
+	m.mut.Unlock()
+	return m
+}
+
+// Status returns the status code for err, or 200 if err is nil.
+func (m *StatusMapper) Status(err error) int {
+	if err == nil {
+		return http.StatusOK
+	}
+	c := Categorize(err)
+
+	m.mut.RLock()
+	defer m.mut.RUnlock()
+
+	if status, ok := m.statuses[c]; ok {
+		return status
+	}
+	return m.statuses[CategoryUnknown]
+}
+
+// SetStatusMapper replaces the mapper used by the engine's HTTP helpers.
+func (e *Engine) SetStatusMapper(m *StatusMapper) {
+	e.statuses.Store(m)
+}
+
+func (e *Engine) StatusMapper() *StatusMapper {
+	return e.statuses.Load()
+}
+
+// HTTPStatus returns the status code the engine's mapper assigns to err.
+func (e *Engine) HTTPStatus(err error) int {
+	return e.StatusMapper().Status(err)
+}
+
+func HTTPStatus(err error) int {
+	return std.HTTPStatus(err)
+}
@@ -0,0 +1,90 @@
+package valex
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCategorize(t *testing.T) {
+	_, validationErr := ValidateStruct(struct {
+		Age int `val:"range,min=0,max=120"`
+	}{Age: -1})
+	_, configErr := ValidateStruct(struct {
+		Age int `val:"foobar"`
+	}{})
+	_, collectedErr := ValidateStruct(orderDummy{A: "x"}, WithCollectAll())
+	_, tenantErr := ValidateStruct(orderDummy{}, WithTenant("nope"))
+	var syntaxErr error = &json.SyntaxError{}
+
+	rec := httptest.NewRecorder()
+	body := http.MaxBytesReader(rec, io.NopCloser(strings.NewReader("0123456789")), 4)
+	_, sizeErr := io.ReadAll(body)
+
+	tests := []struct {
+		name string
+		err  error
+		want ErrorCategory
+	}{
+		{name: "field error", err: validationErr, want: CategoryValidation},
+		{name: "compile error", err: configErr, want: CategoryConfig},
+		{name: "collected with compile error", err: collectedErr, want: CategoryConfig},
+		{name: "unknown tenant", err: tenantErr, want: CategoryConfig},
+		{name: "json syntax", err: fmt.Errorf("decoding: %w", syntaxErr), want: CategoryDecode},
+		{name: "truncated body", err: io.ErrUnexpectedEOF, want: CategoryDecode},
+		{name: "max bytes", err: sizeErr, want: CategorySize},
+		{name: "explicit", err: WithCategory(errors.New("too big"), CategorySize), want: CategorySize},
+		{name: "other", err: errors.New("boom"), want: CategoryUnknown},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := Categorize(tc.err); got != tc.want {
+				t.Errorf("expected %v, got %v (error: %v)", tc.want, got, tc.err)
+			}
+		})
+	}
+}
+
+func TestStatusMapper(t *testing.T) {
+	m := NewStatusMapper()
+	tests := []struct {
+		err  error
+		want int
+	}{
+		{err: nil, want: http.StatusOK},
+		{err: &FieldError{Field: "A", Directive: "min", Err: errors.New("short")}, want: http.StatusUnprocessableEntity},
+		{err: io.ErrUnexpectedEOF, want: http.StatusBadRequest},
+		{err: WithCategory(errors.New("too big"), CategorySize), want: http.StatusRequestEntityTooLarge},
+		{err: errors.New("boom"), want: http.StatusInternalServerError},
+	}
+	for _, tc := range tests {
+		if got := m.Status(tc.err); got != tc.want {
+			t.Errorf("Status(%v) = %d, want %d", tc.err, got, tc.want)
+		}
+	}
+
+	m.Set(CategoryValidation, http.StatusBadRequest)
+	if got := m.Status(&FieldError{Directive: "min"}); got != http.StatusBadRequest {
+		t.Errorf("expected overridden status 400, got %d", got)
+	}
+}
+
+func TestEngine_SetStatusMapper(t *testing.T) {
+	e := NewEngine()
+	err := &FieldError{Directive: "min", Err: errors.New("short")}
+	if got := e.HTTPStatus(err); got != http.StatusUnprocessableEntity {
+		t.Errorf("expected default 422, got %d", got)
+	}
+	e.SetStatusMapper(NewStatusMapper().Set(CategoryValidation, http.StatusBadRequest))
+	if got := e.HTTPStatus(err); got != http.StatusBadRequest {
+		t.Errorf("expected 400 after SetStatusMapper, got %d", got)
+	}
+	if got := HTTPStatus(err); got != http.StatusUnprocessableEntity {
+		t.Errorf("expected the default engine to be unaffected, got %d", got)
+	}
+}
@@ -0,0 +1,95 @@
+package valex
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+)
+
+// StreamValidator validates a document as it is read, so large payloads do
+// not have to be buffered into a string first.
+type StreamValidator interface {
+	ValidateReader(r io.Reader) (ok bool, err error)
+}
+
+// limitReader stops reading with a *http.MaxBytesError once r exceeds
+// maxBytes, which Categorize reports as CategorySize. Zero means no limit.
+func limitReader(r io.Reader, maxBytes int64) io.Reader {
+	if maxBytes <= 0 {
+		return r
+	}
+	return http.MaxBytesReader(nil, io.NopCloser(r), maxBytes)
+}
+
+// sizeError reports err as exceeding the limit of limitReader if it does.
+func sizeError(err error) error {
+	var maxBytes *http.MaxBytesError
+	if errors.As(err, &maxBytes) {
+		return failf(ErrTooLong, "input too large: %w", maxBytes)
+	}
+	return err
+}
+
+// JSONStreamValidator is the streaming JSONValidator. It checks that r holds
+// a single JSON value of at most MaxBytes, reading it token by token.
+type JSONStreamValidator struct {
+	MaxBytes int64
+}
+
+func (v *JSONStreamValidator) ValidateReader(r io.Reader) (ok bool, err error) {
+	dec := json.NewDecoder(limitReader(r, v.MaxBytes))
+	depth := 0
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF && depth > 0 {
+			return false, failf(ErrInvalidFormat, "invalid JSON: truncated input: %w", io.ErrUnexpectedEOF)
+		}
+		if err == io.EOF {
+			return false, failf(ErrInvalidFormat, "invalid JSON: no value")
+		}
+		if err != nil {
+			return false, sizeError(failf(ErrInvalidFormat, "invalid JSON: %w", err))
+		}
+		switch tok {
+		case json.Delim('{'), json.Delim('['):
+			depth++
+		case json.Delim('}'), json.Delim(']'):
+			depth--
+		}
+		if depth == 0 {
+			break
+		}
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		if err == nil {
+			err = errors.New("more than one value")
+		}
+		return false, sizeError(failf(ErrInvalidFormat, "invalid JSON: %w", err))
+	}
+	return true, nil
+}
+
+// XMLStreamValidator is the streaming XMLValidator, reading at most
+// MaxBytes.
+type XMLStreamValidator struct {
+	XMLValidator
+	MaxBytes int64
+}
+
+func (v *XMLStreamValidator) ValidateReader(r io.Reader) (ok bool, err error) {
+	ok, err = v.validate(limitReader(r, v.MaxBytes))
+	return ok, sizeError(err)
+}
+
+// CSVStreamValidator is the streaming CSVValidator, reading at most
+// MaxBytes. Records are read one at a time.
+type CSVStreamValidator struct {
+	CSVValidator
+	MaxBytes int64
+}
+
+func (v *CSVStreamValidator) ValidateReader(r io.Reader) (ok bool, err error) {
+	ok, err = v.validate(limitReader(r, v.MaxBytes))
+	return ok, sizeError(err)
+}
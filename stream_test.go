@@ -0,0 +1,67 @@
+package valex
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestStreamValidators(t *testing.T) {
+	tests := []struct {
+		name  string
+		v     StreamValidator
+		input string
+		err   string
+	}{
+		{"json object", &JSONStreamValidator{}, `{"a": [1, 2, {"b": null}]}`, ""},
+		{"json scalar", &JSONStreamValidator{}, ` 42 `, ""},
+		{"json syntax", &JSONStreamValidator{}, `{"a": }`, "invalid JSON"},
+		{"json truncated", &JSONStreamValidator{}, `{"a": [1, 2`, "truncated input: unexpected EOF"},
+		{"json open object", &JSONStreamValidator{}, `{`, "truncated input: unexpected EOF"},
+		{"json open string", &JSONStreamValidator{}, `{"a`, "unexpected EOF"},
+		{"json empty", &JSONStreamValidator{}, ``, "no value"},
+		{"json two values", &JSONStreamValidator{}, `{} {}`, "more than one value"},
+		{"json limit", &JSONStreamValidator{MaxBytes: 8}, `{"a": "0123456789"}`, "input too large"},
+		{"json within limit", &JSONStreamValidator{MaxBytes: 8}, `[1,2,3]`, ""},
+		{"xml", &XMLStreamValidator{}, `<a><b/></a>`, ""},
+		{"xml syntax", &XMLStreamValidator{}, `<a><b></a>`, "XML parsing error"},
+		{"xml limit", &XMLStreamValidator{MaxBytes: 4}, `<a><b/></a>`, "input too large"},
+		{"csv", &CSVStreamValidator{CSVValidator: CSVValidator{Header: []string{"id"}}}, "id,name\n1,a\n", ""},
+		{"csv header", &CSVStreamValidator{CSVValidator: CSVValidator{Header: []string{"email"}}}, "id,name\n1,a\n", "missing column"},
+		{"csv columns", &CSVStreamValidator{}, "id,name\n1\n", "CSV parsing error"},
+		{"csv empty", &CSVStreamValidator{}, "", "at least one record"},
+		{"csv limit", &CSVStreamValidator{MaxBytes: 10}, "id,name\n1,a\n2,b\n", "input too large"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, err := tc.v.ValidateReader(strings.NewReader(tc.input))
+			if tc.err == "" {
+				if !ok {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if ok || err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("expected error containing %q, got %v", tc.err, err)
+			}
+			if strings.Contains(tc.err, "unexpected EOF") && !errors.Is(err, io.ErrUnexpectedEOF) {
+				t.Errorf("expected io.ErrUnexpectedEOF, got %v", err)
+			}
+			if tc.err == "input too large" && Categorize(err) != CategorySize {
+				t.Errorf("expected CategorySize, got %v", Categorize(err))
+			}
+		})
+	}
+}
+
+func BenchmarkJSONStreamValidator(b *testing.B) {
+	doc := "[" + strings.Repeat(`{"id": 1, "name": "a"},`, 10000) + "{}]"
+	v := &JSONStreamValidator{}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if ok, err := v.ValidateReader(strings.NewReader(doc)); !ok {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package valex
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+// ErrUnknownDirective is matched by errors.Is for a tag naming a directive
+// that is not registered, e.g. `val:"alphnum"`.
+var ErrUnknownDirective = errors.New("unknown directive")
+
+// UnknownDirectiveError reports a directive in a tag that is not registered,
+// along with the closest registered name, if any is close.
+type UnknownDirectiveError struct {
+	Directive  string
+	Suggestion string
+}
+
+func (e *UnknownDirectiveError) Error() string {
+	if e.Suggestion == "" {
+		return fmt.Sprintf("unknown directive %q", e.Directive)
+	}
+	return fmt.Sprintf("unknown directive %q, did you mean %q?", e.Directive, e.Suggestion)
+}
+
+func (e *UnknownDirectiveError) Is(target error) bool {
+	return target == ErrUnknownDirective
+}
+
+// WithStrict checks the tags of the validated type, and of the struct types
+// it dives into, before validating anything. The first invalid tag fails the
+// call, even when its field would not be validated, e.g. because it is empty
+// or outside the selected groups.
+func WithStrict() Option {
+	return func(o *options) {
+		o.strict = true
+	}
+}
+
+// checkTags returns the first tag error in the plans of t and the struct
+// types it dives into.
+func (e *Engine) checkTags(t reflect.Type, o options) error {
+	var tagErr error
+	err := e.walkPlans(t, o, func(_ reflect.Type, p *structPlan) {
+		for _, f := range p.fields {
+			if f.err != nil && tagErr == nil {
+				tagErr = &FieldError{Field: f.name, Path: f.path, Err: f.err}
+			}
+		}
+	})
+	if err != nil {
+		return err
+	}
+	return tagErr
+}
+
+// suggest fills in the suggestion of an unknown directive error in err.
+func suggest(err error, names func() []string) error {
+	var ude *UnknownDirectiveError
+	if errors.As(err, &ude) && ude.Suggestion == "" {
+		ude.Suggestion = closest(ude.Directive, names())
+	}
+	return err
+}
+
+// knownNames returns the names usable in a val tag, or in a sane tag when
+// sanitizers is set.
+func (e *Engine) knownNames(tenant *Tenant, sanitizers bool) []string {
+	var names []string
+	e.mut.RLock()
+	if sanitizers {
+		for name := range e.sanitizers {
+			names = append(names, name)
+		}
+	} else {
+		for name := range e.registry {
+			names = append(names, name)
+		}
+		for name := range e.aliases {
+			names = append(names, name)
+		}
+	}
+	e.mut.RUnlock()
+
+	if tenant != nil {
+		tenant.mut.RLock()
+		reg := tenant.registry
+		if sanitizers {
+			reg = tenant.sanitizers
+		}
+		for name := range reg {
+			names = append(names, name)
+		}
+		tenant.mut.RUnlock()
+	}
+	names = append(names, diveDirective)
+	if !sanitizers {
+		names = append(names, omitEmptyDirective, keepGoingDirective, warnDirective, secretDirective)
+	}
+	slices.Sort(names) // ties go to the first name
+	return names
+}
+
+// closest returns the name in names nearest to name, or "" when none is
+// within a few edits of it.
+func closest(name string, names []string) string {
+	best, bestDist := "", len(name)/3+2
+	for _, n := range names {
+		if d := editDistance(name, n); d < bestDist {
+			best, bestDist = n, d
+		}
+	}
+	return best
+}
+
+// editDistance returns the number of insertions, deletions, substitutions
+// and transpositions of adjacent bytes needed to turn a into b.
+func editDistance(a, b string) int {
+	prev2 := make([]int, len(b)+1)
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				cur[j] = min(cur[j], prev2[j-2]+1)
+			}
+		}
+		prev2, prev, cur = prev, cur, prev2
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,70 @@
+package valex
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestUnknownDirective(t *testing.T) {
+	type user struct {
+		Name string `val:"alphnum"`
+	}
+	_, err := NewEngine().ValidateStruct(&user{Name: "bob"})
+	if !errors.Is(err, ErrUnknownDirective) {
+		t.Fatalf("expected ErrUnknownDirective, got %v", err)
+	}
+	var fe *FieldError
+	var ude *UnknownDirectiveError
+	if !errors.As(err, &fe) || fe.Field != "Name" {
+		t.Errorf("expected a field error for Name, got %v", err)
+	}
+	if !errors.As(err, &ude) || ude.Directive != "alphnum" || ude.Suggestion != "alphanum" {
+		t.Errorf("expected alphnum with suggestion alphanum, got %+v", ude)
+	}
+	if !strings.Contains(err.Error(), `did you mean "alphanum"?`) {
+		t.Errorf("expected a suggestion in %q", err)
+	}
+}
+
+func TestStrict(t *testing.T) {
+	type inner struct {
+		Email string `val:"emial"`
+	}
+	type outer struct {
+		Name  string `val:"omitempty,min,size=2"`
+		Inner *inner `val:"omitempty,dive"`
+	}
+	e := NewEngine()
+	if _, err := e.ValidateStruct(&outer{}); err != nil {
+		t.Fatalf("expected the unreached typo to go unnoticed, got %v", err)
+	}
+	_, err := e.ValidateStruct(&outer{}, WithStrict())
+	var ude *UnknownDirectiveError
+	if !errors.As(err, &ude) || ude.Suggestion != "email" {
+		t.Fatalf("expected unknown directive emial with suggestion email, got %v", err)
+	}
+	if c := Categorize(err); c != CategoryConfig {
+		t.Errorf("expected CategoryConfig, got %v", c)
+	}
+}
+
+func TestClosest(t *testing.T) {
+	names := []string{"alphanum", "email", "max", "min", "required", "url"}
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"alphnum", "alphanum"},
+		{"emial", "email"},
+		{"mni", "min"},
+		{"requird", "required"},
+		{"uri", "url"},
+		{"zzzzzz", ""},
+	}
+	for _, tc := range tests {
+		if got := closest(tc.name, names); got != tc.want {
+			t.Errorf("closest(%q) = %q, expected %q", tc.name, got, tc.want)
+		}
+	}
+}
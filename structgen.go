@@ -0,0 +1,431 @@
+package valex
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// StructGenConfig configures the Go source emitted by StructsFromJSONSchema
+// and StructsFromRules.
+type StructGenConfig struct {
+	Package string
+	// TypeName names the root type of a JSON Schema without a "title".
+	TypeName string
+	// Generator is mentioned in the "Code generated" header.
+	Generator string
+}
+
+type genStruct struct {
+	name   string
+	doc    string
+	fields []genField
+}
+
+type genField struct {
+	name string
+	typ  string
+	tags []string
+}
+
+type structGen struct {
+	e       *Engine
+	cfg     StructGenConfig
+	structs []*genStruct
+	names   map[string]bool
+	defs    map[string]any
+	refs    map[string]string // $ref -> type name
+	imports map[string]bool
+	owner   string // the struct being generated
+}
+
+func newStructGen(e *Engine, cfg StructGenConfig) (*structGen, error) {
+	if cfg.Package == "" {
+		return nil, fmt.Errorf("no package name set")
+	}
+	if cfg.Generator == "" {
+		cfg.Generator = "valex"
+	}
+	return &structGen{
+		e:       e,
+		cfg:     cfg,
+		names:   make(map[string]bool),
+		refs:    make(map[string]string),
+		imports: make(map[string]bool),
+	}, nil
+}
+
+// StructsFromJSONSchema emits Go struct definitions for a JSON Schema object,
+// one per object schema, with json tags for the property names and `val`
+// tags for the constraints valex can express. Keywords without a matching
+// directive are dropped. Schemas in "$defs" become named types.
+func (e *Engine) StructsFromJSONSchema(schema []byte, cfg StructGenConfig) ([]byte, error) {
+	var doc map[string]any
+	if err := json.Unmarshal(schema, &doc); err != nil {
+		return nil, fmt.Errorf("invalid JSON schema: %w", err)
+	}
+	g, err := newStructGen(e, cfg)
+	if err != nil {
+		return nil, err
+	}
+	g.defs, _ = doc["$defs"].(map[string]any)
+
+	name := cfg.TypeName
+	if title, ok := doc["title"].(string); ok && name == "" {
+		name = goName(title)
+	}
+	if name == "" {
+		return nil, fmt.Errorf("schema has no title and no type name is set")
+	}
+	g.refs["#"] = name
+	defNames := make([]string, 0, len(g.defs))
+	for def := range g.defs {
+		defNames = append(defNames, def)
+		g.refs["#/$defs/"+def] = goName(def)
+	}
+	sort.Strings(defNames)
+
+	if err := g.object(name, doc); err != nil {
+		return nil, err
+	}
+	for _, def := range defNames {
+		s, ok := g.defs[def].(map[string]any)
+		if !ok || s["type"] != "object" {
+			continue
+		}
+		if err := g.object(goName(def), s); err != nil {
+			return nil, fmt.Errorf("$defs/%s: %w", def, err)
+		}
+	}
+	return g.source()
+}
+
+func StructsFromJSONSchema(schema []byte, cfg StructGenConfig) ([]byte, error) {
+	return std.StructsFromJSONSchema(schema, cfg)
+}
+
+// StructsFromRules emits a struct for every type in rules, with the rules as
+// `val` tags. As rules carry no types, each field gets the value type of its
+// first directive, or string when that does not determine one.
+func (e *Engine) StructsFromRules(rules Rules, cfg StructGenConfig) ([]byte, error) {
+	g, err := newStructGen(e, cfg)
+	if err != nil {
+		return nil, err
+	}
+	typeNames := make([]string, 0, len(rules))
+	for typeName := range rules {
+		typeNames = append(typeNames, typeName)
+	}
+	sort.Strings(typeNames)
+
+	for _, typeName := range typeNames {
+		s := &genStruct{name: goName(typeName[strings.LastIndexByte(typeName, '.')+1:])}
+		fieldNames := make([]string, 0, len(rules[typeName]))
+		for field := range rules[typeName] {
+			fieldNames = append(fieldNames, field)
+		}
+		sort.Strings(fieldNames)
+
+		for _, field := range fieldNames {
+			tagValue := rules[typeName][field]
+			calls, _, err := e.compileGroupedTag(tagValue, nil)
+			if err != nil {
+				return nil, fmt.Errorf("invalid rule for %s.%s: %w", typeName, field, err)
+			}
+			typ := "string"
+			for _, c := range calls {
+				if c.name == diveDirective {
+					break // element type unknown
+				}
+				if c.d == nil {
+					continue
+				}
+				if t := c.d.valueType(); t.Kind() != reflect.Interface {
+					typ = g.typeString(t)
+					break
+				}
+			}
+			s.fields = append(s.fields, genField{
+				name: field,
+				typ:  typ,
+				tags: []string{fmt.Sprintf("val:%q", tagValue)},
+			})
+		}
+		if err := g.add(s); err != nil {
+			return nil, err
+		}
+	}
+	return g.source()
+}
+
+func StructsFromRules(rules Rules, cfg StructGenConfig) ([]byte, error) {
+	return std.StructsFromRules(rules, cfg)
+}
+
+func (g *structGen) typeString(t reflect.Type) string {
+	if t.PkgPath() != "" {
+		g.imports[t.PkgPath()] = true
+	}
+	return t.String()
+}
+
+func (g *structGen) add(s *genStruct) error {
+	if g.names[s.name] {
+		return fmt.Errorf("duplicate type name %s", s.name)
+	}
+	g.names[s.name] = true
+	g.structs = append(g.structs, s)
+	return nil
+}
+
+func (g *structGen) object(name string, schema map[string]any) error {
+	s := &genStruct{name: name}
+	if desc, ok := schema["description"].(string); ok {
+		s.doc = desc
+	}
+	if err := g.add(s); err != nil {
+		return err
+	}
+	owner := g.owner
+	g.owner = name
+	defer func() { g.owner = owner }()
+
+	required := make(map[string]bool)
+	if req, ok := schema["required"].([]any); ok {
+		for _, r := range req {
+			if name, ok := r.(string); ok {
+				required[name] = true
+			}
+		}
+	}
+	props, _ := schema["properties"].(map[string]any)
+	propNames := make([]string, 0, len(props))
+	for prop := range props {
+		propNames = append(propNames, prop)
+	}
+	sort.Strings(propNames)
+
+	for _, prop := range propNames {
+		ps, ok := props[prop].(map[string]any)
+		if !ok {
+			return fmt.Errorf("property %q: expected an object schema", prop)
+		}
+		f := genField{name: goName(prop)}
+		typ, directives, err := g.property(name+f.name, ps)
+		if err != nil {
+			return fmt.Errorf("property %q: %w", prop, err)
+		}
+		f.typ = typ
+		jsonTag := prop
+		if !required[prop] {
+			jsonTag += ",omitempty"
+		}
+		f.tags = append(f.tags, fmt.Sprintf("json:%q", jsonTag))
+		if len(directives) > 0 {
+			tagValue := strings.Join(directives, ",")
+			if _, _, err := g.e.compileGroupedTag(tagValue, nil); err != nil {
+				return fmt.Errorf("property %q: %w", prop, err)
+			}
+			f.tags = append(f.tags, fmt.Sprintf("val:%q", tagValue))
+		}
+		s.fields = append(s.fields, f)
+	}
+	return nil
+}
+
+// property returns the Go type of a property schema and the directives of
+// its `val` tag. Inline object schemas become types named after the path to
+// them.
+func (g *structGen) property(name string, s map[string]any) (string, []string, error) {
+	if ref, ok := s["$ref"].(string); ok {
+		typeName, ok := g.refs[ref]
+		if !ok {
+			return "", nil, fmt.Errorf("unsupported $ref %q", ref)
+		}
+		if def, ok := g.defs[strings.TrimPrefix(ref, "#/$defs/")].(map[string]any); ok && def["type"] != "object" {
+			return g.property(name, def)
+		}
+		if typeName == g.owner {
+			typeName = "*" + typeName // recursive
+		}
+		return typeName, []string{diveDirective}, nil
+	}
+
+	directives := schemaDirectives(s)
+	switch s["type"] {
+	case "string":
+		if s["format"] == "date-time" {
+			g.imports["time"] = true
+			return "time.Time", nil, nil
+		}
+		if s["contentEncoding"] == "base64" {
+			return "[]byte", nil, nil
+		}
+		return "string", directives, nil
+	case "integer":
+		return "int", directives, nil
+	case "number":
+		return "float64", directives, nil
+	case "boolean":
+		return "bool", directives, nil
+	case "array":
+		items, _ := s["items"].(map[string]any)
+		if items == nil {
+			return "[]any", nil, nil
+		}
+		typ, elem, err := g.property(name+"Item", items)
+		if err != nil {
+			return "", nil, err
+		}
+		if len(elem) > 0 && elem[0] != diveDirective {
+			elem = append([]string{diveDirective}, elem...)
+		}
+		return "[]" + typ, elem, nil
+	case "object":
+		if _, ok := s["properties"]; ok {
+			if err := g.object(name, s); err != nil {
+				return "", nil, err
+			}
+			return name, []string{diveDirective}, nil
+		}
+		if values, ok := s["additionalProperties"].(map[string]any); ok {
+			typ, _, err := g.property(name+"Value", values)
+			if err != nil {
+				return "", nil, err
+			}
+			return "map[string]" + typ, nil, nil
+		}
+		return "map[string]any", nil, nil
+	}
+	return "any", nil, nil
+}
+
+// schemaDirectives reverses the describeSchema methods of the built-in
+// validators.
+func schemaDirectives(s map[string]any) []string {
+	var ds []string
+	if def, ok := s["default"]; ok {
+		ds = append(ds, fmt.Sprintf("default=%v", def))
+	}
+
+	minimum, maximum := schemaInt(s, "minimum"), schemaInt(s, "maximum")
+	switch {
+	case minimum != nil && maximum != nil:
+		ds = append(ds, fmt.Sprintf("range,min=%d,max=%d", *minimum, *maximum))
+	case minimum != nil && *minimum == 0:
+		ds = append(ds, "pos")
+	case maximum != nil && *maximum == 0:
+		ds = append(ds, "neg")
+	}
+
+	minLen, maxLen := schemaInt(s, "minLength"), schemaInt(s, "maxLength")
+	switch {
+	case minLen != nil && maxLen != nil:
+		ds = append(ds, fmt.Sprintf("len,min=%d,max=%d", *minLen, *maxLen))
+	case minLen != nil && *minLen == 1:
+		ds = append(ds, "!empty")
+	case minLen != nil:
+		ds = append(ds, fmt.Sprintf("min,size=%d", *minLen))
+	case maxLen != nil:
+		ds = append(ds, fmt.Sprintf("max,size=%d", *maxLen))
+	}
+
+	switch s["format"] {
+	case "email":
+		ds = append(ds, "email")
+	case "uri":
+		ds = append(ds, "url")
+	case "ipv4":
+		ds = append(ds, "ipv4")
+	case "ipv6":
+		ds = append(ds, "ipv6")
+	}
+	if anyOf, ok := s["anyOf"].([]any); ok && len(anyOf) == 2 {
+		formats := make(map[any]bool)
+		for _, sub := range anyOf {
+			if m, ok := sub.(map[string]any); ok {
+				formats[m["format"]] = true
+			}
+		}
+		if formats["ipv4"] && formats["ipv6"] {
+			ds = append(ds, "ip")
+		}
+	}
+	if p, ok := s["pattern"].(string); ok {
+		switch {
+		case p == `^[a-zA-Z0-9]+$`:
+			ds = append(ds, "alphanum")
+		case !strings.Contains(p, ","):
+			ds = append(ds, "regex="+p)
+		}
+	}
+	switch s["contentMediaType"] {
+	case "application/xml":
+		ds = append(ds, "xml")
+	case "application/json":
+		ds = append(ds, "json")
+	case "text/csv":
+		ds = append(ds, "csv")
+	}
+	return ds
+}
+
+func (g *structGen) source() ([]byte, error) {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by %s. DO NOT EDIT.\n\npackage %s\n\n", g.cfg.Generator, g.cfg.Package)
+	if len(g.imports) > 0 {
+		imports := make([]string, 0, len(g.imports))
+		for imp := range g.imports {
+			imports = append(imports, strconv.Quote(imp))
+		}
+		sort.Strings(imports)
+		fmt.Fprintf(&b, "import (\n%s\n)\n\n", strings.Join(imports, "\n"))
+	}
+	for _, s := range g.structs {
+		if s.doc != "" {
+			for _, line := range strings.Split(s.doc, "\n") {
+				fmt.Fprintf(&b, "// %s\n", line)
+			}
+		}
+		fmt.Fprintf(&b, "type %s struct {\n", s.name)
+		for _, f := range s.fields {
+			fmt.Fprintf(&b, "\t%s %s `%s`\n", f.name, f.typ, strings.Join(f.tags, " "))
+		}
+		b.WriteString("}\n\n")
+	}
+	return format.Source(b.Bytes())
+}
+
+var commonInitialisms = map[string]string{
+	"api": "API", "http": "HTTP", "id": "ID", "ip": "IP", "json": "JSON",
+	"uri": "URI", "url": "URL", "uuid": "UUID", "xml": "XML",
+}
+
+// goName turns a property name like "user_id" or "zip-code" into an exported
+// Go identifier like "UserID" or "ZipCode".
+func goName(s string) string {
+	words := strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	var b strings.Builder
+	for _, w := range words {
+		if up, ok := commonInitialisms[strings.ToLower(w)]; ok {
+			b.WriteString(up)
+			continue
+		}
+		r := []rune(w)
+		r[0] = unicode.ToUpper(r[0])
+		b.WriteString(string(r))
+	}
+	name := b.String()
+	if name == "" || unicode.IsDigit([]rune(name)[0]) {
+		name = "X" + name
+	}
+	return name
+}
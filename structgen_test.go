@@ -0,0 +1,158 @@
+package valex
+
+import (
+	"encoding/json"
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+)
+
+const structGenSchema = `{
+	"title": "user",
+	"type": "object",
+	"required": ["name"],
+	"properties": {
+		"name": {"type": "string", "minLength": 1},
+		"email": {"type": "string", "format": "email"},
+		"age": {"type": "integer", "minimum": 0, "maximum": 130},
+		"created_at": {"type": "string", "format": "date-time"},
+		"tags": {"type": "array", "items": {"type": "string", "maxLength": 10}},
+		"address": {"type": "object", "properties": {"zip": {"type": "string", "minLength": 6, "maxLength": 6}}},
+		"friends": {"type": "array", "items": {"$ref": "#/$defs/friend"}},
+		"parent": {"$ref": "#"}
+	},
+	"$defs": {
+		"friend": {"type": "object", "properties": {"user_id": {"type": "integer", "minimum": 0}}}
+	}
+}`
+
+// normalize collapses the alignment gofmt adds.
+func normalize(src []byte) string {
+	lines := strings.Split(string(src), "\n")
+	for n, line := range lines {
+		lines[n] = strings.Join(strings.Fields(line), " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+func TestStructsFromJSONSchema(t *testing.T) {
+	src, err := StructsFromJSONSchema([]byte(structGenSchema), StructGenConfig{Package: "api"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := parser.ParseFile(token.NewFileSet(), "", src, 0); err != nil {
+		t.Fatalf("generated source does not parse: %v\n%s", err, src)
+	}
+
+	got := normalize(src)
+	for _, want := range []string{
+		"package api",
+		`"time"`,
+		"type User struct {",
+		"Name string `json:\"name\" val:\"!empty\"`",
+		"Email string `json:\"email,omitempty\" val:\"email\"`",
+		"Age int `json:\"age,omitempty\" val:\"range,min=0,max=130\"`",
+		"CreatedAt time.Time `json:\"created_at,omitempty\"`",
+		"Tags []string `json:\"tags,omitempty\" val:\"dive,max,size=10\"`",
+		"Address UserAddress `json:\"address,omitempty\" val:\"dive\"`",
+		"Friends []Friend `json:\"friends,omitempty\" val:\"dive\"`",
+		"Parent *User `json:\"parent,omitempty\" val:\"dive\"`",
+		"type UserAddress struct {",
+		"Zip string `json:\"zip,omitempty\" val:\"len,min=6,max=6\"`",
+		"type Friend struct {",
+		"UserID int `json:\"user_id,omitempty\" val:\"pos\"`",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("generated source lacks %s:\n%s", want, src)
+		}
+	}
+}
+
+func TestStructsFromJSONSchema_RoundTrip(t *testing.T) {
+	schema, err := GenerateJSONSchema(schemaUser{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data, _ := json.Marshal(schema)
+	src, err := StructsFromJSONSchema(data, StructGenConfig{Package: "api", TypeName: "User"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := normalize(src)
+	for _, want := range []string{
+		"ID int `json:\"id,omitempty\" val:\"pos\"`",
+		"Name string `json:\"name\" val:\"!empty\"`",
+		"Next *SchemaAddress `json:\"next,omitempty\" val:\"dive\"`",
+		"Age int `json:\"age,omitempty\" val:\"range,min=0,max=130\"`",
+		"Email string `json:\"email,omitempty\" val:\"email\"`",
+		"Code string `json:\"code,omitempty\" val:\"regex=^[A-Z]{3}$\"`",
+		"Code string `json:\"code,omitempty\" val:\"regex=^[A-Z]{3}$\"`",
+		"Zip string `json:\"zip,omitempty\" val:\"len,min=6,max=6\"`",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("generated source lacks %s:\n%s", want, src)
+		}
+	}
+}
+
+func TestStructsFromJSONSchema_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema string
+		cfg    StructGenConfig
+	}{
+		{name: "no package", schema: structGenSchema},
+		{name: "no type name", schema: `{"type":"object"}`, cfg: StructGenConfig{Package: "api"}},
+		{name: "malformed", schema: `{`, cfg: StructGenConfig{Package: "api"}},
+		{name: "external ref", schema: `{"properties":{"a":{"$ref":"other.json"}}}`, cfg: StructGenConfig{Package: "api", TypeName: "T"}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := StructsFromJSONSchema([]byte(tc.schema), tc.cfg); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestStructsFromRules(t *testing.T) {
+	rules := Rules{
+		"api.User": {"Port": "range,min=1,max=10", "Email": "email,groups=create", "Tags": "dive,email"},
+	}
+	src, err := StructsFromRules(rules, StructGenConfig{Package: "api"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := normalize(src)
+	for _, want := range []string{
+		"type User struct {",
+		"Email string `val:\"email,groups=create\"`",
+		"Port int `val:\"range,min=1,max=10\"`",
+		"Tags string `val:\"dive,email\"`",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("generated source lacks %s:\n%s", want, src)
+		}
+	}
+
+	if _, err := StructsFromRules(Rules{"api.User": {"Port": "nope"}}, StructGenConfig{Package: "api"}); err == nil {
+		t.Error("expected an error for an unknown directive")
+	}
+}
+
+func TestGoName(t *testing.T) {
+	tests := map[string]string{
+		"name":       "Name",
+		"user_id":    "UserID",
+		"zip-code":   "ZipCode",
+		"homeURL":    "HomeURL",
+		"2fa":        "X2fa",
+		"api.Client": "APIClient",
+	}
+	for in, want := range tests {
+		if got := goName(in); got != want {
+			t.Errorf("goName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
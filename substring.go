@@ -0,0 +1,141 @@
+package valex
+
+import "strings"
+
+// The validators in this file look for a fixed string in values, e.g.
+// `val:"prefix=sk_"` for API keys. Their ! variants reject it instead, e.g.
+// `val:"!contains= "` for values without spaces. A string with commas, or
+// with leading or trailing spaces around other characters, must be quoted:
+// `val:"suffix=', '"`.
+
+type ContainsValidator struct {
+	Substr string `param:"contains"`
+}
+
+func (v *ContainsValidator) Validate(val string) (ok bool, err error) {
+	if !strings.Contains(val, v.Substr) {
+		return false, failf(ErrInvalidFormat, "value %q does not contain %q", val, v.Substr)
+	}
+	return true, nil
+}
+
+func (v *ContainsValidator) Name() string {
+	return "contains"
+}
+
+func (v *ContainsValidator) Handle(val string) error {
+	if ok, err := v.Validate(val); !ok {
+		return err
+	}
+	return nil
+}
+
+type NotContainsValidator struct {
+	Substr string `param:"!contains"`
+}
+
+func (v *NotContainsValidator) Validate(val string) (ok bool, err error) {
+	if strings.Contains(val, v.Substr) {
+		return false, failf(ErrNotAllowed, "value %q contains %q", val, v.Substr)
+	}
+	return true, nil
+}
+
+func (v *NotContainsValidator) Name() string {
+	return "!contains"
+}
+
+func (v *NotContainsValidator) Handle(val string) error {
+	if ok, err := v.Validate(val); !ok {
+		return err
+	}
+	return nil
+}
+
+type PrefixValidator struct {
+	Prefix string `param:"prefix"`
+}
+
+func (v *PrefixValidator) Validate(val string) (ok bool, err error) {
+	if !strings.HasPrefix(val, v.Prefix) {
+		return false, failf(ErrInvalidFormat, "value %q does not start with %q", val, v.Prefix)
+	}
+	return true, nil
+}
+
+func (v *PrefixValidator) Name() string {
+	return "prefix"
+}
+
+func (v *PrefixValidator) Handle(val string) error {
+	if ok, err := v.Validate(val); !ok {
+		return err
+	}
+	return nil
+}
+
+type NotPrefixValidator struct {
+	Prefix string `param:"!prefix"`
+}
+
+func (v *NotPrefixValidator) Validate(val string) (ok bool, err error) {
+	if strings.HasPrefix(val, v.Prefix) {
+		return false, failf(ErrNotAllowed, "value %q starts with %q", val, v.Prefix)
+	}
+	return true, nil
+}
+
+func (v *NotPrefixValidator) Name() string {
+	return "!prefix"
+}
+
+func (v *NotPrefixValidator) Handle(val string) error {
+	if ok, err := v.Validate(val); !ok {
+		return err
+	}
+	return nil
+}
+
+type SuffixValidator struct {
+	Suffix string `param:"suffix"`
+}
+
+func (v *SuffixValidator) Validate(val string) (ok bool, err error) {
+	if !strings.HasSuffix(val, v.Suffix) {
+		return false, failf(ErrInvalidFormat, "value %q does not end with %q", val, v.Suffix)
+	}
+	return true, nil
+}
+
+func (v *SuffixValidator) Name() string {
+	return "suffix"
+}
+
+func (v *SuffixValidator) Handle(val string) error {
+	if ok, err := v.Validate(val); !ok {
+		return err
+	}
+	return nil
+}
+
+type NotSuffixValidator struct {
+	Suffix string `param:"!suffix"`
+}
+
+func (v *NotSuffixValidator) Validate(val string) (ok bool, err error) {
+	if strings.HasSuffix(val, v.Suffix) {
+		return false, failf(ErrNotAllowed, "value %q ends with %q", val, v.Suffix)
+	}
+	return true, nil
+}
+
+func (v *NotSuffixValidator) Name() string {
+	return "!suffix"
+}
+
+func (v *NotSuffixValidator) Handle(val string) error {
+	if ok, err := v.Validate(val); !ok {
+		return err
+	}
+	return nil
+}
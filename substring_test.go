@@ -0,0 +1,67 @@
+package valex
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSubstringValidators(t *testing.T) {
+	tests := []struct {
+		name      string
+		v         interface{ Validate(string) (bool, error) }
+		input     string
+		errSubstr string
+	}{
+		{"contains", &ContainsValidator{Substr: "@"}, "a@b", ""},
+		{"contains missing", &ContainsValidator{Substr: "@"}, "ab", `value "ab" does not contain "@"`},
+		{"!contains", &NotContainsValidator{Substr: " "}, "a_b", ""},
+		{"!contains present", &NotContainsValidator{Substr: " "}, "a b", `value "a b" contains " "`},
+		{"prefix", &PrefixValidator{Prefix: "sk_"}, "sk_live", ""},
+		{"prefix missing", &PrefixValidator{Prefix: "sk_"}, "pk_live", `does not start with "sk_"`},
+		{"!prefix", &NotPrefixValidator{Prefix: "/"}, "relative/path", ""},
+		{"!prefix present", &NotPrefixValidator{Prefix: "/"}, "/etc", `starts with "/"`},
+		{"suffix", &SuffixValidator{Suffix: ".json"}, "a.json", ""},
+		{"suffix missing", &SuffixValidator{Suffix: ".json"}, "a.yaml", `does not end with ".json"`},
+		{"!suffix", &NotSuffixValidator{Suffix: "/"}, "a/b", ""},
+		{"!suffix present", &NotSuffixValidator{Suffix: "/"}, "a/", `ends with "/"`},
+	}
+	for _, tc := range tests {
+		ok, err := tc.v.Validate(tc.input)
+		if tc.errSubstr == "" {
+			if !ok {
+				t.Errorf("%s(%q): unexpected error: %v", tc.name, tc.input, err)
+			}
+			continue
+		}
+		if ok || !strings.Contains(err.Error(), tc.errSubstr) {
+			t.Errorf("%s(%q): expected error containing %q, got %v", tc.name, tc.input, tc.errSubstr, err)
+		}
+	}
+}
+
+func TestSubstringValidators_Tags(t *testing.T) {
+	type key struct {
+		Key  string `val:"prefix=sk_,!contains=' '"`
+		Path string `val:"!prefix=/,!suffix=','"`
+	}
+	if _, err := ValidateStruct(&key{Key: "sk_abc", Path: "a/b"}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	for _, k := range []key{{Key: "sk_a b", Path: "a"}, {Key: "pk_abc", Path: "a"}, {Key: "sk_abc", Path: "a,"}} {
+		if _, err := ValidateStruct(&k); err == nil {
+			t.Errorf("%+v: expected an error", k)
+		}
+	}
+}
+
+func TestSubstringValidators_UnquotedSpace(t *testing.T) {
+	type token struct {
+		Value string `val:"!contains= "`
+	}
+	if _, err := ValidateStruct(&token{Value: "abc"}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, err := ValidateStruct(&token{Value: "a b"}); err == nil {
+		t.Error("expected a value with a space to fail")
+	}
+}
@@ -0,0 +1,158 @@
+package valex
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Lookup tables, such as the default blocklist, are embedded from files
+// that can be left out of the binary with build tags: valex_notables
+// excludes all of them and valex_no<name>, e.g. valex_noblocklist, a single
+// one. An excluded table can still be provided at runtime with SetTable.
+
+// TableInfo describes a lookup table for auditing binary size.
+type TableInfo struct {
+	Name     string `json:"name"`
+	Bytes    int    `json:"bytes"`
+	Entries  int    `json:"entries"`
+	Embedded bool   `json:"embedded"` // false when excluded or set at runtime
+	BuildTag string `json:"buildTag"` // excludes the table when set
+}
+
+type table struct {
+	name     string
+	data     string
+	embedded bool
+
+	once   sync.Once
+	parsed any
+}
+
+// lines returns the non-empty lines of the table that are not comments.
+func (t *table) lines() []string {
+	var lines []string
+	s := bufio.NewScanner(strings.NewReader(t.data))
+	for s.Scan() {
+		line := strings.TrimSpace(s.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+var tables = struct {
+	mut sync.RWMutex
+	m   map[string]*table
+}{m: make(map[string]*table)}
+
+// declareTable makes a table known before its data is registered, so its
+// absence can be reported.
+func declareTable(name string) {
+	tables.mut.Lock()
+	defer tables.mut.Unlock()
+
+	if _, ok := tables.m[name]; !ok {
+		tables.m[name] = &table{name: name}
+	}
+}
+
+// embedTable registers embedded table data, from the init func of a file
+// guarded by the table's build tag.
+func embedTable(name, data string) {
+	tables.mut.Lock()
+	defer tables.mut.Unlock()
+
+	tables.m[name] = &table{name: name, data: data, embedded: true}
+}
+
+// SetTable provides or replaces the data of a table at runtime, e.g. one
+// excluded from the binary with its build tag and read from disk instead.
+// The data holds one entry per line; empty lines and lines starting with #
+// are ignored.
+func SetTable(name string, data []byte) error {
+	tables.mut.Lock()
+	defer tables.mut.Unlock()
+
+	if _, ok := tables.m[name]; !ok {
+		return fmt.Errorf("unknown table %q", name)
+	}
+	tables.m[name] = &table{name: name, data: string(data)}
+	return nil
+}
+
+// RefreshTable sets the data of a table from fetch right away and then every
+// interval, until ctx is done, e.g. to keep a domain list current. Errors,
+// from fetch or for an unknown table, are passed to onError, which may be
+// nil; the table keeps its previous data. It returns ctx.Err() once ctx is
+// done, and an error right away if interval is not positive.
+func RefreshTable(ctx context.Context, name string, interval time.Duration, fetch func(ctx context.Context) ([]byte, error), onError func(error)) error {
+	if interval <= 0 {
+		return fmt.Errorf("invalid refresh interval %v for table %q: must be positive", interval, name)
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		data, err := fetch(ctx)
+		if err == nil {
+			err = SetTable(name, data)
+		}
+		if err != nil && ctx.Err() == nil && onError != nil {
+			onError(fmt.Errorf("refreshing table %q: %w", name, err))
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func tableBuildTag(name string) string {
+	return "valex_no" + name
+}
+
+// Tables lists every lookup table, including excluded ones, sorted by name.
+func Tables() []TableInfo {
+	tables.mut.RLock()
+	defer tables.mut.RUnlock()
+
+	infos := make([]TableInfo, 0, len(tables.m))
+	for _, t := range tables.m {
+		infos = append(infos, TableInfo{
+			Name:     t.name,
+			Bytes:    len(t.data),
+			Entries:  len(t.lines()),
+			Embedded: t.embedded,
+			BuildTag: tableBuildTag(t.name),
+		})
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].Name < infos[j].Name
+	})
+	return infos
+}
+
+// tableData returns the table parsed by parse, which runs once per table
+// data.
+func tableData[T any](name string, parse func(lines []string) T) (T, error) {
+	tables.mut.RLock()
+	t, ok := tables.m[name]
+	tables.mut.RUnlock()
+
+	var zero T
+	if !ok || (!t.embedded && t.data == "") {
+		return zero, WithCategory(fmt.Errorf("table %q is not available: it was excluded with the %s or valex_notables build tag, provide it with SetTable", name, tableBuildTag(name)), CategoryConfig)
+	}
+	t.once.Do(func() {
+		t.parsed = parse(t.lines())
+	})
+	return t.parsed.(T), nil
+}
@@ -0,0 +1,106 @@
+package valex
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTables(t *testing.T) {
+	var found bool
+	for _, info := range Tables() {
+		if info.Name != blocklistTable {
+			continue
+		}
+		found = true
+		if info.BuildTag != "valex_noblocklist" {
+			t.Errorf("BuildTag = %q, want valex_noblocklist", info.BuildTag)
+		}
+		if info.Embedded && (info.Bytes == 0 || info.Entries == 0) {
+			t.Errorf("embedded table reported as empty: %+v", info)
+		}
+	}
+	if !found {
+		t.Errorf("table %q not listed", blocklistTable)
+	}
+}
+
+func TestSetTable(t *testing.T) {
+	declareTable("test")
+	if _, err := tableData("test", func(lines []string) []string { return lines }); Categorize(err) != CategoryConfig {
+		t.Errorf("expected a config error for a missing table, got %v", err)
+	}
+	if err := SetTable("nope", nil); err == nil {
+		t.Error("expected an error for an unknown table")
+	}
+
+	if err := SetTable("test", []byte("# comment\nfoo\n\n bar \n")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	lines, err := tableData("test", func(lines []string) string { return strings.Join(lines, ",") })
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if lines != "foo,bar" {
+		t.Errorf("got %q, want %q", lines, "foo,bar")
+	}
+	for _, info := range Tables() {
+		if info.Name == "test" && (info.Embedded || info.Entries != 2) {
+			t.Errorf("unexpected info for a table set at runtime: %+v", info)
+		}
+	}
+}
+
+func TestRefreshTable(t *testing.T) {
+	declareTable("refresh")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	fetches := make(chan int, 10)
+	n := 0
+	fetch := func(ctx context.Context) ([]byte, error) {
+		n++
+		fetches <- n
+		if n == 2 {
+			return nil, errors.New("unavailable")
+		}
+		return []byte(fmt.Sprintf("v%d", n)), nil
+	}
+	errs := make(chan error, 10)
+	done := make(chan error)
+	go func() {
+		done <- RefreshTable(ctx, "refresh", time.Millisecond, fetch, func(err error) { errs <- err })
+	}()
+
+	for <-fetches < 3 {
+	}
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+
+	if err := <-errs; !strings.Contains(err.Error(), `refreshing table "refresh": unavailable`) {
+		t.Errorf("unexpected error: %v", err)
+	}
+	for _, info := range Tables() {
+		if info.Name == "refresh" && info.Entries != 1 {
+			t.Errorf("expected the refreshed data, got %+v", info)
+		}
+	}
+}
+
+func TestRefreshTable_InvalidInterval(t *testing.T) {
+	declareTable("refresh-interval")
+	fetch := func(ctx context.Context) ([]byte, error) {
+		t.Error("unexpected fetch")
+		return nil, nil
+	}
+	for _, interval := range []time.Duration{0, -time.Second} {
+		if err := RefreshTable(context.Background(), "refresh-interval", interval, fetch, nil); err == nil {
+			t.Errorf("interval %v: expected error", interval)
+		}
+	}
+}
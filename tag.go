@@ -1,40 +1,938 @@
 package valex
 
 import (
-	"github.com/tedla-brandsema/tagex"
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"text/template"
+	"time"
 )
 
-const tagKey = "val"
-
-var (
-	tag tagex.Tag
+const (
+	tagKey     = "val"
+	saneTagKey = "sane"
 )
 
-func init() {
-	tag = tagex.NewTag(tagKey)
-	tag.Verb = "validating"
+var std = NewEngine()
+
+// Engine holds the directives available to `val` struct tags and caches the
+// parsed tags of every struct type it has validated.
+//
+// All methods of an Engine are safe for concurrent use, so directives,
+// aliases, tenants and rules can be changed at runtime while structs are
+// being validated. A validation that is already running may still use the
+// previous set; later ones see the change.
+type Engine struct {
+	mut        sync.RWMutex
+	registry   map[string]anyDirective
+	sanitizers map[string]anyDirective
+	tenants    map[string]*Tenant
+	aliases    map[string]string
+	provider   RulesProvider
+	swapMut    sync.Mutex
+	state      atomic.Pointer[ruleState]
+	interned   sync.Map // fingerprint -> configured directive instance
+	statuses   atomic.Pointer[StatusMapper]
+	clock      atomic.Value // clockBox
+	rand       atomic.Value // randBox
+	resolver   atomic.Value // resolverBox
+	coverage   atomic.Pointer[Coverage]
+	fieldNames atomic.Value // fieldNameBox
+	msgMut     sync.Mutex
+	messages   atomic.Pointer[map[string]*template.Template] // copied on write
+	hookMut    sync.Mutex
+	hooks      atomic.Pointer[hooks] // copied on write
+	recorder   atomic.Value          // recorderBox
+	logger     atomic.Value          // loggerBox
+	playground atomic.Bool
+
+	deprecations map[string]Deprecation
+	onDeprecated func(DeprecationUse)
+
+	profile string
+}
+
+func NewEngine() *Engine {
+	e := &Engine{
+		registry:   make(map[string]anyDirective),
+		sanitizers: make(map[string]anyDirective),
+		tenants:    make(map[string]*Tenant),
+		aliases:    make(map[string]string),
+
+		deprecations: make(map[string]Deprecation),
+	}
+	e.state.Store(&ruleState{plans: &sync.Map{}})
+	e.statuses.Store(NewStatusMapper())
+	registerBuiltins(e)
+	registerContribs(e)
+	return e
+}
 
+func Default() *Engine {
+	return std
+}
+
+func registerBuiltins(e *Engine) {
 	// Int directives
-	tagex.RegisterDirective(&tag, &IntRangeValidator{})
-	tagex.RegisterDirective(&tag, &NonNegativeIntValidator{})
-	tagex.RegisterDirective(&tag, &NonPositiveIntValidator{})
+	RegisterDirective(e, &IntRangeValidator{})
+	RegisterDirective(e, &NonNegativeIntValidator{})
+	RegisterDirective(e, &NonPositiveIntValidator{})
+	RegisterDirective(e, &MultipleOfValidator{})
+	RegisterDirective(e, &StepValidator{})
+	RegisterDirective(e, &EvenValidator{})
+	RegisterDirective(e, &OddValidator{})
+	RegisterDirective(e, &PowerOfTwoValidator{})
+	RegisterDirective(e, &PrimeValidator{})
+	RegisterDirective(e, &BitmaskValidator{})
 
 	// String directives
-	tagex.RegisterDirective(&tag, &UrlValidator{})
-	tagex.RegisterDirective(&tag, &EmailValidator{})
-	tagex.RegisterDirective(&tag, &NonEmptyStringValidator{})
-	tagex.RegisterDirective(&tag, &MinLengthValidator{})
-	tagex.RegisterDirective(&tag, &MaxLengthValidator{})
-	tagex.RegisterDirective(&tag, &LengthRangeValidator{})
-	tagex.RegisterDirective(&tag, &AlphaNumericValidator{})
-	tagex.RegisterDirective(&tag, &MACAddressValidator{})
-	tagex.RegisterDirective(&tag, &IpValidator{})
-	tagex.RegisterDirective(&tag, &IPv4Validator{})
-	tagex.RegisterDirective(&tag, &IPv6Validator{})
-	tagex.RegisterDirective(&tag, &XMLValidator{})
-	tagex.RegisterDirective(&tag, &JSONValidator{})
-}
-
-func ValidateStruct(data interface{}) (bool, error) {
-	return tag.ProcessStruct(data)
+	RegisterDirective(e, &UrlValidator{})
+	RegisterDirective(e, &EmailValidator{})
+	RegisterDirective(e, &NonEmptyStringValidator{})
+	RegisterDirective(e, &MinLengthValidator{})
+	RegisterDirective(e, &MaxLengthValidator{})
+	RegisterDirective(e, &LengthRangeValidator{})
+	RegisterDirective(e, &AlphaNumericValidator{})
+	RegisterDirective(e, &RegexValidator{})
+	RegisterDirective(e, &MACAddressValidator{})
+	RegisterDirective(e, &IpValidator{})
+	RegisterDirective(e, &IPv4Validator{})
+	RegisterDirective(e, &IPv6Validator{})
+	RegisterDirective(e, &XMLValidator{})
+	RegisterDirective(e, &JSONValidator{})
+	RegisterDirective(e, &CSVValidator{})
+	RegisterDirective(e, &HTMLValidator{})
+	RegisterDirective(e, &SafeHTMLValidator{})
+	RegisterDirective(e, &NoSQLMetaValidator{})
+	RegisterDirective(e, &NoShellMetaValidator{})
+	RegisterDirective(e, &BlocklistValidator{})
+	RegisterDirective(e, &ContainsValidator{})
+	RegisterDirective(e, &NotContainsValidator{})
+	RegisterDirective(e, &PrefixValidator{})
+	RegisterDirective(e, &NotPrefixValidator{})
+	RegisterDirective(e, &SuffixValidator{})
+	RegisterDirective(e, &NotSuffixValidator{})
+	RegisterDirective(e, &LowerCaseValidator{})
+	RegisterDirective(e, &UpperCaseValidator{})
+	RegisterDirective(e, &CamelCaseValidator{})
+	RegisterDirective(e, &PascalCaseValidator{})
+	RegisterDirective(e, &SnakeCaseValidator{})
+	RegisterDirective(e, &KebabCaseValidator{})
+	RegisterDirective(e, &K8sNameValidator{})
+	RegisterDirective(e, &K8sLabelValueValidator{})
+	RegisterDirective(e, &K8sQuantityValidator{})
+	RegisterDirective(e, &ImageRefValidator{})
+	RegisterDirective(e, &NoEmojiValidator{})
+	RegisterDirective(e, &MaxEmojiValidator{})
+	RegisterDirective(e, &UnicodeValidator{})
+	RegisterDirective(e, &NormalizedValidator{})
+	RegisterDirective(e, &ConfusableValidator{})
+	RegisterDirective(e, &EmailMXValidator{})
+	RegisterDirective(e, &ResolvableHostValidator{})
+	RegisterDirective(e, &DisposableEmailValidator{})
+	RegisterDirective(e, &TLDValidator{})
+	RegisterDirective(e, &SSHPublicKeyValidator{})
+	RegisterDirective(e, &TokenValidator{})
+	RegisterDirective(e, &IntStringValidator{})
+	RegisterDirective(e, &FloatStringValidator{})
+	RegisterDirective(e, &DecimalValidator{})
+	RegisterDirective(e, &MoneyValidator{})
+	RegisterDirective(e, &LuhnValidator{})
+	RegisterDirective(e, &Mod97Validator{})
+	RegisterDirective(e, &VerhoeffValidator{})
+	e.setDirective(defaultDirectiveName, defaultDirective{})
+	e.setDirective(requiredDirectiveName, requiredDirective{})
+	e.setDirective(exprDirectiveName, exprDirective{})
+
+	// Collection directives
+	RegisterDirective(e, &MinItemsValidator{})
+	RegisterDirective(e, &MaxItemsValidator{})
+	RegisterDirective(e, &ItemsRangeValidator{})
+
+	// Byte directives
+	RegisterDirective(e, &MinBytesValidator{})
+	RegisterDirective(e, &MaxBytesValidator{})
+	RegisterDirective(e, &NonEmptyBytesValidator{})
+	RegisterDirective(e, &UTF8Validator{})
+	RegisterDirective(e, &FileTypeValidator{})
+	RegisterDirective(e, &ImageValidator{})
+
+	// Time directives
+	RegisterDirective(e, &PastValidator{})
+	RegisterDirective(e, &FutureValidator{})
+	RegisterDirective(e, &AgeValidator{})
+
+	// Sanitizers
+	RegisterSanitizer(e, &TrimSanitizer{})
+	RegisterSanitizer(e, &LowerSanitizer{})
+	RegisterSanitizer(e, &UpperSanitizer{})
+	RegisterSanitizer(e, &TitleSanitizer{})
+	RegisterSanitizer(e, &TruncateSanitizer{})
+}
+
+// RegisterDirective makes d available to tags under d.Name(), replacing any
+// directive registered under that name. It is safe to call while validating.
+// d is used as a prototype that is copied for every configuration and may
+// be called concurrently, so it must not be modified afterwards.
+func RegisterDirective[T any](r Registrar, d Directive[T]) {
+	r.setDirective(d.Name(), wrapDirective(d))
+}
+
+func (e *Engine) setDirective(name string, d anyDirective) {
+	e.mut.Lock()
+	e.registry[name] = d
+	e.mut.Unlock()
+
+	e.resetPlans() // cached plans may refer to the replaced directive
+}
+
+func (e *Engine) get(name string) (anyDirective, bool) {
+	e.mut.RLock()
+	defer e.mut.RUnlock()
+
+	d, ok := e.registry[name]
+	return d, ok
+}
+
+type FieldError struct {
+	Field     string
+	Path      FieldPath
+	Directive string
+	Err       error
+}
+
+func (fe *FieldError) Error() string {
+	if fe.Directive == "" {
+		return fmt.Sprintf("error validating field %q: %v", fe.Field, fe.Err)
+	}
+	return fmt.Sprintf("error validating field %q: directive %q failed: %v", fe.Field, fe.Directive, fe.Err)
+}
+
+func (fe *FieldError) Unwrap() error {
+	return fe.Err
+}
+
+// NestError places the field errors in err below prefix, e.g. to report the
+// errors of a nested struct validated on its own as errors of the outer one.
+// Other errors are returned as is.
+func NestError(prefix FieldPath, err error) error {
+	nest := func(fe *FieldError) *FieldError {
+		path := append(slices.Clip(prefix), fe.Path...)
+		if fe.Path == nil && fe.Field != "" {
+			path = prefix.Field(fe.Field)
+		}
+		return &FieldError{Field: path.String(), Path: path, Directive: fe.Directive, Err: fe.Err}
+	}
+	switch e := err.(type) {
+	case *FieldError:
+		return nest(e)
+	case ValidationErrors:
+		nested := make(ValidationErrors, len(e))
+		for n, fe := range e {
+			nested[n] = nest(fe)
+		}
+		return nested
+	}
+	return err
+}
+
+// ValidationErrors holds every failure found with WithCollectAll, ordered by
+// struct field declaration order and, within a field, by the order of the
+// directives in its tag. The order never depends on timing or map iteration,
+// so the same input always yields the same list.
+type ValidationErrors []*FieldError
+
+func (ve ValidationErrors) Error() string {
+	msgs := make([]string, len(ve))
+	for n, fe := range ve {
+		msgs[n] = fe.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+func (ve ValidationErrors) Unwrap() []error {
+	errs := make([]error, len(ve))
+	for n, fe := range ve {
+		errs[n] = fe
+	}
+	return errs
+}
+
+type step struct {
+	name        string
+	d           anyDirective
+	inst        any
+	fingerprint string
+	deferred    bool
+	structAware bool    // handled by structAware.handleField
+	rule        RuleRef // zero for sanitizers, which coverage ignores
+}
+
+type fieldPlan struct {
+	index     int
+	name      string
+	path      FieldPath // of the field in a root struct, saves allocating it
+	tag       string
+	groups    []string // see WithGroups
+	steps     []step
+	dive      bool
+	elemSteps []step // directives after "dive", applied to each element
+
+	sanitizers     []step
+	elemSanitizers []step
+
+	err error // reported when the field is validated
+}
+
+type structPlan struct {
+	fields     []fieldPlan
+	mutates    bool // whether a sanitize pass can change anything
+	deprecated []DeprecationUse
+}
+
+type planKey struct {
+	typ    reflect.Type
+	tenant string
+}
+
+func (e *Engine) plan(t reflect.Type, o options) (*structPlan, error) {
+	pe, err := e.forProfile(o)
+	if err != nil {
+		return nil, err
+	}
+	if pe != e {
+		return pe.plan(t, o)
+	}
+	key := planKey{typ: t, tenant: o.tenant}
+	state := e.loadState()
+	if p, ok := state.plans.Load(key); ok {
+		return p.(*structPlan), nil
+	}
+
+	var tenant *Tenant
+	if o.tenant != "" {
+		var ok bool
+		if tenant, ok = e.lookupTenant(o.tenant); !ok {
+			return nil, fmt.Errorf("unknown tenant %q", o.tenant)
+		}
+	}
+	p, loaded := state.plans.LoadOrStore(key, e.compile(t, tenant, state.rules))
+	if !loaded {
+		e.compiled(p.(*structPlan))
+	}
+	return p.(*structPlan), nil
+}
+
+// walkPlans calls fn with the plan of t and of every struct type t dives
+// into, each once.
+func (e *Engine) walkPlans(t reflect.Type, o options, fn func(reflect.Type, *structPlan)) error {
+	seen := make(map[reflect.Type]bool)
+	var walk func(t reflect.Type) error
+	walk = func(t reflect.Type) error {
+		if seen[t] {
+			return nil
+		}
+		seen[t] = true
+		p, err := e.plan(t, o)
+		if err != nil {
+			return err
+		}
+		fn(t, p)
+		for _, f := range p.fields {
+			if !f.dive {
+				continue
+			}
+			if et := diveStructType(t.Field(f.index).Type); et != nil {
+				if err := walk(et); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	return walk(t)
+}
+
+// diveStructType returns the struct type dive descends into from a field of
+// type t, if any.
+func diveStructType(t reflect.Type) reflect.Type {
+	for {
+		switch t.Kind() {
+		case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map:
+			t = t.Elem()
+		case reflect.Struct:
+			return t
+		default:
+			return nil
+		}
+	}
+}
+
+func (e *Engine) compile(t reflect.Type, tenant *Tenant, rules Rules) *structPlan {
+	p := &structPlan{}
+	for n := 0; n < t.NumField(); n++ {
+		field := t.Field(n)
+		tagValue, ok := rules.lookup(t, field.Name)
+		var tagErr error
+		if !ok {
+			tagValue, ok, tagErr = e.fieldTag(field)
+		}
+		saneValue, sane := field.Tag.Lookup(saneTagKey)
+		if !ok && !sane {
+			continue
+		}
+		fp := fieldPlan{index: n, name: field.Name, path: FieldPath{}.Field(field.Name), tag: tagValue}
+		if tagErr != nil {
+			fp.err = tagErr
+		} else if ok {
+			steps, groups, err := e.compileGroupedTag(tagValue, tenant)
+			fp.groups = groups
+			if err != nil {
+				fp.err = err
+			} else {
+				p.deprecated = append(p.deprecated, e.deprecatedUses(t, field.Name, steps)...)
+				for i := range steps {
+					if steps[i].d != nil {
+						steps[i].rule = RuleRef{Type: t.String(), Field: field.Name, Directive: steps[i].name}
+					}
+				}
+				fp.steps, fp.elemSteps, fp.dive = splitDive(steps)
+				fp.sanitizers, fp.steps = splitMutators(fp.steps)
+				fp.elemSanitizers, fp.elemSteps = splitMutators(fp.elemSteps)
+			}
+		}
+		if sane && fp.err == nil {
+			steps, err := e.compileSanitizers(saneValue, tenant)
+			if err != nil {
+				fp.err = fmt.Errorf("%s tag: %w", saneTagKey, err)
+			} else {
+				sanitizers, elemSanitizers, dive := splitDive(steps)
+				// defaults apply to what is left after sanitizing
+				fp.sanitizers = append(sanitizers, fp.sanitizers...)
+				fp.elemSanitizers = append(elemSanitizers, fp.elemSanitizers...)
+				fp.dive = fp.dive || dive
+			}
+		}
+		p.fields = append(p.fields, fp)
+		p.mutates = p.mutates || fp.dive || len(fp.sanitizers) > 0 || len(fp.elemSanitizers) > 0
+	}
+	return p
+}
+
+// compiled is called once for every newly compiled plan.
+func (e *Engine) compiled(p *structPlan) {
+	e.reportDeprecations(p)
+	if c := e.coverage.Load(); c != nil {
+		c.register(p)
+	}
+}
+
+func (e *Engine) deprecatedUses(t reflect.Type, field string, steps []step) []DeprecationUse {
+	var uses []DeprecationUse
+	for _, s := range steps {
+		if d, ok := e.deprecation(s.name); ok {
+			uses = append(uses, DeprecationUse{Deprecation: d, Type: t.String(), Field: field})
+		}
+	}
+	return uses
+}
+
+func splitDive(steps []step) (fieldSteps, elemSteps []step, dive bool) {
+	for n, s := range steps {
+		if s.name == diveDirective {
+			return steps[:n], steps[n+1:], true
+		}
+	}
+	return steps, nil, false
+}
+
+func (e *Engine) compileTag(tagValue string, tenant *Tenant) ([]step, error) {
+	lookup := e.get
+	if tenant != nil {
+		lookup = tenant.get
+	}
+	steps, err := e.compileCalls(e.expandAliases(tagValue), lookup, tenant)
+	return steps, suggest(err, func() []string { return e.knownNames(tenant, false) })
+}
+
+func (e *Engine) compileSanitizers(tagValue string, tenant *Tenant) ([]step, error) {
+	lookup := e.getSanitizer
+	if tenant != nil {
+		lookup = tenant.getSanitizer
+	}
+	steps, err := e.compileCalls(tagValue, lookup, tenant)
+	return steps, suggest(err, func() []string { return e.knownNames(tenant, true) })
+}
+
+func (e *Engine) compileCalls(tagValue string, lookup func(string) (anyDirective, bool), tenant *Tenant) ([]step, error) {
+	calls, err := parseTagValue(tagValue, lookup)
+	if err != nil {
+		return nil, err
+	}
+	if tenant != nil {
+		if err := tenant.applyOverrides(calls); err != nil {
+			return nil, err
+		}
+	}
+	steps := make([]step, 0, len(calls))
+	for _, call := range calls {
+		if marker(call.name) {
+			steps = append(steps, step{name: call.name})
+			continue
+		}
+		inst, err := call.d.instance(call.args)
+		if err != nil {
+			return nil, fmt.Errorf("directive %q: %w", call.name, err)
+		}
+		s := step{name: call.name, d: call.d, inst: inst}
+		_, s.structAware = call.d.(structAware)
+		if d, ok := inst.(Deferrer); ok {
+			s.deferred = d.Deferred()
+		}
+		if fp, err := Fingerprint(inst); err == nil {
+			// identically configured directives are shared across all plans
+			shared, _ := e.interned.LoadOrStore(fp, inst)
+			s.inst, s.fingerprint = shared, fp
+		}
+		steps = append(steps, s)
+	}
+	return steps, nil
+}
+
+func (e *Engine) ValidateStruct(data any, opts ...Option) (bool, error) {
+	return e.validate(context.Background(), data, newOptions(opts), phaseAll)
+}
+
+func (e *Engine) validate(ctx context.Context, data any, o options, ph phase) (bool, error) {
+	v, err := e.run(ctx, data, o, ph)
+	if err != nil {
+		return false, err
+	}
+	return v.result()
+}
+
+// run validates data, a struct or a pointer to one, and returns the
+// finished validation.
+func (e *Engine) run(ctx context.Context, data any, o options, ph phase) (*validation, error) {
+	val := reflect.ValueOf(data)
+	if val.Kind() == reflect.Ptr {
+		val = val.Elem()
+	}
+	if val.Kind() != reflect.Struct {
+		return nil, WithCategory(fmt.Errorf("expected a struct but got %T", data), CategoryConfig)
+	}
+	if o.strict {
+		if err := e.checkTags(val.Type(), o); err != nil {
+			return nil, WithCategory(err, CategoryConfig)
+		}
+	}
+
+	v := e.newValidation(ctx, o, ph)
+	v.root(val, nil)
+	return v, nil
+}
+
+func (e *Engine) newValidation(ctx context.Context, o options, ph phase) *validation {
+	pe, err := e.forProfile(o)
+	if err != nil {
+		return &validation{ctx: ctx, e: e, o: o, phase: ph, err: err}
+	}
+	e = pe
+	ctx = withResolver(withRand(withClock(ctx, e.Clock()), e.Rand()), e.Resolver())
+	v := &validation{ctx: ctx, e: e, o: o, phase: ph}
+	if v.rec = e.loadRecorder(); v.rec != nil {
+		v.start = time.Now()
+	}
+	if o.concurrency > 1 {
+		v.workers = make(chan struct{}, o.concurrency-1) // the caller is a worker too
+	}
+	return v
+}
+
+// root validates the struct val found at path, sanitizing it first, and
+// reports whether validation should continue.
+func (v *validation) root(val reflect.Value, path FieldPath) bool {
+	p, err := v.e.plan(val.Type(), v.o)
+	if err != nil {
+		v.err = err
+		return false
+	}
+	return v.rootPlan(p, val, path)
+}
+
+func (v *validation) rootPlan(p *structPlan, val reflect.Value, path FieldPath) bool {
+	v.typ = val.Type()
+	if v.phase != phaseDeferred && p.mutates {
+		n := len(v.errs)
+		v.sanitizing = true
+		ok := v.fields(p, val, path) && len(v.errs) == n
+		v.sanitizing = false
+		if !ok {
+			return v.err == nil && v.o.collectAll
+		}
+	}
+	return v.fields(p, val, path)
+}
+
+func (v *validation) result() (bool, error) {
+	ok, err := v.outcome()
+	v.record(err)
+	return ok, err
+}
+
+func (v *validation) outcome() (bool, error) {
+	if v.err != nil {
+		return false, WithCategory(v.err, CategoryConfig)
+	}
+	if len(v.errs) == 0 {
+		return true, nil
+	}
+	if !v.o.collectAll && len(v.errs) == 1 {
+		return false, v.errs[0]
+	}
+	return false, v.errs // more than one only when a field keeps going
+}
+
+// validation carries the state of a single ValidateStruct call through
+// nested structs and dived into collections.
+//
+// Sanitizers run in a first pass over the whole struct, so that directives
+// always see sanitized values, including those of other fields.
+type validation struct {
+	ctx        context.Context
+	e          *Engine
+	o          options
+	phase      phase
+	sanitizing bool
+	errs       ValidationErrors
+	warnings   ValidationErrors
+	err        error         // aborts validation, e.g. an unknown tenant
+	workers    chan struct{} // idle worker slots, nil unless WithConcurrency
+	typ        reflect.Type  // of the root struct, to name fields in errors
+	reports    []FieldReport // of every field checked, with options.report
+	rec        Recorder
+	start      time.Time // of the validation, when recording
+}
+
+// fail records fe and reports whether validation should continue.
+func (v *validation) fail(fe *FieldError) bool {
+	v.errs = append(v.errs, v.finish(fe))
+	return v.o.collectAll
+}
+
+func (v *validation) warn(fe *FieldError) {
+	v.warnings = append(v.warnings, v.finish(fe))
+}
+
+func (v *validation) finish(fe *FieldError) *FieldError {
+	fe.Path = slices.Clone(fe.Path) // must not alias the paths held by plans
+	if v.e.fieldNameFunc() != nil {
+		fe.Field = v.e.fieldName(v.typ, fe.Path)
+	}
+	return fe
+}
+
+// selected reports whether the directives of path run, and whether
+// validation needs to descend below path, given the paths passed to
+// WithPaths.
+func (v *validation) selected(path FieldPath) (run, descend bool) {
+	if len(v.o.paths) == 0 {
+		return true, true
+	}
+	for _, p := range v.o.paths {
+		if path.HasPrefix(p) {
+			return true, true
+		}
+		if p.HasPrefix(path) {
+			descend = true
+		}
+	}
+	return false, descend
+}
+
+func (v *validation) structValue(val reflect.Value, path FieldPath) bool {
+	p, err := v.e.plan(val.Type(), v.o)
+	if err != nil {
+		v.err = err
+		return false
+	}
+	return v.fields(p, val, path)
+}
+
+func (v *validation) fields(p *structPlan, val reflect.Value, path FieldPath) bool {
+	if v.concurrent(len(p.fields)) {
+		return v.parallel(len(p.fields), func(c *validation, n int) bool {
+			return c.field(&p.fields[n], val, path)
+		})
+	}
+	for n := range p.fields {
+		if !v.field(&p.fields[n], val, path) {
+			return false
+		}
+	}
+	return true
+}
+
+func (v *validation) field(f *fieldPlan, val reflect.Value, path FieldPath) bool {
+	fieldPath := f.path
+	if path != nil {
+		fieldPath = path.Field(f.name)
+	}
+	run, descend := v.selected(fieldPath)
+	if !descend || (!v.sanitizing && !v.inGroups(f.groups)) {
+		return true
+	}
+	if f.err != nil {
+		if run && !v.sanitizing {
+			return v.fail(&FieldError{Field: fieldPath.String(), Path: fieldPath, Err: f.err})
+		}
+		return true
+	}
+	steps, elemSteps := f.steps, f.elemSteps
+	if v.sanitizing {
+		steps, elemSteps = f.sanitizers, f.elemSanitizers
+	}
+	fieldValue := val.Field(f.index)
+	if run {
+		if !v.runSteps(steps, fieldValue, val, fieldPath) {
+			return v.o.collectAll
+		}
+	}
+	if f.dive && !omitted(steps, fieldValue) {
+		return v.dive(fieldValue, val, fieldPath, elemSteps)
+	}
+	return true
+}
+
+// concurrent reports whether n units of work are spread over workers, which
+// is not worth it when all workers are busy. Sanitizers always run
+// sequentially.
+func (v *validation) concurrent(n int) bool {
+	return v.workers != nil && !v.sanitizing && n > 1 && len(v.workers) < cap(v.workers)
+}
+
+// parallel runs fn for each of n units of work, split in contiguous chunks
+// over the workers. A chunk runs on an idle worker when there is one and on
+// the calling goroutine otherwise, so nested calls cannot starve. Every chunk
+// records its errors on its own validation; these are merged in order,
+// giving the same result as running all units in turn.
+func (v *validation) parallel(n int, fn func(c *validation, i int) bool) bool {
+	chunks := min(n, cap(v.workers)+1)
+	parts := make([]validation, chunks)
+	oks := make([]bool, chunks)
+	var (
+		wg       sync.WaitGroup
+		panicked atomic.Pointer[any]
+	)
+	for k := range parts {
+		c := &parts[k]
+		*c = validation{ctx: v.ctx, e: v.e, o: v.o, phase: v.phase, workers: v.workers, typ: v.typ}
+		run := func() {
+			oks[k] = true
+			for i := k * n / chunks; i < (k+1)*n/chunks; i++ {
+				if !fn(c, i) {
+					oks[k] = false
+					return
+				}
+			}
+		}
+		select {
+		case v.workers <- struct{}{}:
+			wg.Add(1)
+			go func() {
+				defer func() {
+					if r := recover(); r != nil {
+						panicked.Store(&r)
+					}
+					<-v.workers
+					wg.Done()
+				}()
+				run()
+			}()
+		default:
+			run()
+		}
+	}
+	wg.Wait()
+	if r := panicked.Load(); r != nil {
+		panic(*r)
+	}
+
+	for k := range parts {
+		v.errs = append(v.errs, parts[k].errs...)
+		v.warnings = append(v.warnings, parts[k].warnings...)
+		v.reports = append(v.reports, parts[k].reports...)
+		if parts[k].err != nil {
+			v.err = parts[k].err
+			return false
+		}
+		if !oks[k] {
+			return false
+		}
+	}
+	return true
+}
+
+// dive validates the elements of a collection, or the fields of a nested
+// struct, found at path in parent. Map entries are visited in key order.
+func (v *validation) dive(val, parent reflect.Value, path FieldPath, steps []step) bool {
+	switch val.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if val.IsNil() {
+			return true
+		}
+		return v.dive(val.Elem(), parent, path, steps)
+	case reflect.Struct:
+		return v.structValue(val, path)
+	case reflect.Slice, reflect.Array:
+		if v.concurrent(val.Len()) {
+			return v.parallel(val.Len(), func(c *validation, i int) bool {
+				return c.elem(val.Index(i), parent, path.Index(i), steps)
+			})
+		}
+		for i := 0; i < val.Len(); i++ {
+			if !v.elem(val.Index(i), parent, path.Index(i), steps) {
+				return false
+			}
+		}
+	case reflect.Map:
+		keys := val.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+		})
+		if v.concurrent(len(keys)) {
+			return v.parallel(len(keys), func(c *validation, i int) bool {
+				return c.elem(val.MapIndex(keys[i]), parent, path.Key(fmt.Sprint(keys[i].Interface())), steps)
+			})
+		}
+		for _, k := range keys {
+			if !v.elem(val.MapIndex(k), parent, path.Key(fmt.Sprint(k.Interface())), steps) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (v *validation) elem(val, parent reflect.Value, path FieldPath, steps []step) bool {
+	run, descend := v.selected(path)
+	if !descend {
+		return true
+	}
+	if run {
+		if !v.runSteps(steps, val, parent, path) {
+			return v.o.collectAll
+		}
+	}
+	if omitted(steps, val) {
+		return true
+	}
+	for val.Kind() == reflect.Ptr || val.Kind() == reflect.Interface {
+		if val.IsNil() {
+			return true
+		}
+		val = val.Elem()
+	}
+	if val.Kind() == reflect.Struct {
+		return v.structValue(val, path)
+	}
+	return true
+}
+
+// runSteps runs steps on val, records their failures and reports whether
+// all of them passed. It stops at the first failure, unless keepgoing
+// precedes it in steps or WithKeepGoing is set. parent is the struct that
+// holds val, for directives that compare fields.
+func (v *validation) runSteps(steps []step, val, parent reflect.Value, path FieldPath) bool {
+	passed, keepGoing, warn, secret := true, v.o.keepGoing, false, false
+	fr := v.report(path)
+	var hs *hooks
+	if !v.sanitizing {
+		hs = v.e.hooks.Load()
+	}
+	for _, s := range steps {
+		switch s.name {
+		case omitEmptyDirective:
+			if val.IsZero() {
+				return passed
+			}
+			continue
+		case keepGoingDirective:
+			keepGoing = true
+			continue
+		case warnDirective:
+			warn = true
+			continue
+		case secretDirective:
+			secret = true
+			continue
+		}
+		if !v.phase.runs(s) {
+			continue
+		}
+		var call DirectiveCall
+		if hs != nil {
+			call = v.directiveCall(&s, val, path, secret)
+			for _, h := range hs.before {
+				h(v.ctx, call)
+			}
+		}
+		var err error
+		if s.structAware {
+			err = s.d.(structAware).handleField(v.ctx, s.inst, val, parent)
+		} else {
+			err = s.d.handleAny(v.ctx, s.inst, val)
+		}
+		if c := v.e.coverage.Load(); c != nil && s.rule.Directive != "" {
+			c.record(s.rule, err == nil)
+		}
+		if err != nil && secret {
+			err = redactError(v.e.message(s, reflect.Value{}, path, err), val)
+		} else if err != nil {
+			err = v.e.message(s, val, path, err)
+		}
+		if hs != nil {
+			for _, h := range hs.after {
+				h(v.ctx, call, err)
+			}
+		}
+		if fr != nil {
+			fr.add(s.name, warn, err)
+		}
+		if err == nil {
+			continue
+		}
+		fe := &FieldError{Field: path.String(), Path: path, Directive: s.name, Err: err}
+		if warn {
+			v.warn(fe)
+			v.log(fe, val, true, secret)
+			continue
+		}
+		v.fail(fe)
+		v.log(fe, val, false, secret)
+		if !keepGoing {
+			return false
+		}
+		passed = false
+	}
+	return passed
+}
+
+// omitted reports whether steps hold omitempty and val is zero.
+func omitted(steps []step, val reflect.Value) bool {
+	for _, s := range steps {
+		if s.name == omitEmptyDirective {
+			return val.IsZero()
+		}
+	}
+	return false
+}
+
+func ValidateStruct(data interface{}, opts ...Option) (bool, error) {
+	return std.ValidateStruct(data, opts...)
 }
@@ -1,8 +1,15 @@
 package valex
 
 import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 func TestValidateStruct_int(t *testing.T) {
@@ -83,6 +90,44 @@ func TestValidateStruct_string(t *testing.T) {
 			}{Code: "abcd"},
 			wantValid: true,
 		},
+		{
+			name: "Valid CSV with optional parameters omitted",
+			data: struct {
+				Upload string `val:"csv"`
+			}{Upload: "a,b\n1,2\n"},
+			wantValid: true,
+		},
+		{
+			name: "Invalid CSV column count",
+			data: struct {
+				Upload string `val:"csv,cols=3,header=a b"`
+			}{Upload: "a,b\n1,2\n"},
+			wantValid: false,
+			errSubstr: "directive \"csv\" failed",
+		},
+		{
+			name: "Valid regex",
+			data: struct {
+				Code string `val:"regex=^[A-Z]{3}$"`
+			}{Code: "ABC"},
+			wantValid: true,
+		},
+		{
+			name: "Invalid regex match",
+			data: struct {
+				Code string `val:"regex=^[A-Z]{3}$"`
+			}{Code: "abc"},
+			wantValid: false,
+			errSubstr: "does not match pattern",
+		},
+		{
+			name: "Malformed regex",
+			data: struct {
+				Code string `val:"regex=^[A-Z$"`
+			}{Code: "ABC"},
+			wantValid: false,
+			errSubstr: "missing closing ]",
+		},
 		{
 			name: "Invalid length range (too short)",
 			data: struct {
@@ -107,3 +152,394 @@ func TestValidateStruct_string(t *testing.T) {
 		})
 	}
 }
+
+type orderDummy struct {
+	A string `val:"min,size=3"`
+	B int    `val:"range,min=0,max=10"`
+	C string `val:"email"`
+	D string `val:"foobar"`
+	E string `val:"alphanum"`
+	F int    `val:"pos"`
+	G string `val:"max,size=2"`
+}
+
+func TestValidateStruct_CollectAllOrder(t *testing.T) {
+	data := orderDummy{A: "x", B: 11, C: "nope", E: "a b", F: 1, G: "abc"}
+	want := []string{"A", "B", "C", "D", "E", "G"}
+
+	for n := 0; n < 50; n++ {
+		ok, err := ValidateStruct(data, WithCollectAll())
+		if ok {
+			t.Fatal("expected validation to fail")
+		}
+		var errs ValidationErrors
+		if !errors.As(err, &errs) {
+			t.Fatalf("expected ValidationErrors, got %T", err)
+		}
+		var got []string
+		for _, fe := range errs {
+			got = append(got, fe.Field)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("run %d: expected fields in order %v, got %v", n, want, got)
+		}
+	}
+}
+
+func TestValidateStruct_CollectAllConcurrent(t *testing.T) {
+	data := orderDummy{A: "x", B: 11, C: "nope", E: "a b", F: 1, G: "abc"}
+	_, first := ValidateStruct(data, WithCollectAll())
+
+	var wg sync.WaitGroup
+	for n := 0; n < 20; n++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := ValidateStruct(data, WithCollectAll()); err.Error() != first.Error() {
+				t.Errorf("expected %q, got %q", first, err)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestValidateStruct_FirstErrorOnly(t *testing.T) {
+	_, err := ValidateStruct(orderDummy{A: "x", B: 11})
+	var fe *FieldError
+	if !errors.As(err, &fe) || fe.Field != "A" {
+		t.Errorf("expected first error to be for field A, got %v", err)
+	}
+	var errs ValidationErrors
+	if errors.As(err, &errs) {
+		t.Errorf("expected a single FieldError without WithCollectAll, got %v", errs)
+	}
+}
+
+type diveItem struct {
+	SKU string `val:"alphanum"`
+	Qty int    `val:"range,min=1,max=10"`
+}
+
+type diveDummy struct {
+	Items  []diveItem           `val:"dive"`
+	Tags   []string             `val:"dive,max,size=3"`
+	Labels map[string]string    `val:"dive,!empty"`
+	Refs   map[string]*diveItem `val:"dive"`
+	Plain  diveItem
+}
+
+func TestValidateStruct_Dive(t *testing.T) {
+	data := diveDummy{
+		Items:  []diveItem{{SKU: "a1", Qty: 1}, {SKU: "b 2", Qty: 0}},
+		Tags:   []string{"ok", "toolong"},
+		Labels: map[string]string{"b": "", "a": ""},
+		Refs:   map[string]*diveItem{"x": {SKU: "x", Qty: 11}, "nil": nil},
+		Plain:  diveItem{SKU: "not alphanumeric"},
+	}
+	want := []string{"Items[1].SKU", "Items[1].Qty", "Tags[1]", `Labels["a"]`, `Labels["b"]`, `Refs["x"].Qty`}
+
+	_, err := ValidateStruct(data, WithCollectAll())
+	var errs ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("expected ValidationErrors, got %v", err)
+	}
+	var got []string
+	for _, fe := range errs {
+		got = append(got, fe.Path.String())
+		if fe.Field != fe.Path.String() {
+			t.Errorf("expected Field %q to match Path %q", fe.Field, fe.Path)
+		}
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	_, err = ValidateStruct(data)
+	var fe *FieldError
+	if !errors.As(err, &fe) || fe.Field != "Items[1].SKU" {
+		t.Errorf("expected first error at Items[1].SKU, got %v", err)
+	}
+}
+
+func TestValidateStruct_WithPaths(t *testing.T) {
+	data := diveDummy{
+		Items: []diveItem{{SKU: "a 1", Qty: 0}, {SKU: "b 2", Qty: 0}},
+		Tags:  []string{"toolong"},
+	}
+	tests := []struct {
+		paths []string
+		want  []string
+	}{
+		{paths: []string{"Items[1]"}, want: []string{"Items[1].SKU", "Items[1].Qty"}},
+		{paths: []string{"Items[0].Qty"}, want: []string{"Items[0].Qty"}},
+		{paths: []string{"Tags"}, want: []string{"Tags[0]"}},
+		{paths: []string{"Labels", "Plain"}, want: nil},
+	}
+	for _, tc := range tests {
+		t.Run(strings.Join(tc.paths, ","), func(t *testing.T) {
+			var paths []FieldPath
+			for _, p := range tc.paths {
+				paths = append(paths, MustParseFieldPath(p))
+			}
+			_, err := ValidateStruct(data, WithCollectAll(), WithPaths(paths...))
+			var got []string
+			var errs ValidationErrors
+			if errors.As(err, &errs) {
+				for _, fe := range errs {
+					got = append(got, fe.Field)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestNestError(t *testing.T) {
+	prefix := FieldPath{}.Field("Items").Index(1)
+	fe := &FieldError{Field: "Zip", Path: FieldPath{}.Field("Zip"), Directive: "len", Err: errValidationFailed}
+
+	nested, ok := NestError(prefix, fe).(*FieldError)
+	if !ok || nested.Field != "Items[1].Zip" || nested.Directive != "len" || nested.Err != errValidationFailed {
+		t.Errorf("got %#v", nested)
+	}
+	if fe.Field != "Zip" {
+		t.Errorf("original error modified: %v", fe)
+	}
+
+	errs, ok := NestError(prefix, ValidationErrors{fe, {Field: "Name", Directive: "min"}}).(ValidationErrors)
+	if !ok || len(errs) != 2 || errs[1].Field != "Items[1].Name" {
+		t.Errorf("got %v", errs)
+	}
+	if err := NestError(prefix, io.EOF); err != io.EOF {
+		t.Errorf("got %v, want io.EOF unchanged", err)
+	}
+}
+
+type concurrentItem struct {
+	Name string `val:"min,size=3"`
+	Qty  int    `val:"range,min=1,max=10"`
+}
+
+type concurrentDummy struct {
+	ID     int                       `val:"pos"`
+	Code   string                    `val:"alphanum"`
+	Items  []concurrentItem          `val:"dive"`
+	Tags   []string                  `val:"dive,max,size=3"`
+	Labels map[string]string         `val:"dive,!empty"`
+	Nested *concurrentDummy          `val:"dive"`
+	ByKey  map[string]concurrentItem `val:"dive"`
+}
+
+func newConcurrentDummy(depth int) *concurrentDummy {
+	d := &concurrentDummy{ID: -1, Code: "a b", Labels: map[string]string{"a": "", "b": "x", "c": ""}}
+	for n := 0; n < 50; n++ {
+		d.Items = append(d.Items, concurrentItem{Name: strings.Repeat("x", n%5), Qty: n % 12})
+		d.Tags = append(d.Tags, strings.Repeat("t", n%6))
+	}
+	d.ByKey = map[string]concurrentItem{"k1": {Name: "ab"}, "k2": {Name: "abc", Qty: 2}, "k3": {Qty: 11}}
+	if depth > 0 {
+		d.Nested = newConcurrentDummy(depth - 1)
+	}
+	return d
+}
+
+func TestValidateStruct_WithConcurrency(t *testing.T) {
+	data := newConcurrentDummy(2)
+	for _, opts := range [][]Option{nil, {WithCollectAll()}} {
+		_, want := ValidateStruct(data, opts...)
+		for _, n := range []int{1, 2, 8, 64} {
+			for i := 0; i < 10; i++ {
+				_, got := ValidateStruct(data, append(opts, WithConcurrency(n))...)
+				if got.Error() != want.Error() {
+					t.Fatalf("concurrency %d: expected\n%v\ngot\n%v", n, want, got)
+				}
+			}
+		}
+	}
+
+	if ok, err := ValidateStruct(&concurrentDummy{ID: 1, Code: "abc"}, WithConcurrency(4)); !ok {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+// gaugeDirective records how many calls run at the same time.
+type gaugeDirective struct {
+	cur, max *atomic.Int32
+}
+
+func (d *gaugeDirective) Name() string {
+	return "gauge"
+}
+
+func (d *gaugeDirective) Handle(val string) error {
+	n := d.cur.Add(1)
+	defer d.cur.Add(-1)
+	for {
+		m := d.max.Load()
+		if n <= m || d.max.CompareAndSwap(m, n) {
+			break
+		}
+	}
+	time.Sleep(time.Millisecond)
+	return nil
+}
+
+func TestValidateStruct_WithConcurrencyBound(t *testing.T) {
+	var cur, max atomic.Int32
+	e := NewEngine()
+	RegisterDirective(e, &gaugeDirective{cur: &cur, max: &max})
+
+	data := struct {
+		Vals []string `val:"dive,gauge"`
+	}{Vals: make([]string, 40)}
+	if ok, err := e.ValidateStruct(data, WithConcurrency(4)); !ok {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if m := max.Load(); m > 4 || m < 2 {
+		t.Errorf("expected between 2 and 4 concurrent calls, got %d", m)
+	}
+}
+
+type panicDirective struct{}
+
+func (d *panicDirective) Name() string {
+	return "panic"
+}
+
+func (d *panicDirective) Handle(val string) error {
+	panic("boom")
+}
+
+func TestValidateStruct_WithConcurrencyPanic(t *testing.T) {
+	e := NewEngine()
+	RegisterDirective(e, &panicDirective{})
+
+	defer func() {
+		if r := recover(); r != "boom" {
+			t.Errorf("expected the directive's panic, got %v", r)
+		}
+	}()
+	e.ValidateStruct(struct {
+		Vals []string `val:"dive,panic"`
+	}{Vals: make([]string, 8)}, WithConcurrency(4))
+}
+
+// slowDirective stands in for a directive that waits on an external system.
+type slowDirective struct{}
+
+func (d *slowDirective) Name() string {
+	return "slow"
+}
+
+func (d *slowDirective) Handle(val string) error {
+	time.Sleep(50 * time.Microsecond)
+	return nil
+}
+
+func BenchmarkValidateStruct_WithConcurrency(b *testing.B) {
+	e := NewEngine()
+	RegisterDirective(e, &slowDirective{})
+	data := struct {
+		Vals []string `val:"dive,slow"`
+	}{Vals: make([]string, 100)}
+
+	for _, n := range []int{1, 8} {
+		b.Run(fmt.Sprintf("workers=%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				e.ValidateStruct(data, WithConcurrency(n))
+			}
+		})
+	}
+}
+
+type omitEmptyAddress struct {
+	Zip string `val:"len,min=6,max=6"`
+}
+
+func TestValidateStruct_OmitEmpty(t *testing.T) {
+	type dummy struct {
+		Email   string            `val:"omitempty,email"`
+		Age     int               `val:"omitempty,range,min=18,max=130"`
+		Tags    []string          `val:"dive,omitempty,min,size=2"`
+		Address omitEmptyAddress  `val:"omitempty,dive"`
+		Home    *omitEmptyAddress `val:"omitempty,dive"`
+		Before  string            `val:"max,size=3,omitempty,email"`
+	}
+	tests := []struct {
+		name      string
+		data      dummy
+		errSubstr string
+	}{
+		{"all empty", dummy{}, ""},
+		{"empty elements", dummy{Tags: []string{"", "ab"}}, ""},
+		{"invalid email", dummy{Email: "nope"}, `"Email"`},
+		{"invalid age", dummy{Age: 3}, `"Age"`},
+		{"invalid element", dummy{Tags: []string{"", "a"}}, `"Tags[1]"`},
+		{"invalid nested", dummy{Address: omitEmptyAddress{Zip: "1"}}, `"Address.Zip"`},
+		{"invalid pointer", dummy{Home: &omitEmptyAddress{}}, `"Home.Zip"`},
+		{"directive before omitempty", dummy{Before: "toolong"}, `directive "max" failed`},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, err := ValidateStruct(&tc.data)
+			if tc.errSubstr == "" {
+				if !ok {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if ok || !strings.Contains(err.Error(), tc.errSubstr) {
+				t.Errorf("expected error containing %q, got %v", tc.errSubstr, err)
+			}
+		})
+	}
+}
+
+func TestValidateStruct_KeepGoing(t *testing.T) {
+	type dummy struct {
+		Password string   `val:"keepgoing,min,size=8,alphanum,max,size=3"`
+		Code     string   `val:"min,size=8,alphanum"`
+		Tags     []string `val:"dive,keepgoing,min,size=4,alphanum"`
+	}
+	data := dummy{Password: "a b", Code: "a b", Tags: []string{"a b"}}
+	fields := func(err error) []string {
+		var got []string
+		var ve ValidationErrors
+		if errors.As(err, &ve) {
+			for _, fe := range ve {
+				got = append(got, fe.Field+":"+fe.Directive)
+			}
+		} else if fe, ok := err.(*FieldError); ok {
+			got = append(got, fe.Field+":"+fe.Directive)
+		}
+		return got
+	}
+
+	tests := []struct {
+		name string
+		opts []Option
+		want []string
+	}{
+		{"first error", nil, []string{"Password:min", "Password:alphanum"}},
+		{"collect all", []Option{WithCollectAll()}, []string{"Password:min", "Password:alphanum", "Code:min", "Tags[0]:min", "Tags[0]:alphanum"}},
+		{"option", []Option{WithCollectAll(), WithKeepGoing()}, []string{"Password:min", "Password:alphanum", "Code:min", "Code:alphanum", "Tags[0]:min", "Tags[0]:alphanum"}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ValidateStruct(&data, tc.opts...)
+			if got := fields(err); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+
+	_, err := ValidateStruct(&dummy{Password: "abcdefgh", Code: "a b"})
+	if _, ok := err.(*FieldError); !ok {
+		t.Errorf("expected a single *FieldError, got %T", err)
+	}
+}
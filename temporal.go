@@ -0,0 +1,139 @@
+package valex
+
+import (
+	"context"
+	"time"
+)
+
+// The validators in this file read the current time from their Clock field
+// when set, and otherwise from the engine's clock when run from a struct tag
+// or from the system clock when called directly.
+
+func clockOr(c Clock) Clock {
+	if c == nil {
+		return SystemClock
+	}
+	return c
+}
+
+func contextClock(ctx context.Context, c Clock) Clock {
+	if c == nil {
+		return ClockFromContext(ctx)
+	}
+	return c
+}
+
+type PastValidator struct {
+	Clock Clock
+}
+
+func (v *PastValidator) validateAt(now, val time.Time) (bool, error) {
+	if !val.Before(now) {
+		return false, failf(ErrOutOfRange, "time %s is not in the past", val.Format(time.RFC3339))
+	}
+	return true, nil
+}
+
+func (v *PastValidator) Validate(val time.Time) (ok bool, err error) {
+	return v.validateAt(clockOr(v.Clock).Now(), val)
+}
+
+func (v *PastValidator) Name() string {
+	return "past"
+}
+
+func (v *PastValidator) Handle(val time.Time) error {
+	return v.HandleContext(context.Background(), val)
+}
+
+func (v *PastValidator) HandleContext(ctx context.Context, val time.Time) error {
+	if ok, err := v.validateAt(contextClock(ctx, v.Clock).Now(), val); !ok {
+		return err
+	}
+	return nil
+}
+
+// FutureValidator accepts times after now, e.g. expiry dates.
+type FutureValidator struct {
+	Clock Clock
+}
+
+func (v *FutureValidator) validateAt(now, val time.Time) (bool, error) {
+	if !val.After(now) {
+		return false, failf(ErrOutOfRange, "time %s is not in the future", val.Format(time.RFC3339))
+	}
+	return true, nil
+}
+
+func (v *FutureValidator) Validate(val time.Time) (ok bool, err error) {
+	return v.validateAt(clockOr(v.Clock).Now(), val)
+}
+
+func (v *FutureValidator) Name() string {
+	return "future"
+}
+
+func (v *FutureValidator) Handle(val time.Time) error {
+	return v.HandleContext(context.Background(), val)
+}
+
+func (v *FutureValidator) HandleContext(ctx context.Context, val time.Time) error {
+	if ok, err := v.validateAt(contextClock(ctx, v.Clock).Now(), val); !ok {
+		return err
+	}
+	return nil
+}
+
+// AgeValidator checks the age in whole years of a date of birth. A Max of
+// zero means there is no upper bound.
+type AgeValidator struct {
+	Min   int `param:"min,optional"`
+	Max   int `param:"max,optional"`
+	Clock Clock
+}
+
+func age(birth, now time.Time) int {
+	birth = birth.In(now.Location())
+	years := now.Year() - birth.Year()
+	if now.Month() < birth.Month() || (now.Month() == birth.Month() && now.Day() < birth.Day()) {
+		years--
+	}
+	return years
+}
+
+func (v *AgeValidator) validateAt(now, val time.Time) (bool, error) {
+	if val.After(now) {
+		return false, failf(ErrOutOfRange, "date of birth %s is in the future", val.Format(time.DateOnly))
+	}
+	years := age(val, now)
+	var max any // not set
+	if v.Max > 0 {
+		max = v.Max
+	}
+	if years < v.Min {
+		return false, rangeFailf(ErrOutOfRange, years, v.Min, max, "age %d is below the minimum of %d", years, v.Min)
+	}
+	if v.Max > 0 && years > v.Max {
+		return false, rangeFailf(ErrOutOfRange, years, v.Min, max, "age %d is above the maximum of %d", years, v.Max)
+	}
+	return true, nil
+}
+
+func (v *AgeValidator) Validate(val time.Time) (ok bool, err error) {
+	return v.validateAt(clockOr(v.Clock).Now(), val)
+}
+
+func (v *AgeValidator) Name() string {
+	return "age"
+}
+
+func (v *AgeValidator) Handle(val time.Time) error {
+	return v.HandleContext(context.Background(), val)
+}
+
+func (v *AgeValidator) HandleContext(ctx context.Context, val time.Time) error {
+	if ok, err := v.validateAt(contextClock(ctx, v.Clock).Now(), val); !ok {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package valex
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+var testNow = time.Date(2024, time.March, 15, 12, 0, 0, 0, time.UTC)
+
+func TestPastAndFutureValidator(t *testing.T) {
+	clock := FixedClock(testNow)
+	tests := []struct {
+		name     string
+		val      time.Time
+		wantPast bool
+		wantFut  bool
+	}{
+		{name: "earlier", val: testNow.Add(-time.Second), wantPast: true},
+		{name: "later", val: testNow.Add(time.Second), wantFut: true},
+		{name: "now", val: testNow},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if ok, _ := (&PastValidator{Clock: clock}).Validate(tc.val); ok != tc.wantPast {
+				t.Errorf("past: expected %v, got %v", tc.wantPast, ok)
+			}
+			if ok, _ := (&FutureValidator{Clock: clock}).Validate(tc.val); ok != tc.wantFut {
+				t.Errorf("future: expected %v, got %v", tc.wantFut, ok)
+			}
+		})
+	}
+}
+
+func TestAgeValidator(t *testing.T) {
+	birth := func(y int, m time.Month, d int) time.Time { return time.Date(y, m, d, 0, 0, 0, 0, time.UTC) }
+	tests := []struct {
+		name      string
+		v         AgeValidator
+		val       time.Time
+		errSubstr string
+	}{
+		{name: "18 today", v: AgeValidator{Min: 18}, val: birth(2006, time.March, 15)},
+		{name: "18 tomorrow", v: AgeValidator{Min: 18}, val: birth(2006, time.March, 16), errSubstr: "age 17 is below the minimum of 18"},
+		{name: "earlier month", v: AgeValidator{Min: 18}, val: birth(2006, time.February, 28)},
+		{name: "above max", v: AgeValidator{Max: 65}, val: birth(1950, time.January, 1), errSubstr: "above the maximum"},
+		{name: "no max", v: AgeValidator{}, val: birth(1900, time.January, 1)},
+		{name: "unborn", v: AgeValidator{}, val: birth(2030, time.January, 1), errSubstr: "in the future"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.v.Clock = FixedClock(testNow)
+			ok, err := tc.v.Validate(tc.val)
+			if tc.errSubstr == "" {
+				if !ok {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if ok || !strings.Contains(err.Error(), tc.errSubstr) {
+				t.Errorf("expected error containing %q, got %v", tc.errSubstr, err)
+			}
+		})
+	}
+}
+
+type temporalDummy struct {
+	Born    time.Time `val:"age,min=18"`
+	Expires time.Time `val:"future"`
+}
+
+func TestEngine_SetClock(t *testing.T) {
+	e := NewEngine()
+	e.SetClock(FixedClock(testNow))
+	data := temporalDummy{
+		Born:    time.Date(2006, time.March, 15, 0, 0, 0, 0, time.UTC),
+		Expires: testNow.Add(time.Hour),
+	}
+	if ok, err := e.ValidateStruct(data); !ok {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// an hour and a day later the data has expired, and age is unaffected
+	e.SetClock(FixedClock(testNow.Add(25 * time.Hour)))
+	if ok, _ := e.ValidateStruct(data); ok {
+		t.Error("expected the frozen clock to have moved on")
+	}
+
+	e.SetClock(nil)
+	if _, ok := e.Clock().(ClockFunc); !ok {
+		t.Errorf("expected the system clock after SetClock(nil), got %T", e.Clock())
+	}
+	if ok, _ := ValidateStruct(data); ok {
+		t.Error("expected the default engine to use the system clock")
+	}
+}
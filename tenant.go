@@ -0,0 +1,125 @@
+package valex
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Registrar is implemented by the registries directives can be added to:
+// an Engine and each of its tenants.
+type Registrar interface {
+	setDirective(name string, d anyDirective)
+	setSanitizer(name string, s anyDirective)
+}
+
+// Tenant is a named registry within an Engine. Its directives take precedence
+// over the engine's, and its parameter overrides take precedence over the
+// parameters given in struct tags.
+type Tenant struct {
+	name       string
+	engine     *Engine
+	mut        sync.RWMutex
+	registry   map[string]anyDirective
+	sanitizers map[string]anyDirective
+	overrides  map[string]map[string]string
+}
+
+func (t *Tenant) Name() string {
+	return t.name
+}
+
+func (t *Tenant) setDirective(name string, d anyDirective) {
+	t.mut.Lock()
+	t.registry[name] = d
+	t.mut.Unlock()
+
+	t.engine.resetPlans()
+}
+
+func (t *Tenant) get(name string) (anyDirective, bool) {
+	t.mut.RLock()
+	d, ok := t.registry[name]
+	t.mut.RUnlock()
+
+	if ok {
+		return d, true
+	}
+	return t.engine.get(name)
+}
+
+func (t *Tenant) setSanitizer(name string, s anyDirective) {
+	t.mut.Lock()
+	t.sanitizers[name] = s
+	t.mut.Unlock()
+
+	t.engine.resetPlans()
+}
+
+func (t *Tenant) getSanitizer(name string) (anyDirective, bool) {
+	t.mut.RLock()
+	s, ok := t.sanitizers[name]
+	t.mut.RUnlock()
+
+	if ok {
+		return s, true
+	}
+	return t.engine.getSanitizer(name)
+}
+
+// Override sets param of directive to value for every struct validated with
+// this tenant, regardless of the value given in the struct tag.
+func (t *Tenant) Override(directive, param, value string) {
+	t.mut.Lock()
+	if t.overrides[directive] == nil {
+		t.overrides[directive] = make(map[string]string)
+	}
+	t.overrides[directive][param] = value
+	t.mut.Unlock()
+
+	t.engine.resetPlans()
+}
+
+func (t *Tenant) applyOverrides(calls []directiveCall) error {
+	t.mut.RLock()
+	defer t.mut.RUnlock()
+
+	for _, call := range calls {
+		if call.d == nil {
+			continue // dive
+		}
+		for k, v := range t.overrides[call.name] {
+			if _, ok := call.d.params().lookup(k); !ok {
+				return fmt.Errorf("tenant %q overrides unknown parameter %q for directive %q", t.name, k, call.name)
+			}
+			call.args[k] = v
+		}
+	}
+	return nil
+}
+
+// Tenant returns the tenant registered under name, creating it on first use.
+func (e *Engine) Tenant(name string) *Tenant {
+	e.mut.Lock()
+	defer e.mut.Unlock()
+
+	if t, ok := e.tenants[name]; ok {
+		return t
+	}
+	t := &Tenant{
+		name:       name,
+		engine:     e,
+		registry:   make(map[string]anyDirective),
+		sanitizers: make(map[string]anyDirective),
+		overrides:  make(map[string]map[string]string),
+	}
+	e.tenants[name] = t
+	return t
+}
+
+func (e *Engine) lookupTenant(name string) (*Tenant, bool) {
+	e.mut.RLock()
+	defer e.mut.RUnlock()
+
+	t, ok := e.tenants[name]
+	return t, ok
+}
@@ -0,0 +1,72 @@
+package valex
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+type tenantDummy struct {
+	Name string `val:"max,size=10"`
+}
+
+type upperCaseValidator struct{}
+
+func (v *upperCaseValidator) Name() string {
+	return "upper"
+}
+
+func (v *upperCaseValidator) Handle(val string) error {
+	if strings.ToUpper(val) != val {
+		return fmt.Errorf("value %q is not upper case", val)
+	}
+	return nil
+}
+
+func TestEngine_TenantOverride(t *testing.T) {
+	e := NewEngine()
+	e.Tenant("acme").Override("max", "size", "3")
+
+	data := tenantDummy{Name: "Johnny"}
+	if ok, err := e.ValidateStruct(data); !ok {
+		t.Errorf("expected default rules to pass, got %v", err)
+	}
+	ok, err := e.ValidateStruct(data, WithTenant("acme"))
+	if ok || err == nil || !strings.Contains(err.Error(), "maximum length 3") {
+		t.Errorf("expected tenant override to fail with maximum length 3, got ok=%v err=%v", ok, err)
+	}
+}
+
+func TestEngine_TenantUnknown(t *testing.T) {
+	e := NewEngine()
+	ok, err := e.ValidateStruct(tenantDummy{}, WithTenant("nobody"))
+	if ok || err == nil || !strings.Contains(err.Error(), `unknown tenant "nobody"`) {
+		t.Errorf("expected unknown tenant error, got ok=%v err=%v", ok, err)
+	}
+}
+
+func TestEngine_TenantOverrideUnknownParam(t *testing.T) {
+	e := NewEngine()
+	e.Tenant("acme").Override("max", "length", "3")
+
+	ok, err := e.ValidateStruct(tenantDummy{Name: "x"}, WithTenant("acme"))
+	if ok || err == nil || !strings.Contains(err.Error(), `unknown parameter "length"`) {
+		t.Errorf("expected unknown parameter error, got ok=%v err=%v", ok, err)
+	}
+}
+
+func TestEngine_TenantDirective(t *testing.T) {
+	e := NewEngine()
+	RegisterDirective[string](e.Tenant("acme"), &upperCaseValidator{})
+
+	data := struct {
+		Code string `val:"upper"`
+	}{Code: "abc"}
+
+	if ok, err := e.ValidateStruct(data); ok || !strings.Contains(err.Error(), `unknown directive "upper"`) {
+		t.Errorf("expected tenant directive to be unknown to the engine, got ok=%v err=%v", ok, err)
+	}
+	if ok, err := e.ValidateStruct(data, WithTenant("acme")); ok || !strings.Contains(err.Error(), "not upper case") {
+		t.Errorf("expected tenant directive to run, got ok=%v err=%v", ok, err)
+	}
+}
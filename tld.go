@@ -0,0 +1,91 @@
+package valex
+
+import (
+	"net"
+	"net/mail"
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/idna"
+)
+
+const tldTable = "tlds"
+
+func init() {
+	declareTable(tldTable)
+}
+
+func topLevelDomains() (map[string]bool, error) {
+	return tableData(tldTable, domainSet)
+}
+
+// TLDValidator accepts host names, email addresses and URLs whose domain
+// ends in a top-level domain delegated by IANA, rejecting e.g.
+// "user@localhost" and "http://intranet/". The embedded "tlds" table lists
+// the domains and can be replaced with SetTable; Allow adds private ones,
+// e.g. `val:"email,tld,allow=internal corp"`.
+type TLDValidator struct {
+	Allow []string `param:"allow,optional"`
+}
+
+func (v *TLDValidator) Validate(val string) (ok bool, err error) {
+	host, err := hostOf(val)
+	if err != nil {
+		return false, err
+	}
+	host = strings.TrimSuffix(host, ".")
+	if net.ParseIP(host) != nil {
+		return false, failf(ErrInvalidFormat, "host %q is an IP address, not a domain", host)
+	}
+	dot := strings.LastIndexByte(host, '.')
+	if dot < 0 {
+		return false, failf(ErrInvalidFormat, "host %q has no top-level domain", host)
+	}
+	tld, err := idna.Lookup.ToASCII(host[dot+1:])
+	if err != nil || tld == "" {
+		return false, failf(ErrInvalidFormat, "host %q has an invalid top-level domain", host)
+	}
+
+	set, err := topLevelDomains()
+	if err != nil {
+		return false, err
+	}
+	if !set[tld] && !containsFold(v.Allow, tld) {
+		return false, failf(ErrInvalidFormat, "host %q has an unknown top-level domain %q", host, tld)
+	}
+	return true, nil
+}
+
+// hostOf returns the host of a URL, the domain of an email address or val
+// itself.
+func hostOf(val string) (string, error) {
+	switch {
+	case strings.Contains(val, "://"):
+		u, err := url.Parse(val)
+		if err != nil {
+			return "", err
+		}
+		if u.Hostname() == "" {
+			return "", failf(ErrInvalidFormat, "value %q has no host", val)
+		}
+		return u.Hostname(), nil
+	case strings.Contains(val, "@"):
+		addr, err := mail.ParseAddress(val)
+		if err != nil {
+			return "", err
+		}
+		return addr.Address[strings.LastIndexByte(addr.Address, '@')+1:], nil
+	}
+	return val, nil
+}
+
+func (v *TLDValidator) Name() string {
+	return "tld"
+}
+
+func (v *TLDValidator) Handle(val string) error {
+	if ok, err := v.Validate(val); !ok {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,12 @@
+//go:build !valex_notables && !valex_notlds
+
+package valex
+
+import _ "embed"
+
+//go:embed tlds.txt
+var tldData string
+
+func init() {
+	embedTable(tldTable, tldData)
+}
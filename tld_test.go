@@ -0,0 +1,58 @@
+package valex
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTLDValidator(t *testing.T) {
+	provideTable(t, tldTable, "tlds.txt")
+
+	tests := []struct {
+		v         TLDValidator
+		input     string
+		errSubstr string
+	}{
+		{TLDValidator{}, "example.com", ""},
+		{TLDValidator{}, "example.co.uk.", ""},
+		{TLDValidator{}, "jane@example.DE", ""},
+		{TLDValidator{}, "Jane <jane@example.nl>", ""},
+		{TLDValidator{}, "https://shop.example.online:8443/cart", ""},
+		{TLDValidator{}, "пример.рф", ""},
+		{TLDValidator{}, "example.xn--p1ai", ""},
+		{TLDValidator{}, "jane@localhost", `host "localhost" has no top-level domain`},
+		{TLDValidator{}, "http://intranet/", "has no top-level domain"},
+		{TLDValidator{}, "server.local", `unknown top-level domain "local"`},
+		{TLDValidator{}, "example.notatld", "unknown top-level domain"},
+		{TLDValidator{}, "http://192.168.1.1/", "is an IP address"},
+		{TLDValidator{}, "file:///etc/passwd", "has no host"},
+		{TLDValidator{Allow: []string{"internal"}}, "jane@build.internal", ""},
+		{TLDValidator{Allow: []string{"internal"}}, "build.corp", "unknown top-level domain"},
+	}
+	for _, tc := range tests {
+		ok, err := tc.v.Validate(tc.input)
+		if tc.errSubstr == "" {
+			if !ok {
+				t.Errorf("%q: unexpected error: %v", tc.input, err)
+			}
+			continue
+		}
+		if ok || !strings.Contains(err.Error(), tc.errSubstr) {
+			t.Errorf("%q: expected error containing %q, got %v", tc.input, tc.errSubstr, err)
+		}
+	}
+}
+
+func TestTLDValidator_Tag(t *testing.T) {
+	provideTable(t, tldTable, "tlds.txt")
+
+	type account struct {
+		Email string `val:"email,tld"`
+	}
+	if _, err := ValidateStruct(&account{Email: "jane@example.org"}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, err := ValidateStruct(&account{Email: "jane@localhost"}); err == nil {
+		t.Error("expected an address without a top-level domain to fail")
+	}
+}
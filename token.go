@@ -0,0 +1,99 @@
+package valex
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+var tokenCharsets = map[string]string{
+	"hex":       "0123456789abcdef",
+	"base32":    "ABCDEFGHIJKLMNOPQRSTUVWXYZ234567",
+	"base58":    "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz",
+	"base64":    "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789+/=",
+	"base64url": "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789-_",
+	"alnum":     "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789",
+}
+
+// TokenValidator checks the shape of API keys and other secrets before they
+// are looked up: a fixed Prefix, followed by Length characters from Charset
+// (one of hex, base32, base58, base64, base64url or alnum) with a Shannon
+// entropy of at least MinEntropy bits per character, e.g.
+// `val:"token,prefix=sk_live_,length=32,charset=alnum,entropy=4"`. Low
+// entropy catches placeholders such as "sk_live_aaaaaaaa".
+type TokenValidator struct {
+	Prefix     string  `param:"prefix,optional"`
+	Length     int     `param:"length,optional"`
+	Charset    string  `param:"charset,optional"`
+	MinEntropy float64 `param:"entropy,optional"`
+}
+
+func (v *TokenValidator) Validate(val string) (ok bool, err error) {
+	body, ok := strings.CutPrefix(val, v.Prefix)
+	if !ok {
+		return false, failf(ErrInvalidFormat, "token does not start with %q", v.Prefix)
+	}
+	if v.Length > 0 && len(body) != v.Length {
+		sentinel := ErrTooShort
+		if len(body) > v.Length {
+			sentinel = ErrTooLong
+		}
+		return false, rangeFailf(sentinel, len(body), v.Length, v.Length, "token has %d characters after the prefix, expected %d", len(body), v.Length)
+	}
+	if v.Charset != "" {
+		chars, ok := tokenCharsets[v.Charset]
+		if !ok {
+			return false, fmt.Errorf("unknown charset %q", v.Charset)
+		}
+		for _, r := range body {
+			if !strings.ContainsRune(chars, r) {
+				return false, failf(ErrInvalidFormat, "token contains %q, which is not in the %s charset", r, v.Charset)
+			}
+		}
+	}
+	if e := shannonEntropy(body); e < v.MinEntropy {
+		return false, failf(ErrOutOfRange, "token entropy of %.2f bits per character is less than the minimum of %g", e, v.MinEntropy)
+	}
+	return true, nil
+}
+
+// shannonEntropy returns the entropy of the bytes of s in bits per byte.
+func shannonEntropy(s string) float64 {
+	var counts [256]int
+	for i := 0; i < len(s); i++ {
+		counts[s[i]]++
+	}
+	var e float64
+	for _, c := range counts {
+		if c > 0 {
+			p := float64(c) / float64(len(s))
+			e -= p * math.Log2(p)
+		}
+	}
+	return e
+}
+
+func (v *TokenValidator) configure() error {
+	if _, ok := tokenCharsets[v.Charset]; v.Charset != "" && !ok {
+		return fmt.Errorf("unknown charset %q", v.Charset)
+	}
+	if v.Length < 0 || v.MinEntropy < 0 {
+		return errors.New("length and entropy cannot be negative")
+	}
+	if v.Length > 0 && v.MinEntropy > math.Log2(float64(v.Length)) {
+		return fmt.Errorf("entropy %g is unreachable with %d characters", v.MinEntropy, v.Length)
+	}
+	return nil
+}
+
+func (v *TokenValidator) Name() string {
+	return "token"
+}
+
+func (v *TokenValidator) Handle(val string) error {
+	if ok, err := v.Validate(val); !ok {
+		return err
+	}
+	return nil
+}
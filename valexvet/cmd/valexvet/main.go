@@ -0,0 +1,13 @@
+// Command valexvet reports invalid `val` tags:
+//
+//	go vet -vettool=$(which valexvet) ./...
+package main
+
+import (
+	"github.com/tedla-brandsema/valex/valexvet"
+	"golang.org/x/tools/go/analysis/singlechecker"
+)
+
+func main() {
+	singlechecker.Main(valexvet.Analyzer)
+}
@@ -0,0 +1,17 @@
+module github.com/tedla-brandsema/valex/valexvet
+
+go 1.23.2
+
+require (
+	github.com/tedla-brandsema/valex v0.0.0
+	golang.org/x/tools v0.26.0
+)
+
+require (
+	golang.org/x/mod v0.21.0 // indirect
+	golang.org/x/net v0.38.0 // indirect
+	golang.org/x/sync v0.12.0 // indirect
+	golang.org/x/text v0.23.0 // indirect
+)
+
+replace github.com/tedla-brandsema/valex => ../
@@ -0,0 +1,21 @@
+package a
+
+import "time"
+
+type Tags []string
+
+type User struct {
+	Name     string            `val:"alphanum,min,size=2"`
+	Nick     string            `val:"alphnum"`      // want `field Nick: invalid val tag: unknown directive "alphnum", did you mean "alphanum"\?`
+	Bio      string            `val:"max,size=big"` // want `field Bio: invalid val tag: directive "max": unable to convert value "big" to int`
+	Age      int               `val:"email"`        // want `field Age: directive "email" expects string but the field is int`
+	Emails   []string          `val:"dive,email"`
+	Scores   []int             `val:"dive,email"` // want `field Scores: directive "email" expects string but the element is int`
+	Labels   map[string]string `val:"omitempty,dive,alphanum"`
+	Tags     Tags              `json:"tags"`
+	Created  time.Time         `val:"expr=value > 0"`
+	Optional *string           `val:"omitempty"`
+	Email    *string           `val:"omitempty,email"`
+	Twice    **string          `val:"omitempty,min,size=2"`
+	Count    *int              `val:"omitempty,email"` // want `field Count: directive "email" expects string but the field is \*int`
+}
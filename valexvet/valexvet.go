@@ -0,0 +1,156 @@
+// Package valexvet provides an analyzer that reports invalid `val` struct
+// tags before they are hit at runtime: unknown directives, parameters that
+// do not parse, and directives that cannot handle the type of their field.
+//
+// Analyzer checks tags against the built-in directives. Projects with
+// directives of their own build a vet tool from NewAnalyzer:
+//
+//	e := valex.NewEngine()
+//	valex.RegisterDirective(e, &SlugValidator{})
+//	singlechecker.Main(valexvet.NewAnalyzer(e))
+package valexvet
+
+import (
+	"go/ast"
+	"go/types"
+	"reflect"
+	"strconv"
+
+	"github.com/tedla-brandsema/valex"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+const tagKey = "val"
+
+var Analyzer = NewAnalyzer(valex.Default())
+
+// NewAnalyzer returns an analyzer that checks `val` tags against the
+// directives and aliases registered with e.
+func NewAnalyzer(e *valex.Engine) *analysis.Analyzer {
+	return &analysis.Analyzer{
+		Name:     "valexvet",
+		Doc:      "check valex `val` struct tags",
+		URL:      "https://pkg.go.dev/github.com/tedla-brandsema/valex/valexvet",
+		Requires: []*analysis.Analyzer{inspect.Analyzer},
+		Run: func(pass *analysis.Pass) (any, error) {
+			run(pass, e)
+			return nil, nil
+		},
+	}
+}
+
+func run(pass *analysis.Pass, e *valex.Engine) {
+	ins := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	ins.Preorder([]ast.Node{(*ast.StructType)(nil)}, func(n ast.Node) {
+		for _, field := range n.(*ast.StructType).Fields.List {
+			if field.Tag == nil {
+				continue
+			}
+			raw, err := strconv.Unquote(field.Tag.Value)
+			if err != nil {
+				continue
+			}
+			tagValue, ok := reflect.StructTag(raw).Lookup(tagKey)
+			if !ok {
+				continue
+			}
+			checkField(pass, e, field, tagValue)
+		}
+	})
+}
+
+func checkField(pass *analysis.Pass, e *valex.Engine, field *ast.Field, tagValue string) {
+	name := types.ExprString(field.Type)
+	if len(field.Names) > 0 {
+		name = field.Names[0].Name
+	}
+	tds, err := e.ParseTag(tagValue)
+	if err != nil {
+		pass.Reportf(field.Tag.Pos(), "field %s: invalid val tag: %v", name, err)
+		return
+	}
+
+	typ, what := pass.TypesInfo.TypeOf(field.Type), "field"
+	for _, td := range tds {
+		if td.Name == "dive" {
+			typ, what = elemType(typ), "element"
+			continue
+		}
+		if td.Directive == nil || typ == nil {
+			continue
+		}
+		if !handles(td.ValueType, typ) {
+			pass.Reportf(field.Tag.Pos(), "field %s: directive %q expects %s but the %s is %s",
+				name, td.Name, td.ValueType, what, types.TypeString(typ, types.RelativeTo(pass.Pkg)))
+		}
+	}
+}
+
+// elemType returns the type the directives following dive see, or nil when
+// that cannot be told from t.
+func elemType(t types.Type) types.Type {
+	if t == nil {
+		return nil
+	}
+	if p, ok := t.Underlying().(*types.Pointer); ok {
+		t = p.Elem()
+	}
+	switch u := t.Underlying().(type) {
+	case *types.Slice:
+		return u.Elem()
+	case *types.Array:
+		return u.Elem()
+	case *types.Map:
+		return u.Elem()
+	}
+	return nil
+}
+
+// handles reports whether a directive on values of type vt accepts a value
+// of type t, or the value t points to as the engine follows pointers,
+// following reflect's assignability rules as far as the names of the types
+// tell. Doubtful cases pass.
+func handles(vt reflect.Type, t types.Type) bool {
+	for {
+		if assignable(vt, t) {
+			return true
+		}
+		p, ok := types.Unalias(t).(*types.Pointer)
+		if !ok {
+			return false
+		}
+		t = p.Elem()
+	}
+}
+
+func assignable(vt reflect.Type, t types.Type) bool {
+	t = types.Unalias(t)
+	if vt.Kind() == reflect.Interface {
+		return true // e.g. expr, or directives on any
+	}
+	if _, ok := t.Underlying().(*types.Interface); ok {
+		return true // decided by the dynamic type
+	}
+	qual := func(p *types.Package) string { return p.Name() }
+	if types.TypeString(t, qual) == vt.String() {
+		return true
+	}
+	_, basic := t.(*types.Basic)
+	_, named := t.(*types.Named)
+	switch vtNamed := vt.Name() != ""; {
+	case (named || basic) && vtNamed:
+		return false
+	case named:
+		// assignable to an unnamed type with the same underlying type
+		return types.TypeString(t.Underlying(), qual) == vt.String()
+	case vtNamed:
+		// and the other way around
+		if vt.Kind() <= reflect.Complex128 || vt.Kind() == reflect.String {
+			return types.TypeString(t, qual) == vt.Kind().String()
+		}
+		return true // the underlying type of vt cannot be spelled
+	}
+	return false
+}
@@ -0,0 +1,12 @@
+package valexvet_test
+
+import (
+	"testing"
+
+	"github.com/tedla-brandsema/valex/valexvet"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), valexvet.Analyzer, "a")
+}
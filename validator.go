@@ -1,7 +1,6 @@
 package valex
 
 import (
-	"cmp"
 	"errors"
 	"fmt"
 )
@@ -16,11 +15,24 @@ func (p ValidatorFunc[T]) Validate(val T) (ok bool, err error) {
 	return p(val)
 }
 
-type ValidatedValue[T cmp.Ordered] struct {
+// ValidatedValue holds a value that passed Validator. Like other Go values
+// it is not safe for concurrent use without synchronization.
+type ValidatedValue[T any] struct {
 	value     T
+	set       bool
 	Validator Validator[T]
 }
 
+// NewValidated returns a ValidatedValue holding initial, or an error when
+// initial does not pass v.
+func NewValidated[T any](v Validator[T], initial T) (*ValidatedValue[T], error) {
+	vv := &ValidatedValue[T]{Validator: v}
+	if err := vv.Set(initial); err != nil {
+		return nil, err
+	}
+	return vv, nil
+}
+
 func (v *ValidatedValue[T]) Set(val T) error {
 	if v.Validator == nil {
 		return errors.New("no validator set")
@@ -29,14 +41,35 @@ func (v *ValidatedValue[T]) Set(val T) error {
 		return err
 	}
 	v.value = val
+	v.set = true
 
 	return nil
 }
 
+// MustSet is like Set but panics if val does not pass validation.
+func (v *ValidatedValue[T]) MustSet(val T) {
+	if err := v.Set(val); err != nil {
+		panic(err)
+	}
+}
+
 func (v *ValidatedValue[T]) Get() T {
 	return v.value
 }
 
+// IsSet reports whether a value passed validation since v was created or
+// last reset. The zero value, and the default of a flag, are not set.
+func (v *ValidatedValue[T]) IsSet() bool {
+	return v.set
+}
+
+// Reset restores the zero value of T, keeping the Validator.
+func (v *ValidatedValue[T]) Reset() {
+	var zero T
+	v.value = zero
+	v.set = false
+}
+
 func (v *ValidatedValue[T]) String() string {
 	return fmt.Sprintf("%v", v.value)
 }
@@ -47,3 +80,26 @@ func MustValidate[T any](val T, v Validator[T]) T {
 	}
 	return val
 }
+
+// TryValidate returns val if it passes v, and the zero value of T with the
+// error otherwise, e.g. `name, err := valex.TryValidate(input, &valex.MinLengthValidator{Size: 3})`.
+func TryValidate[T any](val T, v Validator[T]) (T, error) {
+	if ok, err := v.Validate(val); !ok {
+		var zero T
+		return zero, err
+	}
+	return val, nil
+}
+
+// ValidateAll runs every validator in vs on val and returns their errors
+// joined, or nil if val passes all of them. Unlike a CompositeValidator it
+// does not stop at the first failure.
+func ValidateAll[T any](val T, vs ...Validator[T]) error {
+	var errs []error
+	for _, v := range vs {
+		if ok, err := v.Validate(val); !ok {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
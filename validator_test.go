@@ -0,0 +1,117 @@
+package valex
+
+import (
+	"errors"
+	"net/netip"
+	"strings"
+	"testing"
+)
+
+func TestValidatedValue(t *testing.T) {
+	v := ValidatedValue[string]{Validator: &MinLengthValidator{Size: 3}}
+	if v.IsSet() {
+		t.Error("expected the zero value not to be set")
+	}
+	if err := v.Set("ab"); err == nil {
+		t.Error("expected a short value to fail")
+	}
+	if v.IsSet() || v.Get() != "" {
+		t.Errorf("expected a failed Set to leave the value alone, got %q", v.Get())
+	}
+	if err := v.Set("abc"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !v.IsSet() || v.Get() != "abc" {
+		t.Errorf("expected abc to be set, got %q", v.Get())
+	}
+
+	v.Reset()
+	if v.IsSet() || v.Get() != "" {
+		t.Errorf("expected Reset to clear the value, got %q", v.Get())
+	}
+	if err := v.Set("abcd"); err != nil {
+		t.Errorf("expected the validator to survive Reset, got %v", err)
+	}
+
+	var empty ValidatedValue[int]
+	if err := empty.Set(1); err == nil || err.Error() != "no validator set" {
+		t.Errorf("expected a missing validator error, got %v", err)
+	}
+}
+
+func TestValidatedValue_MustSet(t *testing.T) {
+	v := ValidatedValue[int]{Validator: &IntRangeValidator{Min: 1, Max: 10}}
+	v.MustSet(5)
+	if v.Get() != 5 {
+		t.Errorf("expected 5, got %d", v.Get())
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("expected MustSet to panic")
+		}
+	}()
+	v.MustSet(11)
+}
+
+func TestNewValidated(t *testing.T) {
+	// netip.Addr is not ordered, which ValidatedValue used to require.
+	private := ValidatorFunc[netip.Addr](func(a netip.Addr) (bool, error) {
+		if !a.IsPrivate() {
+			return false, errors.New("address is not private")
+		}
+		return true, nil
+	})
+
+	v, err := NewValidated(private, netip.MustParseAddr("10.0.0.1"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !v.IsSet() || v.String() != "10.0.0.1" {
+		t.Errorf("expected 10.0.0.1 to be set, got %v", v)
+	}
+
+	if _, err := NewValidated(private, netip.MustParseAddr("8.8.8.8")); err == nil || !strings.Contains(err.Error(), "not private") {
+		t.Errorf("expected a validation error, got %v", err)
+	}
+
+	tags, err := NewValidated(ValidatorFunc[[]string](func(s []string) (bool, error) {
+		if len(s) == 0 {
+			return false, errors.New("no tags")
+		}
+		return true, nil
+	}), []string{"a", "b"})
+	if err != nil || len(tags.Get()) != 2 {
+		t.Errorf("expected a slice value, got %v, %v", tags, err)
+	}
+}
+
+func TestTryValidate(t *testing.T) {
+	name, err := TryValidate("jane", &MinLengthValidator{Size: 3})
+	if err != nil || name != "jane" {
+		t.Errorf("expected jane, got %q, %v", name, err)
+	}
+	age, err := TryValidate(200, &IntRangeValidator{Min: 0, Max: 130})
+	if err == nil || age != 0 {
+		t.Errorf("expected the zero value and an error, got %d, %v", age, err)
+	}
+}
+
+func TestValidateAll(t *testing.T) {
+	min := &MinLengthValidator{Size: 3}
+	alnum := &AlphaNumericValidator{}
+	if err := ValidateAll("jane", min, alnum); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+	if err := ValidateAll[string]("jane"); err != nil {
+		t.Errorf("expected no error without validators, got %v", err)
+	}
+
+	err := ValidateAll("a!", min, alnum)
+	if err == nil || len(strings.Split(err.Error(), "\n")) != 2 {
+		t.Fatalf("expected both errors, got %v", err)
+	}
+	if _, minErr := min.Validate("a!"); !strings.Contains(err.Error(), minErr.Error()) {
+		t.Errorf("expected %q in %q", minErr, err)
+	}
+}
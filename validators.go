@@ -1,7 +1,9 @@
 package valex
 
 import (
+	"bytes"
 	"cmp"
+	"encoding/csv"
 	"encoding/json"
 	"encoding/xml"
 	"errors"
@@ -9,9 +11,12 @@ import (
 	"io"
 	"net"
 	"net/mail"
+	"net/netip"
 	"net/url"
 	"regexp"
+	"slices"
 	"strings"
+	"unicode/utf8"
 )
 
 type CmpRangeValidator[T cmp.Ordered] struct {
@@ -21,7 +26,7 @@ type CmpRangeValidator[T cmp.Ordered] struct {
 
 func (v *CmpRangeValidator[T]) Validate(val T) (ok bool, err error) {
 	if cmp.Less(val, v.Min) || cmp.Less(v.Max, val) {
-		return false, fmt.Errorf("value %v is out of range [%v, %v]", val, v.Min, v.Max)
+		return false, rangeFailf(ErrOutOfRange, val, v.Min, v.Max, "value %v is out of range [%v, %v]", val, v.Min, v.Max)
 	}
 	return true, nil
 }
@@ -33,7 +38,7 @@ type IntRangeValidator struct {
 
 func (v *IntRangeValidator) Validate(val int) (ok bool, err error) {
 	if val < v.Min || val > v.Max {
-		return false, fmt.Errorf("value %d is out of range [%d, %d]", val, v.Min, v.Max)
+		return false, rangeFailf(ErrOutOfRange, val, v.Min, v.Max, "value %d is out of range [%d, %d]", val, v.Min, v.Max)
 	}
 	return true, nil
 }
@@ -53,7 +58,7 @@ type NonNegativeIntValidator struct{}
 
 func (v *NonNegativeIntValidator) Validate(val int) (ok bool, err error) {
 	if val < 0 {
-		return false, fmt.Errorf("value %d is a negative integer", val)
+		return false, rangeFailf(ErrOutOfRange, val, 0, nil, "value %d is a negative integer", val)
 	}
 	return true, nil
 }
@@ -73,7 +78,7 @@ type NonPositiveIntValidator struct{}
 
 func (v *NonPositiveIntValidator) Validate(val int) (ok bool, err error) {
 	if val > 0 {
-		return false, fmt.Errorf("value %d is a positive integer", val)
+		return false, rangeFailf(ErrOutOfRange, val, nil, 0, "value %d is a positive integer", val)
 	}
 	return true, nil
 }
@@ -89,14 +94,33 @@ func (v *NonPositiveIntValidator) Handle(val int) error {
 	return nil
 }
 
-type UrlValidator struct{}
+// Default limits keeping hostile XML from costing XMLValidator unbounded
+// time. Both can be raised with directive parameters.
+const (
+	maxXMLTokens = 4 << 20
+	maxXMLDepth  = 10000 // as encoding/json, which caps JSON itself
+)
+
+// tooLarge reports input of n bytes over limit, with CategorySize.
+func tooLarge(n int, limit ByteSize) error {
+	return WithCategory(failf(ErrTooLong, "input too large: %d bytes exceeds the maximum of %d", n, limit), CategorySize)
+}
+
+// UrlValidator checks that a value is an absolute URL or an absolute path.
+// MaxBytes limits its length, e.g. `val:"url,maxbytes=8KiB"`; by default it
+// is not limited.
+type UrlValidator struct {
+	MaxBytes ByteSize `param:"maxbytes,optional"`
+}
 
 func (v *UrlValidator) Validate(val string) (ok bool, err error) {
-	_, err = url.ParseRequestURI(val)
-	if err == nil {
-		ok = true
+	if v.MaxBytes > 0 && ByteSize(len(val)) > v.MaxBytes {
+		return false, tooLarge(len(val), v.MaxBytes)
+	}
+	if _, err = url.ParseRequestURI(val); err != nil {
+		return false, withSentinel(err, ErrInvalidFormat)
 	}
-	return
+	return true, nil
 }
 
 func (v *UrlValidator) Name() string {
@@ -110,14 +134,31 @@ func (v *UrlValidator) Handle(val string) error {
 	return nil
 }
 
-type EmailValidator struct{}
+// EmailValidator accepts RFC 5322 addresses, including display names such as
+// "John <john@example.com>". Stricter policies for form input are set with
+// parameters, e.g. `val:"email,no_display_name,max=254,ascii_only"`: a bare
+// address, at most Max bytes long, without non-ASCII characters.
+type EmailValidator struct {
+	NoDisplayName bool `param:"no_display_name,optional"`
+	Max           int  `param:"max,optional"`
+	ASCIIOnly     bool `param:"ascii_only,optional"`
+}
 
 func (v *EmailValidator) Validate(val string) (ok bool, err error) {
-	_, err = mail.ParseAddress(val)
-	if err == nil {
-		ok = true
+	addr, err := mail.ParseAddress(val)
+	if err != nil {
+		return false, withSentinel(err, ErrInvalidFormat)
+	}
+	if v.NoDisplayName && (addr.Name != "" || strings.ContainsAny(val, "<>")) {
+		return false, failf(ErrInvalidFormat, "value %q is not a bare email address", val)
+	}
+	if v.Max > 0 && len(addr.Address) > v.Max {
+		return false, rangeFailf(ErrTooLong, len(addr.Address), nil, v.Max, "email address %q exceeds the maximum length of %d", addr.Address, v.Max)
+	}
+	if v.ASCIIOnly && !isASCII(addr.Address) {
+		return false, failf(ErrInvalidFormat, "email address %q contains non-ASCII characters", addr.Address)
 	}
-	return
+	return true, nil
 }
 
 func (v *EmailValidator) Name() string {
@@ -131,11 +172,20 @@ func (v *EmailValidator) Handle(val string) error {
 	return nil
 }
 
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
 type NonEmptyStringValidator struct{}
 
 func (v *NonEmptyStringValidator) Validate(val string) (ok bool, err error) {
 	if val == "" {
-		return false, fmt.Errorf("string is empty")
+		return false, failf(ErrEmpty, "string is empty")
 	}
 	return true, nil
 }
@@ -151,16 +201,19 @@ func (v *NonEmptyStringValidator) Handle(val string) error {
 	return nil
 }
 
+// MinLengthValidator checks that a value is at least Size bytes long, or
+// Size runes with Runes set, e.g. `val:"min,size=3,runes"`.
 type MinLengthValidator struct {
-	Size int `param:"size"`
+	Size  int  `param:"size"`
+	Runes bool `param:"runes,optional"`
 }
 
 func (v *MinLengthValidator) Validate(val string) (ok bool, err error) {
 	if v.Size == 0 {
 		return false, errors.New(`value of parameter "size" cannot be 0`)
 	}
-	if len(val) < v.Size {
-		return false, fmt.Errorf("value %s exeeds minimum length %d", val, v.Size)
+	if l := strLen(val, v.Runes); l < v.Size {
+		return false, rangeFailf(ErrTooShort, l, v.Size, nil, "value %s exeeds minimum length %d", val, v.Size)
 	}
 	return true, nil
 }
@@ -176,16 +229,19 @@ func (v *MinLengthValidator) Handle(val string) error {
 	return nil
 }
 
+// MaxLengthValidator checks that a value is at most Size bytes long, or
+// Size runes with Runes set.
 type MaxLengthValidator struct {
-	Size int `param:"size"`
+	Size  int  `param:"size"`
+	Runes bool `param:"runes,optional"`
 }
 
 func (v *MaxLengthValidator) Validate(val string) (ok bool, err error) {
 	if v.Size == 0 {
 		return false, errors.New(`value of parameter "size" cannot be 0`)
 	}
-	if len(val) > v.Size {
-		return false, fmt.Errorf("value %s exeeds maximum length %d", val, v.Size)
+	if l := strLen(val, v.Runes); l > v.Size {
+		return false, rangeFailf(ErrTooLong, l, nil, v.Size, "value %s exeeds maximum length %d", val, v.Size)
 	}
 	return true, nil
 }
@@ -201,13 +257,16 @@ func (v *MaxLengthValidator) Handle(val string) error {
 	return nil
 }
 
+// LengthRangeValidator checks that the length of a value is within Min and
+// Max, counted in bytes or, with Runes set, in runes.
 type LengthRangeValidator struct {
-	Min int `param:"min"`
-	Max int `param:"max"`
+	Min   int  `param:"min"`
+	Max   int  `param:"max"`
+	Runes bool `param:"runes,optional"`
 }
 
 func (v *LengthRangeValidator) Validate(val string) (ok bool, err error) {
-	l := len(val)
+	l := strLen(val, v.Runes)
 	if v.Min == 0 {
 		return false, errors.New(`"min" value cannot be 0`)
 	}
@@ -215,7 +274,11 @@ func (v *LengthRangeValidator) Validate(val string) (ok bool, err error) {
 		return false, errors.New(`"max" value cannot be 0`)
 	}
 	if l < v.Min || l > v.Max {
-		return false, fmt.Errorf("value %q with length %d is not in range [%d, %d]", val, l, v.Min, v.Max)
+		sentinel := ErrTooLong
+		if l < v.Min {
+			sentinel = ErrTooShort
+		}
+		return false, rangeFailf(sentinel, l, v.Min, v.Max, "value %q with length %d is not in range [%d, %d]", val, l, v.Min, v.Max)
 	}
 	return true, nil
 }
@@ -231,32 +294,81 @@ func (v *LengthRangeValidator) Handle(val string) error {
 	return nil
 }
 
+// strLen returns the length of s in bytes, or in runes if runes is set.
+func strLen(s string, runes bool) int {
+	if runes {
+		return utf8.RuneCountInString(s)
+	}
+	return len(s)
+}
+
+// RegexValidator checks values against Pattern. As a directive the pattern
+// is given as the tag value, e.g. `val:"regex=^[a-z]+$"`; a pattern with a
+// comma must be quoted, e.g. `val:"regex='^[a-z]{2,4}$'"`.
 type RegexValidator struct {
 	Pattern *regexp.Regexp
+	Expr    string `param:"regex"`
 }
 
 func (v *RegexValidator) Validate(val string) (ok bool, err error) {
-	if !v.Pattern.MatchString(val) {
-		return false, fmt.Errorf("value %q does not match pattern %q", val, v.Pattern.String())
+	re := v.Pattern
+	if re == nil {
+		if re, err = compileRegex(v.Expr); err != nil {
+			return false, fmt.Errorf("invalid pattern %q: %w", v.Expr, err)
+		}
+	}
+	if !re.MatchString(val) {
+		return false, failf(ErrInvalidFormat, "value %q does not match pattern %q", val, re.String())
 	}
 	return true, nil
 }
 
-// TODO: implement Directive for RegexValidator
+// configure compiles Expr when the directive is set up, so a bad pattern is
+// reported as a tag error.
+func (v *RegexValidator) configure() error {
+	re, err := compileRegex(v.Expr)
+	if err != nil {
+		return err
+	}
+	v.Pattern = re
+	return nil
+}
+
+func (v *RegexValidator) Name() string {
+	return "regex"
+}
+
+func (v *RegexValidator) Handle(val string) error {
+	if ok, err := v.Validate(val); !ok {
+		return err
+	}
+	return nil
+}
 
 type AlphaNumericValidator struct{}
 
 func (v *AlphaNumericValidator) Validate(val string) (ok bool, err error) {
-	matched, err := regexp.MatchString(`^[a-zA-Z0-9]+$`, val)
-	if err != nil {
-		return false, err
-	}
-	if !matched {
-		return false, fmt.Errorf("value %q is not alphanumeric", val)
+	if !isAlphaNumeric(val) {
+		return false, failf(ErrInvalidFormat, "value %q is not alphanumeric", val)
 	}
 	return true, nil
 }
 
+// isAlphaNumeric reports whether s is non-empty and holds only ASCII letters
+// and digits.
+func isAlphaNumeric(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if (c < 'a' || c > 'z') && (c < 'A' || c > 'Z') && (c < '0' || c > '9') {
+			return false
+		}
+	}
+	return true
+}
+
 func (v *AlphaNumericValidator) Name() string {
 	return "alphanum"
 }
@@ -271,9 +383,12 @@ func (v *AlphaNumericValidator) Handle(val string) error {
 type MACAddressValidator struct{}
 
 func (v *MACAddressValidator) Validate(val string) (ok bool, err error) {
+	if !strings.ContainsAny(val, ":-.") {
+		return false, failf(ErrInvalidFormat, "invalid MAC address %q: missing separators", val)
+	}
 	_, err = net.ParseMAC(val)
 	if err != nil {
-		return false, fmt.Errorf("invalid MAC address %q: %v", val, err)
+		return false, failf(ErrInvalidFormat, "invalid MAC address %q: %v", val, err)
 	}
 	return true, nil
 }
@@ -292,8 +407,8 @@ func (v *MACAddressValidator) Handle(val string) error {
 type IpValidator struct{}
 
 func (v *IpValidator) Validate(val string) (ok bool, err error) {
-	if ip := net.ParseIP(val); ip == nil {
-		return false, fmt.Errorf("invalid IP address %q", val)
+	if _, ok := parseAddr(val); !ok {
+		return false, failf(ErrInvalidFormat, "invalid IP address %q", val)
 	}
 	return true, nil
 }
@@ -309,12 +424,18 @@ func (v *IpValidator) Handle(val string) error {
 	return nil
 }
 
+// parseAddr parses an IP address like net.ParseIP, which rejects zones, but
+// without allocating.
+func parseAddr(s string) (netip.Addr, bool) {
+	addr, err := netip.ParseAddr(s)
+	return addr, err == nil && addr.Zone() == ""
+}
+
 type IPv4Validator struct{}
 
 func (v *IPv4Validator) Validate(val string) (ok bool, err error) {
-	ip := net.ParseIP(val)
-	if ip == nil || ip.To4() == nil {
-		return false, fmt.Errorf("invalid IPv4 address %q", val)
+	if addr, ok := parseAddr(val); !ok || !(addr.Is4() || addr.Is4In6()) {
+		return false, failf(ErrInvalidFormat, "invalid IPv4 address %q", val)
 	}
 	return true, nil
 }
@@ -333,9 +454,8 @@ func (v *IPv4Validator) Handle(val string) error {
 type IPv6Validator struct{}
 
 func (v *IPv6Validator) Validate(val string) (ok bool, err error) {
-	ip := net.ParseIP(val)
-	if ip == nil || ip.To4() != nil {
-		return false, fmt.Errorf("invalid IPv6 address %q", val)
+	if addr, ok := parseAddr(val); !ok || !addr.Is6() || addr.Is4In6() {
+		return false, failf(ErrInvalidFormat, "invalid IPv6 address %q", val)
 	}
 	return true, nil
 }
@@ -351,28 +471,76 @@ func (v *IPv6Validator) Handle(val string) error {
 	return nil
 }
 
-type XMLValidator struct{}
+// XMLValidator checks that a value is well-formed XML with at least one
+// element. SingleRoot rejects documents with more than one root element or
+// with text outside of it, NoDTD rejects DOCTYPE and other <!...>
+// declarations, and MaxBytes limits the size of the document, e.g.
+// `val:"xml,singleroot,nodtd,maxbytes=1MiB"`. MaxDepth and MaxTokens lower
+// or raise the default limits of 10000 levels of nesting and 4194304
+// tokens; the size is not limited by default.
+type XMLValidator struct {
+	SingleRoot bool     `param:"singleroot,optional"`
+	NoDTD      bool     `param:"nodtd,optional"`
+	MaxDepth   int      `param:"maxdepth,optional"`
+	MaxTokens  int      `param:"maxtokens,optional"`
+	MaxBytes   ByteSize `param:"maxbytes,optional"`
+}
 
 func (v *XMLValidator) Validate(val string) (ok bool, err error) {
-	decoder := xml.NewDecoder(strings.NewReader(val))
-	var hasElement bool
+	if v.MaxBytes > 0 && ByteSize(len(val)) > v.MaxBytes {
+		return false, tooLarge(len(val), v.MaxBytes)
+	}
+	return v.validate(strings.NewReader(val))
+}
 
-	for {
+// validate checks the XML in r. encoding/xml does not expand entities
+// declared in a DTD, it rejects them as undefined, so entity expansion is no
+// concern; the number of tokens and the nesting depth are capped instead.
+func (v *XMLValidator) validate(r io.Reader) (ok bool, err error) {
+	decoder := xml.NewDecoder(r)
+	maxDepth := cmp.Or(v.MaxDepth, maxXMLDepth)
+	maxTokens := cmp.Or(v.MaxTokens, maxXMLTokens)
+	var roots int
+	depth := 0
+
+	for tokens := 1; ; tokens++ {
 		tok, err := decoder.Token()
 		if err != nil {
 			if err == io.EOF {
 				break
 			}
-			return false, fmt.Errorf("XML parsing error: %w", err)
+			return false, failf(ErrInvalidFormat, "XML parsing error: %w", err)
+		}
+		if tokens > maxTokens {
+			return false, failf(ErrTooLong, "XML document exceeds %d tokens", maxTokens)
 		}
 
-		if _, ok := tok.(xml.StartElement); ok { // atleast one tag
-			hasElement = true
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			if depth == 0 {
+				roots++
+			}
+			if v.SingleRoot && roots > 1 {
+				return false, failf(ErrInvalidFormat, "XML document has more than one root element")
+			}
+			if depth++; depth > maxDepth {
+				return false, failf(ErrTooLong, "XML document exceeds a depth of %d", maxDepth)
+			}
+		case xml.EndElement:
+			depth--
+		case xml.CharData:
+			if v.SingleRoot && depth == 0 && len(bytes.TrimSpace(tok)) > 0 {
+				return false, failf(ErrInvalidFormat, "XML document has text outside the root element")
+			}
+		case xml.Directive:
+			if v.NoDTD {
+				return false, failf(ErrInvalidFormat, "XML document contains a DTD or other declaration")
+			}
 		}
 	}
 
-	if !hasElement {
-		return false, fmt.Errorf("XML document must contain at least one element")
+	if roots == 0 { // atleast one tag
+		return false, failf(ErrInvalidFormat, "XML document must contain at least one element")
 	}
 
 	return true, nil
@@ -389,15 +557,90 @@ func (v *XMLValidator) Handle(val string) error {
 	return nil
 }
 
-type JSONValidator struct{}
+// JSONValidator checks that a value is valid JSON. Kind requires the value
+// to be an object, array, string, number, boolean or null, MaxDepth limits
+// nesting and MaxBytes limits the size, which is not limited by default,
+// e.g. `val:"json,kind=object,maxdepth=64,maxbytes=1MiB"`.
+type JSONValidator struct {
+	Kind     string   `param:"kind,optional"`
+	MaxDepth int      `param:"maxdepth,optional"`
+	MaxBytes ByteSize `param:"maxbytes,optional"`
+}
+
+var jsonKinds = []string{"object", "array", "string", "number", "boolean", "null"}
+
+// configure rejects an unknown Kind when the directive is set up.
+func (v *JSONValidator) configure() error {
+	if v.Kind != "" && !slices.Contains(jsonKinds, v.Kind) {
+		return fmt.Errorf("unknown JSON kind %q, expected one of %s", v.Kind, strings.Join(jsonKinds, ", "))
+	}
+	return nil
+}
 
 func (v *JSONValidator) Validate(val string) (ok bool, err error) {
+	if v.MaxBytes > 0 && ByteSize(len(val)) > v.MaxBytes {
+		return false, tooLarge(len(val), v.MaxBytes)
+	}
 	if !json.Valid([]byte(val)) {
-		return false, fmt.Errorf("invalid JSON")
+		return false, failf(ErrInvalidFormat, "invalid JSON")
+	}
+	if v.Kind != "" {
+		if kind := jsonKind(val); kind != v.Kind {
+			return false, failf(ErrInvalidFormat, "JSON value is %s %s, expected %s", article(kind), kind, article(v.Kind)+" "+v.Kind)
+		}
+	}
+	if v.MaxDepth > 0 && jsonDepth(val) > v.MaxDepth {
+		return false, failf(ErrTooLong, "JSON value exceeds a depth of %d", v.MaxDepth)
 	}
 	return true, nil
 }
 
+// jsonKind returns the kind of the valid JSON value in val.
+func jsonKind(val string) string {
+	switch strings.TrimLeft(val, " \t\r\n")[0] {
+	case '{':
+		return "object"
+	case '[':
+		return "array"
+	case '"':
+		return "string"
+	case 't', 'f':
+		return "boolean"
+	case 'n':
+		return "null"
+	}
+	return "number"
+}
+
+func article(kind string) string {
+	if strings.IndexByte("aeiou", kind[0]) >= 0 {
+		return "an"
+	}
+	return "a"
+}
+
+// jsonDepth returns the deepest nesting of objects and arrays in the valid
+// JSON value in val.
+func jsonDepth(val string) int {
+	depth, deepest := 0, 0
+	inString := false
+	for i := 0; i < len(val); i++ {
+		switch c := val[i]; {
+		case inString && c == '\\':
+			i++
+		case c == '"':
+			inString = !inString
+		case inString:
+		case c == '{' || c == '[':
+			depth++
+			deepest = max(deepest, depth)
+		case c == '}' || c == ']':
+			depth--
+		}
+	}
+	return deepest
+}
+
 func (v *JSONValidator) Name() string {
 	return "json"
 }
@@ -409,6 +652,111 @@ func (v *JSONValidator) Handle(val string) error {
 	return nil
 }
 
+type CSVValidator struct {
+	Cols   int      `param:"cols,optional"`
+	Header []string `param:"header,optional"`
+}
+
+func (v *CSVValidator) Validate(val string) (ok bool, err error) {
+	return v.validate(strings.NewReader(val))
+}
+
+// validate reads the records one at a time, so only the header is kept.
+func (v *CSVValidator) validate(rd io.Reader) (ok bool, err error) {
+	r := csv.NewReader(rd)
+	r.FieldsPerRecord = v.Cols // 0 makes the first record set the column count
+	r.ReuseRecord = true
+
+	header, err := r.Read()
+	if err == io.EOF {
+		return false, failf(ErrInvalidFormat, "CSV document must contain at least one record")
+	}
+	if err != nil {
+		return false, failf(ErrInvalidFormat, "CSV parsing error: %w", err)
+	}
+	if len(v.Header) > 0 {
+		present := make(map[string]bool, len(header))
+		for _, name := range header {
+			present[strings.TrimSpace(name)] = true
+		}
+		for _, name := range v.Header {
+			if !present[name] {
+				return false, failf(ErrInvalidFormat, "CSV header is missing column %q", name)
+			}
+		}
+	}
+	for {
+		if _, err := r.Read(); err == io.EOF {
+			return true, nil
+		} else if err != nil {
+			return false, failf(ErrInvalidFormat, "CSV parsing error: %w", err)
+		}
+	}
+}
+
+func (v *CSVValidator) Name() string {
+	return "csv"
+}
+
+func (v *CSVValidator) Handle(val string) error {
+	if ok, err := v.Validate(val); !ok {
+		return err
+	}
+	return nil
+}
+
+// NoSQLMetaValidator is a defense-in-depth heuristic that rejects strings
+// containing characters or sequences commonly used in SQL injection. It is no
+// substitute for parameterized queries.
+type NoSQLMetaValidator struct{}
+
+var sqlMetaSequences = []string{"'", "\"", ";", "`", "\\", "--", "/*", "*/", "\x00"}
+
+func (v *NoSQLMetaValidator) Validate(val string) (ok bool, err error) {
+	for _, seq := range sqlMetaSequences {
+		if i := strings.Index(val, seq); i >= 0 {
+			return false, failf(ErrNotAllowed, "value %q contains SQL metacharacter %q at position %d", val, seq, i)
+		}
+	}
+	return true, nil
+}
+
+func (v *NoSQLMetaValidator) Name() string {
+	return "nosqlmeta"
+}
+
+func (v *NoSQLMetaValidator) Handle(val string) error {
+	if ok, err := v.Validate(val); !ok {
+		return err
+	}
+	return nil
+}
+
+// NoShellMetaValidator is a defense-in-depth heuristic that rejects strings
+// containing shell metacharacters. It is no substitute for passing arguments
+// to commands without a shell.
+type NoShellMetaValidator struct{}
+
+const shellMetaChars = "|&;<>()$`\\\"' \t\n\r*?[]{}~#!\x00"
+
+func (v *NoShellMetaValidator) Validate(val string) (ok bool, err error) {
+	if i := strings.IndexAny(val, shellMetaChars); i >= 0 {
+		return false, failf(ErrNotAllowed, "value %q contains shell metacharacter %q at position %d", val, val[i], i)
+	}
+	return true, nil
+}
+
+func (v *NoShellMetaValidator) Name() string {
+	return "noshellmeta"
+}
+
+func (v *NoShellMetaValidator) Handle(val string) error {
+	if ok, err := v.Validate(val); !ok {
+		return err
+	}
+	return nil
+}
+
 type CompositeValidator[T cmp.Ordered] struct {
 	Validators []Validator[T]
 }
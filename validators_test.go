@@ -1,7 +1,13 @@
 package valex
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
 	"regexp"
+	"strings"
 	"testing"
 )
 
@@ -98,6 +104,47 @@ func TestEmailValidator(t *testing.T) {
 	}
 }
 
+func TestEmailValidator_Policy(t *testing.T) {
+	tests := []struct {
+		v         EmailValidator
+		input     string
+		errSubstr string
+	}{
+		{EmailValidator{}, "John <john@example.com>", ""},
+		{EmailValidator{NoDisplayName: true}, "john@example.com", ""},
+		{EmailValidator{NoDisplayName: true}, "John <john@example.com>", "is not a bare email address"},
+		{EmailValidator{NoDisplayName: true}, "<john@example.com>", "is not a bare email address"},
+		{EmailValidator{Max: 254}, "john@example.com", ""},
+		{EmailValidator{Max: 20}, "johnathan@example.com", "exceeds the maximum length of 20"},
+		{EmailValidator{Max: 20}, "John Longname <john@example.com>", ""},
+		{EmailValidator{}, "jörg@example.com", ""},
+		{EmailValidator{ASCIIOnly: true}, "jörg@example.com", "contains non-ASCII characters"},
+		{EmailValidator{ASCIIOnly: true}, "Jörg <joerg@example.com>", ""},
+	}
+	for _, tc := range tests {
+		ok, err := tc.v.Validate(tc.input)
+		if tc.errSubstr == "" {
+			if !ok {
+				t.Errorf("%+v(%q): unexpected error: %v", tc.v, tc.input, err)
+			}
+			continue
+		}
+		if ok || !strings.Contains(err.Error(), tc.errSubstr) {
+			t.Errorf("%+v(%q): expected error containing %q, got %v", tc.v, tc.input, tc.errSubstr, err)
+		}
+	}
+
+	type signup struct {
+		Email string `val:"email,no_display_name,max=254,ascii_only"`
+	}
+	if _, err := ValidateStruct(&signup{Email: "john@example.com"}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, err := ValidateStruct(&signup{Email: "John <john@example.com>"}); err == nil {
+		t.Error("expected a display name to fail")
+	}
+}
+
 func TestNonEmptyStringValidator(t *testing.T) {
 	v := &NonEmptyStringValidator{}
 	tests := []struct {
@@ -173,6 +220,28 @@ func TestLengthRangeValidator(t *testing.T) {
 	}
 }
 
+func TestLengthValidators_Runes(t *testing.T) {
+	tests := []struct {
+		v     Validator[string]
+		input string
+		ok    bool
+	}{
+		{&MaxLengthValidator{Size: 3}, "日本", false},
+		{&MaxLengthValidator{Size: 3, Runes: true}, "日本", true},
+		{&MaxLengthValidator{Size: 3, Runes: true}, "日本語!", false},
+		{&MinLengthValidator{Size: 3, Runes: true}, "日本", false},
+		{&MinLengthValidator{Size: 2, Runes: true}, "日本", true},
+		{&LengthRangeValidator{Min: 2, Max: 3, Runes: true}, "日本語", true},
+		{&LengthRangeValidator{Min: 2, Max: 3, Runes: true}, "日", false},
+	}
+	for _, tc := range tests {
+		ok, err := tc.v.Validate(tc.input)
+		if ok != tc.ok {
+			t.Errorf("%+v(%q): expected ok=%v, got ok=%v (err: %v)", tc.v, tc.input, tc.ok, ok, err)
+		}
+	}
+}
+
 func TestRegexValidator(t *testing.T) {
 	pattern := regexp.MustCompile(`^\d+$`)
 	v := &RegexValidator{Pattern: pattern}
@@ -336,6 +405,43 @@ func TestXMLValidator(t *testing.T) {
 	}
 }
 
+func TestXMLValidator_Options(t *testing.T) {
+	tests := []struct {
+		v         XMLValidator
+		input     string
+		errSubstr string
+	}{
+		{XMLValidator{}, `<a/><b/>`, ""},
+		{XMLValidator{SingleRoot: true}, `<a/><b/>`, "more than one root element"},
+		{XMLValidator{SingleRoot: true}, `<a/>text`, "text outside the root element"},
+		{XMLValidator{SingleRoot: true}, "<?xml version=\"1.0\"?>\n<a><b/></a>\n", ""},
+		{XMLValidator{}, `<!DOCTYPE a><a/>`, ""},
+		{XMLValidator{NoDTD: true}, `<!DOCTYPE a><a/>`, "contains a DTD"},
+		{XMLValidator{NoDTD: true}, `<!-- note --><a/>`, ""},
+		{XMLValidator{MaxDepth: 2}, `<a><b/></a>`, ""},
+		{XMLValidator{MaxDepth: 2}, `<a><b><c/></b></a>`, "exceeds a depth of 2"},
+	}
+	for _, tc := range tests {
+		ok, err := tc.v.Validate(tc.input)
+		if tc.errSubstr == "" {
+			if !ok {
+				t.Errorf("%+v(%q): unexpected error: %v", tc.v, tc.input, err)
+			}
+			continue
+		}
+		if ok || !strings.Contains(err.Error(), tc.errSubstr) {
+			t.Errorf("%+v(%q): expected error containing %q, got %v", tc.v, tc.input, tc.errSubstr, err)
+		}
+	}
+
+	type doc struct {
+		Body string `val:"xml,singleroot,nodtd,maxdepth=32"`
+	}
+	if _, err := ValidateStruct(&doc{Body: `<a/><b/>`}); err == nil {
+		t.Error("expected the tag parameters to be applied")
+	}
+}
+
 func TestJSONValidator(t *testing.T) {
 	v := &JSONValidator{}
 	tests := []struct {
@@ -364,6 +470,46 @@ func TestJSONValidator(t *testing.T) {
 	}
 }
 
+func TestJSONValidator_Options(t *testing.T) {
+	tests := []struct {
+		v         JSONValidator
+		input     string
+		errSubstr string
+	}{
+		{JSONValidator{Kind: "object"}, ` {"a": 1}`, ""},
+		{JSONValidator{Kind: "object"}, `[1]`, "JSON value is an array, expected an object"},
+		{JSONValidator{Kind: "array"}, "\n[]", ""},
+		{JSONValidator{Kind: "number"}, `-1.5e3`, ""},
+		{JSONValidator{Kind: "boolean"}, `null`, "JSON value is a null, expected a boolean"},
+		{JSONValidator{MaxDepth: 2}, `{"a": [1, "[[["]}`, ""},
+		{JSONValidator{MaxDepth: 2}, `{"a": [{}]}`, "exceeds a depth of 2"},
+		{JSONValidator{MaxBytes: 4}, `[1,2]`, "input too large"},
+		{JSONValidator{MaxBytes: 8}, `[1,2]`, ""},
+	}
+	for _, tc := range tests {
+		ok, err := tc.v.Validate(tc.input)
+		if tc.errSubstr == "" {
+			if !ok {
+				t.Errorf("%+v(%q): unexpected error: %v", tc.v, tc.input, err)
+			}
+			continue
+		}
+		if ok || !strings.Contains(err.Error(), tc.errSubstr) {
+			t.Errorf("%+v(%q): expected error containing %q, got %v", tc.v, tc.input, tc.errSubstr, err)
+		}
+	}
+
+	type payload struct {
+		Body string `val:"json,kind=object,maxdepth=64,maxbytes=1MiB"`
+	}
+	if _, err := ValidateStruct(&payload{Body: `[]`}); err == nil {
+		t.Error("expected the tag parameters to be applied")
+	}
+	if _, err := ParseTag("json,kind=map"); err == nil || !strings.Contains(err.Error(), `unknown JSON kind "map"`) {
+		t.Errorf("expected an unknown kind error, got %v", err)
+	}
+}
+
 func TestCompositeValidator_String(t *testing.T) {
 	nonEmpty := &NonEmptyStringValidator{}
 	minLength := &MinLengthValidator{Size: 3}
@@ -407,3 +553,247 @@ func TestCompositeValidator_Int(t *testing.T) {
 		}
 	}
 }
+
+func TestCSVValidator(t *testing.T) {
+	tests := []struct {
+		v     *CSVValidator
+		input string
+		ok    bool
+	}{
+		{&CSVValidator{}, "a,b,c\n1,2,3\n", true},
+		{&CSVValidator{}, "a,b,c\n1,2\n", false}, // ragged records
+		{&CSVValidator{}, "a,\"b\n", false},      // unterminated quote
+		{&CSVValidator{}, "", false},
+		{&CSVValidator{Cols: 3}, "a,b,c\n1,2,3\n", true},
+		{&CSVValidator{Cols: 2}, "a,b,c\n1,2,3\n", false},
+		{&CSVValidator{Header: []string{"id", "email"}}, "id,name,email\n1,john,j@example.com\n", true},
+		{&CSVValidator{Header: []string{"id", "email"}}, "id,name\n1,john\n", false},
+	}
+	for _, tc := range tests {
+		ok, err := tc.v.Validate(tc.input)
+		if ok != tc.ok {
+			t.Errorf("%T(%q): expected ok=%v, got ok=%v, err=%v", *tc.v, tc.input, tc.ok, ok, err)
+		}
+	}
+}
+
+func TestNoSQLMetaValidator(t *testing.T) {
+	v := &NoSQLMetaValidator{}
+	tests := []struct {
+		input string
+		ok    bool
+	}{
+		{"customer_42", true},
+		{"O Brien", true},
+		{"x' OR '1'='1", false},
+		{"1; DROP TABLE users", false},
+		{"admin--", false},
+		{"a/*comment*/", false},
+		{"back\\slash", false},
+		{"nul\x00byte", false},
+	}
+	for _, tt := range tests {
+		ok, err := v.Validate(tt.input)
+		if ok != tt.ok {
+			t.Errorf("%T(%q): expected ok=%v, got ok=%v, err=%v", *v, tt.input, tt.ok, ok, err)
+		}
+	}
+}
+
+func TestNoShellMetaValidator(t *testing.T) {
+	v := &NoShellMetaValidator{}
+	tests := []struct {
+		input string
+		ok    bool
+	}{
+		{"report-2024.csv", true},
+		{"user_name", true},
+		{"file; rm -rf /", false},
+		{"$(whoami)", false},
+		{"`id`", false},
+		{"a|b", false},
+		{"with space", false},
+		{"glob*", false},
+		{"line\nbreak", false},
+	}
+	for _, tt := range tests {
+		ok, err := v.Validate(tt.input)
+		if ok != tt.ok {
+			t.Errorf("%T(%q): expected ok=%v, got ok=%v, err=%v", *v, tt.input, tt.ok, ok, err)
+		}
+	}
+}
+
+// validInputs holds a passing value for each built-in string validator.
+var validInputs = []struct {
+	v         Validator[string]
+	input     string
+	allocFree bool
+}{
+	{&AlphaNumericValidator{}, "abcXYZ123", true},
+	{&NonEmptyStringValidator{}, "x", true},
+	{&MinLengthValidator{Size: 3}, "john", true},
+	{&MaxLengthValidator{Size: 8}, "john", true},
+	{&LengthRangeValidator{Min: 3, Max: 8}, "john", true},
+	{&IpValidator{}, "2001:db8::1", true},
+	{&IPv4Validator{}, "192.168.1.1", true},
+	{&IPv6Validator{}, "2001:db8::1", true},
+	{&JSONValidator{}, `{"a":[1,2,3]}`, false},
+	{&NoSQLMetaValidator{}, "plain value", true},
+	{&NoShellMetaValidator{}, "plain-value", true},
+	{&UrlValidator{}, "https://example.com/path?q=1", false},
+	{&EmailValidator{}, "john@example.com", false},
+	{&MACAddressValidator{}, "00:1a:2b:3c:4d:5e", false},
+	{&XMLValidator{}, "<a><b>1</b></a>", false},
+	{&CSVValidator{Cols: 2}, "a,b\n1,2", false},
+}
+
+func TestValidators_AllocFree(t *testing.T) {
+	for _, tc := range validInputs {
+		if !tc.allocFree {
+			continue
+		}
+		allocs := testing.AllocsPerRun(100, func() {
+			if ok, err := tc.v.Validate(tc.input); !ok {
+				t.Fatalf("%T(%q): unexpected error: %v", tc.v, tc.input, err)
+			}
+		})
+		if allocs != 0 {
+			t.Errorf("%T(%q): %v allocations on success, want 0", tc.v, tc.input, allocs)
+		}
+	}
+	allocs := testing.AllocsPerRun(100, func() {
+		(&IntRangeValidator{Min: 1, Max: 10}).Validate(5)
+	})
+	if allocs != 0 {
+		t.Errorf("IntRangeValidator: %v allocations on success, want 0", allocs)
+	}
+}
+
+func BenchmarkValidators(b *testing.B) {
+	for _, tc := range validInputs {
+		b.Run(fmt.Sprintf("%T", tc.v)[len("*valex."):], func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if ok, err := tc.v.Validate(tc.input); !ok {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func TestFormatValidators_Limits(t *testing.T) {
+	deepXML := strings.Repeat("<a>", maxXMLDepth+1) + strings.Repeat("</a>", maxXMLDepth+1)
+	deepJSON := strings.Repeat("[", 10001) + strings.Repeat("]", 10001)
+	tests := []struct {
+		name      string
+		validate  func() (bool, error)
+		errSubstr string
+	}{
+		{"xml depth", func() (bool, error) { return (&XMLValidator{}).Validate(deepXML) }, "exceeds a depth of"},
+		{"xml size", func() (bool, error) {
+			return (&XMLValidator{MaxBytes: 16}).Validate("<a>" + strings.Repeat(" ", 16) + "</a>")
+		}, "input too large"},
+		{"xml tokens", func() (bool, error) {
+			return (&XMLValidator{MaxTokens: 8}).Validate(strings.Repeat("<a></a>", 8))
+		}, "exceeds 8 tokens"},
+		{"xml entity", func() (bool, error) {
+			return (&XMLValidator{}).Validate(`<!DOCTYPE a [<!ENTITY e "x">]><a>&e;</a>`)
+		}, "invalid character entity"},
+		{"json depth", func() (bool, error) { return (&JSONValidator{}).Validate(deepJSON) }, "invalid JSON"},
+		{"json stream depth", func() (bool, error) {
+			return (&JSONStreamValidator{}).ValidateReader(strings.NewReader(deepJSON))
+		}, "max depth"},
+		{"json size", func() (bool, error) {
+			return (&JSONValidator{MaxBytes: 16}).Validate(`"` + strings.Repeat("a", 16) + `"`)
+		}, "input too large"},
+		{"url size", func() (bool, error) {
+			return (&UrlValidator{MaxBytes: 32}).Validate("https://example.com/" + strings.Repeat("a", 32))
+		}, "input too large"},
+		{"mac size", func() (bool, error) { return (&MACAddressValidator{}).Validate(strings.Repeat("0:", 1000)) }, "invalid MAC address"},
+	}
+	for _, tc := range tests {
+		ok, err := tc.validate()
+		if ok || err == nil || !strings.Contains(err.Error(), tc.errSubstr) {
+			t.Errorf("%s: expected error containing %q, got %v", tc.name, tc.errSubstr, err)
+		}
+	}
+	_, err := (&XMLValidator{MaxBytes: 16}).Validate("<a>" + strings.Repeat(" ", 16) + "</a>")
+	if Categorize(err) != CategorySize || !errors.Is(err, ErrTooLong) {
+		t.Errorf("expected CategorySize and ErrTooLong, got %v and %v", Categorize(err), err)
+	}
+
+	// Without limits set, input of any size validates.
+	long := "https://example.com/" + strings.Repeat("a", 64<<10)
+	if ok, err := (&UrlValidator{}).Validate(long); !ok {
+		t.Errorf("unexpected error for long URL: %v", err)
+	}
+	if ok, err := (&XMLValidator{}).Validate("<a>" + strings.Repeat(" ", 17<<20) + "</a>"); !ok {
+		t.Errorf("unexpected error for large XML: %v", err)
+	}
+}
+
+func FuzzXMLValidator(f *testing.F) {
+	f.Add(`<root><child>value</child></root>`)
+	f.Add(`<?xml version="1.0"?><!DOCTYPE a [<!ENTITY e "x">]><a>&e;</a>`)
+	f.Add(`<a xmlns:x="urn:x"><x:b/></a><c/>`)
+	f.Fuzz(func(t *testing.T, s string) {
+		ok, err := (&XMLValidator{}).Validate(s)
+		if ok != (err == nil) {
+			t.Fatalf("ok=%v but err=%v", ok, err)
+		}
+		if sok, _ := (&XMLStreamValidator{}).ValidateReader(strings.NewReader(s)); sok != ok {
+			t.Fatalf("stream ok=%v, string ok=%v", sok, ok)
+		}
+	})
+}
+
+func FuzzJSONValidator(f *testing.F) {
+	f.Add(`{"key": [1, 2.5e3, true, null, "é"]}`)
+	f.Add(`[[[[]]]]`)
+	f.Add(`{"a": }`)
+	f.Fuzz(func(t *testing.T, s string) {
+		ok, _ := (&JSONValidator{}).Validate(s)
+		if ok != json.Valid([]byte(s)) {
+			t.Fatalf("ok=%v disagrees with json.Valid", ok)
+		}
+		if sok, _ := (&JSONStreamValidator{}).ValidateReader(strings.NewReader(s)); sok != ok {
+			t.Fatalf("stream ok=%v, string ok=%v", sok, ok)
+		}
+	})
+}
+
+func FuzzUrlValidator(f *testing.F) {
+	f.Add("https://example.com/a?b=c#d")
+	f.Add("/relative/path")
+	f.Add("http://[::1]:80/%zz")
+	f.Fuzz(func(t *testing.T, s string) {
+		ok, err := (&UrlValidator{}).Validate(s)
+		if ok != (err == nil) {
+			t.Fatalf("ok=%v but err=%v", ok, err)
+		}
+		if ok {
+			if _, err := url.ParseRequestURI(s); err != nil {
+				t.Fatalf("accepted unparsable URL: %v", err)
+			}
+		}
+	})
+}
+
+func FuzzMACAddressValidator(f *testing.F) {
+	f.Add("00:1A:2B:3C:4D:5E")
+	f.Add("0000.5e00.5301")
+	f.Add("02-00-5e-10-00-00-00-01")
+	f.Fuzz(func(t *testing.T, s string) {
+		ok, err := (&MACAddressValidator{}).Validate(s)
+		if ok != (err == nil) {
+			t.Fatalf("ok=%v but err=%v", ok, err)
+		}
+		if ok {
+			if _, err := net.ParseMAC(s); err != nil {
+				t.Fatalf("accepted unparsable MAC address: %v", err)
+			}
+		}
+	})
+}